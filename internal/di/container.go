@@ -3,10 +3,18 @@ package di
 import (
 	"net/http"
 
+	"github.com/sirawong/crud-arise/internal/outbox"
+	"github.com/sirawong/crud-arise/internal/scheduler"
+	"github.com/sirawong/crud-arise/internal/services/job"
+	"github.com/sirawong/crud-arise/internal/services/stream"
 	"github.com/sirawong/crud-arise/pkg/config"
 )
 
 type Application struct {
 	httpServer *http.Server
+	scheduler  *scheduler.Scheduler
+	dispatcher *outbox.Dispatcher
+	broker     *stream.Broker
+	jobs       *job.Pool
 	Cfg        *config.Config
 }
@@ -1,12 +1,49 @@
 package di
 
 import (
+	"context"
+	"fmt"
+	"log"
+	nethttp "net/http"
+	"time"
+
 	"github.com/sirawong/crud-arise/internal/handler/http"
+	audit2 "github.com/sirawong/crud-arise/internal/handler/http/audit"
+	change2 "github.com/sirawong/crud-arise/internal/handler/http/change"
 	category2 "github.com/sirawong/crud-arise/internal/handler/http/category"
+	idempotency2 "github.com/sirawong/crud-arise/internal/handler/http/idempotency"
+	job2 "github.com/sirawong/crud-arise/internal/handler/http/job"
+	lot2 "github.com/sirawong/crud-arise/internal/handler/http/lot"
+	order2 "github.com/sirawong/crud-arise/internal/handler/http/order"
+	returns2 "github.com/sirawong/crud-arise/internal/handler/http/returns"
 	product2 "github.com/sirawong/crud-arise/internal/handler/http/product"
+	purchaseorder2 "github.com/sirawong/crud-arise/internal/handler/http/purchaseorder"
+	retention2 "github.com/sirawong/crud-arise/internal/handler/http/retention"
+	stock2 "github.com/sirawong/crud-arise/internal/handler/http/stock"
+	stream2 "github.com/sirawong/crud-arise/internal/handler/http/stream"
+	supplier2 "github.com/sirawong/crud-arise/internal/handler/http/supplier"
+	webhook2 "github.com/sirawong/crud-arise/internal/handler/http/webhook"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/outbox"
+	domainrepo "github.com/sirawong/crud-arise/internal/domain/repository"
 	"github.com/sirawong/crud-arise/internal/repository"
+	"github.com/sirawong/crud-arise/internal/repository/memory"
+	"github.com/sirawong/crud-arise/internal/scheduler"
+	"github.com/sirawong/crud-arise/internal/services/audit"
+	"github.com/sirawong/crud-arise/internal/services/change"
 	"github.com/sirawong/crud-arise/internal/services/category"
+	"github.com/sirawong/crud-arise/internal/services/idempotency"
+	"github.com/sirawong/crud-arise/internal/services/job"
+	"github.com/sirawong/crud-arise/internal/services/lot"
+	"github.com/sirawong/crud-arise/internal/services/order"
+	"github.com/sirawong/crud-arise/internal/services/returns"
 	"github.com/sirawong/crud-arise/internal/services/product"
+	"github.com/sirawong/crud-arise/internal/services/purchaseorder"
+	"github.com/sirawong/crud-arise/internal/services/reservation"
+	"github.com/sirawong/crud-arise/internal/services/retention"
+	"github.com/sirawong/crud-arise/internal/services/stream"
+	"github.com/sirawong/crud-arise/internal/services/supplier"
+	"github.com/sirawong/crud-arise/internal/services/webhook"
 	"github.com/sirawong/crud-arise/pkg/config"
 	"github.com/sirawong/crud-arise/pkg/database"
 )
@@ -22,19 +59,251 @@ func NewApplication() (*Application, func(), error) {
 		return nil, nil, err
 	}
 
+	txManager := repository.NewTxManager(db)
+
 	categoryRepo := repository.NewCategoryRepository(db)
-	categoryService := category.NewCategoryService(categoryRepo)
+	categoryService := category.NewCategoryService(txManager, categoryRepo, cfg.BatchMaxItems)
 	categoryHandler := category2.NewCategoryHandler(categoryService)
 
 	productRepo := repository.NewProductRepository(db)
-	productService := product.NewProductService(productRepo, categoryRepo)
+	productService := product.NewProductService(txManager, productRepo, categoryRepo, cfg.BatchMaxItems)
 	productHandler := product2.NewProductHandler(productService)
 
-	httpRouter := http.NewRouter(productHandler, categoryHandler)
+	lotRepo := repository.NewLotRepository(db)
+	lotService := lot.NewLotService(lotRepo, productRepo)
+	lotHandler := lot2.NewLotHandler(lotService)
+
+	supplierRepo := repository.NewSupplierRepository(db)
+	productSupplierRepo := repository.NewProductSupplierRepository(db)
+	supplierService := supplier.NewSupplierService(supplierRepo, productSupplierRepo, productRepo)
+	supplierHandler := supplier2.NewSupplierHandler(supplierService)
+
+	purchaseOrderRepo := repository.NewPurchaseOrderRepository(db)
+	purchaseOrderService := purchaseorder.NewPurchaseOrderService(purchaseOrderRepo, productRepo, supplierRepo)
+	purchaseOrderHandler := purchaseorder2.NewPurchaseOrderHandler(purchaseOrderService)
+
+	orderRepo := repository.NewOrderRepository(db)
+	orderService := order.NewOrderService(txManager, orderRepo, productRepo)
+	orderHandler := order2.NewOrderHandler(orderService)
+
+	returnRepo := repository.NewReturnRepository(db)
+	returnService := returns.NewReturnService(txManager, returnRepo, orderRepo, productRepo)
+	returnHandler := returns2.NewReturnHandler(returnService)
+
+	var idempotencyRepo domainrepo.IdempotencyRepository
+	switch cfg.IdempotencyStore {
+	case "postgres":
+		idempotencyRepo = repository.NewIdempotencyRepository(db)
+	case "memory":
+		idempotencyRepo = memory.NewIdempotencyRepository()
+	default:
+		cleanup()
+		return nil, nil, fmt.Errorf("unknown idempotency store %q", cfg.IdempotencyStore)
+	}
+	idempotencyService := idempotency.NewIdempotencyService(idempotencyRepo, cfg.IdempotencyTTL, cfg.IdempotencyInFlightTimeout)
+	idempotencyHandler := idempotency2.NewIdempotencyHandler(idempotencyService)
+
+	if cfg.RetentionDays < 1 || cfg.RetentionBatchSize < 1 {
+		cleanup()
+		return nil, nil, fmt.Errorf("retention days and batch size must be positive, got %d and %d", cfg.RetentionDays, cfg.RetentionBatchSize)
+	}
+	retentionService := retention.NewRetentionService(productRepo, categoryRepo, repository.NewRetentionRunRepository(db), entity.RetentionPolicy{
+		Days:      cfg.RetentionDays,
+		BatchSize: cfg.RetentionBatchSize,
+		DryRun:    cfg.RetentionDryRun,
+	})
+	retentionHandler := retention2.NewRetentionHandler(retentionService)
+
+	auditRepo := repository.NewAuditRepository(db)
+	auditService := audit.NewAuditService(auditRepo)
+	auditHandler := audit2.NewAuditHandler(auditService)
+
+	changeRepo := repository.NewChangeRepository(db)
+	changeService := change.NewChangeService(changeRepo)
+	changeHandler := change2.NewChangeHandler(changeService)
+
+	if cfg.StreamBatchSize < 1 || cfg.StreamBufferSize < 1 || cfg.StreamHeartbeatInterval <= 0 {
+		cleanup()
+		return nil, nil, fmt.Errorf("stream batch size, buffer size and heartbeat interval must be positive, got %d, %d and %s", cfg.StreamBatchSize, cfg.StreamBufferSize, cfg.StreamHeartbeatInterval)
+	}
+	broker := stream.NewBroker(changeRepo, entity.StreamPolicy{
+		PollInterval: cfg.StreamPollInterval,
+		BatchSize:    cfg.StreamBatchSize,
+		BufferSize:   cfg.StreamBufferSize,
+	})
+	streamHandler := stream2.NewStreamHandler(broker, cfg.StreamHeartbeatInterval)
+
+	if cfg.ReservationBatchSize < 1 || cfg.ReservationTTL <= 0 {
+		cleanup()
+		return nil, nil, fmt.Errorf("reservation batch size and TTL must be positive, got %d and %s", cfg.ReservationBatchSize, cfg.ReservationTTL)
+	}
+	reservationRepo := repository.NewReservationRepository(db)
+	reservationService := reservation.NewReservationService(txManager, reservationRepo, productRepo, entity.ReservationPolicy{
+		TTL:       cfg.ReservationTTL,
+		BatchSize: cfg.ReservationBatchSize,
+	})
+
+	if cfg.WebSocketMaxConnections < 1 || cfg.WebSocketMaxSubscriptions < 1 || cfg.WebSocketPingInterval <= 0 || cfg.WebSocketPongTimeout <= 0 {
+		cleanup()
+		return nil, nil, fmt.Errorf("websocket connection and subscription limits, ping interval and pong timeout must be positive, got %d, %d, %s and %s", cfg.WebSocketMaxConnections, cfg.WebSocketMaxSubscriptions, cfg.WebSocketPingInterval, cfg.WebSocketPongTimeout)
+	}
+	if len(cfg.WebSocketTokens) == 0 {
+		log.Println("WS_TOKENS is not set; no client can connect to the stock channel")
+	}
+	stockHandler := stock2.NewStockHandler(productService, reservationService, broker, stock2.Config{
+		Tokens:           cfg.WebSocketTokens,
+		MaxConnections:   cfg.WebSocketMaxConnections,
+		MaxSubscriptions: cfg.WebSocketMaxSubscriptions,
+		PingInterval:     cfg.WebSocketPingInterval,
+		PongTimeout:      cfg.WebSocketPongTimeout,
+	})
+
+	if cfg.WebhookBatchSize < 1 || cfg.WebhookMaxAttempts < 1 || cfg.WebhookDisableAfter < 1 {
+		cleanup()
+		return nil, nil, fmt.Errorf("webhook batch size, max attempts and disable after must be positive, got %d, %d and %d", cfg.WebhookBatchSize, cfg.WebhookMaxAttempts, cfg.WebhookDisableAfter)
+	}
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookService := webhook.NewWebhookService(webhookRepo, &nethttp.Client{Timeout: cfg.WebhookTimeout}, entity.WebhookPolicy{
+		BatchSize:    cfg.WebhookBatchSize,
+		Lease:        cfg.WebhookLease,
+		MaxAttempts:  cfg.WebhookMaxAttempts,
+		Backoff:      cfg.WebhookRetryBackoff,
+		MaxBackoff:   cfg.WebhookMaxRetryBackoff,
+		DisableAfter: cfg.WebhookDisableAfter,
+	})
+	webhookHandler := webhook2.NewWebhookHandler(webhookService)
+
+	var publisher outbox.Publisher
+	switch cfg.OutboxPublisher {
+	case "log":
+		publisher = outbox.NewLogPublisher()
+	case "memory":
+		publisher = outbox.NewMemoryPublisher()
+	default:
+		cleanup()
+		return nil, nil, fmt.Errorf("unknown outbox publisher %q", cfg.OutboxPublisher)
+	}
+	if cfg.OutboxBatchSize < 1 || cfg.OutboxMaxAttempts < 1 {
+		cleanup()
+		return nil, nil, fmt.Errorf("outbox batch size and max attempts must be positive, got %d and %d", cfg.OutboxBatchSize, cfg.OutboxMaxAttempts)
+	}
+	outboxRepo := repository.NewOutboxRepository(db)
+	// Webhooks get their own deliveries of every event the configured publisher is given.
+	dispatcher := outbox.NewDispatcher(outboxRepo, outbox.Publishers{publisher, webhookService}, entity.OutboxPolicy{
+		BatchSize:   cfg.OutboxBatchSize,
+		Lease:       cfg.OutboxLease,
+		MaxAttempts: cfg.OutboxMaxAttempts,
+		Backoff:     cfg.OutboxRetryBackoff,
+		MaxBackoff:  cfg.OutboxMaxRetryBackoff,
+	}, cfg.OutboxPollInterval)
+
+	if cfg.JobLease <= 0 || cfg.JobMaxAttempts < 1 || cfg.JobChunkSize < 1 || cfg.JobMaxImportItems < 1 {
+		cleanup()
+		return nil, nil, fmt.Errorf("job lease, max attempts, chunk size and max import items must be positive, got %s, %d, %d and %d", cfg.JobLease, cfg.JobMaxAttempts, cfg.JobChunkSize, cfg.JobMaxImportItems)
+	}
+	if cfg.JobChunkSize > cfg.BatchMaxItems {
+		cleanup()
+		return nil, nil, fmt.Errorf("job chunk size %d exceeds the batch limit %d", cfg.JobChunkSize, cfg.BatchMaxItems)
+	}
+	jobRepo := repository.NewJobRepository(db)
+	exportPageRepo := repository.NewExportPageRepository(db)
+	jobService := job.NewJobService(jobRepo, exportPageRepo)
+	jobPool := job.NewPool(jobRepo, map[entity.JobType]job.Runner{
+		entity.JobTypeProductImport:      job.NewImportRunner(productService, cfg.JobChunkSize),
+		entity.JobTypeProductExport:      job.NewExportRunner(txManager, productRepo, exportPageRepo, cfg.JobChunkSize),
+		entity.JobTypeProductPriceChange: job.NewPriceChangeRunner(txManager, productRepo, productService, cfg.JobChunkSize),
+	}, entity.JobPolicy{
+		Workers:      cfg.JobWorkers,
+		PollInterval: cfg.JobPollInterval,
+		Lease:        cfg.JobLease,
+		MaxAttempts:  cfg.JobMaxAttempts,
+		ChunkSize:    cfg.JobChunkSize,
+	})
+	jobHandler := job2.NewJobHandler(jobService, cfg.JobMaxImportItems)
+
+	httpRouter := http.NewRouter(cfg, productHandler, categoryHandler, lotHandler, supplierHandler, purchaseOrderHandler, orderHandler, returnHandler, idempotencyHandler, retentionHandler, auditHandler, changeHandler, webhookHandler, streamHandler, stockHandler, jobHandler)
 	httpServer := httpRouter.NewServer(cfg)
 
+	jobScheduler := scheduler.NewScheduler(
+		scheduler.Task{
+			Name:     "quarantine-expired-lots",
+			Interval: cfg.LotQuarantineInterval,
+			Run: func(ctx context.Context) error {
+				count, err := lotService.QuarantineExpired(ctx)
+				if count > 0 {
+					log.Printf("quarantined %d expired lots", count)
+				}
+				return err
+			},
+		},
+		scheduler.Task{
+			Name:     "purge-expired-idempotency-keys",
+			Interval: cfg.IdempotencyPurgeInterval,
+			Run: func(ctx context.Context) error {
+				count, err := idempotencyService.PurgeExpired(ctx)
+				if count > 0 {
+					log.Printf("purged %d expired idempotency keys", count)
+				}
+				return err
+			},
+		},
+		scheduler.Task{
+			Name:     "purge-expired-trash",
+			Interval: cfg.RetentionInterval,
+			Run: func(ctx context.Context) error {
+				_, err := retentionService.Run(ctx)
+				return err
+			},
+		},
+		scheduler.Task{
+			Name:     "release-expired-reservations",
+			Interval: cfg.ReservationExpiryInterval,
+			Run: func(ctx context.Context) error {
+				count, err := reservationService.ReleaseExpired(ctx)
+				if count > 0 {
+					log.Printf("released %d expired stock reservations", count)
+				}
+				return err
+			},
+		},
+		scheduler.Task{
+			Name:     "deliver-webhooks",
+			Interval: cfg.WebhookDeliveryInterval,
+			Run: func(ctx context.Context) error {
+				_, err := webhookService.DeliverDue(ctx)
+				return err
+			},
+		},
+		scheduler.Task{
+			Name:     "purge-published-outbox-events",
+			Interval: cfg.OutboxPurgeInterval,
+			Run: func(ctx context.Context) error {
+				count, err := outboxRepo.PurgePublished(ctx, time.Now().Add(-cfg.OutboxRetention))
+				if count > 0 {
+					log.Printf("purged %d published outbox events", count)
+				}
+				return err
+			},
+		},
+		scheduler.Task{
+			Name:     "purge-finished-jobs",
+			Interval: cfg.JobPurgeInterval,
+			Run: func(ctx context.Context) error {
+				count, err := jobRepo.PurgeFinished(ctx, time.Now().Add(-cfg.JobRetention))
+				if count > 0 {
+					log.Printf("purged %d finished jobs", count)
+				}
+				return err
+			},
+		},
+	)
+
 	return &Application{
 			httpServer: httpServer,
+			scheduler:  jobScheduler,
+			dispatcher: dispatcher,
+			jobs:       jobPool,
+			broker:     broker,
 			Cfg:        cfg,
 		}, func() {
 			cleanup()
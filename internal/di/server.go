@@ -8,6 +8,13 @@ import (
 )
 
 func (a *Application) Start() error {
+	if err := a.broker.Start(); err != nil {
+		return err
+	}
+	a.scheduler.Start()
+	a.dispatcher.Start()
+	a.jobs.Start()
+
 	go func() {
 		log.Println("Starting HTTP server...")
 		log.Printf("HTTP server is listening on %s", a.httpServer.Addr)
@@ -19,11 +26,20 @@ func (a *Application) Start() error {
 	return nil
 }
 
+// Shutdown stops every component even when an earlier one fails, and returns their errors joined.
 func (a *Application) Shutdown(ctx context.Context) error {
-	err := a.httpServer.Shutdown(ctx)
-	if err != nil {
-		return err
-	}
+	var errs []error
 
-	return nil
+	// Streams never go idle, so they are ended before the server waits for idle connections.
+	errs = append(errs, a.broker.Stop(ctx))
+
+	// Running jobs are put back in the queue and resume from their checkpoints on the next start.
+	// They are stopped before the server drains, which can take up to the whole deadline.
+	errs = append(errs, a.jobs.Stop(ctx))
+
+	errs = append(errs, a.httpServer.Shutdown(ctx))
+	errs = append(errs, a.scheduler.Stop(ctx))
+	errs = append(errs, a.dispatcher.Stop(ctx))
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// AuditEntityType names the kind of record an audit entry is about.
+type AuditEntityType string
+
+const (
+	AuditEntityProduct  AuditEntityType = "product"
+	AuditEntityCategory AuditEntityType = "category"
+)
+
+func (t AuditEntityType) IsValid() bool {
+	switch t {
+	case AuditEntityProduct, AuditEntityCategory:
+		return true
+	}
+	return false
+}
+
+// AuditAction is what a change did to a record.
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+	AuditActionPurge   AuditAction = "purge"
+)
+
+// SystemActor is recorded for changes made outside a request, such as by scheduled jobs.
+const SystemActor = "system"
+
+// Actor is who a change is made by and the request it is made in.
+type Actor struct {
+	Name      string
+	RequestID string
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx that attributes the changes made with it to actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor carried by ctx, falling back to SystemActor.
+func ActorFrom(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	if actor.Name == "" {
+		actor.Name = SystemActor
+	}
+	return actor
+}
+
+// FieldChange is the value of one field before and after a change; Old is nil for a created
+// record and New is nil for a purged one.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AuditEntry records one change to a product or category, keyed by column name in Changes.
+type AuditEntry struct {
+	ID         int64
+	Actor      string
+	RequestID  string
+	OccurredAt time.Time
+	EntityType AuditEntityType
+	EntityID   string
+	Action     AuditAction
+	Changes    map[string]FieldChange
+}
+
+type AuditFilter struct {
+	EntityType AuditEntityType
+	EntityID   string
+	Actor      string
+	From       *time.Time
+	To         *time.Time
+	Pagination
+}
@@ -0,0 +1,36 @@
+package entity
+
+// BatchMode decides what a batch write does when some of its items cannot be written.
+type BatchMode string
+
+const (
+	// BatchAtomic writes all items in one transaction; a single failing item aborts the batch.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort writes every item it can and reports the ones it could not.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// BatchResult is the outcome of one item of a batch write. Err is nil when the item was written.
+// Created tells an upsert that inserted the item from one that updated it.
+type BatchResult struct {
+	Index   int
+	ID      string
+	Created bool
+	Err     error
+}
+
+// BatchItemError explains why one item of an atomic batch failed. It is returned to clients as
+// error details, hence the json tags.
+type BatchItemError struct {
+	Index     int    `json:"index"`
+	ID        string `json:"id,omitempty"`
+	ErrorCode string `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// VersionedID names a resource to delete. A non-zero Version makes the delete conditional on the
+// resource still being at that version.
+type VersionedID struct {
+	ID      string
+	Version int
+}
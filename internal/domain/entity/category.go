@@ -5,8 +5,20 @@ import "time"
 type Category struct {
 	ID        string
 	Name      string
+	Version   int
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// CategoryPatch holds the fields a partial update sets. Nil fields are left as they are.
+type CategoryPatch struct {
+	Name    *string
+	Version int
+}
+
+func (p CategoryPatch) IsEmpty() bool {
+	return p.Name == nil
 }
 
 type CategoriesFilter struct {
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// Change is one entry of the changes feed: a recorded write to a product or category, numbered
+// by Seq in the order the writes committed.
+type Change struct {
+	Seq        int64
+	EntityType AuditEntityType
+	EntityID   string
+	Action     AuditAction
+	// Revision is the revision the write stored, 0 for a purge.
+	Revision int
+	// Fields lists the columns an update changed. It is empty for other actions and for updates
+	// recorded before fields were.
+	Fields     []string
+	OccurredAt time.Time
+	// Product or Category, depending on EntityType, is the record as the write left it. Both are
+	// nil for a purge and once the record has been purged since.
+	Product  *Product
+	Category *Category
+}
+
+// IsTombstone tells whether the change took the record out of the catalog.
+func (c Change) IsTombstone() bool {
+	return c.Action == AuditActionDelete || c.Action == AuditActionPurge
+}
+
+// Changed tells whether the change is an update of field.
+func (c Change) Changed(field string) bool {
+	return slices.Contains(c.Fields, field)
+}
+
+// ChangePage is a page of the changes feed. Next is the sequence number to read on from, which
+// stays at the one read from when the page is empty.
+type ChangePage struct {
+	Changes []Change
+	Next    int64
+	HasMore bool
+}
@@ -1,6 +1,16 @@
 package entity
 
+import "time"
+
 type Pagination struct {
 	Limit  int
 	Offset int
 }
+
+// ListStats summarizes everything a list filter matches, ignoring pagination. It changes
+// whenever a matching row is added, removed or updated, so it can stand in for the list itself
+// when checking whether a client's copy is still current.
+type ListStats struct {
+	Count        int64
+	LastModified time.Time
+}
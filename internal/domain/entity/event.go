@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType names a domain event.
+type EventType string
+
+const (
+	EventProductCreated      EventType = "ProductCreated"
+	EventProductUpdated      EventType = "ProductUpdated"
+	EventProductPriceChanged EventType = "ProductPriceChanged"
+	EventStockChanged        EventType = "StockChanged"
+	EventProductDeleted      EventType = "ProductDeleted"
+	EventProductRestored     EventType = "ProductRestored"
+	EventProductPurged       EventType = "ProductPurged"
+	EventCategoryCreated     EventType = "CategoryCreated"
+	EventCategoryUpdated     EventType = "CategoryUpdated"
+	EventCategoryDeleted     EventType = "CategoryDeleted"
+	EventCategoryRestored    EventType = "CategoryRestored"
+	EventCategoryPurged      EventType = "CategoryPurged"
+)
+
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventProductCreated, EventProductUpdated, EventProductPriceChanged, EventStockChanged,
+		EventProductDeleted, EventProductRestored, EventProductPurged,
+		EventCategoryCreated, EventCategoryUpdated, EventCategoryDeleted, EventCategoryRestored,
+		EventCategoryPurged:
+		return true
+	}
+	return false
+}
+
+// DomainEvent is an event kept in the outbox until it is published. Payload is a JSON object
+// with the record's state after the change, or before it for a purge, and the changed fields.
+type DomainEvent struct {
+	ID            int64
+	Type          EventType
+	AggregateType AuditEntityType
+	AggregateID   string
+	Actor         string
+	RequestID     string
+	OccurredAt    time.Time
+	Payload       json.RawMessage
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	PublishedAt   *time.Time
+	DeadAt        *time.Time
+}
+
+// OutboxPolicy decides how events are delivered from the outbox.
+type OutboxPolicy struct {
+	// BatchSize caps the events claimed at a time.
+	BatchSize int
+	// Lease is how long a claimed event is left to its dispatcher before it is due again, which
+	// is how events claimed by a dispatcher that stopped mid-delivery get delivered.
+	Lease time.Duration
+	// MaxAttempts is how many failed deliveries an event gets before it is dead-lettered.
+	MaxAttempts int
+	// Backoff is the wait after the first failed delivery; it doubles with every further
+	// failure up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+type IdempotencyStatus string
+
+const (
+	IdempotencyStatusInProgress IdempotencyStatus = "in_progress"
+	IdempotencyStatusCompleted  IdempotencyStatus = "completed"
+)
+
+// IdempotencyRecord remembers the outcome of a request made with an Idempotency-Key so that
+// retries of the same request get the same response instead of repeating its side effects.
+// Token identifies the reservation that wrote the record: a request that outlives its in-flight
+// timeout loses the key to a retry, and its token then no longer lets it complete or release it.
+type IdempotencyRecord struct {
+	ClientID        string
+	Key             string
+	Token           string
+	RequestHash     string
+	Status          IdempotencyStatus
+	ResponseStatus  int
+	ResponseHeaders map[string]string
+	ResponseBody    []byte
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
+
+// IdempotentResponse is the response stored for a completed request.
+type IdempotentResponse struct {
+	Status  int
+	Headers map[string]string
+	Body    []byte
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type JobType string
+
+const (
+	JobTypeProductImport      JobType = "product.import"
+	JobTypeProductExport      JobType = "product.export"
+	JobTypeProductPriceChange JobType = "product.price_change"
+)
+
+func (t JobType) IsValid() bool {
+	return t == JobTypeProductImport || t == JobTypeProductExport || t == JobTypeProductPriceChange
+}
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// IsFinished tells whether a job in this status is done running for good.
+func (s JobStatus) IsFinished() bool {
+	return s == JobStatusSucceeded || s == JobStatusFailed || s == JobStatusCancelled
+}
+
+// JobProgress counts the items a job has done out of the ones it has to do. Total is 0 until the
+// job knows it.
+type JobProgress struct {
+	Done  int
+	Total int
+}
+
+// Job is a piece of work run in the background by the worker pool. Params, Checkpoint and Result
+// are JSON documents whose shape depends on Type.
+type Job struct {
+	ID     string
+	Type   JobType
+	Params json.RawMessage
+	Status JobStatus
+	// Progress and Checkpoint are saved as the job runs. A job that is interrupted resumes from
+	// its checkpoint when it is run again.
+	Progress   JobProgress
+	Checkpoint json.RawMessage
+	Result     json.RawMessage
+	Error      string
+	// Attempts counts the times the job was started, including resumes after an interruption.
+	Attempts        int
+	CancelRequested bool
+	// Actor and RequestID are those of the request that queued the job; its writes are
+	// attributed to them.
+	Actor      string
+	RequestID  string
+	LeaseUntil *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// JobPolicy decides how jobs are run.
+type JobPolicy struct {
+	// Workers is how many jobs run at a time.
+	Workers int
+	// PollInterval is how often an idle worker looks for a queued job.
+	PollInterval time.Duration
+	// Lease is how long a running job is kept from other workers without a heartbeat. A job
+	// whose worker died is resumed once its lease has passed.
+	Lease time.Duration
+	// MaxAttempts caps the times a job is started; a job interrupted that often fails instead.
+	MaxAttempts int
+	// ChunkSize is how many items a job reads or writes at a time.
+	ChunkSize int
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type LotStatus string
+
+const (
+	LotStatusActive      LotStatus = "active"
+	LotStatusQuarantined LotStatus = "quarantined"
+)
+
+type Lot struct {
+	ID         string
+	ProductID  string
+	LotNumber  string
+	Quantity   int
+	ReceivedAt time.Time
+	ExpiresAt  time.Time
+	Status     LotStatus
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+
+	Product *Product
+}
+
+type LotConsumption struct {
+	LotID     string
+	LotNumber string
+	Quantity  int
+}
+
+type ExpiringLotsFilter struct {
+	Before time.Time
+	Pagination
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusPaid      OrderStatus = "paid"
+	OrderStatusShipped   OrderStatus = "shipped"
+	OrderStatusCancelled OrderStatus = "cancelled"
+)
+
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:    {OrderStatusShipped, OrderStatusCancelled},
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type Order struct {
+	ID            string
+	Status        OrderStatus
+	CustomerRef   string
+	TotalQuantity int
+	TotalAmount   float64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	Lines []OrderLine
+}
+
+// OrderLine snapshots the product as it was when the order was placed.
+type OrderLine struct {
+	ID          string
+	OrderID     string
+	ProductID   string
+	ProductName string
+	SKU         string
+	UnitPrice   float64
+	Quantity    int
+	LineTotal   float64
+}
+
+type OrdersFilter struct {
+	Status *OrderStatus
+	Pagination
+}
+
+// CalculateTotals fills in line totals and the order totals from unit prices and quantities.
+func (o *Order) CalculateTotals() {
+	o.TotalQuantity = 0
+	o.TotalAmount = 0
+	for i := range o.Lines {
+		o.Lines[i].LineTotal = RoundMoney(o.Lines[i].UnitPrice * float64(o.Lines[i].Quantity))
+		o.TotalQuantity += o.Lines[i].Quantity
+		o.TotalAmount += o.Lines[i].LineTotal
+	}
+	o.TotalAmount = RoundMoney(o.TotalAmount)
+}
+
+// RoundMoney rounds an amount to whole cents.
+func RoundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
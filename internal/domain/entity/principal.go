@@ -0,0 +1,18 @@
+package entity
+
+import "context"
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal its caller authenticated as.
+// Unlike the actor, which callers name themselves, the principal is established by the server.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the principal carried by ctx, empty when the caller did not
+// authenticate.
+func PrincipalFrom(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
@@ -12,13 +12,33 @@ type Product struct {
 	Price       *float64
 	Stock       *int
 	ImageURL    *string
+	Version     int
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DeletedAt   *time.Time
 
 	CategoryID string
 	Category   *Category
 }
 
+// ProductPatch holds the fields a partial update sets. Nil fields are left as they are; an empty
+// Description or ImageURL clears it.
+type ProductPatch struct {
+	Name        *string
+	Description *string
+	SKU         *string
+	Price       *float64
+	Stock       *int
+	ImageURL    *string
+	CategoryID  *string
+	Version     int
+}
+
+func (p ProductPatch) IsEmpty() bool {
+	return p.Name == nil && p.Description == nil && p.SKU == nil && p.Price == nil &&
+		p.Stock == nil && p.ImageURL == nil && p.CategoryID == nil
+}
+
 type ProductFilter struct {
 	Name       *string
 	CategoryID *string
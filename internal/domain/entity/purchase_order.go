@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderStatusSubmitted         PurchaseOrderStatus = "submitted"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderStatusCancelled         PurchaseOrderStatus = "cancelled"
+)
+
+var purchaseOrderTransitions = map[PurchaseOrderStatus][]PurchaseOrderStatus{
+	PurchaseOrderStatusDraft:             {PurchaseOrderStatusSubmitted, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusSubmitted:         {PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusPartiallyReceived: {PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived, PurchaseOrderStatusCancelled},
+}
+
+func (s PurchaseOrderStatus) CanTransitionTo(next PurchaseOrderStatus) bool {
+	for _, allowed := range purchaseOrderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s PurchaseOrderStatus) CanReceive() bool {
+	return s == PurchaseOrderStatusSubmitted || s == PurchaseOrderStatusPartiallyReceived
+}
+
+type PurchaseOrder struct {
+	ID          string
+	SupplierID  *string
+	Status      PurchaseOrderStatus
+	Notes       string
+	SubmittedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Lines    []PurchaseOrderLine
+	Supplier *Supplier
+}
+
+type PurchaseOrderLine struct {
+	ID               string
+	PurchaseOrderID  string
+	ProductID        string
+	Quantity         int
+	ReceivedQuantity int
+	UnitCost         float64
+
+	Product *Product
+}
+
+func (l PurchaseOrderLine) Outstanding() int {
+	return l.Quantity - l.ReceivedQuantity
+}
+
+type PurchaseOrderReceipt struct {
+	LineID    string
+	Quantity  int
+	LotNumber string
+	ExpiresAt *time.Time
+}
+
+type PurchaseOrdersFilter struct {
+	Status     *PurchaseOrderStatus
+	SupplierID *string
+	Pagination
+}
+
+// ApplyReceipts adds received quantities to the matching lines and moves the order to
+// partially_received or received. It rejects unknown lines and over-receiving.
+func (po *PurchaseOrder) ApplyReceipts(receipts []PurchaseOrderReceipt) error {
+	if !po.Status.CanReceive() {
+		return fmt.Errorf("purchase order in status %s cannot be received", po.Status)
+	}
+
+	lines := make(map[string]*PurchaseOrderLine, len(po.Lines))
+	for i := range po.Lines {
+		lines[po.Lines[i].ID] = &po.Lines[i]
+	}
+
+	for _, receipt := range receipts {
+		line, ok := lines[receipt.LineID]
+		if !ok {
+			return fmt.Errorf("line %s does not belong to purchase order", receipt.LineID)
+		}
+		if receipt.Quantity <= 0 {
+			return fmt.Errorf("line %s: received quantity must be greater than zero", receipt.LineID)
+		}
+		if receipt.Quantity > line.Outstanding() {
+			return fmt.Errorf("line %s: receiving %d exceeds outstanding quantity %d", receipt.LineID, receipt.Quantity, line.Outstanding())
+		}
+		line.ReceivedQuantity += receipt.Quantity
+	}
+
+	po.Status = PurchaseOrderStatusReceived
+	for _, line := range po.Lines {
+		if line.Outstanding() > 0 {
+			po.Status = PurchaseOrderStatusPartiallyReceived
+			break
+		}
+	}
+
+	return nil
+}
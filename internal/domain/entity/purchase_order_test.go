@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSubmittedOrder() *PurchaseOrder {
+	return &PurchaseOrder{
+		Status: PurchaseOrderStatusSubmitted,
+		Lines: []PurchaseOrderLine{
+			{ID: "line-1", Quantity: 10},
+			{ID: "line-2", Quantity: 5, ReceivedQuantity: 2},
+		},
+	}
+}
+
+func TestApplyReceipts_Partial(t *testing.T) {
+	order := newSubmittedOrder()
+
+	err := order.ApplyReceipts([]PurchaseOrderReceipt{{LineID: "line-1", Quantity: 10}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, PurchaseOrderStatusPartiallyReceived, order.Status)
+	assert.Equal(t, 10, order.Lines[0].ReceivedQuantity)
+}
+
+func TestApplyReceipts_Complete(t *testing.T) {
+	order := newSubmittedOrder()
+
+	err := order.ApplyReceipts([]PurchaseOrderReceipt{
+		{LineID: "line-1", Quantity: 10},
+		{LineID: "line-2", Quantity: 3},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, PurchaseOrderStatusReceived, order.Status)
+}
+
+func TestApplyReceipts_OverReceiving(t *testing.T) {
+	order := newSubmittedOrder()
+
+	err := order.ApplyReceipts([]PurchaseOrderReceipt{
+		{LineID: "line-2", Quantity: 2},
+		{LineID: "line-2", Quantity: 2},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestApplyReceipts_UnknownLine(t *testing.T) {
+	order := newSubmittedOrder()
+
+	err := order.ApplyReceipts([]PurchaseOrderReceipt{{LineID: "other", Quantity: 1}})
+
+	assert.Error(t, err)
+}
+
+func TestApplyReceipts_DraftOrder(t *testing.T) {
+	order := newSubmittedOrder()
+	order.Status = PurchaseOrderStatusDraft
+
+	err := order.ApplyReceipts([]PurchaseOrderReceipt{{LineID: "line-1", Quantity: 1}})
+
+	assert.Error(t, err)
+}
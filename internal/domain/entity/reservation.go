@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+type ReservationStatus string
+
+const (
+	ReservationStatusHeld     ReservationStatus = "held"
+	ReservationStatusReleased ReservationStatus = "released"
+	ReservationStatusExpired  ReservationStatus = "expired"
+)
+
+// Reservation holds stock of a product for a terminal until it is released or expires. The stock
+// is taken when the reservation is made and put back when it ends.
+type Reservation struct {
+	ID        string
+	ProductID string
+	Quantity  int
+	Status    ReservationStatus
+	// Actor is who made the reservation, as attributed by the request that made it.
+	Actor string
+	// Owner is the authenticated principal that made the reservation and the only one that can
+	// release it.
+	Owner      string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	ReleasedAt *time.Time
+}
+
+// ReservationPolicy decides how long reservations are held and how they are expired.
+type ReservationPolicy struct {
+	// TTL is how long a reservation holds its stock before it expires.
+	TTL time.Duration
+	// BatchSize caps the expired reservations released at a time.
+	BatchSize int
+}
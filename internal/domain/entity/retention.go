@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// RetentionPolicy decides which soft-deleted products and categories are purged for good.
+type RetentionPolicy struct {
+	// Days is how long a row stays in the trash before it is purged.
+	Days int
+	// BatchSize caps the rows looked up and purged per statement.
+	BatchSize int
+	// DryRun only reports the rows that would be purged.
+	DryRun bool
+}
+
+// RetentionRun is the outcome of one run of the retention policy. Err is nil when the run
+// completed; a failed run still reports what it purged before failing.
+type RetentionRun struct {
+	StartedAt     time.Time
+	FinishedAt    time.Time
+	DeletedBefore time.Time
+	DryRun        bool
+	Products      RetentionResult
+	Categories    RetentionResult
+	Err           error
+}
+
+// RetentionResult lists the rows of one table a run purged, or would have purged in a dry run,
+// and the ones it kept because other rows still reference them.
+type RetentionResult struct {
+	Purged  []string
+	Skipped []string
+}
@@ -0,0 +1,112 @@
+package entity
+
+import "time"
+
+type ReturnStatus string
+
+const (
+	ReturnStatusAuthorized ReturnStatus = "authorized"
+	ReturnStatusReceived   ReturnStatus = "received"
+	ReturnStatusCancelled  ReturnStatus = "cancelled"
+)
+
+var returnTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnStatusAuthorized: {ReturnStatusReceived, ReturnStatusCancelled},
+}
+
+func (s ReturnStatus) CanTransitionTo(next ReturnStatus) bool {
+	for _, allowed := range returnTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ReturnDisposition says what happens to returned units once they arrive.
+type ReturnDisposition string
+
+const (
+	ReturnDispositionRestock   ReturnDisposition = "restock"
+	ReturnDispositionRefurbish ReturnDisposition = "refurbish"
+	ReturnDispositionScrap     ReturnDisposition = "scrap"
+)
+
+func (d ReturnDisposition) IsValid() bool {
+	switch d {
+	case ReturnDispositionRestock, ReturnDispositionRefurbish, ReturnDispositionScrap:
+		return true
+	}
+	return false
+}
+
+// ReturnAuthorization (RMA) records products a customer is allowed to send back. Units of the
+// same product with different dispositions are recorded as separate lines.
+type ReturnAuthorization struct {
+	ID         string
+	OrderID    *string
+	Status     ReturnStatus
+	Reason     string
+	ReceivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+
+	Lines []ReturnLine
+}
+
+type ReturnLine struct {
+	ID          string
+	ReturnID    string
+	ProductID   string
+	Quantity    int
+	Disposition ReturnDisposition
+}
+
+// RestockAdjustments returns the stock changes for the lines marked for restock.
+func (r ReturnAuthorization) RestockAdjustments() []StockAdjustment {
+	var adjustments []StockAdjustment
+	for _, line := range r.Lines {
+		if line.Disposition == ReturnDispositionRestock {
+			adjustments = append(adjustments, StockAdjustment{ProductID: line.ProductID, Delta: line.Quantity})
+		}
+	}
+	return adjustments
+}
+
+type ReturnsFilter struct {
+	Status    *ReturnStatus
+	OrderID   *string
+	ProductID *string
+	Pagination
+}
+
+type ReturnRateGroup string
+
+const (
+	ReturnRateByProduct  ReturnRateGroup = "product"
+	ReturnRateByCategory ReturnRateGroup = "category"
+)
+
+type ReturnRateFilter struct {
+	GroupBy ReturnRateGroup
+	From    *time.Time
+	To      *time.Time
+}
+
+// ReturnRate compares received returns with quantities sold on paid or shipped orders for one
+// product or category.
+type ReturnRate struct {
+	ID               string
+	Name             string
+	SoldQuantity     int
+	ReturnedQuantity int
+	Rate             float64
+}
+
+// CalculateRate sets Rate to returned/sold, or zero when nothing was sold.
+func (r *ReturnRate) CalculateRate() {
+	r.Rate = 0
+	if r.SoldQuantity > 0 {
+		r.Rate = float64(r.ReturnedQuantity) / float64(r.SoldQuantity)
+	}
+}
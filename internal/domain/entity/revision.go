@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// Revision describes one stored state of a product or category. Revisions are numbered per record
+// from 1, and each write that changes the record adds the next one.
+type Revision struct {
+	EntityType AuditEntityType
+	EntityID   string
+	Number     int
+	Action     AuditAction
+	Actor      string
+	RequestID  string
+	CreatedAt  time.Time
+}
+
+// ProductRevision is a revision together with the product as it was stored in it.
+type ProductRevision struct {
+	Revision
+	Product Product
+}
+
+// CategoryRevision is a revision together with the category as it was stored in it.
+type CategoryRevision struct {
+	Revision
+	Category Category
+}
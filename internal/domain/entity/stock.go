@@ -0,0 +1,24 @@
+package entity
+
+type StockAdjustment struct {
+	ProductID string
+	Delta     int
+}
+
+type StockShortageReason string
+
+const (
+	StockShortageNotFound     StockShortageReason = "PRODUCT_NOT_FOUND"
+	StockShortageInsufficient StockShortageReason = "INSUFFICIENT_STOCK"
+	StockShortageNoActiveLot  StockShortageReason = "NO_ACTIVE_LOT"
+)
+
+// StockShortage explains why a single adjustment could not be applied. It is returned to
+// clients as error details, hence the json tags.
+type StockShortage struct {
+	Index     int                 `json:"line"`
+	ProductID string              `json:"productId"`
+	Requested int                 `json:"requested"`
+	Available int                 `json:"available"`
+	Reason    StockShortageReason `json:"reason"`
+}
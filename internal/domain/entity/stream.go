@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// StreamFilter picks the product changes a stream subscriber is sent: those of the listed
+// products and of the products in the listed categories. An empty filter keeps every change.
+type StreamFilter struct {
+	// CategoryIDs keeps the changes that leave a product in one of these categories.
+	CategoryIDs []string
+	ProductIDs  []string
+}
+
+// Matches tells whether change is a product change the filter keeps. A purge carries no product,
+// so only a filter on product IDs can keep it.
+func (f StreamFilter) Matches(change Change) bool {
+	if change.EntityType != AuditEntityProduct {
+		return false
+	}
+	if len(f.CategoryIDs) == 0 && len(f.ProductIDs) == 0 {
+		return true
+	}
+	if slices.Contains(f.ProductIDs, change.EntityID) {
+		return true
+	}
+	return change.Product != nil && slices.Contains(f.CategoryIDs, change.Product.CategoryID)
+}
+
+// StreamPolicy decides how changes are streamed to subscribers.
+type StreamPolicy struct {
+	// PollInterval is how often the changes feed is read for new changes.
+	PollInterval time.Duration
+	// BatchSize caps the changes read at a time.
+	BatchSize int
+	// BufferSize is how many changes may wait for a subscriber before it is cut off as too slow.
+	BufferSize int
+}
@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+type Supplier struct {
+	ID           string
+	Name         string
+	ContactName  string
+	ContactEmail string
+	ContactPhone string
+	LeadTimeDays int
+	Currency     string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type SuppliersFilter struct {
+	Name *string
+	Pagination
+}
+
+type ProductSupplier struct {
+	ProductID        string
+	SupplierID       string
+	SupplierSKU      string
+	CostPrice        float64
+	MinOrderQuantity int
+	Preferred        bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+
+	Product  *Product
+	Supplier *Supplier
+}
@@ -0,0 +1,7 @@
+package entity
+
+// VersionConflict is returned as error details when a write was made against a stale version,
+// so the client can see which version the resource is at now.
+type VersionConflict struct {
+	CurrentVersion int `json:"currentVersion"`
+}
@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Webhook is a partner's subscription to domain events, delivered by POSTing them to URL.
+type Webhook struct {
+	ID  string
+	URL string
+	// EventTypes lists the events delivered; all of them when empty.
+	EventTypes []EventType
+	Filter     WebhookFilter
+	// Secret signs every delivery.
+	Secret  string
+	Enabled bool
+	// DisabledReason says why a webhook was disabled automatically.
+	DisabledReason      string
+	ConsecutiveFailures int
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// WebhookFilter narrows the events of a webhook to some records. An empty list does not filter.
+type WebhookFilter struct {
+	// CategoryIDs keeps the events of these categories and of the products in them.
+	CategoryIDs []string
+	// ProductIDs keeps the events of these products.
+	ProductIDs []string
+}
+
+// Subscribes tells whether the webhook is sent events of eventType.
+func (w Webhook) Subscribes(eventType EventType) bool {
+	return len(w.EventTypes) == 0 || slices.Contains(w.EventTypes, eventType)
+}
+
+type WebhooksFilter struct {
+	Pagination
+}
+
+// WebhookDeliveryStatus is where a delivery stands.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliverySucceeded, WebhookDeliveryFailed:
+		return true
+	}
+	return false
+}
+
+// WebhookDelivery is one event sent, or to be sent, to a webhook, with the outcome of its
+// latest attempt. A redelivery is a delivery of its own that points at the one it repeats.
+type WebhookDelivery struct {
+	ID        int64
+	WebhookID string
+	EventID   int64
+	EventType EventType
+	// Payload is the request body, kept so a redelivery sends the same one.
+	Payload        json.RawMessage
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	ResponseStatus int
+	ResponseBody   string
+	LastError      string
+	NextAttemptAt  time.Time
+	RedeliveryOf   *int64
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+type WebhookDeliveriesFilter struct {
+	Status *WebhookDeliveryStatus
+	Pagination
+}
+
+// WebhookPolicy decides how deliveries are sent.
+type WebhookPolicy struct {
+	// BatchSize caps the deliveries claimed at a time.
+	BatchSize int
+	// Lease is how long a claimed delivery is left to its sender before it is due again.
+	Lease time.Duration
+	// MaxAttempts is how many attempts a delivery gets before it fails for good.
+	MaxAttempts int
+	// Backoff is the wait after the first failed attempt; it doubles with every further
+	// failure up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// DisableAfter is how many attempts in a row may fail before the webhook is disabled.
+	DisableAfter int
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=audit.go -destination=mocks/mock_audit.go -package=mocks
+type AuditRepository interface {
+	// FindAll lists audit entries, most recent first. Entries are written by the product and
+	// category repositories, in the transaction of the change they record.
+	FindAll(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirawong/crud-arise/internal/domain/entity"
 )
@@ -9,8 +10,26 @@ import (
 //go:generate mockgen -source=category.go -destination=mocks/mock_category.go -package=mocks
 type CategoryRepository interface {
 	Create(ctx context.Context, category *entity.Category) (string, error)
+	CreateMany(ctx context.Context, categories []entity.Category) ([]string, error)
 	FindByID(ctx context.Context, id string) (*entity.Category, error)
+	FindByName(ctx context.Context, name string) (*entity.Category, error)
 	Update(ctx context.Context, category *entity.Category) error
+	Patch(ctx context.Context, id string, patch entity.CategoryPatch) error
 	FindAll(ctx context.Context, filter entity.CategoriesFilter) ([]entity.Category, error)
-	Delete(ctx context.Context, id string) error
+	Stats(ctx context.Context, filter entity.CategoriesFilter) (*entity.ListStats, error)
+	UpdateMany(ctx context.Context, categories []entity.Category) ([]error, error)
+	Delete(ctx context.Context, id string, version int) error
+	DeleteMany(ctx context.Context, refs []entity.VersionedID) ([]error, error)
+	FindDeleted(ctx context.Context, pagination entity.Pagination) ([]entity.Category, error)
+	FindDeletedByID(ctx context.Context, id string) (*entity.Category, error)
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string) error
+	// FindExpiredIDs lists, in id order, up to limit ids of rows soft-deleted before deletedBefore
+	// whose id sorts after afterID; an empty afterID starts from the first one.
+	FindExpiredIDs(ctx context.Context, deletedBefore time.Time, afterID string, limit int) ([]string, error)
+	PurgeMany(ctx context.Context, ids []string) error
+	// FindRevisions lists a page of the revisions of the category with id, deleted or not, newest
+	// first.
+	FindRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error)
+	FindRevision(ctx context.Context, id string, number int) (*entity.CategoryRevision, error)
 }
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=change.go -destination=mocks/mock_change.go -package=mocks
+type ChangeRepository interface {
+	// FindSince lists up to limit changes numbered after since, in order, each with the record as
+	// the change left it. Changes are written by the product and category repositories along
+	// with their audit entries.
+	FindSince(ctx context.Context, since int64, limit int) ([]entity.Change, error)
+	// LastSeq returns the number of the latest change, 0 while there is none.
+	LastSeq(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+)
+
+//go:generate mockgen -source=export_page.go -destination=mocks/mock_export_page.go -package=mocks
+type ExportPageRepository interface {
+	// Save stores page of a job's export, numbered from 1, replacing it if it was written before.
+	Save(ctx context.Context, jobID string, page int, products json.RawMessage) error
+	// Find returns page of a job's export, failing with NOT_FOUND when it was not written.
+	Find(ctx context.Context, jobID string, page int) (json.RawMessage, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=idempotency.go -destination=mocks/mock_idempotency.go -package=mocks
+type IdempotencyRepository interface {
+	// Reserve stores record unless an unexpired record already exists for the same client and
+	// key, in which case it returns that record and leaves it untouched. A nil record means the
+	// reservation was taken.
+	Reserve(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error)
+	// Complete stores the response on the in-progress record of the same client, key and token.
+	// It returns ErrNotFound once the reservation has been taken over or released.
+	Complete(ctx context.Context, record *entity.IdempotencyRecord) error
+	// Delete drops the record of client and key if it still belongs to the reservation token.
+	Delete(ctx context.Context, clientID, key, token string) error
+	DeleteExpired(ctx context.Context, asOf time.Time) (int64, error)
+}
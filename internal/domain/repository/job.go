@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=job.go -destination=mocks/mock_job.go -package=mocks
+type JobRepository interface {
+	Create(ctx context.Context, job *entity.Job) (string, error)
+	FindByID(ctx context.Context, id string) (*entity.Job, error)
+	// Claim starts the oldest queued job, or resumes a running one whose lease has passed, and
+	// leases it for lease. It returns nil when there is no job to run.
+	//
+	// The methods that write a running job's state take the job's Attempts as Claim returned it,
+	// and fail with a conflict once the job has stopped running or another worker has claimed it
+	// since.
+	Claim(ctx context.Context, lease time.Duration) (*entity.Job, error)
+	// Heartbeat extends the lease of a running job and tells whether it was asked to cancel.
+	Heartbeat(ctx context.Context, id string, attempts int, lease time.Duration) (bool, error)
+	SaveProgress(ctx context.Context, id string, attempts int, progress entity.JobProgress, checkpoint json.RawMessage) error
+	// Finish ends a running job in status with its result or error.
+	Finish(ctx context.Context, id string, attempts int, status entity.JobStatus, result json.RawMessage, errMsg string) error
+	// Requeue puts a running job back in the queue, keeping its progress and checkpoint.
+	Requeue(ctx context.Context, id string, attempts int) error
+	// RequestCancel cancels a queued job right away and asks a running one to cancel. It fails
+	// with a conflict once the job is finished.
+	RequestCancel(ctx context.Context, id string) (*entity.Job, error)
+	// PurgeFinished deletes the jobs that finished before finishedBefore.
+	PurgeFinished(ctx context.Context, finishedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=lot.go -destination=mocks/mock_lot.go -package=mocks
+type LotRepository interface {
+	Create(ctx context.Context, lot *entity.Lot) (string, error)
+	FindByProductID(ctx context.Context, productID string) ([]entity.Lot, error)
+	FindExpiring(ctx context.Context, filter entity.ExpiringLotsFilter) ([]entity.Lot, error)
+	Consume(ctx context.Context, productID string, quantity int, asOf time.Time) ([]entity.LotConsumption, error)
+	QuarantineExpired(ctx context.Context, asOf time.Time) (int64, error)
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/sirawong/crud-arise/internal/domain/entity"
 	gomock "go.uber.org/mock/gomock"
@@ -56,18 +57,48 @@ func (mr *MockCategoryRepositoryMockRecorder) Create(ctx, category any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategoryRepository)(nil).Create), ctx, category)
 }
 
+// CreateMany mocks base method.
+func (m *MockCategoryRepository) CreateMany(ctx context.Context, categories []entity.Category) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, categories)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockCategoryRepositoryMockRecorder) CreateMany(ctx, categories any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockCategoryRepository)(nil).CreateMany), ctx, categories)
+}
+
 // Delete mocks base method.
-func (m *MockCategoryRepository) Delete(ctx context.Context, id string) error {
+func (m *MockCategoryRepository) Delete(ctx context.Context, id string, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCategoryRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) Delete(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepository)(nil).Delete), ctx, id, version)
+}
+
+// DeleteMany mocks base method.
+func (m *MockCategoryRepository) DeleteMany(ctx context.Context, refs []entity.VersionedID) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, refs)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockCategoryRepositoryMockRecorder) DeleteMany(ctx, refs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockCategoryRepository)(nil).DeleteMany), ctx, refs)
 }
 
 // FindAll mocks base method.
@@ -100,6 +131,167 @@ func (mr *MockCategoryRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCategoryRepository)(nil).FindByID), ctx, id)
 }
 
+// FindByName mocks base method.
+func (m *MockCategoryRepository) FindByName(ctx context.Context, name string) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockCategoryRepositoryMockRecorder) FindByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockCategoryRepository)(nil).FindByName), ctx, name)
+}
+
+// FindDeleted mocks base method.
+func (m *MockCategoryRepository) FindDeleted(ctx context.Context, pagination entity.Pagination) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeleted", ctx, pagination)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeleted indicates an expected call of FindDeleted.
+func (mr *MockCategoryRepositoryMockRecorder) FindDeleted(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeleted", reflect.TypeOf((*MockCategoryRepository)(nil).FindDeleted), ctx, pagination)
+}
+
+// FindDeletedByID mocks base method.
+func (m *MockCategoryRepository) FindDeletedByID(ctx context.Context, id string) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByID indicates an expected call of FindDeletedByID.
+func (mr *MockCategoryRepositoryMockRecorder) FindDeletedByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByID", reflect.TypeOf((*MockCategoryRepository)(nil).FindDeletedByID), ctx, id)
+}
+
+// FindExpiredIDs mocks base method.
+func (m *MockCategoryRepository) FindExpiredIDs(ctx context.Context, deletedBefore time.Time, afterID string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredIDs", ctx, deletedBefore, afterID, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredIDs indicates an expected call of FindExpiredIDs.
+func (mr *MockCategoryRepositoryMockRecorder) FindExpiredIDs(ctx, deletedBefore, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredIDs", reflect.TypeOf((*MockCategoryRepository)(nil).FindExpiredIDs), ctx, deletedBefore, afterID, limit)
+}
+
+// FindRevision mocks base method.
+func (m *MockCategoryRepository) FindRevision(ctx context.Context, id string, number int) (*entity.CategoryRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevision", ctx, id, number)
+	ret0, _ := ret[0].(*entity.CategoryRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevision indicates an expected call of FindRevision.
+func (mr *MockCategoryRepositoryMockRecorder) FindRevision(ctx, id, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevision", reflect.TypeOf((*MockCategoryRepository)(nil).FindRevision), ctx, id, number)
+}
+
+// FindRevisions mocks base method.
+func (m *MockCategoryRepository) FindRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevisions", ctx, id, pagination)
+	ret0, _ := ret[0].([]entity.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevisions indicates an expected call of FindRevisions.
+func (mr *MockCategoryRepositoryMockRecorder) FindRevisions(ctx, id, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevisions", reflect.TypeOf((*MockCategoryRepository)(nil).FindRevisions), ctx, id, pagination)
+}
+
+// Patch mocks base method.
+func (m *MockCategoryRepository) Patch(ctx context.Context, id string, patch entity.CategoryPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockCategoryRepositoryMockRecorder) Patch(ctx, id, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockCategoryRepository)(nil).Patch), ctx, id, patch)
+}
+
+// Purge mocks base method.
+func (m *MockCategoryRepository) Purge(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockCategoryRepositoryMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockCategoryRepository)(nil).Purge), ctx, id)
+}
+
+// PurgeMany mocks base method.
+func (m *MockCategoryRepository) PurgeMany(ctx context.Context, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeMany", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeMany indicates an expected call of PurgeMany.
+func (mr *MockCategoryRepositoryMockRecorder) PurgeMany(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeMany", reflect.TypeOf((*MockCategoryRepository)(nil).PurgeMany), ctx, ids)
+}
+
+// Restore mocks base method.
+func (m *MockCategoryRepository) Restore(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockCategoryRepositoryMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockCategoryRepository)(nil).Restore), ctx, id)
+}
+
+// Stats mocks base method.
+func (m *MockCategoryRepository) Stats(ctx context.Context, filter entity.CategoriesFilter) (*entity.ListStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, filter)
+	ret0, _ := ret[0].(*entity.ListStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockCategoryRepositoryMockRecorder) Stats(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockCategoryRepository)(nil).Stats), ctx, filter)
+}
+
 // Update mocks base method.
 func (m *MockCategoryRepository) Update(ctx context.Context, category *entity.Category) error {
 	m.ctrl.T.Helper()
@@ -113,3 +305,18 @@ func (mr *MockCategoryRepositoryMockRecorder) Update(ctx, category any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategoryRepository)(nil).Update), ctx, category)
 }
+
+// UpdateMany mocks base method.
+func (m *MockCategoryRepository) UpdateMany(ctx context.Context, categories []entity.Category) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMany", ctx, categories)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMany indicates an expected call of UpdateMany.
+func (mr *MockCategoryRepositoryMockRecorder) UpdateMany(ctx, categories any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMany", reflect.TypeOf((*MockCategoryRepository)(nil).UpdateMany), ctx, categories)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: change.go
+//
+// Generated by this command:
+//
+//	mockgen -source=change.go -destination=mocks/mock_change.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChangeRepository is a mock of ChangeRepository interface.
+type MockChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChangeRepositoryMockRecorder is the mock recorder for MockChangeRepository.
+type MockChangeRepositoryMockRecorder struct {
+	mock *MockChangeRepository
+}
+
+// NewMockChangeRepository creates a new mock instance.
+func NewMockChangeRepository(ctrl *gomock.Controller) *MockChangeRepository {
+	mock := &MockChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeRepository) EXPECT() *MockChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindSince mocks base method.
+func (m *MockChangeRepository) FindSince(ctx context.Context, since int64, limit int) ([]entity.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSince", ctx, since, limit)
+	ret0, _ := ret[0].([]entity.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSince indicates an expected call of FindSince.
+func (mr *MockChangeRepositoryMockRecorder) FindSince(ctx, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSince", reflect.TypeOf((*MockChangeRepository)(nil).FindSince), ctx, since, limit)
+}
+
+// LastSeq mocks base method.
+func (m *MockChangeRepository) LastSeq(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastSeq", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastSeq indicates an expected call of LastSeq.
+func (mr *MockChangeRepositoryMockRecorder) LastSeq(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastSeq", reflect.TypeOf((*MockChangeRepository)(nil).LastSeq), ctx)
+}
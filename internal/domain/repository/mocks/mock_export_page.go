@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: export_page.go
+//
+// Generated by this command:
+//
+//	mockgen -source=export_page.go -destination=mocks/mock_export_page.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	json "encoding/json"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExportPageRepository is a mock of ExportPageRepository interface.
+type MockExportPageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportPageRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockExportPageRepositoryMockRecorder is the mock recorder for MockExportPageRepository.
+type MockExportPageRepositoryMockRecorder struct {
+	mock *MockExportPageRepository
+}
+
+// NewMockExportPageRepository creates a new mock instance.
+func NewMockExportPageRepository(ctrl *gomock.Controller) *MockExportPageRepository {
+	mock := &MockExportPageRepository{ctrl: ctrl}
+	mock.recorder = &MockExportPageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportPageRepository) EXPECT() *MockExportPageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockExportPageRepository) Find(ctx context.Context, jobID string, page int) (json.RawMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, jobID, page)
+	ret0, _ := ret[0].(json.RawMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockExportPageRepositoryMockRecorder) Find(ctx, jobID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockExportPageRepository)(nil).Find), ctx, jobID, page)
+}
+
+// Save mocks base method.
+func (m *MockExportPageRepository) Save(ctx context.Context, jobID string, page int, products json.RawMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, jobID, page, products)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockExportPageRepositoryMockRecorder) Save(ctx, jobID, page, products any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockExportPageRepository)(nil).Save), ctx, jobID, page, products)
+}
@@ -0,0 +1,176 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=job.go -destination=mocks/mock_job.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	json "encoding/json"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockJobRepository) Claim(ctx context.Context, lease time.Duration) (*entity.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, lease)
+	ret0, _ := ret[0].(*entity.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockJobRepositoryMockRecorder) Claim(ctx, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockJobRepository)(nil).Claim), ctx, lease)
+}
+
+// Create mocks base method.
+func (m *MockJobRepository) Create(ctx context.Context, job *entity.Job) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, job)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockJobRepositoryMockRecorder) Create(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockJobRepository)(nil).Create), ctx, job)
+}
+
+// FindByID mocks base method.
+func (m *MockJobRepository) FindByID(ctx context.Context, id string) (*entity.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockJobRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockJobRepository)(nil).FindByID), ctx, id)
+}
+
+// Finish mocks base method.
+func (m *MockJobRepository) Finish(ctx context.Context, id string, attempts int, status entity.JobStatus, result json.RawMessage, errMsg string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, id, attempts, status, result, errMsg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockJobRepositoryMockRecorder) Finish(ctx, id, attempts, status, result, errMsg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockJobRepository)(nil).Finish), ctx, id, attempts, status, result, errMsg)
+}
+
+// Heartbeat mocks base method.
+func (m *MockJobRepository) Heartbeat(ctx context.Context, id string, attempts int, lease time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Heartbeat", ctx, id, attempts, lease)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockJobRepositoryMockRecorder) Heartbeat(ctx, id, attempts, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockJobRepository)(nil).Heartbeat), ctx, id, attempts, lease)
+}
+
+// PurgeFinished mocks base method.
+func (m *MockJobRepository) PurgeFinished(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeFinished", ctx, finishedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeFinished indicates an expected call of PurgeFinished.
+func (mr *MockJobRepositoryMockRecorder) PurgeFinished(ctx, finishedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeFinished", reflect.TypeOf((*MockJobRepository)(nil).PurgeFinished), ctx, finishedBefore)
+}
+
+// RequestCancel mocks base method.
+func (m *MockJobRepository) RequestCancel(ctx context.Context, id string) (*entity.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCancel", ctx, id)
+	ret0, _ := ret[0].(*entity.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestCancel indicates an expected call of RequestCancel.
+func (mr *MockJobRepositoryMockRecorder) RequestCancel(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCancel", reflect.TypeOf((*MockJobRepository)(nil).RequestCancel), ctx, id)
+}
+
+// Requeue mocks base method.
+func (m *MockJobRepository) Requeue(ctx context.Context, id string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requeue", ctx, id, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Requeue indicates an expected call of Requeue.
+func (mr *MockJobRepositoryMockRecorder) Requeue(ctx, id, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requeue", reflect.TypeOf((*MockJobRepository)(nil).Requeue), ctx, id, attempts)
+}
+
+// SaveProgress mocks base method.
+func (m *MockJobRepository) SaveProgress(ctx context.Context, id string, attempts int, progress entity.JobProgress, checkpoint json.RawMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProgress", ctx, id, attempts, progress, checkpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProgress indicates an expected call of SaveProgress.
+func (mr *MockJobRepositoryMockRecorder) SaveProgress(ctx, id, attempts, progress, checkpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProgress", reflect.TypeOf((*MockJobRepository)(nil).SaveProgress), ctx, id, attempts, progress, checkpoint)
+}
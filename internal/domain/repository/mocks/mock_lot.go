@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lot.go
+//
+// Generated by this command:
+//
+//	mockgen -source=lot.go -destination=mocks/mock_lot.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLotRepository is a mock of LotRepository interface.
+type MockLotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLotRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLotRepositoryMockRecorder is the mock recorder for MockLotRepository.
+type MockLotRepositoryMockRecorder struct {
+	mock *MockLotRepository
+}
+
+// NewMockLotRepository creates a new mock instance.
+func NewMockLotRepository(ctrl *gomock.Controller) *MockLotRepository {
+	mock := &MockLotRepository{ctrl: ctrl}
+	mock.recorder = &MockLotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLotRepository) EXPECT() *MockLotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockLotRepository) Consume(ctx context.Context, productID string, quantity int, asOf time.Time) ([]entity.LotConsumption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, productID, quantity, asOf)
+	ret0, _ := ret[0].([]entity.LotConsumption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockLotRepositoryMockRecorder) Consume(ctx, productID, quantity, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockLotRepository)(nil).Consume), ctx, productID, quantity, asOf)
+}
+
+// Create mocks base method.
+func (m *MockLotRepository) Create(ctx context.Context, lot *entity.Lot) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, lot)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLotRepositoryMockRecorder) Create(ctx, lot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLotRepository)(nil).Create), ctx, lot)
+}
+
+// FindByProductID mocks base method.
+func (m *MockLotRepository) FindByProductID(ctx context.Context, productID string) ([]entity.Lot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProductID", ctx, productID)
+	ret0, _ := ret[0].([]entity.Lot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProductID indicates an expected call of FindByProductID.
+func (mr *MockLotRepositoryMockRecorder) FindByProductID(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProductID", reflect.TypeOf((*MockLotRepository)(nil).FindByProductID), ctx, productID)
+}
+
+// FindExpiring mocks base method.
+func (m *MockLotRepository) FindExpiring(ctx context.Context, filter entity.ExpiringLotsFilter) ([]entity.Lot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiring", ctx, filter)
+	ret0, _ := ret[0].([]entity.Lot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiring indicates an expected call of FindExpiring.
+func (mr *MockLotRepositoryMockRecorder) FindExpiring(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiring", reflect.TypeOf((*MockLotRepository)(nil).FindExpiring), ctx, filter)
+}
+
+// QuarantineExpired mocks base method.
+func (m *MockLotRepository) QuarantineExpired(ctx context.Context, asOf time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantineExpired", ctx, asOf)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuarantineExpired indicates an expected call of QuarantineExpired.
+func (mr *MockLotRepositoryMockRecorder) QuarantineExpired(ctx, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantineExpired", reflect.TypeOf((*MockLotRepository)(nil).QuarantineExpired), ctx, asOf)
+}
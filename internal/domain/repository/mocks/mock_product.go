@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/sirawong/crud-arise/internal/domain/entity"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,20 @@ func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AdjustStock mocks base method.
+func (m *MockProductRepository) AdjustStock(ctx context.Context, adjustments []entity.StockAdjustment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, adjustments)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockProductRepositoryMockRecorder) AdjustStock(ctx, adjustments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockProductRepository)(nil).AdjustStock), ctx, adjustments)
+}
+
 // Create mocks base method.
 func (m *MockProductRepository) Create(ctx context.Context, product *entity.Product) (string, error) {
 	m.ctrl.T.Helper()
@@ -56,18 +71,63 @@ func (mr *MockProductRepositoryMockRecorder) Create(ctx, product any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductRepository)(nil).Create), ctx, product)
 }
 
+// CreateMany mocks base method.
+func (m *MockProductRepository) CreateMany(ctx context.Context, products []entity.Product) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, products)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockProductRepositoryMockRecorder) CreateMany(ctx, products any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockProductRepository)(nil).CreateMany), ctx, products)
+}
+
 // Delete mocks base method.
-func (m *MockProductRepository) Delete(ctx context.Context, id string) error {
+func (m *MockProductRepository) Delete(ctx context.Context, id string, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockProductRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) Delete(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductRepository)(nil).Delete), ctx, id, version)
+}
+
+// DeleteMany mocks base method.
+func (m *MockProductRepository) DeleteMany(ctx context.Context, refs []entity.VersionedID) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, refs)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockProductRepositoryMockRecorder) DeleteMany(ctx, refs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockProductRepository)(nil).DeleteMany), ctx, refs)
+}
+
+// FindAfter mocks base method.
+func (m *MockProductRepository) FindAfter(ctx context.Context, filter entity.ProductFilter, afterID string, limit int) ([]entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAfter", ctx, filter, afterID, limit)
+	ret0, _ := ret[0].([]entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAfter indicates an expected call of FindAfter.
+func (mr *MockProductRepositoryMockRecorder) FindAfter(ctx, filter, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAfter", reflect.TypeOf((*MockProductRepository)(nil).FindAfter), ctx, filter, afterID, limit)
 }
 
 // FindAll mocks base method.
@@ -100,6 +160,182 @@ func (mr *MockProductRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProductRepository)(nil).FindByID), ctx, id)
 }
 
+// FindByIDs mocks base method.
+func (m *MockProductRepository) FindByIDs(ctx context.Context, ids []string) ([]entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].([]entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockProductRepositoryMockRecorder) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockProductRepository)(nil).FindByIDs), ctx, ids)
+}
+
+// FindBySKU mocks base method.
+func (m *MockProductRepository) FindBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySKU", ctx, sku)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySKU indicates an expected call of FindBySKU.
+func (mr *MockProductRepositoryMockRecorder) FindBySKU(ctx, sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySKU", reflect.TypeOf((*MockProductRepository)(nil).FindBySKU), ctx, sku)
+}
+
+// FindDeleted mocks base method.
+func (m *MockProductRepository) FindDeleted(ctx context.Context, pagination entity.Pagination) ([]entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeleted", ctx, pagination)
+	ret0, _ := ret[0].([]entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeleted indicates an expected call of FindDeleted.
+func (mr *MockProductRepositoryMockRecorder) FindDeleted(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeleted", reflect.TypeOf((*MockProductRepository)(nil).FindDeleted), ctx, pagination)
+}
+
+// FindDeletedByID mocks base method.
+func (m *MockProductRepository) FindDeletedByID(ctx context.Context, id string) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByID indicates an expected call of FindDeletedByID.
+func (mr *MockProductRepositoryMockRecorder) FindDeletedByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByID", reflect.TypeOf((*MockProductRepository)(nil).FindDeletedByID), ctx, id)
+}
+
+// FindExpiredIDs mocks base method.
+func (m *MockProductRepository) FindExpiredIDs(ctx context.Context, deletedBefore time.Time, afterID string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredIDs", ctx, deletedBefore, afterID, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredIDs indicates an expected call of FindExpiredIDs.
+func (mr *MockProductRepositoryMockRecorder) FindExpiredIDs(ctx, deletedBefore, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredIDs", reflect.TypeOf((*MockProductRepository)(nil).FindExpiredIDs), ctx, deletedBefore, afterID, limit)
+}
+
+// FindRevision mocks base method.
+func (m *MockProductRepository) FindRevision(ctx context.Context, id string, number int) (*entity.ProductRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevision", ctx, id, number)
+	ret0, _ := ret[0].(*entity.ProductRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevision indicates an expected call of FindRevision.
+func (mr *MockProductRepositoryMockRecorder) FindRevision(ctx, id, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevision", reflect.TypeOf((*MockProductRepository)(nil).FindRevision), ctx, id, number)
+}
+
+// FindRevisions mocks base method.
+func (m *MockProductRepository) FindRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevisions", ctx, id, pagination)
+	ret0, _ := ret[0].([]entity.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevisions indicates an expected call of FindRevisions.
+func (mr *MockProductRepositoryMockRecorder) FindRevisions(ctx, id, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevisions", reflect.TypeOf((*MockProductRepository)(nil).FindRevisions), ctx, id, pagination)
+}
+
+// Patch mocks base method.
+func (m *MockProductRepository) Patch(ctx context.Context, id string, patch entity.ProductPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockProductRepositoryMockRecorder) Patch(ctx, id, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockProductRepository)(nil).Patch), ctx, id, patch)
+}
+
+// Purge mocks base method.
+func (m *MockProductRepository) Purge(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockProductRepositoryMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockProductRepository)(nil).Purge), ctx, id)
+}
+
+// PurgeMany mocks base method.
+func (m *MockProductRepository) PurgeMany(ctx context.Context, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeMany", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeMany indicates an expected call of PurgeMany.
+func (mr *MockProductRepositoryMockRecorder) PurgeMany(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeMany", reflect.TypeOf((*MockProductRepository)(nil).PurgeMany), ctx, ids)
+}
+
+// Restore mocks base method.
+func (m *MockProductRepository) Restore(ctx context.Context, id, categoryID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, categoryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockProductRepositoryMockRecorder) Restore(ctx, id, categoryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProductRepository)(nil).Restore), ctx, id, categoryID)
+}
+
+// Stats mocks base method.
+func (m *MockProductRepository) Stats(ctx context.Context, filter entity.ProductFilter) (*entity.ListStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, filter)
+	ret0, _ := ret[0].(*entity.ListStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockProductRepositoryMockRecorder) Stats(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockProductRepository)(nil).Stats), ctx, filter)
+}
+
 // Update mocks base method.
 func (m *MockProductRepository) Update(ctx context.Context, product *entity.Product) error {
 	m.ctrl.T.Helper()
@@ -113,3 +349,33 @@ func (mr *MockProductRepositoryMockRecorder) Update(ctx, product any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductRepository)(nil).Update), ctx, product)
 }
+
+// UpdateMany mocks base method.
+func (m *MockProductRepository) UpdateMany(ctx context.Context, products []entity.Product) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMany", ctx, products)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMany indicates an expected call of UpdateMany.
+func (mr *MockProductRepositoryMockRecorder) UpdateMany(ctx, products any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMany", reflect.TypeOf((*MockProductRepository)(nil).UpdateMany), ctx, products)
+}
+
+// UpsertBySKU mocks base method.
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, products []entity.Product) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertBySKU", ctx, products)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertBySKU indicates an expected call of UpsertBySKU.
+func (mr *MockProductRepositoryMockRecorder) UpsertBySKU(ctx, products any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertBySKU", reflect.TypeOf((*MockProductRepository)(nil).UpsertBySKU), ctx, products)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: product_supplier.go
+//
+// Generated by this command:
+//
+//	mockgen -source=product_supplier.go -destination=mocks/mock_product_supplier.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductSupplierRepository is a mock of ProductSupplierRepository interface.
+type MockProductSupplierRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductSupplierRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockProductSupplierRepositoryMockRecorder is the mock recorder for MockProductSupplierRepository.
+type MockProductSupplierRepositoryMockRecorder struct {
+	mock *MockProductSupplierRepository
+}
+
+// NewMockProductSupplierRepository creates a new mock instance.
+func NewMockProductSupplierRepository(ctrl *gomock.Controller) *MockProductSupplierRepository {
+	mock := &MockProductSupplierRepository{ctrl: ctrl}
+	mock.recorder = &MockProductSupplierRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductSupplierRepository) EXPECT() *MockProductSupplierRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProductSupplierRepository) Delete(ctx context.Context, productID, supplierID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, productID, supplierID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductSupplierRepositoryMockRecorder) Delete(ctx, productID, supplierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductSupplierRepository)(nil).Delete), ctx, productID, supplierID)
+}
+
+// FindByProductID mocks base method.
+func (m *MockProductSupplierRepository) FindByProductID(ctx context.Context, productID string) ([]entity.ProductSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProductID", ctx, productID)
+	ret0, _ := ret[0].([]entity.ProductSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProductID indicates an expected call of FindByProductID.
+func (mr *MockProductSupplierRepositoryMockRecorder) FindByProductID(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProductID", reflect.TypeOf((*MockProductSupplierRepository)(nil).FindByProductID), ctx, productID)
+}
+
+// FindBySupplierID mocks base method.
+func (m *MockProductSupplierRepository) FindBySupplierID(ctx context.Context, supplierID string) ([]entity.ProductSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySupplierID", ctx, supplierID)
+	ret0, _ := ret[0].([]entity.ProductSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySupplierID indicates an expected call of FindBySupplierID.
+func (mr *MockProductSupplierRepositoryMockRecorder) FindBySupplierID(ctx, supplierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySupplierID", reflect.TypeOf((*MockProductSupplierRepository)(nil).FindBySupplierID), ctx, supplierID)
+}
+
+// Upsert mocks base method.
+func (m *MockProductSupplierRepository) Upsert(ctx context.Context, link *entity.ProductSupplier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockProductSupplierRepositoryMockRecorder) Upsert(ctx, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockProductSupplierRepository)(nil).Upsert), ctx, link)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: purchase_order.go
+//
+// Generated by this command:
+//
+//	mockgen -source=purchase_order.go -destination=mocks/mock_purchase_order.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPurchaseOrderRepository is a mock of PurchaseOrderRepository interface.
+type MockPurchaseOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseOrderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPurchaseOrderRepositoryMockRecorder is the mock recorder for MockPurchaseOrderRepository.
+type MockPurchaseOrderRepositoryMockRecorder struct {
+	mock *MockPurchaseOrderRepository
+}
+
+// NewMockPurchaseOrderRepository creates a new mock instance.
+func NewMockPurchaseOrderRepository(ctrl *gomock.Controller) *MockPurchaseOrderRepository {
+	mock := &MockPurchaseOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockPurchaseOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseOrderRepository) EXPECT() *MockPurchaseOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPurchaseOrderRepository) Create(ctx context.Context, order *entity.PurchaseOrder) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, order)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) Create(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).Create), ctx, order)
+}
+
+// FindAll mocks base method.
+func (m *MockPurchaseOrderRepository) FindAll(ctx context.Context, filter entity.PurchaseOrdersFilter) ([]entity.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
+	ret0, _ := ret[0].([]entity.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) FindAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).FindAll), ctx, filter)
+}
+
+// FindByID mocks base method.
+func (m *MockPurchaseOrderRepository) FindByID(ctx context.Context, id string) (*entity.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).FindByID), ctx, id)
+}
+
+// Receive mocks base method.
+func (m *MockPurchaseOrderRepository) Receive(ctx context.Context, id string, receipts []entity.PurchaseOrderReceipt) (*entity.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, id, receipts)
+	ret0, _ := ret[0].(*entity.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) Receive(ctx, id, receipts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).Receive), ctx, id, receipts)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockPurchaseOrderRepository) UpdateStatus(ctx context.Context, id string, from, to entity.PurchaseOrderStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockPurchaseOrderRepositoryMockRecorder) UpdateStatus(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPurchaseOrderRepository)(nil).UpdateStatus), ctx, id, from, to)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reservation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reservation.go -destination=mocks/mock_reservation.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReservationRepository is a mock of ReservationRepository interface.
+type MockReservationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReservationRepositoryMockRecorder is the mock recorder for MockReservationRepository.
+type MockReservationRepositoryMockRecorder struct {
+	mock *MockReservationRepository
+}
+
+// NewMockReservationRepository creates a new mock instance.
+func NewMockReservationRepository(ctrl *gomock.Controller) *MockReservationRepository {
+	mock := &MockReservationRepository{ctrl: ctrl}
+	mock.recorder = &MockReservationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationRepository) EXPECT() *MockReservationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReservationRepository) Create(ctx context.Context, reservation *entity.Reservation) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, reservation)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReservationRepositoryMockRecorder) Create(ctx, reservation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReservationRepository)(nil).Create), ctx, reservation)
+}
+
+// FindByID mocks base method.
+func (m *MockReservationRepository) FindByID(ctx context.Context, id string) (*entity.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockReservationRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockReservationRepository)(nil).FindByID), ctx, id)
+}
+
+// FindExpired mocks base method.
+func (m *MockReservationRepository) FindExpired(ctx context.Context, asOf time.Time, limit int) ([]entity.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpired", ctx, asOf, limit)
+	ret0, _ := ret[0].([]entity.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpired indicates an expected call of FindExpired.
+func (mr *MockReservationRepositoryMockRecorder) FindExpired(ctx, asOf, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpired", reflect.TypeOf((*MockReservationRepository)(nil).FindExpired), ctx, asOf, limit)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockReservationRepository) UpdateStatus(ctx context.Context, id string, from, to entity.ReservationStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockReservationRepositoryMockRecorder) UpdateStatus(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockReservationRepository)(nil).UpdateStatus), ctx, id, from, to)
+}
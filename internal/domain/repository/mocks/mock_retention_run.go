@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: retention_run.go
+//
+// Generated by this command:
+//
+//	mockgen -source=retention_run.go -destination=mocks/mock_retention_run.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRetentionRunRepository is a mock of RetentionRunRepository interface.
+type MockRetentionRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetentionRunRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRetentionRunRepositoryMockRecorder is the mock recorder for MockRetentionRunRepository.
+type MockRetentionRunRepositoryMockRecorder struct {
+	mock *MockRetentionRunRepository
+}
+
+// NewMockRetentionRunRepository creates a new mock instance.
+func NewMockRetentionRunRepository(ctrl *gomock.Controller) *MockRetentionRunRepository {
+	mock := &MockRetentionRunRepository{ctrl: ctrl}
+	mock.recorder = &MockRetentionRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetentionRunRepository) EXPECT() *MockRetentionRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRetentionRunRepository) Create(ctx context.Context, run *entity.RetentionRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRetentionRunRepositoryMockRecorder) Create(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRetentionRunRepository)(nil).Create), ctx, run)
+}
+
+// FindLatest mocks base method.
+func (m *MockRetentionRunRepository) FindLatest(ctx context.Context) (*entity.RetentionRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatest", ctx)
+	ret0, _ := ret[0].(*entity.RetentionRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatest indicates an expected call of FindLatest.
+func (mr *MockRetentionRunRepositoryMockRecorder) FindLatest(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatest", reflect.TypeOf((*MockRetentionRunRepository)(nil).FindLatest), ctx)
+}
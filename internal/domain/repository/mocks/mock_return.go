@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: return.go
+//
+// Generated by this command:
+//
+//	mockgen -source=return.go -destination=mocks/mock_return.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReturnRepository is a mock of ReturnRepository interface.
+type MockReturnRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReturnRepositoryMockRecorder is the mock recorder for MockReturnRepository.
+type MockReturnRepositoryMockRecorder struct {
+	mock *MockReturnRepository
+}
+
+// NewMockReturnRepository creates a new mock instance.
+func NewMockReturnRepository(ctrl *gomock.Controller) *MockReturnRepository {
+	mock := &MockReturnRepository{ctrl: ctrl}
+	mock.recorder = &MockReturnRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnRepository) EXPECT() *MockReturnRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReturnRepository) Create(ctx context.Context, rma *entity.ReturnAuthorization) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, rma)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnRepositoryMockRecorder) Create(ctx, rma any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturnRepository)(nil).Create), ctx, rma)
+}
+
+// FindAll mocks base method.
+func (m *MockReturnRepository) FindAll(ctx context.Context, filter entity.ReturnsFilter) ([]entity.ReturnAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
+	ret0, _ := ret[0].([]entity.ReturnAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockReturnRepositoryMockRecorder) FindAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockReturnRepository)(nil).FindAll), ctx, filter)
+}
+
+// FindByID mocks base method.
+func (m *MockReturnRepository) FindByID(ctx context.Context, id string) (*entity.ReturnAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.ReturnAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockReturnRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockReturnRepository)(nil).FindByID), ctx, id)
+}
+
+// ReturnRates mocks base method.
+func (m *MockReturnRepository) ReturnRates(ctx context.Context, filter entity.ReturnRateFilter) ([]entity.ReturnRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnRates", ctx, filter)
+	ret0, _ := ret[0].([]entity.ReturnRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnRates indicates an expected call of ReturnRates.
+func (mr *MockReturnRepositoryMockRecorder) ReturnRates(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnRates", reflect.TypeOf((*MockReturnRepository)(nil).ReturnRates), ctx, filter)
+}
+
+// ReturnedQuantities mocks base method.
+func (m *MockReturnRepository) ReturnedQuantities(ctx context.Context, orderID string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnedQuantities", ctx, orderID)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnedQuantities indicates an expected call of ReturnedQuantities.
+func (mr *MockReturnRepositoryMockRecorder) ReturnedQuantities(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnedQuantities", reflect.TypeOf((*MockReturnRepository)(nil).ReturnedQuantities), ctx, orderID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockReturnRepository) UpdateStatus(ctx context.Context, id string, from, to entity.ReturnStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockReturnRepositoryMockRecorder) UpdateStatus(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockReturnRepository)(nil).UpdateStatus), ctx, id, from, to)
+}
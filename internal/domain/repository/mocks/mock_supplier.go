@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: supplier.go
+//
+// Generated by this command:
+//
+//	mockgen -source=supplier.go -destination=mocks/mock_supplier.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSupplierRepository is a mock of SupplierRepository interface.
+type MockSupplierRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupplierRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSupplierRepositoryMockRecorder is the mock recorder for MockSupplierRepository.
+type MockSupplierRepositoryMockRecorder struct {
+	mock *MockSupplierRepository
+}
+
+// NewMockSupplierRepository creates a new mock instance.
+func NewMockSupplierRepository(ctrl *gomock.Controller) *MockSupplierRepository {
+	mock := &MockSupplierRepository{ctrl: ctrl}
+	mock.recorder = &MockSupplierRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupplierRepository) EXPECT() *MockSupplierRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSupplierRepository) Create(ctx context.Context, supplier *entity.Supplier) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, supplier)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSupplierRepositoryMockRecorder) Create(ctx, supplier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSupplierRepository)(nil).Create), ctx, supplier)
+}
+
+// Delete mocks base method.
+func (m *MockSupplierRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSupplierRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSupplierRepository)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockSupplierRepository) FindAll(ctx context.Context, filter entity.SuppliersFilter) ([]entity.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
+	ret0, _ := ret[0].([]entity.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSupplierRepositoryMockRecorder) FindAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSupplierRepository)(nil).FindAll), ctx, filter)
+}
+
+// FindByID mocks base method.
+func (m *MockSupplierRepository) FindByID(ctx context.Context, id string) (*entity.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockSupplierRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSupplierRepository)(nil).FindByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockSupplierRepository) Update(ctx context.Context, supplier *entity.Supplier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, supplier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSupplierRepositoryMockRecorder) Update(ctx, supplier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSupplierRepository)(nil).Update), ctx, supplier)
+}
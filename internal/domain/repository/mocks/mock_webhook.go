@@ -0,0 +1,220 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook.go -destination=mocks/mock_webhook.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueDeliveries mocks base method.
+func (m *MockWebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDeliveries", ctx, limit, lease)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDeliveries indicates an expected call of ClaimDueDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ClaimDueDeliveries(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ClaimDueDeliveries), ctx, limit, lease)
+}
+
+// Create mocks base method.
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, webhook)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookRepositoryMockRecorder) Create(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookRepository)(nil).Create), ctx, webhook)
+}
+
+// CreateDeliveries mocks base method.
+func (m *MockWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeliveries", ctx, deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeliveries indicates an expected call of CreateDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) CreateDeliveries(ctx, deliveries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).CreateDeliveries), ctx, deliveries)
+}
+
+// CreateRedelivery mocks base method.
+func (m *MockWebhookRepository) CreateRedelivery(ctx context.Context, delivery *entity.WebhookDelivery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRedelivery", ctx, delivery)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRedelivery indicates an expected call of CreateRedelivery.
+func (mr *MockWebhookRepositoryMockRecorder) CreateRedelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRedelivery", reflect.TypeOf((*MockWebhookRepository)(nil).CreateRedelivery), ctx, delivery)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookRepository)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockWebhookRepository) FindAll(ctx context.Context, filter entity.WebhooksFilter) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockWebhookRepositoryMockRecorder) FindAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockWebhookRepository)(nil).FindAll), ctx, filter)
+}
+
+// FindByID mocks base method.
+func (m *MockWebhookRepository) FindByID(ctx context.Context, id string) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockWebhookRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockWebhookRepository)(nil).FindByID), ctx, id)
+}
+
+// FindDeliveries mocks base method.
+func (m *MockWebhookRepository) FindDeliveries(ctx context.Context, webhookID string, filter entity.WebhookDeliveriesFilter) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveries", ctx, webhookID, filter)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveries indicates an expected call of FindDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) FindDeliveries(ctx, webhookID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).FindDeliveries), ctx, webhookID, filter)
+}
+
+// FindDelivery mocks base method.
+func (m *MockWebhookRepository) FindDelivery(ctx context.Context, webhookID string, id int64) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDelivery", ctx, webhookID, id)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDelivery indicates an expected call of FindDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) FindDelivery(ctx, webhookID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).FindDelivery), ctx, webhookID, id)
+}
+
+// FindEnabled mocks base method.
+func (m *MockWebhookRepository) FindEnabled(ctx context.Context) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEnabled", ctx)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEnabled indicates an expected call of FindEnabled.
+func (mr *MockWebhookRepositoryMockRecorder) FindEnabled(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEnabled", reflect.TypeOf((*MockWebhookRepository)(nil).FindEnabled), ctx)
+}
+
+// RecordAttempt mocks base method.
+func (m *MockWebhookRepository) RecordAttempt(ctx context.Context, delivery *entity.WebhookDelivery, disableAfter int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttempt", ctx, delivery, disableAfter)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockWebhookRepositoryMockRecorder) RecordAttempt(ctx, delivery, disableAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockWebhookRepository)(nil).RecordAttempt), ctx, delivery, disableAfter)
+}
+
+// Update mocks base method.
+func (m *MockWebhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookRepositoryMockRecorder) Update(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookRepository)(nil).Update), ctx, webhook)
+}
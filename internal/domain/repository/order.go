@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=order.go -destination=mocks/mock_order.go -package=mocks
+type OrderRepository interface {
+	Create(ctx context.Context, order *entity.Order) (string, error)
+	FindByID(ctx context.Context, id string) (*entity.Order, error)
+	FindAll(ctx context.Context, filter entity.OrdersFilter) ([]entity.Order, error)
+	UpdateStatus(ctx context.Context, id string, from, to entity.OrderStatus) error
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=outbox.go -destination=mocks/mock_outbox.go -package=mocks
+type OutboxRepository interface {
+	// ClaimDue leases up to limit events that are due for delivery, oldest first, so no other
+	// dispatcher claims them until lease has passed. Events are written by the product and
+	// category repositories, in the transaction of the change they announce.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]entity.DomainEvent, error)
+	MarkPublished(ctx context.Context, id int64) error
+	// MarkFailed counts a failed delivery and makes the event due again at nextAttemptAt.
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	// MarkDead counts a failed delivery and dead-letters the event, which is not delivered again.
+	MarkDead(ctx context.Context, id int64, lastError string) error
+	// PurgePublished removes the events published before publishedBefore and returns how many.
+	PurgePublished(ctx context.Context, publishedBefore time.Time) (int64, error)
+}
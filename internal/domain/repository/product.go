@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirawong/crud-arise/internal/domain/entity"
 )
@@ -9,8 +10,32 @@ import (
 //go:generate mockgen -source=product.go -destination=mocks/mock_product.go -package=mocks
 type ProductRepository interface {
 	Create(ctx context.Context, product *entity.Product) (string, error)
+	CreateMany(ctx context.Context, products []entity.Product) ([]string, error)
 	FindByID(ctx context.Context, id string) (*entity.Product, error)
+	FindBySKU(ctx context.Context, sku string) (*entity.Product, error)
 	Update(ctx context.Context, product *entity.Product) error
+	Patch(ctx context.Context, id string, patch entity.ProductPatch) error
 	FindAll(ctx context.Context, filter entity.ProductFilter) ([]entity.Product, error)
-	Delete(ctx context.Context, id string) error
+	// FindAfter lists, in id order, up to limit products matching filter whose id sorts after
+	// afterID; an empty afterID starts from the first one. The filter's pagination is ignored.
+	FindAfter(ctx context.Context, filter entity.ProductFilter, afterID string, limit int) ([]entity.Product, error)
+	Stats(ctx context.Context, filter entity.ProductFilter) (*entity.ListStats, error)
+	UpdateMany(ctx context.Context, products []entity.Product) ([]error, error)
+	UpsertBySKU(ctx context.Context, products []entity.Product) ([]entity.BatchResult, error)
+	Delete(ctx context.Context, id string, version int) error
+	DeleteMany(ctx context.Context, refs []entity.VersionedID) ([]error, error)
+	FindByIDs(ctx context.Context, ids []string) ([]entity.Product, error)
+	FindDeleted(ctx context.Context, pagination entity.Pagination) ([]entity.Product, error)
+	FindDeletedByID(ctx context.Context, id string) (*entity.Product, error)
+	Restore(ctx context.Context, id, categoryID string) error
+	Purge(ctx context.Context, id string) error
+	// FindExpiredIDs lists, in id order, up to limit ids of rows soft-deleted before deletedBefore
+	// whose id sorts after afterID; an empty afterID starts from the first one.
+	FindExpiredIDs(ctx context.Context, deletedBefore time.Time, afterID string, limit int) ([]string, error)
+	PurgeMany(ctx context.Context, ids []string) error
+	// FindRevisions lists a page of the revisions of the product with id, deleted or not, newest
+	// first.
+	FindRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error)
+	FindRevision(ctx context.Context, id string, number int) (*entity.ProductRevision, error)
+	AdjustStock(ctx context.Context, adjustments []entity.StockAdjustment) error
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=product_supplier.go -destination=mocks/mock_product_supplier.go -package=mocks
+type ProductSupplierRepository interface {
+	Upsert(ctx context.Context, link *entity.ProductSupplier) error
+	Delete(ctx context.Context, productID, supplierID string) error
+	FindByProductID(ctx context.Context, productID string) ([]entity.ProductSupplier, error)
+	FindBySupplierID(ctx context.Context, supplierID string) ([]entity.ProductSupplier, error)
+}
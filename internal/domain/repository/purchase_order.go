@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=purchase_order.go -destination=mocks/mock_purchase_order.go -package=mocks
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, order *entity.PurchaseOrder) (string, error)
+	FindByID(ctx context.Context, id string) (*entity.PurchaseOrder, error)
+	FindAll(ctx context.Context, filter entity.PurchaseOrdersFilter) ([]entity.PurchaseOrder, error)
+	UpdateStatus(ctx context.Context, id string, from, to entity.PurchaseOrderStatus) error
+	Receive(ctx context.Context, id string, receipts []entity.PurchaseOrderReceipt) (*entity.PurchaseOrder, error)
+}
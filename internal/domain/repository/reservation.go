@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=reservation.go -destination=mocks/mock_reservation.go -package=mocks
+type ReservationRepository interface {
+	Create(ctx context.Context, reservation *entity.Reservation) (string, error)
+	FindByID(ctx context.Context, id string) (*entity.Reservation, error)
+	// FindExpired lists up to limit held reservations that expired before asOf, oldest first.
+	FindExpired(ctx context.Context, asOf time.Time, limit int) ([]entity.Reservation, error)
+	// UpdateStatus ends the reservation with id, moving it from one status to another and
+	// stamping when it was released. It fails with a conflict when it is no longer in from.
+	UpdateStatus(ctx context.Context, id string, from, to entity.ReservationStatus) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=retention_run.go -destination=mocks/mock_retention_run.go -package=mocks
+type RetentionRunRepository interface {
+	Create(ctx context.Context, run *entity.RetentionRun) error
+	// FindLatest returns the run that started last, failing with NOT_FOUND before the first one.
+	FindLatest(ctx context.Context) (*entity.RetentionRun, error)
+}
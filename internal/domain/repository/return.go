@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=return.go -destination=mocks/mock_return.go -package=mocks
+type ReturnRepository interface {
+	Create(ctx context.Context, rma *entity.ReturnAuthorization) (string, error)
+	FindByID(ctx context.Context, id string) (*entity.ReturnAuthorization, error)
+	FindAll(ctx context.Context, filter entity.ReturnsFilter) ([]entity.ReturnAuthorization, error)
+	UpdateStatus(ctx context.Context, id string, from, to entity.ReturnStatus) error
+	ReturnedQuantities(ctx context.Context, orderID string) (map[string]int, error)
+	ReturnRates(ctx context.Context, filter entity.ReturnRateFilter) ([]entity.ReturnRate, error)
+}
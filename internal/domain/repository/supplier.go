@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=supplier.go -destination=mocks/mock_supplier.go -package=mocks
+type SupplierRepository interface {
+	Create(ctx context.Context, supplier *entity.Supplier) (string, error)
+	FindByID(ctx context.Context, id string) (*entity.Supplier, error)
+	Update(ctx context.Context, supplier *entity.Supplier) error
+	FindAll(ctx context.Context, filter entity.SuppliersFilter) ([]entity.Supplier, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+//go:generate mockgen -source=tx.go -destination=mocks/mock_tx.go -package=mocks
+type TxManager interface {
+	// WithinTx runs fn in a transaction that every repository called with the ctx passed to fn
+	// takes part in. It commits when fn returns nil and rolls back otherwise. Nested calls join
+	// the outer transaction.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+//go:generate mockgen -source=webhook.go -destination=mocks/mock_webhook.go -package=mocks
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *entity.Webhook) (string, error)
+	FindByID(ctx context.Context, id string) (*entity.Webhook, error)
+	FindAll(ctx context.Context, filter entity.WebhooksFilter) ([]entity.Webhook, error)
+	FindEnabled(ctx context.Context) ([]entity.Webhook, error)
+	// Update saves the webhook's settings. Enabling a webhook also clears its failures.
+	Update(ctx context.Context, webhook *entity.Webhook) error
+	// Delete removes the webhook together with its deliveries.
+	Delete(ctx context.Context, id string) error
+
+	// CreateDeliveries queues deliveries of events, skipping the ones already queued for the same
+	// webhook and event, so an event published twice is still delivered once.
+	CreateDeliveries(ctx context.Context, deliveries []entity.WebhookDelivery) error
+	// CreateRedelivery queues delivery and returns its ID; it is never skipped.
+	CreateRedelivery(ctx context.Context, delivery *entity.WebhookDelivery) (int64, error)
+	FindDeliveries(ctx context.Context, webhookID string, filter entity.WebhookDeliveriesFilter) ([]entity.WebhookDelivery, error)
+	FindDelivery(ctx context.Context, webhookID string, id int64) (*entity.WebhookDelivery, error)
+	// ClaimDueDeliveries leases up to limit pending deliveries of enabled webhooks that are due,
+	// oldest first, so no other sender claims them until lease has passed.
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+	// RecordAttempt saves the outcome of an attempt to send delivery and counts it towards its
+	// webhook's failures in a row. A webhook failing disableAfter times in a row is disabled, in
+	// which case RecordAttempt reports true.
+	RecordAttempt(ctx context.Context, delivery *entity.WebhookDelivery, disableAfter int) (bool, error)
+}
@@ -6,6 +6,17 @@ var (
 	ErrNotFound        = New("NOT_FOUND", "data not found")
 	ErrInvalidArgument = New("INVALID_ARGUMENT", "invalid argument provided")
 	ErrInternal        = New("INTERNAL_ERROR", "an internal errors occurred")
+	ErrConflict        = New("CONFLICT", "resource is in a conflicting state")
+	ErrUnprocessable   = New("UNPROCESSABLE_ENTITY", "request cannot be processed")
+
+	ErrPreconditionFailed   = New("PRECONDITION_FAILED", "resource version does not match")
+	ErrPreconditionRequired = New("PRECONDITION_REQUIRED", "request must be conditional")
+
+	ErrInsufficientStock = New("INSUFFICIENT_STOCK", "insufficient stock")
+
+	ErrUnavailable = New("UNAVAILABLE", "service is temporarily unavailable")
+
+	ErrUnauthenticated = New("UNAUTHENTICATED", "valid credentials are required")
 )
 
 func New(code, message string) *AppError {
@@ -27,6 +38,15 @@ func (e *AppError) WithMessage(msg string) *AppError {
 	}
 }
 
+func (e *AppError) WithDetails(details interface{}) *AppError {
+	return &AppError{
+		Code:    e.Code,
+		Message: e.Message,
+		Details: details,
+		Err:     e.Err,
+	}
+}
+
 func GetCode(err error) string {
 	var appErr *AppError
 	if errors.As(err, &appErr) {
@@ -35,3 +55,12 @@ func GetCode(err error) string {
 
 	return ErrInternal.Code
 }
+
+func GetDetails(err error) interface{} {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Details
+	}
+
+	return nil
+}
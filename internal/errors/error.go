@@ -7,6 +7,7 @@ import (
 type AppError struct {
 	Code    string
 	Message string
+	Details interface{}
 	Err     error
 }
 
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type FilterAuditRequest struct {
+	EntityType string `form:"entityType"`
+	EntityID   string `form:"entityId"`
+	Actor      string `form:"actor"`
+	From       string `form:"from"`
+	To         string `form:"to"`
+	Limit      int    `form:"limit"`
+	Offset     int    `form:"offset"`
+}
+
+// ToDomain takes from and to as RFC 3339 timestamps or as dates (YYYY-MM-DD), which stand for
+// midnight UTC.
+func (r FilterAuditRequest) ToDomain() (entity.AuditFilter, error) {
+	from, err := parseTime("from", r.From)
+	if err != nil {
+		return entity.AuditFilter{}, err
+	}
+	to, err := parseTime("to", r.To)
+	if err != nil {
+		return entity.AuditFilter{}, err
+	}
+
+	return entity.AuditFilter{
+		EntityType: entity.AuditEntityType(r.EntityType),
+		EntityID:   r.EntityID,
+		Actor:      r.Actor,
+		From:       from,
+		To:         to,
+		Pagination: entity.Pagination{
+			Limit:  r.Limit,
+			Offset: r.Offset,
+		},
+	}, nil
+}
+
+func parseTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("%s must be an RFC 3339 timestamp or a YYYY-MM-DD date", name))
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// AuditEntry represents one recorded change to a product or category
+type AuditEntry struct {
+	ID         int64                  `json:"id"`
+	Actor      string                 `json:"actor"`
+	RequestID  string                 `json:"requestId,omitempty"`
+	OccurredAt time.Time              `json:"occurredAt"`
+	EntityType string                 `json:"entityType"`
+	EntityID   string                 `json:"entityId"`
+	Action     string                 `json:"action"`
+	Changes    map[string]FieldChange `json:"changes"`
+} //	@name	AuditEntry
+
+// FieldChange represents the value of a column before and after a change
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+} //	@name	FieldChange
+
+func AuditEntryFromDomain(entry *entity.AuditEntry) *AuditEntry {
+	if entry == nil {
+		return nil
+	}
+
+	changes := make(map[string]FieldChange, len(entry.Changes))
+	for column, change := range entry.Changes {
+		changes[column] = FieldChange{Old: change.Old, New: change.New}
+	}
+
+	return &AuditEntry{
+		ID:         entry.ID,
+		Actor:      entry.Actor,
+		RequestID:  entry.RequestID,
+		OccurredAt: entry.OccurredAt,
+		EntityType: string(entry.EntityType),
+		EntityID:   entry.EntityID,
+		Action:     string(entry.Action),
+		Changes:    changes,
+	}
+}
+
+func AuditEntriesFromDomain(entries []entity.AuditEntry) []AuditEntry {
+	result := make([]AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, *AuditEntryFromDomain(&entry))
+	}
+
+	return result
+}
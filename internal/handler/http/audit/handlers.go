@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/audit/dto"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	auditSrv "github.com/sirawong/crud-arise/internal/services/audit"
+)
+
+type AuditHandler struct {
+	auditService auditSrv.AuditService
+}
+
+func NewAuditHandler(auditService auditSrv.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// ListAll godoc
+//
+//	@Summary		Get the audit log
+//	@Description	Get the recorded creates, updates and deletes of products and categories, newest first, with the changed fields
+//	@Tags			audit
+//	@Produce		json
+//	@Param			entityType	query		string					false	"product or category"
+//	@Param			entityId	query		string					false	"Filter by product or category ID"
+//	@Param			actor		query		string					false	"Filter by actor"
+//	@Param			from		query		string					false	"Start (RFC 3339 or YYYY-MM-DD, inclusive)"
+//	@Param			to			query		string					false	"End (RFC 3339 or YYYY-MM-DD, exclusive)"
+//	@Param			limit		query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset		query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200			{array}		dto.AuditEntry			"Audit entries"
+//	@Failure		400			{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/audit [get]
+func (h AuditHandler) ListAll(c *gin.Context) {
+	var query dto.FilterAuditRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	filter, err := query.ToDomain()
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	entries, err := h.auditService.List(c, filter)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AuditEntriesFromDomain(entries))
+}
@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/audit/dto"
+	"github.com/sirawong/crud-arise/internal/services/audit/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockAuditService
+	handler     *AuditHandler
+	router      *gin.Engine
+}
+
+func (suite *AuditHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockAuditService(suite.mockCtrl)
+	suite.handler = NewAuditHandler(suite.mockService)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	v1.GET("/audit", suite.handler.ListAll)
+}
+
+func (suite *AuditHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *AuditHandlerTestSuite) TestListAll_Success() {
+	from := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 4, 18, 30, 0, 0, time.UTC)
+
+	suite.mockService.EXPECT().
+		List(gomock.Any(), entity.AuditFilter{
+			EntityType: entity.AuditEntityProduct,
+			EntityID:   "product-1",
+			Actor:      "alice",
+			From:       &from,
+			To:         &to,
+			Pagination: entity.Pagination{Limit: 5},
+		}).
+		Return([]entity.AuditEntry{{
+			ID:         7,
+			Actor:      "alice",
+			RequestID:  "req-1",
+			OccurredAt: from.Add(time.Hour),
+			EntityType: entity.AuditEntityProduct,
+			EntityID:   "product-1",
+			Action:     entity.AuditActionUpdate,
+			Changes:    map[string]entity.FieldChange{"price": {Old: 999.99, New: 899.99}},
+		}}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/audit?entityType=product&entityId=product-1&actor=alice&from=2025-03-04&to=2025-03-04T18:30:00Z&limit=5", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response []dto.AuditEntry
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Len(response, 1)
+	suite.Equal("update", response[0].Action)
+	suite.Equal("req-1", response[0].RequestID)
+	suite.Equal(dto.FieldChange{Old: 999.99, New: 899.99}, response[0].Changes["price"])
+}
+
+func (suite *AuditHandlerTestSuite) TestListAll_InvalidTime() {
+	req, _ := http.NewRequest("GET", "/api/v1/audit?from=last-tuesday", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *AuditHandlerTestSuite) TestListAll_ServiceError() {
+
+	suite.mockService.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return(nil, apperr.ErrInvalidArgument.WithMessage("entityType must be product or category")).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/audit?entityType=supplier", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func TestAuditHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditHandlerTestSuite))
+}
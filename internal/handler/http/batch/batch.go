@@ -0,0 +1,98 @@
+// Package batch holds the request and response shapes shared by the batch endpoints.
+package batch
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+// Item statuses reported in a batch response.
+const (
+	StatusCreated = "created"
+	StatusUpdated = "updated"
+	StatusDeleted = "deleted"
+	StatusFailed  = "failed"
+)
+
+// Mode maps the mode member of a batch request. Atomic is the default.
+func Mode(mode string) entity.BatchMode {
+	if mode == "" {
+		return entity.BatchAtomic
+	}
+	return entity.BatchMode(mode)
+}
+
+// DeleteItem names a resource to delete. A non-zero version makes its delete conditional.
+type DeleteItem struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+} //	@name	BatchDeleteItem
+
+// DeleteRequest represents the request payload for deleting several resources at once
+type DeleteRequest struct {
+	Mode  string       `json:"mode" enums:"atomic,best_effort"`
+	Items []DeleteItem `json:"items" binding:"required"`
+} //	@name	BatchDeleteRequest
+
+func (r DeleteRequest) ToDomain() []entity.VersionedID {
+	refs := make([]entity.VersionedID, 0, len(r.Items))
+	for _, item := range r.Items {
+		refs = append(refs, entity.VersionedID{ID: item.ID, Version: item.Version})
+	}
+	return refs
+}
+
+// Result reports what happened to one item of a batch
+type Result struct {
+	Index     int    `json:"index"`
+	ID        string `json:"id,omitempty"`
+	Status    string `json:"status"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Message   string `json:"message,omitempty"`
+} //	@name	BatchResult
+
+// Response represents the response of a batch write
+type Response struct {
+	Results   []Result `json:"results"`
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+} //	@name	BatchResponse
+
+// Respond writes the per-item results of a batch. Items that were written are reported with
+// status.
+func Respond(c *gin.Context, status string, results []entity.BatchResult) {
+	respond(c, results, func(entity.BatchResult) string { return status })
+}
+
+// RespondUpserted writes the per-item results of a batch upsert, reporting each written item as
+// created or updated.
+func RespondUpserted(c *gin.Context, results []entity.BatchResult) {
+	respond(c, results, func(result entity.BatchResult) string {
+		if result.Created {
+			return StatusCreated
+		}
+		return StatusUpdated
+	})
+}
+
+func respond(c *gin.Context, results []entity.BatchResult, status func(entity.BatchResult) string) {
+	response := Response{Results: make([]Result, 0, len(results))}
+	for _, result := range results {
+		item := Result{Index: result.Index, ID: result.ID}
+		if result.Err != nil {
+			item.Status = StatusFailed
+			item.ErrorCode = apperr.GetCode(result.Err)
+			item.Message = result.Err.Error()
+			response.Failed++
+		} else {
+			item.Status = status(result)
+			response.Succeeded++
+		}
+		response.Results = append(response.Results, item)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
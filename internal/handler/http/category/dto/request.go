@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/handler/http/mergepatch"
 	"github.com/sirawong/crud-arise/pkg/utils"
 )
 
@@ -16,6 +17,55 @@ func (r CategoryRequest) ToDomain() entity.Category {
 	}
 }
 
+// CategoryPatchRequest represents a JSON Merge Patch for a category
+type CategoryPatchRequest struct {
+	Name mergepatch.Field[string] `json:"name" swaggertype:"string"`
+} //	@name	CategoryPatchRequest
+
+func (r CategoryPatchRequest) ToDomain() (entity.CategoryPatch, error) {
+	name, err := mergepatch.Required(r.Name, "name")
+	if err != nil {
+		return entity.CategoryPatch{}, err
+	}
+	return entity.CategoryPatch{Name: name}, nil
+}
+
+// CategoryBatchCreateRequest represents the request payload for creating several categories at once
+type CategoryBatchCreateRequest struct {
+	Mode  string            `json:"mode" enums:"atomic,best_effort"`
+	Items []CategoryRequest `json:"items" binding:"required"`
+} //	@name	CategoryBatchCreateRequest
+
+func (r CategoryBatchCreateRequest) ToDomain() []entity.Category {
+	categories := make([]entity.Category, 0, len(r.Items))
+	for _, item := range r.Items {
+		categories = append(categories, item.ToDomain())
+	}
+	return categories
+}
+
+// CategoryBatchUpdateItem replaces one category of a batch. A non-zero version makes its update
+// conditional.
+type CategoryBatchUpdateItem struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+} //	@name	CategoryBatchUpdateItem
+
+// CategoryBatchUpdateRequest represents the request payload for replacing several categories at once
+type CategoryBatchUpdateRequest struct {
+	Mode  string                    `json:"mode" enums:"atomic,best_effort"`
+	Items []CategoryBatchUpdateItem `json:"items" binding:"required"`
+} //	@name	CategoryBatchUpdateRequest
+
+func (r CategoryBatchUpdateRequest) ToDomain() []entity.Category {
+	categories := make([]entity.Category, 0, len(r.Items))
+	for _, item := range r.Items {
+		categories = append(categories, entity.Category{ID: item.ID, Name: item.Name, Version: item.Version})
+	}
+	return categories
+}
+
 type FilterCategoriesRequest struct {
 	Name   string `form:"name,omitempty"`
 	Limit  int    `form:"limit"`
@@ -31,3 +81,34 @@ func (r FilterCategoriesRequest) ToDomain() entity.CategoriesFilter {
 		},
 	}
 }
+
+type FilterTrashRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+func (r FilterTrashRequest) ToPagination() entity.Pagination {
+	return entity.Pagination{
+		Limit:  r.Limit,
+		Offset: r.Offset,
+	}
+}
+
+// FilterRevisionsRequest pages through the revisions of a category.
+type FilterRevisionsRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+func (r FilterRevisionsRequest) ToPagination() entity.Pagination {
+	return entity.Pagination{
+		Limit:  r.Limit,
+		Offset: r.Offset,
+	}
+}
+
+// RevisionURI names one revision of a category in the path.
+type RevisionURI struct {
+	ID       string `uri:"id" binding:"required"`
+	Revision int    `uri:"rev" binding:"required,min=1"`
+}
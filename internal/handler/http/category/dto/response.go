@@ -8,10 +8,12 @@ import (
 
 // Category represents the response payload for a category
 type Category struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt,omitempty"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
 } //	@name	Category
 
 func CategoryFromDomain(category *entity.Category) *Category {
@@ -21,8 +23,10 @@ func CategoryFromDomain(category *entity.Category) *Category {
 	return &Category{
 		ID:        category.ID,
 		Name:      category.Name,
+		Version:   category.Version,
 		CreatedAt: category.CreatedAt,
 		UpdatedAt: category.UpdatedAt,
+		DeletedAt: category.DeletedAt,
 	}
 }
 
@@ -37,3 +41,46 @@ func CategoriesFromDomain(categories []entity.Category) []*Category {
 
 	return result
 }
+
+// Revision represents one stored state of a category, without the category itself
+type Revision struct {
+	Revision  int       `json:"revision"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	RequestID string    `json:"requestId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+} //	@name	CategoryRevisionSummary
+
+// CategoryRevision represents a revision together with the category as it was stored in it
+type CategoryRevision struct {
+	Revision
+	Category *Category `json:"category"`
+} //	@name	CategoryRevision
+
+func RevisionFromDomain(revision entity.Revision) Revision {
+	return Revision{
+		Revision:  revision.Number,
+		Action:    string(revision.Action),
+		Actor:     revision.Actor,
+		RequestID: revision.RequestID,
+		CreatedAt: revision.CreatedAt,
+	}
+}
+
+func RevisionsFromDomain(revisions []entity.Revision) []Revision {
+	result := make([]Revision, 0, len(revisions))
+	for _, revision := range revisions {
+		result = append(result, RevisionFromDomain(revision))
+	}
+	return result
+}
+
+func CategoryRevisionFromDomain(revision *entity.CategoryRevision) *CategoryRevision {
+	if revision == nil {
+		return nil
+	}
+	return &CategoryRevision{
+		Revision: RevisionFromDomain(revision.Revision),
+		Category: CategoryFromDomain(&revision.Category),
+	}
+}
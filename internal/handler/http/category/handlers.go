@@ -2,11 +2,16 @@ package category
 
 import (
 	"net/http"
+	"path"
 
 	"github.com/gin-gonic/gin"
 	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/batch"
 	"github.com/sirawong/crud-arise/internal/handler/http/category/dto"
 	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/mergepatch"
+	"github.com/sirawong/crud-arise/internal/handler/http/precondition"
+	"github.com/sirawong/crud-arise/internal/handler/http/prefer"
 	categorySrv "github.com/sirawong/crud-arise/internal/services/category"
 )
 
@@ -26,7 +31,10 @@ func NewCategoryHandler(categoryService categorySrv.CategoryService) *CategoryHa
 //	@Accept			json
 //	@Produce		json
 //	@Param			category	body		dto.CategoryRequest		true	"Category information"
-//	@Success		201			{object}	map[string]interface{}	"{"status": "category_id"}"
+//	@Param			Prefer	header		string	false	"return=minimal to leave out the response body"
+//	@Success		201		{object}	dto.Category	"Created category"
+//	@Header			201	{string}	Location	"URL of the created category"
+//	@Header			201	{string}	ETag	"Entity tag of the created version"
 //	@Failure		400			{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
 //	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
 //	@Router			/categories [post]
@@ -37,13 +45,15 @@ func (h CategoryHandler) Create(c *gin.Context) {
 		return
 	}
 
-	id, err := h.categoryService.Create(c, req.ToDomain())
+	category, err := h.categoryService.Create(c, req.ToDomain())
 	if err != nil {
 		handlererr.RespondWithError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"status": id})
+	c.Header("Location", path.Join(c.Request.URL.Path, category.ID))
+	c.Header("ETag", precondition.ETag(category.Version))
+	prefer.Respond(c, http.StatusCreated, dto.CategoryFromDomain(category))
 }
 
 // Update godoc
@@ -54,10 +64,16 @@ func (h CategoryHandler) Create(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id			path		string					true	"Category ID"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
 //	@Param			category	body		dto.CategoryRequest		true	"Category information"
-//	@Success		200			{object}	map[string]interface{}	"{"status": "updated"}"
+//	@Param			Prefer	header		string	false	"return=minimal to leave out the response body"
+//	@Success		200		{object}	dto.Category	"Category as stored"
+//	@Success		204		"No body with Prefer: return=minimal"
+//	@Header			200,204	{string}	ETag	"Entity tag of the new version"
 //	@Failure		400			{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
 //	@Failure		404			{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		412			{object}	map[string]interface{}	"{"error_code": "PRECONDITION_FAILED", "message": "error description", "details": {"currentVersion": 3}}"
+//	@Failure		428			{object}	map[string]interface{}	"{"error_code": "PRECONDITION_REQUIRED", "message": "error	description"}"
 //	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
 //	@Router			/categories/{id} [put]
 func (h CategoryHandler) Update(c *gin.Context) {
@@ -73,13 +89,77 @@ func (h CategoryHandler) Update(c *gin.Context) {
 		return
 	}
 
-	err := h.categoryService.Update(c, id, req.ToDomain())
+	version, err := precondition.ExpectedVersion(c)
 	if err != nil {
 		handlererr.RespondWithError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": "updated"})
+	category := req.ToDomain()
+	category.Version = version
+	updated, err := h.categoryService.Update(c, id, category)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", precondition.ETag(updated.Version))
+	prefer.Respond(c, http.StatusOK, dto.CategoryFromDomain(updated))
+}
+
+// Patch godoc
+//
+//	@Summary		Patch a category
+//	@Description	Change some fields of a category with a JSON Merge Patch (RFC 7396). Members left out are not changed.
+//	@Tags			categories
+//	@Accept			application/merge-patch+json,json
+//	@Produce		json
+//	@Param			id			path		string					true	"Category ID"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
+//	@Param			category	body		dto.CategoryPatchRequest	true	"Fields to change"
+//	@Param			Prefer	header		string	false	"return=minimal to leave out the response body"
+//	@Success		200		{object}	dto.Category	"Category as stored"
+//	@Success		204		"No body with Prefer: return=minimal"
+//	@Header			200,204	{string}	ETag	"Entity tag of the new version"
+//	@Failure		400			{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404			{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		412			{object}	map[string]interface{}	"{"error_code": "PRECONDITION_FAILED", "message": "error description", "details": {"currentVersion": 3}}"
+//	@Failure		428			{object}	map[string]interface{}	"{"error_code": "PRECONDITION_REQUIRED", "message": "error	description"}"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/categories/{id} [patch]
+func (h CategoryHandler) Patch(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	var req dto.CategoryPatchRequest
+	if err := mergepatch.Bind(c, &req); err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	patch, err := req.ToDomain()
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	patch.Version, err = precondition.ExpectedVersion(c)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	patched, err := h.categoryService.Patch(c, id, patch)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", precondition.ETag(patched.Version))
+	prefer.Respond(c, http.StatusOK, dto.CategoryFromDomain(patched))
 }
 
 // GetByID godoc
@@ -90,7 +170,12 @@ func (h CategoryHandler) Update(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string					true	"Category ID"
+//	@Param			If-None-Match	header		string	false	"ETag from an earlier response"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified from an earlier response"
 //	@Success		200	{object}	dto.Category			"Category information"
+//	@Success		304	"Not modified"
+//	@Header			200,304	{string}	ETag	"Entity tag of the current representation"
+//	@Header			200,304	{string}	Last-Modified	"Time of the latest change"
 //	@Failure		400	{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
 //	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
 //	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
@@ -108,6 +193,10 @@ func (h CategoryHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	if precondition.NotModified(c, precondition.ETag(category.Version), category.UpdatedAt) {
+		return
+	}
+
 	c.JSON(http.StatusOK, dto.CategoryFromDomain(category))
 }
 
@@ -121,7 +210,12 @@ func (h CategoryHandler) GetByID(c *gin.Context) {
 //	@Param			name	query		string					false	"Search insensitive by category name"
 //	@Param			limit	query		int						false	"Limit number of results (default: 10, limit: 100)"
 //	@Param			offset	query		int						false	"Offset for pagination (default: 0)"
+//	@Param			If-None-Match	header		string	false	"ETag from an earlier response"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified from an earlier response"
 //	@Success		200		{array}		dto.Category			"List of categories"
+//	@Success		304	"Not modified"
+//	@Header			200,304	{string}	ETag	"Entity tag of the current representation"
+//	@Header			200,304	{string}	Last-Modified	"Time of the latest change"
 //	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error	description"}"
 //	@Router			/categories [get]
 func (h CategoryHandler) ListAll(c *gin.Context) {
@@ -131,7 +225,17 @@ func (h CategoryHandler) ListAll(c *gin.Context) {
 		return
 	}
 
-	categories, err := h.categoryService.GetAll(c, query.ToDomain())
+	filter := query.ToDomain()
+	stats, err := h.categoryService.GetListStats(c, filter)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+	if precondition.NotModified(c, precondition.ListETag(query, *stats), stats.LastModified) {
+		return
+	}
+
+	categories, err := h.categoryService.GetAll(c, filter)
 	if err != nil {
 		handlererr.RespondWithError(c, err)
 		return
@@ -148,9 +252,12 @@ func (h CategoryHandler) ListAll(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string					true	"Category ID"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
 //	@Success		200	{object}	map[string]interface{}	"{"status": "deleted"}"
 //	@Failure		400	{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
 //	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		412	{object}	map[string]interface{}	"{"error_code": "PRECONDITION_FAILED", "message": "error description", "details": {"currentVersion": 3}}"
+//	@Failure		428	{object}	map[string]interface{}	"{"error_code": "PRECONDITION_REQUIRED", "message": "error	description"}"
 //	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
 //	@Router			/categories/{id} [delete]
 func (h CategoryHandler) Delete(c *gin.Context) {
@@ -160,7 +267,13 @@ func (h CategoryHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err := h.categoryService.Delete(c, id)
+	version, err := precondition.ExpectedVersion(c)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	err = h.categoryService.Delete(c, id, version)
 	if err != nil {
 		handlererr.RespondWithError(c, err)
 		return
@@ -168,3 +281,275 @@ func (h CategoryHandler) Delete(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 }
+
+// ListTrash godoc
+//
+//	@Summary		List deleted categories
+//	@Description	Get deleted categories, most recently deleted first
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset	query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200		{array}		dto.Category			"List of deleted categories"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/categories/trash [get]
+func (h CategoryHandler) ListTrash(c *gin.Context) {
+	var query dto.FilterTrashRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	categories, err := h.categoryService.ListTrash(c, query.ToPagination())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CategoriesFromDomain(categories))
+}
+
+// Restore godoc
+//
+//	@Summary		Restore a deleted category
+//	@Description	Bring a deleted category back. It fails with CONFLICT when a live category has taken its name.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Category ID"
+//	@Param			Prefer	header		string	false	"return=minimal to leave out the response body"
+//	@Success		200		{object}	dto.Category	"Category as restored"
+//	@Success		204		"No body with Prefer: return=minimal"
+//	@Header			200,204	{string}	ETag	"Entity tag of the new version"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409		{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/categories/{id}/restore [post]
+func (h CategoryHandler) Restore(c *gin.Context) {
+	category, err := h.categoryService.Restore(c, c.Param("id"))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", precondition.ETag(category.Version))
+	prefer.Respond(c, http.StatusOK, dto.CategoryFromDomain(category))
+}
+
+// Purge godoc
+//
+//	@Summary		Purge a deleted category
+//	@Description	Remove a deleted category for good. A category that still has products, deleted ones included, cannot be purged.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Category ID"
+//	@Success		200	{object}	map[string]interface{}	"{"status": "purged"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/categories/{id}/purge [delete]
+func (h CategoryHandler) Purge(c *gin.Context) {
+	err := h.categoryService.Purge(c, c.Param("id"))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "purged"})
+}
+
+// ListRevisions godoc
+//
+//	@Summary		List the revisions of a category
+//	@Description	Get the revisions of a category, deleted or not, newest first. Each write that changes the category stores its full state as the next revision.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Category ID"
+//	@Param			limit	query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset	query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200		{array}		dto.Revision			"Revisions, newest first"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/categories/{id}/revisions [get]
+func (h CategoryHandler) ListRevisions(c *gin.Context) {
+	var query dto.FilterRevisionsRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	revisions, err := h.categoryService.ListRevisions(c, c.Param("id"), query.ToPagination())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RevisionsFromDomain(revisions))
+}
+
+// GetRevision godoc
+//
+//	@Summary		Get a category as of a revision
+//	@Description	Get the category as it was stored in one of its revisions
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Category ID"
+//	@Param			rev	path		int						true	"Revision number"
+//	@Success		200	{object}	dto.CategoryRevision		"Category as of the revision"
+//	@Failure		400	{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/categories/{id}/revisions/{rev} [get]
+func (h CategoryHandler) GetRevision(c *gin.Context) {
+	var uri dto.RevisionURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	revision, err := h.categoryService.GetRevision(c, uri.ID, uri.Revision)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CategoryRevisionFromDomain(revision))
+}
+
+// Revert godoc
+//
+//	@Summary		Revert a category to a revision
+//	@Description	Bring a live category's name back to what it was in a revision. The revert is stored as a new revision; a revert that would change nothing leaves the category untouched. It fails with CONFLICT when a live category has taken the name.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Category ID"
+//	@Param			rev			path		int		true	"Revision number"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
+//	@Param			Prefer		header		string	false	"return=minimal to leave out the response body"
+//	@Success		200		{object}	dto.Category	"Category as stored"
+//	@Success		204		"No body with Prefer: return=minimal"
+//	@Header			200,204	{string}	ETag	"Entity tag of the new version"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409		{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		412		{object}	map[string]interface{}	"{"error_code": "PRECONDITION_FAILED", "message": "error description", "details": {"currentVersion": 3}}"
+//	@Failure		428		{object}	map[string]interface{}	"{"error_code": "PRECONDITION_REQUIRED", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/categories/{id}/revisions/{rev}/revert [post]
+func (h CategoryHandler) Revert(c *gin.Context) {
+	var uri dto.RevisionURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	version, err := precondition.ExpectedVersion(c)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	reverted, err := h.categoryService.Revert(c, uri.ID, uri.Revision, version)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", precondition.ETag(reverted.Version))
+	prefer.Respond(c, http.StatusOK, dto.CategoryFromDomain(reverted))
+}
+
+// BatchCreate godoc
+//
+//	@Summary		Create several categories
+//	@Description	Create up to BATCH_MAX_ITEMS categories with multi-row inserts. In atomic mode (the default) nothing is created unless every category can be and a failure answers with the first failing item's error and every failing item in details; in best_effort mode the result of each item is reported.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			categories	body		dto.CategoryBatchCreateRequest	true	"Category creation information"
+//	@Success		200		{object}	batch.Response	"Result of each item"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error description", "details": [{"index": 1, "error_code": "NOT_FOUND", "message": "error description"}]}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/categories:batchCreate [post]
+func (h CategoryHandler) BatchCreate(c *gin.Context) {
+	var req dto.CategoryBatchCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	results, err := h.categoryService.BatchCreate(c, req.ToDomain(), batch.Mode(req.Mode))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	batch.Respond(c, batch.StatusCreated, results)
+}
+
+// BatchUpdate godoc
+//
+//	@Summary		Replace several categories
+//	@Description	Replace up to BATCH_MAX_ITEMS categories with multi-row updates. An item's version makes its update conditional. Modes work as for batchCreate.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			categories	body		dto.CategoryBatchUpdateRequest	true	"Category update information"
+//	@Success		200		{object}	batch.Response	"Result of each item"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error description", "details": [{"index": 1, "id": "id", "error_code": "NOT_FOUND", "message": "error description"}]}"
+//	@Failure		412		{object}	map[string]interface{}	"{"error_code": "PRECONDITION_FAILED", "message": "error description", "details": [{"index": 1, "id": "id", "error_code": "PRECONDITION_FAILED", "message": "error description"}]}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/categories:batchUpdate [post]
+func (h CategoryHandler) BatchUpdate(c *gin.Context) {
+	var req dto.CategoryBatchUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	results, err := h.categoryService.BatchUpdate(c, req.ToDomain(), batch.Mode(req.Mode))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	batch.Respond(c, batch.StatusUpdated, results)
+}
+
+// BatchDelete godoc
+//
+//	@Summary		Delete several categories
+//	@Description	Delete up to BATCH_MAX_ITEMS categories with multi-row updates. An item's version makes its delete conditional. Modes work as for batchCreate.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			categories	body		batch.DeleteRequest	true	"Category IDs"
+//	@Success		200		{object}	batch.Response	"Result of each item"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error description", "details": [{"index": 1, "id": "id", "error_code": "NOT_FOUND", "message": "error description"}]}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/categories:batchDelete [post]
+func (h CategoryHandler) BatchDelete(c *gin.Context) {
+	var req batch.DeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	results, err := h.categoryService.BatchDelete(c, req.ToDomain(), batch.Mode(req.Mode))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	batch.Respond(c, batch.StatusDeleted, results)
+}
@@ -14,7 +14,9 @@ import (
 
 	"github.com/sirawong/crud-arise/internal/domain/entity"
 	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/batch"
 	"github.com/sirawong/crud-arise/internal/handler/http/category/dto"
+	"github.com/sirawong/crud-arise/internal/handler/http/custommethod"
 	"github.com/sirawong/crud-arise/internal/services/category/mocks"
 	"github.com/stretchr/testify/suite"
 )
@@ -40,9 +42,21 @@ func (suite *CategoryHandlerTestSuite) SetupTest() {
 		cate.POST("/", suite.handler.Create)
 		cate.GET("/", suite.handler.ListAll)
 		cate.GET("/:id", suite.handler.GetByID)
+		cate.GET("/trash", suite.handler.ListTrash)
 		cate.PUT("/:id", suite.handler.Update)
+		cate.PATCH("/:id", suite.handler.Patch)
 		cate.DELETE("/:id", suite.handler.Delete)
+		cate.POST("/:id/restore", suite.handler.Restore)
+		cate.DELETE("/:id/purge", suite.handler.Purge)
+		cate.GET("/:id/revisions", suite.handler.ListRevisions)
+		cate.GET("/:id/revisions/:rev", suite.handler.GetRevision)
+		cate.POST("/:id/revisions/:rev/revert", suite.handler.Revert)
 	}
+	v1.POST("/categories:method", custommethod.Dispatch(map[string]gin.HandlerFunc{
+		"batchCreate": suite.handler.BatchCreate,
+		"batchUpdate": suite.handler.BatchUpdate,
+		"batchDelete": suite.handler.BatchDelete,
+	}))
 }
 
 func (suite *CategoryHandlerTestSuite) TearDownTest() {
@@ -58,7 +72,7 @@ func (suite *CategoryHandlerTestSuite) TestCreate_Success() {
 
 	suite.mockService.EXPECT().
 		Create(gomock.Any(), gomock.Any()).
-		Return(expectedID, nil).
+		Return(&entity.Category{ID: expectedID, Name: request.Name, Version: 1}, nil).
 		Times(1)
 
 	body, _ := json.Marshal(request)
@@ -68,11 +82,33 @@ func (suite *CategoryHandlerTestSuite) TestCreate_Success() {
 	suite.router.ServeHTTP(w, req)
 
 	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal("/api/v1/categories/"+expectedID, w.Header().Get("Location"))
+	suite.Equal(`"1"`, w.Header().Get("ETag"))
 
-	var response map[string]interface{}
+	var response dto.Category
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	suite.NoError(err)
-	suite.Equal(expectedID, response["status"])
+	suite.Equal(expectedID, response.ID)
+	suite.Equal(request.Name, response.Name)
+}
+
+func (suite *CategoryHandlerTestSuite) TestCreate_PreferReturnMinimal() {
+
+	suite.mockService.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(&entity.Category{ID: "category-123", Name: "Test Category", Version: 1}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/categories/", bytes.NewBufferString(`{"name": "Test Category"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Prefer", "return=minimal")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal("/api/v1/categories/category-123", w.Header().Get("Location"))
+	suite.Equal("return=minimal", w.Header().Get("Preference-Applied"))
+	suite.Empty(w.Body.Bytes())
 }
 
 func (suite *CategoryHandlerTestSuite) TestCreate_InvalidJSON() {
@@ -96,7 +132,7 @@ func (suite *CategoryHandlerTestSuite) TestCreate_ServiceError() {
 
 	suite.mockService.EXPECT().
 		Create(gomock.Any(), gomock.Any()).
-		Return("", expectedErr).
+		Return(nil, expectedErr).
 		Times(1)
 
 	body, _ := json.Marshal(request)
@@ -117,7 +153,7 @@ func (suite *CategoryHandlerTestSuite) TestUpdate_Success() {
 
 	suite.mockService.EXPECT().
 		Update(gomock.Any(), categoryID, gomock.Any()).
-		Return(nil).
+		Return(&entity.Category{ID: categoryID, Name: request.Name, Version: 4}, nil).
 		Times(1)
 
 	body, _ := json.Marshal(request)
@@ -127,11 +163,34 @@ func (suite *CategoryHandlerTestSuite) TestUpdate_Success() {
 	suite.router.ServeHTTP(w, req)
 
 	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
 
-	var response map[string]interface{}
+	var response dto.Category
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	suite.NoError(err)
-	suite.Equal("updated", response["status"])
+	suite.Equal(categoryID, response.ID)
+	suite.Equal(request.Name, response.Name)
+}
+
+func (suite *CategoryHandlerTestSuite) TestUpdate_PreferReturnMinimal() {
+
+	categoryID := "category-123"
+
+	suite.mockService.EXPECT().
+		Update(gomock.Any(), categoryID, gomock.Any()).
+		Return(&entity.Category{ID: categoryID, Name: "Updated Category", Version: 4}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/categories/%s", categoryID), bytes.NewBufferString(`{"name": "Updated Category"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Prefer", "return=minimal")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
+	suite.Equal("return=minimal", w.Header().Get("Preference-Applied"))
+	suite.Empty(w.Body.Bytes())
 }
 
 func (suite *CategoryHandlerTestSuite) TestUpdate_ServiceError() {
@@ -144,7 +203,7 @@ func (suite *CategoryHandlerTestSuite) TestUpdate_ServiceError() {
 
 	suite.mockService.EXPECT().
 		Update(gomock.Any(), categoryID, gomock.Any()).
-		Return(expectedErr).
+		Return(nil, expectedErr).
 		Times(1)
 
 	body, _ := json.Marshal(request)
@@ -156,12 +215,31 @@ func (suite *CategoryHandlerTestSuite) TestUpdate_ServiceError() {
 	suite.Equal(http.StatusNotFound, w.Code)
 }
 
+func (suite *CategoryHandlerTestSuite) TestUpdate_VersionMismatch() {
+
+	categoryID := "category-123"
+
+	suite.mockService.EXPECT().
+		Update(gomock.Any(), categoryID, entity.Category{Name: "Updated Category", Version: 1}).
+		Return(nil, apperr.ErrPreconditionFailed.WithDetails(entity.VersionConflict{CurrentVersion: 2})).
+		Times(1)
+
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/categories/%s", categoryID), bytes.NewBufferString(`{"name": "Updated Category"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusPreconditionFailed, w.Code)
+}
+
 func (suite *CategoryHandlerTestSuite) TestGetByID_Success() {
 
 	categoryID := "category-123"
 	expectedCategory := &entity.Category{
 		ID:        categoryID,
 		Name:      "Test Category",
+		Version:   2,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -182,6 +260,7 @@ func (suite *CategoryHandlerTestSuite) TestGetByID_Success() {
 	suite.NoError(err)
 	suite.Equal(expectedCategory.ID, response.ID)
 	suite.Equal(expectedCategory.Name, response.Name)
+	suite.Equal(`"2"`, w.Header().Get("ETag"))
 }
 
 func (suite *CategoryHandlerTestSuite) TestGetByID_ServiceError() {
@@ -218,6 +297,11 @@ func (suite *CategoryHandlerTestSuite) TestListAll_Success() {
 		},
 	}
 
+	suite.mockService.EXPECT().
+		GetListStats(gomock.Any(), gomock.Any()).
+		Return(&entity.ListStats{Count: 2, LastModified: time.Now()}, nil).
+		Times(1)
+
 	suite.mockService.EXPECT().
 		GetAll(gomock.Any(), gomock.Any()).
 		Return(expectedCategories, nil).
@@ -239,6 +323,11 @@ func (suite *CategoryHandlerTestSuite) TestListAll_Success() {
 
 func (suite *CategoryHandlerTestSuite) TestListAll_WithFilters() {
 
+	suite.mockService.EXPECT().
+		GetListStats(gomock.Any(), gomock.Any()).
+		Return(&entity.ListStats{Count: 2, LastModified: time.Now()}, nil).
+		Times(1)
+
 	suite.mockService.EXPECT().
 		GetAll(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(ctx interface{}, filter entity.CategoriesFilter) ([]entity.Category, error) {
@@ -255,12 +344,97 @@ func (suite *CategoryHandlerTestSuite) TestListAll_WithFilters() {
 	suite.Equal(http.StatusOK, w.Code)
 }
 
+func (suite *CategoryHandlerTestSuite) TestListAll_NotModified() {
+
+	stats := &entity.ListStats{Count: 2, LastModified: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	suite.mockService.EXPECT().
+		GetListStats(gomock.Any(), gomock.Any()).
+		Return(stats, nil).
+		Times(2)
+
+	suite.mockService.EXPECT().
+		GetAll(gomock.Any(), gomock.Any()).
+		Return([]entity.Category{}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/categories/?limit=5", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	suite.NotEmpty(etag)
+	suite.Equal("Fri, 01 May 2026 12:00:00 GMT", w.Header().Get("Last-Modified"))
+
+	req, _ = http.NewRequest("GET", "/api/v1/categories/?limit=5", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotModified, w.Code)
+	suite.Empty(w.Body.String())
+}
+
+func (suite *CategoryHandlerTestSuite) TestListAll_DifferentFilterChangesETag() {
+
+	stats := &entity.ListStats{Count: 2, LastModified: time.Now()}
+
+	suite.mockService.EXPECT().
+		GetListStats(gomock.Any(), gomock.Any()).
+		Return(stats, nil).
+		Times(2)
+
+	suite.mockService.EXPECT().
+		GetAll(gomock.Any(), gomock.Any()).
+		Return([]entity.Category{}, nil).
+		Times(2)
+
+	req, _ := http.NewRequest("GET", "/api/v1/categories/?limit=5", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	first := w.Header().Get("ETag")
+
+	req, _ = http.NewRequest("GET", "/api/v1/categories/?limit=5&offset=5", nil)
+	req.Header.Set("If-None-Match", first)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.NotEqual(first, w.Header().Get("ETag"))
+}
+
+func (suite *CategoryHandlerTestSuite) TestGetByID_NotModified() {
+
+	updatedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.mockService.EXPECT().
+		GetByID(gomock.Any(), "id-1").
+		Return(&entity.Category{ID: "id-1", Version: 4, UpdatedAt: updatedAt}, nil).
+		Times(2)
+
+	req, _ := http.NewRequest("GET", "/api/v1/categories/id-1", nil)
+	req.Header.Set("If-None-Match", `W/"4"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotModified, w.Code)
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
+
+	req, _ = http.NewRequest("GET", "/api/v1/categories/id-1", nil)
+	req.Header.Set("If-Modified-Since", "Fri, 01 May 2026 11:00:00 GMT")
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
 func (suite *CategoryHandlerTestSuite) TestDelete_Success() {
 
 	categoryID := "category-123"
 
 	suite.mockService.EXPECT().
-		Delete(gomock.Any(), categoryID).
+		Delete(gomock.Any(), categoryID, 0).
 		Return(nil).
 		Times(1)
 
@@ -276,13 +450,30 @@ func (suite *CategoryHandlerTestSuite) TestDelete_Success() {
 	suite.Equal("deleted", response["status"])
 }
 
+func (suite *CategoryHandlerTestSuite) TestDelete_IfMatch() {
+
+	categoryID := "category-123"
+
+	suite.mockService.EXPECT().
+		Delete(gomock.Any(), categoryID, 7).
+		Return(nil).
+		Times(1)
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/categories/%s", categoryID), nil)
+	req.Header.Set("If-Match", `"7"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
 func (suite *CategoryHandlerTestSuite) TestDelete_ServiceError() {
 
 	categoryID := "category-123"
 	expectedErr := apperr.ErrNotFound.WithMessage("category not found")
 
 	suite.mockService.EXPECT().
-		Delete(gomock.Any(), categoryID).
+		Delete(gomock.Any(), categoryID, 0).
 		Return(expectedErr).
 		Times(1)
 
@@ -293,6 +484,203 @@ func (suite *CategoryHandlerTestSuite) TestDelete_ServiceError() {
 	suite.Equal(http.StatusNotFound, w.Code)
 }
 
+func (suite *CategoryHandlerTestSuite) TestPatch_Success() {
+
+	name := "Renamed"
+
+	suite.mockService.EXPECT().
+		Patch(gomock.Any(), "category-123", entity.CategoryPatch{Name: &name, Version: 2}).
+		Return(&entity.Category{ID: "category-123", Name: name, Version: 3}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("PATCH", "/api/v1/categories/category-123", bytes.NewBufferString(`{"name": "Renamed"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"3"`, w.Header().Get("ETag"))
+
+	var response dto.Category
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal(name, response.Name)
+}
+
+func (suite *CategoryHandlerTestSuite) TestPatch_NullName() {
+
+	req, _ := http.NewRequest("PATCH", "/api/v1/categories/category-123", bytes.NewBufferString(`{"name": null}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *CategoryHandlerTestSuite) TestBatchCreate_Success() {
+
+	suite.mockService.EXPECT().
+		BatchCreate(gomock.Any(), []entity.Category{{Name: "Books"}, {Name: "Games"}}, entity.BatchAtomic).
+		Return([]entity.BatchResult{{Index: 0, ID: "category-1"}, {Index: 1, ID: "category-2"}}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/categories:batchCreate", bytes.NewBufferString(`{"items": [{"name": "Books"}, {"name": "Games"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response batch.Response
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal(2, response.Succeeded)
+	suite.Equal(batch.StatusCreated, response.Results[1].Status)
+	suite.Equal("category-2", response.Results[1].ID)
+}
+
+func (suite *CategoryHandlerTestSuite) TestBatchUpdate_BestEffort() {
+
+	suite.mockService.EXPECT().
+		BatchUpdate(gomock.Any(), []entity.Category{{ID: "category-1", Name: "Books", Version: 2}}, entity.BatchBestEffort).
+		Return([]entity.BatchResult{{Index: 0, ID: "category-1", Err: apperr.ErrPreconditionFailed}}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/categories:batchUpdate", bytes.NewBufferString(`{"mode": "best_effort", "items": [{"id": "category-1", "version": 2, "name": "Books"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response batch.Response
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal(1, response.Failed)
+	suite.Equal(batch.StatusFailed, response.Results[0].Status)
+	suite.Equal("PRECONDITION_FAILED", response.Results[0].ErrorCode)
+}
+
+func (suite *CategoryHandlerTestSuite) TestBatchDelete_ServiceError() {
+
+	suite.mockService.EXPECT().
+		BatchDelete(gomock.Any(), gomock.Any(), entity.BatchAtomic).
+		Return(nil, apperr.ErrInvalidArgument.WithMessage("batch has 4 items, the limit is 3")).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/categories:batchDelete", bytes.NewBufferString(`{"items": [{"id": "1"}, {"id": "2"}, {"id": "3"}, {"id": "4"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *CategoryHandlerTestSuite) TestListTrash_Success() {
+
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	suite.mockService.EXPECT().
+		ListTrash(gomock.Any(), entity.Pagination{Limit: 5, Offset: 10}).
+		Return([]entity.Category{{ID: "category-1", Name: "Phones", DeletedAt: &deletedAt}}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/categories/trash?limit=5&offset=10", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"deletedAt":"2026-01-02T03:04:05Z"`)
+}
+
+func (suite *CategoryHandlerTestSuite) TestRestore_Success() {
+
+	suite.mockService.EXPECT().
+		Restore(gomock.Any(), "category-1").
+		Return(&entity.Category{ID: "category-1", Name: "Phones", Version: 3}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/categories/category-1/restore", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"3"`, w.Header().Get("ETag"))
+	suite.NotContains(w.Body.String(), "deletedAt")
+}
+
+func (suite *CategoryHandlerTestSuite) TestPurge_Conflict() {
+
+	suite.mockService.EXPECT().
+		Purge(gomock.Any(), "category-1").
+		Return(apperr.ErrConflict.WithMessage("category still has products, deleted ones included")).
+		Times(1)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/categories/category-1/purge", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *CategoryHandlerTestSuite) TestListRevisions_DefaultPagination() {
+
+	suite.mockService.EXPECT().
+		ListRevisions(gomock.Any(), "category-1", entity.Pagination{}).
+		Return([]entity.Revision{{EntityID: "category-1", Number: 1, Action: entity.AuditActionCreate, Actor: "system"}}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/categories/category-1/revisions", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response []dto.Revision
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Len(response, 1)
+	suite.Equal("create", response[0].Action)
+}
+
+func (suite *CategoryHandlerTestSuite) TestGetRevision_Success() {
+
+	suite.mockService.EXPECT().
+		GetRevision(gomock.Any(), "category-1", 3).
+		Return(&entity.CategoryRevision{
+			Revision: entity.Revision{EntityID: "category-1", Number: 3, Action: entity.AuditActionDelete},
+			Category: entity.Category{ID: "category-1", Name: "Old", Version: 3},
+		}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/categories/category-1/revisions/3", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.CategoryRevision
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(3, response.Revision.Revision)
+	suite.Equal("delete", response.Action)
+	suite.Equal("Old", response.Category.Name)
+}
+
+func (suite *CategoryHandlerTestSuite) TestRevert_VersionMismatch() {
+
+	suite.mockService.EXPECT().
+		Revert(gomock.Any(), "category-1", 1, 2).
+		Return(nil, apperr.ErrPreconditionFailed.WithDetails(entity.VersionConflict{CurrentVersion: 3})).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/categories/category-1/revisions/1/revert", nil)
+	req.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusPreconditionFailed, w.Code)
+}
+
 func TestCategoryHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(CategoryHandlerTestSuite))
 }
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+// tokenPrefix versions the continuation token, so its format can change without old tokens
+// being misread.
+const tokenPrefix = "v1:"
+
+type FilterChangesRequest struct {
+	Since string `form:"since"`
+	Limit int    `form:"limit"`
+}
+
+// SinceSeq decodes the continuation token in Since into the sequence number to read on from. No
+// token reads from the start of the feed.
+func (r FilterChangesRequest) SinceSeq() (int64, error) {
+	if r.Since == "" {
+		return 0, nil
+	}
+
+	seq, ok := ParseToken(r.Since)
+	if !ok {
+		return 0, apperr.ErrInvalidArgument.WithMessage("since is not a token returned by this feed")
+	}
+	return seq, nil
+}
+
+// ParseToken decodes a continuation token into the sequence number it stands for and reports
+// whether it is one.
+func ParseToken(token string) (int64, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(decoded), tokenPrefix) {
+		return 0, false
+	}
+	seq, err := strconv.ParseInt(strings.TrimPrefix(string(decoded), tokenPrefix), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, false
+	}
+	return seq, true
+}
+
+// Token encodes seq as an opaque continuation token.
+func Token(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(tokenPrefix + strconv.FormatInt(seq, 10)))
+}
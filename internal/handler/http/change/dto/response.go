@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/pkg/utils"
+)
+
+// ChangePage represents a page of the changes feed. NextToken reads on after the last change of
+// the page, or from the same place when the page is empty.
+type ChangePage struct {
+	Changes   []Change `json:"changes"`
+	NextToken string   `json:"nextToken"`
+	HasMore   bool     `json:"hasMore"`
+} //	@name	ChangePage
+
+// Change represents one write to a product or category. Product or Category is the record as the
+// write left it; both are left out for a purge or once the record has been purged since.
+type Change struct {
+	Sequence   int64     `json:"sequence"`
+	EntityType string    `json:"entityType"`
+	EntityID   string    `json:"entityId"`
+	Action     string    `json:"action"`
+	Tombstone  bool      `json:"tombstone"`
+	Revision   int       `json:"revision,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Product    *Product  `json:"product,omitempty"`
+	Category   *Category `json:"category,omitempty"`
+} //	@name	Change
+
+// Product represents a product as a change left it
+type Product struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	SKU         string     `json:"sku"`
+	Price       float64    `json:"price"`
+	Stock       int        `json:"stock"`
+	ImageURL    string     `json:"imageUrl"`
+	CategoryID  string     `json:"categoryId"`
+	Version     int        `json:"version"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+} //	@name	ChangeProduct
+
+// Category represents a category as a change left it
+type Category struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+} //	@name	ChangeCategory
+
+func ChangePageFromDomain(page *entity.ChangePage) *ChangePage {
+	if page == nil {
+		return nil
+	}
+	changes := make([]Change, 0, len(page.Changes))
+	for _, change := range page.Changes {
+		changes = append(changes, ChangeFromDomain(change))
+	}
+	return &ChangePage{
+		Changes:   changes,
+		NextToken: Token(page.Next),
+		HasMore:   page.HasMore,
+	}
+}
+
+func ChangeFromDomain(change entity.Change) Change {
+	result := Change{
+		Sequence:   change.Seq,
+		EntityType: string(change.EntityType),
+		EntityID:   change.EntityID,
+		Action:     string(change.Action),
+		Tombstone:  change.IsTombstone(),
+		Revision:   change.Revision,
+		OccurredAt: change.OccurredAt,
+	}
+	if product := change.Product; product != nil {
+		result.Product = &Product{
+			ID:          product.ID,
+			Name:        product.Name,
+			Description: product.Description,
+			SKU:         product.SKU,
+			Price:       utils.GetValue(product.Price),
+			Stock:       utils.GetValue(product.Stock),
+			ImageURL:    utils.GetValue(product.ImageURL),
+			CategoryID:  product.CategoryID,
+			Version:     product.Version,
+			CreatedAt:   product.CreatedAt,
+			UpdatedAt:   product.UpdatedAt,
+			DeletedAt:   product.DeletedAt,
+		}
+	}
+	if category := change.Category; category != nil {
+		result.Category = &Category{
+			ID:        category.ID,
+			Name:      category.Name,
+			Version:   category.Version,
+			CreatedAt: category.CreatedAt,
+			UpdatedAt: category.UpdatedAt,
+			DeletedAt: category.DeletedAt,
+		}
+	}
+	return result
+}
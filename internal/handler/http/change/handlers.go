@@ -0,0 +1,53 @@
+package change
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/change/dto"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	changeSrv "github.com/sirawong/crud-arise/internal/services/change"
+)
+
+type ChangeHandler struct {
+	changeService changeSrv.ChangeService
+}
+
+func NewChangeHandler(changeService changeSrv.ChangeService) *ChangeHandler {
+	return &ChangeHandler{changeService: changeService}
+}
+
+// ListSince godoc
+//
+//	@Summary		Read the changes feed
+//	@Description	Get the creates, updates, deletes, restores and purges of products and categories in the order they committed, each with the record as it was left. Deletes and purges are tombstones. Pass nextToken as since to read on; no change is skipped however writes interleave. Without since the feed starts with the state of every record at the time it was introduced.
+//	@Tags			changes
+//	@Produce		json
+//	@Param			since	query		string					false	"Continuation token from a previous page"
+//	@Param			limit	query		int						false	"Limit number of results (default: 100, limit: 1000)"
+//	@Success		200		{object}	dto.ChangePage			"Changes, oldest first"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/changes [get]
+func (h ChangeHandler) ListSince(c *gin.Context) {
+	var query dto.FilterChangesRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	since, err := query.SinceSeq()
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	page, err := h.changeService.List(c, since, query.Limit)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ChangePageFromDomain(page))
+}
@@ -0,0 +1,132 @@
+package change
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/change/dto"
+	"github.com/sirawong/crud-arise/internal/services/change/mocks"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChangeHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockChangeService
+	handler     *ChangeHandler
+	router      *gin.Engine
+}
+
+func (suite *ChangeHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockChangeService(suite.mockCtrl)
+	suite.handler = NewChangeHandler(suite.mockService)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	v1.GET("/changes", suite.handler.ListSince)
+}
+
+func (suite *ChangeHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *ChangeHandlerTestSuite) TestListSince_FromStart() {
+	occurredAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	deletedAt := occurredAt.Add(time.Hour)
+
+	suite.mockService.EXPECT().
+		List(gomock.Any(), int64(0), 2).
+		Return(&entity.ChangePage{
+			Changes: []entity.Change{
+				{Seq: 1, EntityType: entity.AuditEntityProduct, EntityID: "product-1", Action: entity.AuditActionCreate,
+					Revision: 1, OccurredAt: occurredAt,
+					Product: &entity.Product{ID: "product-1", Name: "Hammer", Price: utils.SetPtr(9.5), CategoryID: "category-1"}},
+				{Seq: 2, EntityType: entity.AuditEntityCategory, EntityID: "category-2", Action: entity.AuditActionDelete,
+					Revision: 3, OccurredAt: deletedAt,
+					Category: &entity.Category{ID: "category-2", Name: "Old", DeletedAt: &deletedAt}},
+			},
+			Next:    2,
+			HasMore: true,
+		}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/changes?limit=2", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.ChangePage
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.True(response.HasMore)
+	suite.Equal(dto.Token(2), response.NextToken)
+	suite.Len(response.Changes, 2)
+	suite.False(response.Changes[0].Tombstone)
+	suite.Equal("Hammer", response.Changes[0].Product.Name)
+	suite.Equal("category-1", response.Changes[0].Product.CategoryID)
+	suite.Nil(response.Changes[0].Category)
+	suite.True(response.Changes[1].Tombstone)
+	suite.Equal("delete", response.Changes[1].Action)
+	suite.Equal(&deletedAt, response.Changes[1].Category.DeletedAt)
+}
+
+func (suite *ChangeHandlerTestSuite) TestListSince_ResumesFromToken() {
+	suite.mockService.EXPECT().
+		List(gomock.Any(), int64(41), 0).
+		Return(&entity.ChangePage{
+			Changes: []entity.Change{{Seq: 42, EntityType: entity.AuditEntityProduct, EntityID: "product-1", Action: entity.AuditActionPurge}},
+			Next:    42,
+		}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/changes?since="+dto.Token(41), nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.ChangePage
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.False(response.HasMore)
+	suite.Equal(dto.Token(42), response.NextToken)
+	suite.True(response.Changes[0].Tombstone)
+	suite.Nil(response.Changes[0].Product)
+}
+
+func (suite *ChangeHandlerTestSuite) TestListSince_InvalidToken() {
+	for _, token := range []string{"42", "!!!", "djE6LTE", "djI6NDI"} {
+		req, _ := http.NewRequest("GET", "/api/v1/changes?since="+token, nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		suite.Equal(http.StatusBadRequest, w.Code, token)
+	}
+}
+
+func (suite *ChangeHandlerTestSuite) TestListSince_ServiceError() {
+	suite.mockService.EXPECT().
+		List(gomock.Any(), int64(0), 0).
+		Return(nil, apperr.ErrInternal).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/changes", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func TestChangeHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ChangeHandlerTestSuite))
+}
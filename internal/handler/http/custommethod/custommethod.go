@@ -0,0 +1,25 @@
+// Package custommethod routes custom methods such as POST /products:batchCreate.
+package custommethod
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+)
+
+// Dispatch serves the custom methods of one collection. Gin reads the colon as the start of a
+// path parameter, so the route is registered once as "/products:method" and the method name
+// arrives in that parameter, colon included.
+func Dispatch(methods map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name, ok := strings.CutPrefix(c.Param("method"), ":")
+		handler, known := methods[name]
+		if !ok || !known {
+			handlererr.RespondWithError(c, apperr.ErrNotFound.WithMessage("unknown method"))
+			return
+		}
+		handler(c)
+	}
+}
@@ -0,0 +1,39 @@
+package custommethod
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/products/", func(c *gin.Context) { c.String(http.StatusCreated, "create") })
+	router.POST("/products:method", Dispatch(map[string]gin.HandlerFunc{
+		"batchCreate": func(c *gin.Context) { c.String(http.StatusOK, "batchCreate") },
+	}))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("/products:batchCreate")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "batchCreate", w.Body.String())
+
+	w = serve("/products/")
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = serve("/products:batchDrop")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve("/productsbatchCreate")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
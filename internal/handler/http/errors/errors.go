@@ -13,6 +13,18 @@ func mapErrorToHTTPStatus(code string) int {
 		return http.StatusNotFound
 	case apperr.ErrInvalidArgument.Code:
 		return http.StatusBadRequest
+	case apperr.ErrConflict.Code, apperr.ErrInsufficientStock.Code:
+		return http.StatusConflict
+	case apperr.ErrUnprocessable.Code:
+		return http.StatusUnprocessableEntity
+	case apperr.ErrPreconditionFailed.Code:
+		return http.StatusPreconditionFailed
+	case apperr.ErrPreconditionRequired.Code:
+		return http.StatusPreconditionRequired
+	case apperr.ErrUnavailable.Code:
+		return http.StatusServiceUnavailable
+	case apperr.ErrUnauthenticated.Code:
+		return http.StatusUnauthorized
 	default:
 		return http.StatusInternalServerError
 	}
@@ -21,5 +33,10 @@ func mapErrorToHTTPStatus(code string) int {
 func RespondWithError(c *gin.Context, err error) {
 	code := apperr.GetCode(err)
 	httpStatus := mapErrorToHTTPStatus(code)
-	c.JSON(httpStatus, gin.H{"error_code": code, "message": err.Error()})
+
+	body := gin.H{"error_code": code, "message": err.Error()}
+	if details := apperr.GetDetails(err); details != nil {
+		body["details"] = details
+	}
+	c.JSON(httpStatus, body)
 }
@@ -0,0 +1,110 @@
+package idempotency
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawong/crud-arise/internal/repository/memory"
+	"github.com/sirawong/crud-arise/internal/services/idempotency"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFlow runs the middleware against the real service and the in-memory store.
+func TestFlow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var created atomic.Int32
+	entered, release := make(chan struct{}), make(chan struct{})
+	router := gin.New()
+	router.Use(NewIdempotencyHandler(idempotency.NewIdempotencyService(memory.NewIdempotencyRepository(), time.Hour, time.Hour)).Handle)
+	router.POST("/things", func(c *gin.Context) {
+		if c.Query("wait") != "" {
+			close(entered)
+			<-release
+		}
+		n := created.Add(1)
+		c.JSON(http.StatusCreated, gin.H{"n": n})
+	})
+
+	post := func(clientID, key, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set(HeaderClientID, clientID)
+		req.Header.Set(HeaderKey, key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("client-1", "key-1", "/things", `{"name":"a"}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.JSONEq(t, `{"n":1}`, first.Body.String())
+
+	retry := post("client-1", "key-1", "/things", `{"name":"a"}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.JSONEq(t, `{"n":1}`, retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(HeaderReplayed))
+
+	mismatch := post("client-1", "key-1", "/things", `{"name":"b"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, mismatch.Code)
+
+	otherClient := post("client-2", "key-1", "/things", `{"name":"a"}`)
+	assert.Equal(t, http.StatusCreated, otherClient.Code)
+	assert.JSONEq(t, `{"n":2}`, otherClient.Body.String())
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- post("client-1", "key-2", "/things?wait=1", `{}`) }()
+	<-entered
+	inFlight := post("client-1", "key-2", "/things?wait=1", `{}`)
+	assert.Equal(t, http.StatusConflict, inFlight.Code)
+	close(release)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+	assert.Equal(t, int32(3), created.Load())
+}
+
+// TestFlow_TakenOver checks that a request outliving the in-flight timeout cannot store its
+// response over the reservation of the retry that took its key over.
+func TestFlow_TakenOver(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var created atomic.Int32
+	entered := []chan struct{}{make(chan struct{}), make(chan struct{})}
+	release := []chan struct{}{make(chan struct{}), make(chan struct{})}
+	router := gin.New()
+	router.Use(NewIdempotencyHandler(idempotency.NewIdempotencyService(memory.NewIdempotencyRepository(), time.Hour, 10*time.Millisecond)).Handle)
+	router.POST("/things", func(c *gin.Context) {
+		n := created.Add(1)
+		close(entered[n-1])
+		<-release[n-1]
+		c.JSON(http.StatusCreated, gin.H{"n": n})
+	})
+
+	post := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/things", bytes.NewBufferString(`{}`))
+		req.Header.Set(HeaderClientID, "client-1")
+		req.Header.Set(HeaderKey, "key-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first, retry := make(chan *httptest.ResponseRecorder), make(chan *httptest.ResponseRecorder)
+	go func() { first <- post() }()
+	<-entered[0]
+	time.Sleep(20 * time.Millisecond)
+	go func() { retry <- post() }()
+	<-entered[1]
+
+	close(release[0])
+	assert.JSONEq(t, `{"n":1}`, (<-first).Body.String())
+	close(release[1])
+	assert.JSONEq(t, `{"n":2}`, (<-retry).Body.String())
+
+	replayed := post()
+	assert.Equal(t, "true", replayed.Header().Get(HeaderReplayed))
+	assert.JSONEq(t, `{"n":2}`, replayed.Body.String())
+}
@@ -0,0 +1,146 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/services/idempotency"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderClientID = "X-Client-ID"
+	HeaderReplayed = "Idempotent-Replayed"
+)
+
+// storedHeaders are the response headers replayed along with the stored status and body.
+var storedHeaders = []string{"Content-Type", "Location", "ETag", "Last-Modified"}
+
+type IdempotencyHandler struct {
+	idempotencyService idempotency.IdempotencyService
+}
+
+func NewIdempotencyHandler(idempotencyService idempotency.IdempotencyService) *IdempotencyHandler {
+	return &IdempotencyHandler{
+		idempotencyService: idempotencyService,
+	}
+}
+
+// Handle makes POST requests carrying an Idempotency-Key safe to retry. The first response below
+// 500 is stored per client and key and replayed for retries of the same request; a server error
+// releases the key so the retry runs again.
+func (h IdempotencyHandler) Handle(c *gin.Context) {
+	key := c.GetHeader(HeaderKey)
+	if c.Request.Method != http.MethodPost || key == "" {
+		c.Next()
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("could not read request body"))
+		c.Abort()
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	clientID := clientIdentity(c)
+	stored, token, err := h.idempotencyService.Begin(c, clientID, key, requestHash(c.Request, body))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		c.Abort()
+		return
+	}
+	if stored != nil {
+		for name, value := range stored.Headers {
+			c.Header(name, value)
+		}
+		c.Header(HeaderReplayed, "true")
+		c.Data(stored.Status, stored.Headers["Content-Type"], stored.Body)
+		c.Abort()
+		return
+	}
+
+	// The outcome is recorded even if the client has gone away, otherwise the key would stay
+	// reserved until the in-flight timeout.
+	ctx := context.WithoutCancel(c.Request.Context())
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			h.release(ctx, clientID, key, token)
+			panic(recovered)
+		}
+	}()
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	c.Next()
+
+	status := recorder.Status()
+	if status >= http.StatusInternalServerError {
+		h.release(ctx, clientID, key, token)
+		return
+	}
+
+	headers := make(map[string]string)
+	for _, name := range storedHeaders {
+		if value := recorder.Header().Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+	err = h.idempotencyService.Complete(ctx, clientID, key, token, entity.IdempotentResponse{
+		Status:  status,
+		Headers: headers,
+		Body:    recorder.body.Bytes(),
+	})
+	if err != nil {
+		log.Printf("idempotency: could not store response for key %q: %v", key, err)
+	}
+}
+
+func (h IdempotencyHandler) release(ctx context.Context, clientID, key, token string) {
+	if err := h.idempotencyService.Release(ctx, clientID, key, token); err != nil {
+		log.Printf("idempotency: could not release key %q: %v", key, err)
+	}
+}
+
+// clientIdentity scopes keys to a caller, so two clients picking the same key do not see each
+// other's responses. Callers identify themselves with X-Client-ID and fall back to their address.
+func clientIdentity(c *gin.Context) string {
+	if clientID := c.GetHeader(HeaderClientID); clientID != "" {
+		return clientID
+	}
+	return c.ClientIP()
+}
+
+// requestHash fingerprints what a key was first used for, so reusing it for a different request
+// can be told apart from a retry.
+func requestHash(r *http.Request, body []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	sum.Write(body)
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
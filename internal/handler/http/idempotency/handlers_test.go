@@ -0,0 +1,181 @@
+package idempotency
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/services/idempotency/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockIdempotencyService
+	handler     *IdempotencyHandler
+	router      *gin.Engine
+	calls       int
+}
+
+func (suite *IdempotencyHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockIdempotencyService(suite.mockCtrl)
+	suite.handler = NewIdempotencyHandler(suite.mockService)
+	suite.calls = 0
+
+	suite.router = gin.New()
+	suite.router.Use(suite.handler.Handle)
+	suite.router.POST("/things", func(c *gin.Context) {
+		suite.calls++
+		c.Header("Location", "/things/thing-1")
+		c.JSON(http.StatusCreated, gin.H{"id": "thing-1"})
+	})
+	suite.router.POST("/broken", func(c *gin.Context) {
+		suite.calls++
+		c.JSON(http.StatusInternalServerError, gin.H{"error_code": "INTERNAL_ERROR"})
+	})
+	suite.router.GET("/things", func(c *gin.Context) {
+		suite.calls++
+		c.Status(http.StatusOK)
+	})
+}
+
+func (suite *IdempotencyHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *IdempotencyHandlerTestSuite) post(path, key string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", path, bytes.NewBufferString(`{"name":"thing"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderClientID, "client-1")
+	if key != "" {
+		req.Header.Set(HeaderKey, key)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *IdempotencyHandlerTestSuite) TestHandle_WithoutKey() {
+
+	w := suite.post("/things", "")
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal(1, suite.calls)
+}
+
+func (suite *IdempotencyHandlerTestSuite) TestHandle_IgnoresOtherMethods() {
+
+	req, _ := http.NewRequest("GET", "/things", nil)
+	req.Header.Set(HeaderKey, "key-1")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(1, suite.calls)
+}
+
+func (suite *IdempotencyHandlerTestSuite) TestHandle_StoresFirstResponse() {
+
+	suite.mockService.EXPECT().
+		Begin(gomock.Any(), "client-1", "key-1", gomock.Any()).
+		Return(nil, "token-1", nil).
+		Times(1)
+
+	suite.mockService.EXPECT().
+		Complete(gomock.Any(), "client-1", "key-1", "token-1", entity.IdempotentResponse{
+			Status: http.StatusCreated,
+			Headers: map[string]string{
+				"Content-Type": "application/json; charset=utf-8",
+				"Location":     "/things/thing-1",
+			},
+			Body: []byte(`{"id":"thing-1"}`),
+		}).
+		Return(nil).
+		Times(1)
+
+	w := suite.post("/things", "key-1")
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal(1, suite.calls)
+	suite.Empty(w.Header().Get(HeaderReplayed))
+}
+
+func (suite *IdempotencyHandlerTestSuite) TestHandle_ReplaysStoredResponse() {
+
+	suite.mockService.EXPECT().
+		Begin(gomock.Any(), "client-1", "key-1", gomock.Any()).
+		Return(&entity.IdempotentResponse{
+			Status: http.StatusCreated,
+			Headers: map[string]string{
+				"Content-Type": "application/json; charset=utf-8",
+				"Location":     "/things/thing-1",
+			},
+			Body: []byte(`{"id":"thing-1"}`),
+		}, "", nil).
+		Times(1)
+
+	w := suite.post("/things", "key-1")
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal(0, suite.calls)
+	suite.Equal("true", w.Header().Get(HeaderReplayed))
+	suite.Equal("/things/thing-1", w.Header().Get("Location"))
+	suite.JSONEq(`{"id":"thing-1"}`, w.Body.String())
+}
+
+func (suite *IdempotencyHandlerTestSuite) TestHandle_InProgress() {
+
+	suite.mockService.EXPECT().
+		Begin(gomock.Any(), "client-1", "key-1", gomock.Any()).
+		Return(nil, "", apperr.ErrConflict.WithMessage("a request with this idempotency key is still in progress")).
+		Times(1)
+
+	w := suite.post("/things", "key-1")
+
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.Equal(0, suite.calls)
+}
+
+func (suite *IdempotencyHandlerTestSuite) TestHandle_DifferentRequest() {
+
+	suite.mockService.EXPECT().
+		Begin(gomock.Any(), "client-1", "key-1", gomock.Any()).
+		Return(nil, "", apperr.ErrUnprocessable.WithMessage("idempotency key has already been used for a different request")).
+		Times(1)
+
+	w := suite.post("/things", "key-1")
+
+	suite.Equal(http.StatusUnprocessableEntity, w.Code)
+	suite.Equal(0, suite.calls)
+}
+
+func (suite *IdempotencyHandlerTestSuite) TestHandle_ReleasesOnServerError() {
+
+	suite.mockService.EXPECT().
+		Begin(gomock.Any(), "client-1", "key-1", gomock.Any()).
+		Return(nil, "token-1", nil).
+		Times(1)
+
+	suite.mockService.EXPECT().
+		Release(gomock.Any(), "client-1", "key-1", "token-1").
+		Return(nil).
+		Times(1)
+
+	w := suite.post("/broken", "key-1")
+
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	suite.Equal(1, suite.calls)
+}
+
+func TestIdempotencyHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyHandlerTestSuite))
+}
@@ -0,0 +1,50 @@
+package identity
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+)
+
+const (
+	HeaderActor     = "X-Actor"
+	HeaderClientID  = "X-Client-ID"
+	HeaderRequestID = "X-Request-ID"
+)
+
+// maxLength is the longest actor or request ID the audit log stores.
+const maxLength = 255
+
+// Attach attributes the changes a request makes to an actor and to the request's ID, both carried
+// on the request context. The actor is X-Actor, falling back to the X-Client-ID the caller
+// identifies itself with and then to its address. X-Request-ID is kept when the client sends one,
+// generated otherwise, and echoed on the response.
+func Attach(c *gin.Context) {
+	actor := c.GetHeader(HeaderActor)
+	if actor == "" {
+		actor = c.GetHeader(HeaderClientID)
+	}
+	if actor == "" {
+		actor = c.ClientIP()
+	}
+
+	requestID := c.GetHeader(HeaderRequestID)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+
+	if len(actor) > maxLength || len(requestID) > maxLength {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("actor and request id must be at most 255 characters"))
+		c.Abort()
+		return
+	}
+
+	c.Header(HeaderRequestID, requestID)
+	c.Request = c.Request.WithContext(entity.WithActor(c.Request.Context(), entity.Actor{
+		Name:      actor,
+		RequestID: requestID,
+	}))
+	c.Next()
+}
@@ -0,0 +1,63 @@
+package identity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(headers map[string]string) (*httptest.ResponseRecorder, entity.Actor) {
+	var actor entity.Actor
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(Attach)
+	router.GET("/", func(c *gin.Context) {
+		actor = entity.ActorFrom(c)
+		c.Status(http.StatusNoContent)
+	})
+
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, actor
+}
+
+func TestAttach(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w, actor := serve(map[string]string{HeaderActor: "alice", HeaderClientID: "client-1", HeaderRequestID: "req-1"})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, entity.Actor{Name: "alice", RequestID: "req-1"}, actor)
+	assert.Equal(t, "req-1", w.Header().Get(HeaderRequestID))
+
+	_, actor = serve(map[string]string{HeaderClientID: "client-1"})
+	assert.Equal(t, "client-1", actor.Name)
+
+	w, actor = serve(nil)
+	assert.Equal(t, "10.0.0.1", actor.Name)
+	assert.NotEmpty(t, actor.RequestID)
+	assert.Equal(t, actor.RequestID, w.Header().Get(HeaderRequestID))
+}
+
+func TestAttach_TooLong(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w, _ := serve(map[string]string{HeaderActor: strings.Repeat("a", 256)})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestActorFrom_DefaultsToSystem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	assert.Equal(t, entity.SystemActor, entity.ActorFrom(c).Name)
+}
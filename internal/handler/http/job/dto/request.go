@@ -0,0 +1,80 @@
+package dto
+
+import (
+	jobSrv "github.com/sirawong/crud-arise/internal/services/job"
+)
+
+// ImportItemRequest represents one product of an import, created or replaced by its SKU
+type ImportItemRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	SKU         string   `json:"sku" binding:"required"`
+	Price       *float64 `json:"price" binding:"required,min=0"`
+	Stock       *int     `json:"stock" binding:"required,min=0"`
+	ImageURL    string   `json:"imageUrl"`
+	CategoryID  string   `json:"categoryId" binding:"required"`
+} //	@name	ImportItemRequest
+
+// ImportRequest represents the request payload for importing products in the background
+type ImportRequest struct {
+	Items []ImportItemRequest `json:"items" binding:"required,min=1,dive"`
+} //	@name	ImportRequest
+
+func (r ImportRequest) ToParams() jobSrv.ImportParams {
+	items := make([]jobSrv.ImportItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, jobSrv.ImportItem{
+			Name:        item.Name,
+			Description: item.Description,
+			SKU:         item.SKU,
+			Price:       item.Price,
+			Stock:       item.Stock,
+			ImageURL:    item.ImageURL,
+			CategoryID:  item.CategoryID,
+		})
+	}
+	return jobSrv.ImportParams{Items: items}
+}
+
+// ProductQueryRequest selects the products a job works on; all of them when empty
+type ProductQueryRequest struct {
+	Name       *string  `json:"name"`
+	CategoryID *string  `json:"categoryId" binding:"omitempty,uuid"`
+	MinPrice   *float64 `json:"minPrice" binding:"omitempty,min=0"`
+	MaxPrice   *float64 `json:"maxPrice" binding:"omitempty,min=0"`
+} //	@name	ProductQueryRequest
+
+func (r ProductQueryRequest) toQuery() jobSrv.ProductQuery {
+	return jobSrv.ProductQuery{
+		Name:       r.Name,
+		CategoryID: r.CategoryID,
+		MinPrice:   r.MinPrice,
+		MaxPrice:   r.MaxPrice,
+	}
+}
+
+// ExportRequest represents the request payload for exporting products in the background
+type ExportRequest struct {
+	ProductQueryRequest
+} //	@name	ExportRequest
+
+func (r ExportRequest) ToParams() jobSrv.ExportParams {
+	return jobSrv.ExportParams{ProductQuery: r.toQuery()}
+}
+
+// PriceChangeRequest represents the request payload for repricing products in the background
+type PriceChangeRequest struct {
+	ProductQueryRequest
+	// Percent raises, or when negative lowers, each price by that percentage.
+	Percent float64 `json:"percent" binding:"required,gt=-100"`
+} //	@name	PriceChangeRequest
+
+func (r PriceChangeRequest) ToParams() jobSrv.PriceChangeParams {
+	return jobSrv.PriceChangeParams{ProductQuery: r.toQuery(), Percent: r.Percent}
+}
+
+// ExportPageURI names one page of an export job in the path.
+type ExportPageURI struct {
+	ID   string `uri:"id" binding:"required"`
+	Page int    `uri:"page" binding:"required,min=1"`
+}
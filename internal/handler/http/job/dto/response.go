@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// Job represents the response payload for a background job. Result is set once the job has
+// succeeded; its shape depends on the job's type.
+type Job struct {
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	Progress        JobProgress     `json:"progress"`
+	Result          json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	Error           string          `json:"error,omitempty"`
+	Attempts        int             `json:"attempts"`
+	CancelRequested bool            `json:"cancelRequested"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	StartedAt       *time.Time      `json:"startedAt,omitempty"`
+	FinishedAt      *time.Time      `json:"finishedAt,omitempty"`
+	URL             string          `json:"url"`
+} //	@name	Job
+
+// JobProgress represents how many items a job has done out of the ones it has to do
+type JobProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+} //	@name	JobProgress
+
+// JobFromDomain maps a job, polled at url.
+func JobFromDomain(job *entity.Job, url string) *Job {
+	if job == nil {
+		return nil
+	}
+	return &Job{
+		ID:     job.ID,
+		Type:   string(job.Type),
+		Status: string(job.Status),
+		Progress: JobProgress{
+			Done:  job.Progress.Done,
+			Total: job.Progress.Total,
+		},
+		Result:          job.Result,
+		Error:           job.Error,
+		Attempts:        job.Attempts,
+		CancelRequested: job.CancelRequested,
+		CreatedAt:       job.CreatedAt,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+		URL:             url,
+	}
+}
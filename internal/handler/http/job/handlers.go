@@ -0,0 +1,206 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/job/dto"
+	jobSrv "github.com/sirawong/crud-arise/internal/services/job"
+)
+
+type JobHandler struct {
+	jobService     jobSrv.JobService
+	maxImportItems int
+}
+
+func NewJobHandler(jobService jobSrv.JobService, maxImportItems int) *JobHandler {
+	return &JobHandler{
+		jobService:     jobService,
+		maxImportItems: maxImportItems,
+	}
+}
+
+// ImportProducts godoc
+//
+//	@Summary		Import products in the background
+//	@Description	Queue a job that creates or replaces the products by SKU, up to JOB_MAX_IMPORT_ITEMS of them, a chunk at a time. Items that fail are reported in the job's result.
+//	@Tags			jobs
+//	@Accept			json
+//	@Produce		json
+//	@Param			products	body		dto.ImportRequest		true	"Products to import"
+//	@Success		202			{object}	dto.Job					"Queued job"
+//	@Header			202			{string}	Location				"URL of the job"
+//	@Failure		400			{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products:import [post]
+func (h JobHandler) ImportProducts(c *gin.Context) {
+	var req dto.ImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+	if len(req.Items) > h.maxImportItems {
+		msg := fmt.Sprintf("import has %d items, the limit is %d", len(req.Items), h.maxImportItems)
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage(msg))
+		return
+	}
+
+	h.enqueue(c, entity.JobTypeProductImport, req.ToParams())
+}
+
+// ExportProducts godoc
+//
+//	@Summary		Export products in the background
+//	@Description	Queue a job that writes the products matching the query to pages read from /jobs/{id}/pages/{page}; the job's result counts the products and pages. An empty body exports every product.
+//	@Tags			jobs
+//	@Accept			json
+//	@Produce		json
+//	@Param			query	body		dto.ExportRequest		false	"Products to export"
+//	@Success		202		{object}	dto.Job					"Queued job"
+//	@Header			202		{string}	Location				"URL of the job"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products:export [post]
+func (h JobHandler) ExportProducts(c *gin.Context) {
+	var req dto.ExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	h.enqueue(c, entity.JobTypeProductExport, req.ToParams())
+}
+
+// ChangePrices godoc
+//
+//	@Summary		Reprice products in the background
+//	@Description	Queue a job that raises, or lowers, the price of the products matching the query by a percentage, rounded to cents. A product changed while the job runs is reported as failed in the job's result.
+//	@Tags			jobs
+//	@Accept			json
+//	@Produce		json
+//	@Param			change	body		dto.PriceChangeRequest	true	"Products to reprice and by how much"
+//	@Success		202		{object}	dto.Job					"Queued job"
+//	@Header			202		{string}	Location				"URL of the job"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products:changePrices [post]
+func (h JobHandler) ChangePrices(c *gin.Context) {
+	var req dto.PriceChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	h.enqueue(c, entity.JobTypeProductPriceChange, req.ToParams())
+}
+
+// enqueue queues the job and answers with where to poll it. The jobs sit next to the collection
+// whose custom method queued them.
+func (h JobHandler) enqueue(c *gin.Context, jobType entity.JobType, params interface{}) {
+	job, err := h.jobService.Enqueue(c, jobType, params)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	url := path.Join(path.Dir(c.Request.URL.Path), "jobs", job.ID)
+	c.Header("Location", url)
+	c.JSON(http.StatusAccepted, dto.JobFromDomain(job, url))
+}
+
+// GetByID godoc
+//
+//	@Summary		Get a job
+//	@Description	Poll a background job for its status and progress, and its result once it has succeeded
+//	@Tags			jobs
+//	@Produce		json
+//	@Param			id	path		string					true	"Job ID"
+//	@Success		200	{object}	dto.Job					"Job"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/jobs/{id} [get]
+func (h JobHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	job, err := h.jobService.GetByID(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JobFromDomain(job, c.Request.URL.Path))
+}
+
+// Cancel godoc
+//
+//	@Summary		Cancel a job
+//	@Description	Cancel a queued job, or ask a running one to stop. A running job stops at its next heartbeat, keeping the writes it already made; until then it is reported with cancelRequested set.
+//	@Tags			jobs
+//	@Produce		json
+//	@Param			id	path		string					true	"Job ID"
+//	@Success		200	{object}	dto.Job					"Cancelled job"
+//	@Success		202	{object}	dto.Job					"Running job asked to stop"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/jobs/{id}/cancel [post]
+func (h JobHandler) Cancel(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	job, err := h.jobService.Cancel(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if !job.Status.IsFinished() {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, dto.JobFromDomain(job, path.Dir(c.Request.URL.Path)))
+}
+
+// GetExportPage godoc
+//
+//	@Summary		Get a page of an export
+//	@Description	Get one page of the products a succeeded export job wrote, in id order. The job's result tells how many pages there are; each holds up to JOB_CHUNK_SIZE products.
+//	@Tags			jobs
+//	@Produce		json
+//	@Param			id		path		string					true	"Job ID"
+//	@Param			page	path		int						true	"Page number, from 1"
+//	@Success		200		{array}		jobSrv.ExportedProduct	"Exported products"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409		{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/jobs/{id}/pages/{page} [get]
+func (h JobHandler) GetExportPage(c *gin.Context) {
+	var uri dto.ExportPageURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	products, err := h.jobService.GetExportPage(c, uri.ID, uri.Page)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", products)
+}
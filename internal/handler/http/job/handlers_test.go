@@ -0,0 +1,258 @@
+package job
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/custommethod"
+	"github.com/sirawong/crud-arise/internal/handler/http/job/dto"
+	jobSrv "github.com/sirawong/crud-arise/internal/services/job"
+	"github.com/sirawong/crud-arise/internal/services/job/mocks"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockJobService
+	handler     *JobHandler
+	router      *gin.Engine
+}
+
+func (suite *JobHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockJobService(suite.mockCtrl)
+	suite.handler = NewJobHandler(suite.mockService, 2)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	v1.POST("/products:method", custommethod.Dispatch(map[string]gin.HandlerFunc{
+		"import":       suite.handler.ImportProducts,
+		"export":       suite.handler.ExportProducts,
+		"changePrices": suite.handler.ChangePrices,
+	}))
+	jobs := v1.Group("/jobs")
+	{
+		jobs.GET("/:id", suite.handler.GetByID)
+		jobs.POST("/:id/cancel", suite.handler.Cancel)
+		jobs.GET("/:id/pages/:page", suite.handler.GetExportPage)
+	}
+}
+
+func (suite *JobHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *JobHandlerTestSuite) post(url string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		raw, _ := json.Marshal(body)
+		buf.Write(raw)
+	}
+	req, _ := http.NewRequest("POST", url, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *JobHandlerTestSuite) queued(jobType entity.JobType) *entity.Job {
+	return &entity.Job{
+		ID:        "job-1",
+		Type:      jobType,
+		Status:    entity.JobStatusQueued,
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (suite *JobHandlerTestSuite) TestImportProducts_Accepted() {
+	request := dto.ImportRequest{Items: []dto.ImportItemRequest{{
+		Name:       "Keyboard",
+		SKU:        "KB-1",
+		Price:      utils.SetPtr(49.5),
+		Stock:      utils.SetPtr(3),
+		CategoryID: "category-1",
+	}}}
+
+	suite.mockService.EXPECT().
+		Enqueue(gomock.Any(), entity.JobTypeProductImport, jobSrv.ImportParams{Items: []jobSrv.ImportItem{{
+			Name:       "Keyboard",
+			SKU:        "KB-1",
+			Price:      utils.SetPtr(49.5),
+			Stock:      utils.SetPtr(3),
+			CategoryID: "category-1",
+		}}}).
+		Return(suite.queued(entity.JobTypeProductImport), nil).
+		Times(1)
+
+	w := suite.post("/api/v1/products:import", request)
+
+	suite.Equal(http.StatusAccepted, w.Code)
+	suite.Equal("/api/v1/jobs/job-1", w.Header().Get("Location"))
+
+	var response dto.Job
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal("job-1", response.ID)
+	suite.Equal("product.import", response.Type)
+	suite.Equal("queued", response.Status)
+	suite.Equal("/api/v1/jobs/job-1", response.URL)
+}
+
+func (suite *JobHandlerTestSuite) TestImportProducts_TooManyItems() {
+	item := dto.ImportItemRequest{Name: "Keyboard", SKU: "KB-1", Price: utils.SetPtr(1.0), Stock: utils.SetPtr(1), CategoryID: "c"}
+
+	w := suite.post("/api/v1/products:import", dto.ImportRequest{Items: []dto.ImportItemRequest{item, item, item}})
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *JobHandlerTestSuite) TestImportProducts_InvalidItem() {
+	w := suite.post("/api/v1/products:import", dto.ImportRequest{Items: []dto.ImportItemRequest{{Name: "Keyboard"}}})
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *JobHandlerTestSuite) TestExportProducts_EmptyBody() {
+	suite.mockService.EXPECT().
+		Enqueue(gomock.Any(), entity.JobTypeProductExport, jobSrv.ExportParams{}).
+		Return(suite.queued(entity.JobTypeProductExport), nil).
+		Times(1)
+
+	w := suite.post("/api/v1/products:export", nil)
+
+	suite.Equal(http.StatusAccepted, w.Code)
+	suite.Equal("/api/v1/jobs/job-1", w.Header().Get("Location"))
+}
+
+func (suite *JobHandlerTestSuite) TestChangePrices_Accepted() {
+	suite.mockService.EXPECT().
+		Enqueue(gomock.Any(), entity.JobTypeProductPriceChange, jobSrv.PriceChangeParams{
+			ProductQuery: jobSrv.ProductQuery{MinPrice: utils.SetPtr(10.0)},
+			Percent:      -15,
+		}).
+		Return(suite.queued(entity.JobTypeProductPriceChange), nil).
+		Times(1)
+
+	w := suite.post("/api/v1/products:changePrices", map[string]interface{}{"minPrice": 10, "percent": -15})
+
+	suite.Equal(http.StatusAccepted, w.Code)
+}
+
+func (suite *JobHandlerTestSuite) TestChangePrices_InvalidPercent() {
+	w := suite.post("/api/v1/products:changePrices", map[string]interface{}{"percent": -100})
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *JobHandlerTestSuite) TestGetByID_Success() {
+	startedAt := time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC)
+	suite.mockService.EXPECT().
+		GetByID(gomock.Any(), "job-1").
+		Return(&entity.Job{
+			ID:        "job-1",
+			Type:      entity.JobTypeProductExport,
+			Status:    entity.JobStatusSucceeded,
+			Progress:  entity.JobProgress{Done: 2, Total: 2},
+			Result:    json.RawMessage(`{"count":2,"pages":1}`),
+			Attempts:  1,
+			StartedAt: &startedAt,
+		}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/jobs/job-1", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.Job
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(dto.JobProgress{Done: 2, Total: 2}, response.Progress)
+	suite.JSONEq(`{"count":2,"pages":1}`, string(response.Result))
+	suite.Equal("/api/v1/jobs/job-1", response.URL)
+}
+
+func (suite *JobHandlerTestSuite) TestGetByID_NotFound() {
+	suite.mockService.EXPECT().GetByID(gomock.Any(), "job-1").Return(nil, apperr.ErrNotFound).Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/jobs/job-1", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *JobHandlerTestSuite) TestCancel_Queued() {
+	suite.mockService.EXPECT().
+		Cancel(gomock.Any(), "job-1").
+		Return(&entity.Job{ID: "job-1", Status: entity.JobStatusCancelled, CancelRequested: true}, nil).
+		Times(1)
+
+	w := suite.post("/api/v1/jobs/job-1/cancel", nil)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.Job
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal("cancelled", response.Status)
+	suite.Equal("/api/v1/jobs/job-1", response.URL)
+}
+
+func (suite *JobHandlerTestSuite) TestCancel_Running() {
+	suite.mockService.EXPECT().
+		Cancel(gomock.Any(), "job-1").
+		Return(&entity.Job{ID: "job-1", Status: entity.JobStatusRunning, CancelRequested: true}, nil).
+		Times(1)
+
+	w := suite.post("/api/v1/jobs/job-1/cancel", nil)
+
+	suite.Equal(http.StatusAccepted, w.Code)
+}
+
+func (suite *JobHandlerTestSuite) TestCancel_Finished() {
+	suite.mockService.EXPECT().
+		Cancel(gomock.Any(), "job-1").
+		Return(nil, apperr.ErrConflict.WithMessage("job is already succeeded")).
+		Times(1)
+
+	w := suite.post("/api/v1/jobs/job-1/cancel", nil)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *JobHandlerTestSuite) TestGetExportPage_Success() {
+	suite.mockService.EXPECT().
+		GetExportPage(gomock.Any(), "job-1", 2).
+		Return(json.RawMessage(`[{"id":"p3","sku":"C"}]`), nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/jobs/job-1/pages/2", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`[{"id":"p3","sku":"C"}]`, w.Body.String())
+}
+
+func (suite *JobHandlerTestSuite) TestGetExportPage_InvalidPage() {
+	req, _ := http.NewRequest("GET", "/api/v1/jobs/job-1/pages/0", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func TestJobHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(JobHandlerTestSuite))
+}
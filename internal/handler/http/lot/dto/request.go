@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// LotCreateRequest represents the request payload for receiving a lot
+type LotCreateRequest struct {
+	LotNumber  string    `json:"lotNumber" binding:"required"`
+	Quantity   int       `json:"quantity" binding:"required,min=1"`
+	ReceivedAt time.Time `json:"receivedAt"`
+	ExpiresAt  time.Time `json:"expiresAt" binding:"required"`
+} //	@name	LotCreateRequest
+
+func (r LotCreateRequest) ToDomain() entity.Lot {
+	return entity.Lot{
+		LotNumber:  r.LotNumber,
+		Quantity:   r.Quantity,
+		ReceivedAt: r.ReceivedAt,
+		ExpiresAt:  r.ExpiresAt,
+	}
+}
+
+// LotConsumeRequest represents the request payload for consuming stock from lots
+type LotConsumeRequest struct {
+	Quantity int `json:"quantity" binding:"required,min=1"`
+} //	@name	LotConsumeRequest
+
+type FilterExpiringLotsRequest struct {
+	Within string `form:"within"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+func (r FilterExpiringLotsRequest) ToPagination() entity.Pagination {
+	return entity.Pagination{
+		Limit:  r.Limit,
+		Offset: r.Offset,
+	}
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// Lot represents the response payload for a lot
+type Lot struct {
+	ID         string    `json:"id"`
+	ProductID  string    `json:"productId"`
+	LotNumber  string    `json:"lotNumber"`
+	Quantity   int       `json:"quantity"`
+	ReceivedAt time.Time `json:"receivedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt,omitempty"`
+
+	Product *Product `json:"product,omitempty"`
+} //	@name	Lot
+
+// Product represents product information in lot response
+type Product struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	SKU  string `json:"sku"`
+} //	@name	LotProduct
+
+// LotConsumption represents how much was taken from a single lot
+type LotConsumption struct {
+	LotID     string `json:"lotId"`
+	LotNumber string `json:"lotNumber"`
+	Quantity  int    `json:"quantity"`
+} //	@name	LotConsumption
+
+func LotFromDomain(lot *entity.Lot) *Lot {
+	if lot == nil {
+		return nil
+	}
+
+	var product *Product
+	if lot.Product != nil {
+		product = &Product{
+			ID:   lot.Product.ID,
+			Name: lot.Product.Name,
+			SKU:  lot.Product.SKU,
+		}
+	}
+	return &Lot{
+		ID:         lot.ID,
+		ProductID:  lot.ProductID,
+		LotNumber:  lot.LotNumber,
+		Quantity:   lot.Quantity,
+		ReceivedAt: lot.ReceivedAt,
+		ExpiresAt:  lot.ExpiresAt,
+		Status:     string(lot.Status),
+		CreatedAt:  lot.CreatedAt,
+		UpdatedAt:  lot.UpdatedAt,
+		Product:    product,
+	}
+}
+
+func LotsFromDomain(lots []entity.Lot) []Lot {
+	result := make([]Lot, 0, len(lots))
+	for _, lot := range lots {
+		result = append(result, *LotFromDomain(&lot))
+	}
+
+	return result
+}
+
+func ConsumptionsFromDomain(consumptions []entity.LotConsumption) []LotConsumption {
+	result := make([]LotConsumption, 0, len(consumptions))
+	for _, consumption := range consumptions {
+		result = append(result, LotConsumption{
+			LotID:     consumption.LotID,
+			LotNumber: consumption.LotNumber,
+			Quantity:  consumption.Quantity,
+		})
+	}
+
+	return result
+}
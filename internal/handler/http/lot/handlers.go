@@ -0,0 +1,164 @@
+package lot
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/lot/dto"
+	lotSrv "github.com/sirawong/crud-arise/internal/services/lot"
+	"github.com/sirawong/crud-arise/pkg/utils"
+)
+
+const defaultExpiringWithin = 30 * 24 * time.Hour
+
+type LotHandler struct {
+	lotService lotSrv.LotService
+}
+
+func NewLotHandler(lotService lotSrv.LotService) *LotHandler {
+	return &LotHandler{lotService: lotService}
+}
+
+// Receive godoc
+//
+//	@Summary		Receive a lot
+//	@Description	Add a new lot of stock with an expiry date to a product. A product's first lot is refused while the product has stock outside of lots.
+//	@Tags			lots
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Product ID"
+//	@Param			lot	body		dto.LotCreateRequest	true	"Lot information"
+//	@Success		201	{object}	map[string]interface{}	"{"id": "lot_id"}"
+//	@Failure		400	{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id}/lots [post]
+func (h LotHandler) Receive(c *gin.Context) {
+	productID := c.Param("id")
+	if productID == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	var req dto.LotCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	id, err := h.lotService.Receive(c, productID, req.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// ListByProduct godoc
+//
+//	@Summary		Get lots of a product
+//	@Description	Get all lots of a product ordered by expiry date
+//	@Tags			lots
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Product ID"
+//	@Success		200	{array}		dto.Lot					"List of lots"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id}/lots [get]
+func (h LotHandler) ListByProduct(c *gin.Context) {
+	productID := c.Param("id")
+	if productID == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	lots, err := h.lotService.GetByProductID(c, productID)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LotsFromDomain(lots))
+}
+
+// Consume godoc
+//
+//	@Summary		Consume stock from lots
+//	@Description	Take stock out of a product's lots, first-expired-first-out
+//	@Tags			lots
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Product ID"
+//	@Param			request	body		dto.LotConsumeRequest	true	"Quantity to consume"
+//	@Success		200		{array}		dto.LotConsumption		"Quantities taken per lot"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error		description"}"
+//	@Failure		409		{object}	map[string]interface{}	"{"error_code": "INSUFFICIENT_STOCK", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error			description"}"
+//	@Router			/products/{id}/lots/consume [post]
+func (h LotHandler) Consume(c *gin.Context) {
+	productID := c.Param("id")
+	if productID == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	var req dto.LotConsumeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	consumptions, err := h.lotService.Consume(c, productID, req.Quantity)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ConsumptionsFromDomain(consumptions))
+}
+
+// ListExpiring godoc
+//
+//	@Summary		Get expiring lots
+//	@Description	Get active lots that expire within the given window
+//	@Tags			lots
+//	@Accept			json
+//	@Produce		json
+//	@Param			within	query		string					false	"Expiry window, e.g. 30d, 2w or 72h (default: 30d)"
+//	@Param			limit	query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset	query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200		{array}		dto.Lot					"List of expiring lots"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/lots/expiring [get]
+func (h LotHandler) ListExpiring(c *gin.Context) {
+	var query dto.FilterExpiringLotsRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	within := defaultExpiringWithin
+	if query.Within != "" {
+		var err error
+		within, err = utils.ParseDuration(query.Within)
+		if err != nil {
+			handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+			return
+		}
+	}
+
+	lots, err := h.lotService.GetExpiring(c, within, query.ToPagination())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LotsFromDomain(lots))
+}
@@ -0,0 +1,168 @@
+package lot
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/lot/dto"
+	"github.com/sirawong/crud-arise/internal/services/lot/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type LotHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockLotService
+	handler     *LotHandler
+	router      *gin.Engine
+}
+
+func (suite *LotHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockLotService(suite.mockCtrl)
+	suite.handler = NewLotHandler(suite.mockService)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	prd := v1.Group("/products")
+	{
+		prd.POST("/:id/lots", suite.handler.Receive)
+		prd.GET("/:id/lots", suite.handler.ListByProduct)
+		prd.POST("/:id/lots/consume", suite.handler.Consume)
+	}
+	v1.GET("/lots/expiring", suite.handler.ListExpiring)
+}
+
+func (suite *LotHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *LotHandlerTestSuite) TestReceive_Success() {
+
+	request := dto.LotCreateRequest{
+		LotNumber: "LOT-001",
+		Quantity:  50,
+		ExpiresAt: time.Now().Add(90 * 24 * time.Hour),
+	}
+
+	suite.mockService.EXPECT().
+		Receive(gomock.Any(), "product-123", gomock.Any()).
+		Return("lot-123", nil).
+		Times(1)
+
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/api/v1/products/product-123/lots", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("lot-123", response["id"])
+}
+
+func (suite *LotHandlerTestSuite) TestReceive_MissingExpiry() {
+
+	invalidJSON := `{"lotNumber": "LOT-001", "quantity": 10}`
+
+	req, _ := http.NewRequest("POST", "/api/v1/products/product-123/lots", bytes.NewBufferString(invalidJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *LotHandlerTestSuite) TestListByProduct_Success() {
+
+	expectedLots := []entity.Lot{
+		{ID: "lot-1", ProductID: "product-123", LotNumber: "LOT-001", Quantity: 5, Status: entity.LotStatusActive},
+		{ID: "lot-2", ProductID: "product-123", LotNumber: "LOT-002", Quantity: 0, Status: entity.LotStatusQuarantined},
+	}
+
+	suite.mockService.EXPECT().
+		GetByProductID(gomock.Any(), "product-123").
+		Return(expectedLots, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/product-123/lots", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response []dto.Lot
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Len(response, 2)
+	suite.Equal("quarantined", response[1].Status)
+}
+
+func (suite *LotHandlerTestSuite) TestConsume_InsufficientStock() {
+
+	suite.mockService.EXPECT().
+		Consume(gomock.Any(), "product-123", 100).
+		Return(nil, apperr.ErrInsufficientStock.WithMessage("not enough unexpired stock in lots")).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products/product-123/lots/consume", bytes.NewBufferString(`{"quantity": 100}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *LotHandlerTestSuite) TestListExpiring_ParsesWindow() {
+
+	suite.mockService.EXPECT().
+		GetExpiring(gomock.Any(), 14*24*time.Hour, gomock.Any()).
+		Return([]entity.Lot{}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/lots/expiring?within=2w", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *LotHandlerTestSuite) TestListExpiring_DefaultWindow() {
+
+	suite.mockService.EXPECT().
+		GetExpiring(gomock.Any(), 30*24*time.Hour, gomock.Any()).
+		Return([]entity.Lot{}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/lots/expiring", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *LotHandlerTestSuite) TestListExpiring_InvalidWindow() {
+
+	req, _ := http.NewRequest("GET", "/api/v1/lots/expiring?within=soon", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func TestLotHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(LotHandlerTestSuite))
+}
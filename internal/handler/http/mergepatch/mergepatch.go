@@ -0,0 +1,75 @@
+// Package mergepatch decodes RFC 7396 JSON Merge Patch documents, which tell apart a member that
+// is missing (leave the field alone), null (remove the field) and any other value (replace it).
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+const ContentType = "application/merge-patch+json"
+
+// Field is one member of a merge patch.
+type Field[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
+
+// Required returns the new value of a field that cannot be removed, or nil when the patch leaves
+// it alone.
+func Required[T any](f Field[T], name string) (*T, error) {
+	if !f.Set {
+		return nil, nil
+	}
+	if f.Null {
+		return nil, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("%s cannot be null", name))
+	}
+	return &f.Value, nil
+}
+
+// Clearable returns the new value of a field that null resets to its zero value, or nil when the
+// patch leaves it alone.
+func Clearable[T any](f Field[T]) *T {
+	if !f.Set {
+		return nil
+	}
+	if f.Null {
+		var zero T
+		return &zero
+	}
+	return &f.Value
+}
+
+// Bind decodes the request body into patch, which must be a JSON object. Members patch does not
+// know are rejected rather than ignored.
+func Bind(c *gin.Context, patch interface{}) error {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return apperr.ErrInvalidArgument.Wrap(err)
+	}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) == 0 || trimmed[0] != '{' {
+		return apperr.ErrInvalidArgument.WithMessage("merge patch must be a JSON object")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patch); err != nil {
+		return apperr.ErrInvalidArgument.Wrap(err)
+	}
+	return nil
+}
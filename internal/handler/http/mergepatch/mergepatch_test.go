@@ -0,0 +1,67 @@
+package mergepatch
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type patch struct {
+	Name  Field[string]  `json:"name"`
+	Note  Field[string]  `json:"note"`
+	Price Field[float64] `json:"price"`
+}
+
+func bind(body string) (patch, error) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest("PATCH", "/", bytes.NewBufferString(body))
+	var p patch
+	err := Bind(c, &p)
+	return p, err
+}
+
+func TestBind(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	p, err := bind(`{"note": null, "price": 0}`)
+	assert.NoError(t, err)
+	assert.False(t, p.Name.Set)
+	assert.True(t, p.Note.Set)
+	assert.True(t, p.Note.Null)
+	assert.True(t, p.Price.Set)
+	assert.False(t, p.Price.Null)
+	assert.Equal(t, 0.0, p.Price.Value)
+
+	_, err = bind(`[{"op": "replace"}]`)
+	assert.Equal(t, apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+
+	_, err = bind(`{"unknown": 1}`)
+	assert.Equal(t, apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+
+	_, err = bind(`{"price": "free"}`)
+	assert.Equal(t, apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func TestRequired(t *testing.T) {
+	value, err := Required(Field[string]{}, "name")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	_, err = Required(Field[string]{Set: true, Null: true}, "name")
+	assert.Equal(t, apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+
+	value, err = Required(Field[string]{Set: true, Value: "Widget"}, "name")
+	assert.NoError(t, err)
+	assert.Equal(t, "Widget", *value)
+}
+
+func TestClearable(t *testing.T) {
+	assert.Nil(t, Clearable(Field[string]{}))
+	assert.Equal(t, "", *Clearable(Field[string]{Set: true, Null: true}))
+	assert.Equal(t, "text", *Clearable(Field[string]{Set: true, Value: "text"}))
+}
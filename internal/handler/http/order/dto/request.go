@@ -0,0 +1,52 @@
+package dto
+
+import "github.com/sirawong/crud-arise/internal/domain/entity"
+
+// OrderCreateRequest represents the request payload for placing an order
+type OrderCreateRequest struct {
+	CustomerRef string             `json:"customerRef"`
+	Lines       []OrderLineRequest `json:"lines" binding:"required,min=1,dive"`
+} //	@name	OrderCreateRequest
+
+// OrderLineRequest represents a single product and quantity of an order
+type OrderLineRequest struct {
+	ProductID string `json:"productId" binding:"required"`
+	Quantity  int    `json:"quantity" binding:"required,min=1"`
+} //	@name	OrderLineRequest
+
+func (r OrderCreateRequest) ToDomain() entity.Order {
+	lines := make([]entity.OrderLine, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, entity.OrderLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+		})
+	}
+
+	return entity.Order{
+		CustomerRef: r.CustomerRef,
+		Lines:       lines,
+	}
+}
+
+type FilterOrdersRequest struct {
+	Status *string `form:"status,omitempty"`
+	Limit  int     `form:"limit"`
+	Offset int     `form:"offset"`
+}
+
+func (r FilterOrdersRequest) ToDomain() entity.OrdersFilter {
+	var status *entity.OrderStatus
+	if r.Status != nil {
+		value := entity.OrderStatus(*r.Status)
+		status = &value
+	}
+
+	return entity.OrdersFilter{
+		Status: status,
+		Pagination: entity.Pagination{
+			Limit:  r.Limit,
+			Offset: r.Offset,
+		},
+	}
+}
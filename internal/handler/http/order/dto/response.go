@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// Order represents the response payload for an order
+type Order struct {
+	ID            string      `json:"id"`
+	Status        string      `json:"status"`
+	CustomerRef   string      `json:"customerRef"`
+	Lines         []OrderLine `json:"lines"`
+	TotalQuantity int         `json:"totalQuantity"`
+	TotalAmount   float64     `json:"totalAmount"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	UpdatedAt     time.Time   `json:"updatedAt,omitempty"`
+} //	@name	Order
+
+// OrderLine represents an order line with the product snapshot taken at order time
+type OrderLine struct {
+	ID          string  `json:"id"`
+	ProductID   string  `json:"productId"`
+	ProductName string  `json:"productName"`
+	SKU         string  `json:"sku"`
+	UnitPrice   float64 `json:"unitPrice"`
+	Quantity    int     `json:"quantity"`
+	LineTotal   float64 `json:"lineTotal"`
+} //	@name	OrderLine
+
+func OrderFromDomain(order *entity.Order) *Order {
+	if order == nil {
+		return nil
+	}
+
+	lines := make([]OrderLine, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		lines = append(lines, OrderLine{
+			ID:          line.ID,
+			ProductID:   line.ProductID,
+			ProductName: line.ProductName,
+			SKU:         line.SKU,
+			UnitPrice:   line.UnitPrice,
+			Quantity:    line.Quantity,
+			LineTotal:   line.LineTotal,
+		})
+	}
+
+	return &Order{
+		ID:            order.ID,
+		Status:        string(order.Status),
+		CustomerRef:   order.CustomerRef,
+		Lines:         lines,
+		TotalQuantity: order.TotalQuantity,
+		TotalAmount:   order.TotalAmount,
+		CreatedAt:     order.CreatedAt,
+		UpdatedAt:     order.UpdatedAt,
+	}
+}
+
+func OrdersFromDomain(orders []entity.Order) []Order {
+	result := make([]Order, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, *OrderFromDomain(&order))
+	}
+
+	return result
+}
@@ -0,0 +1,228 @@
+package order
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/order/dto"
+	orderSrv "github.com/sirawong/crud-arise/internal/services/order"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+// OrderFlowTestSuite runs the real order service behind a test server. The repositories are
+// mocks backed by in-memory maps so stock levels can be checked across requests.
+type OrderFlowTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	server      *httptest.Server
+	stock       map[string]int
+	orders      map[string]*entity.Order
+	nextOrderID int
+}
+
+func (suite *OrderFlowTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.stock = map[string]int{"product-1": 10, "product-2": 3}
+	suite.orders = map[string]*entity.Order{}
+	suite.nextOrderID = 0
+
+	txManager := mocks.NewMockTxManager(suite.mockCtrl)
+	txManager.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	productRepo := mocks.NewMockProductRepository(suite.mockCtrl)
+	productRepo.EXPECT().FindByIDs(gomock.Any(), gomock.Any()).DoAndReturn(suite.findProducts).AnyTimes()
+	productRepo.EXPECT().AdjustStock(gomock.Any(), gomock.Any()).DoAndReturn(suite.adjustStock).AnyTimes()
+
+	orderRepo := mocks.NewMockOrderRepository(suite.mockCtrl)
+	orderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(suite.createOrder).AnyTimes()
+	orderRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).DoAndReturn(suite.findOrder).AnyTimes()
+	orderRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(suite.updateStatus).AnyTimes()
+
+	router := gin.New()
+	registerRoutes(router, NewOrderHandler(orderSrv.NewOrderService(txManager, orderRepo, productRepo)))
+	suite.server = httptest.NewServer(router)
+}
+
+func (suite *OrderFlowTestSuite) TearDownTest() {
+	suite.server.Close()
+	suite.mockCtrl.Finish()
+}
+
+func (suite *OrderFlowTestSuite) findProducts(_ context.Context, ids []string) ([]entity.Product, error) {
+	var products []entity.Product
+	for _, id := range ids {
+		if stock, ok := suite.stock[id]; ok {
+			products = append(products, entity.Product{ID: id, Name: id, SKU: id, Price: utils.SetPtr(2.0), Stock: utils.SetPtr(stock)})
+		}
+	}
+	return products, nil
+}
+
+func (suite *OrderFlowTestSuite) adjustStock(_ context.Context, adjustments []entity.StockAdjustment) error {
+	next := make(map[string]int, len(suite.stock))
+	for id, stock := range suite.stock {
+		next[id] = stock
+	}
+
+	var shortages []entity.StockShortage
+	for i, adjustment := range adjustments {
+		if next[adjustment.ProductID]+adjustment.Delta < 0 {
+			shortages = append(shortages, entity.StockShortage{
+				Index:     i,
+				ProductID: adjustment.ProductID,
+				Requested: -adjustment.Delta,
+				Available: next[adjustment.ProductID],
+				Reason:    entity.StockShortageInsufficient,
+			})
+			continue
+		}
+		next[adjustment.ProductID] += adjustment.Delta
+	}
+	if len(shortages) > 0 {
+		return apperr.ErrInsufficientStock.WithDetails(shortages)
+	}
+
+	suite.stock = next
+	return nil
+}
+
+func (suite *OrderFlowTestSuite) createOrder(_ context.Context, order *entity.Order) (string, error) {
+	suite.nextOrderID++
+	id := fmt.Sprintf("order-%d", suite.nextOrderID)
+	stored := *order
+	stored.ID = id
+	suite.orders[id] = &stored
+	return id, nil
+}
+
+func (suite *OrderFlowTestSuite) findOrder(_ context.Context, id string) (*entity.Order, error) {
+	order, ok := suite.orders[id]
+	if !ok {
+		return nil, apperr.ErrNotFound
+	}
+	result := *order
+	return &result, nil
+}
+
+func (suite *OrderFlowTestSuite) updateStatus(_ context.Context, id string, from, to entity.OrderStatus) error {
+	order, ok := suite.orders[id]
+	if !ok {
+		return apperr.ErrNotFound
+	}
+	if order.Status != from {
+		return apperr.ErrConflict
+	}
+	order.Status = to
+	return nil
+}
+
+func (suite *OrderFlowTestSuite) post(path string, body interface{}) *http.Response {
+	payload, _ := json.Marshal(body)
+	resp, err := http.Post(suite.server.URL+path, "application/json", bytes.NewBuffer(payload))
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *OrderFlowTestSuite) placeOrder(lines ...dto.OrderLineRequest) (int, []byte) {
+	resp := suite.post("/api/v1/orders/", dto.OrderCreateRequest{Lines: lines})
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	suite.Require().NoError(err)
+	return resp.StatusCode, body
+}
+
+func (suite *OrderFlowTestSuite) TestPlacePayShip() {
+
+	status, body := suite.placeOrder(
+		dto.OrderLineRequest{ProductID: "product-1", Quantity: 4},
+		dto.OrderLineRequest{ProductID: "product-2", Quantity: 1},
+	)
+	suite.Require().Equal(http.StatusCreated, status)
+
+	var order dto.Order
+	suite.Require().NoError(json.Unmarshal(body, &order))
+	suite.Equal("pending", order.Status)
+	suite.Equal(10.0, order.TotalAmount)
+	suite.Equal(6, suite.stock["product-1"])
+	suite.Equal(2, suite.stock["product-2"])
+
+	resp := suite.post("/api/v1/orders/"+order.ID+"/pay", nil)
+	resp.Body.Close()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+
+	resp = suite.post("/api/v1/orders/"+order.ID+"/ship", nil)
+	resp.Body.Close()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+
+	resp = suite.post("/api/v1/orders/"+order.ID+"/cancel", nil)
+	resp.Body.Close()
+	suite.Equal(http.StatusConflict, resp.StatusCode)
+	suite.Equal(6, suite.stock["product-1"])
+}
+
+func (suite *OrderFlowTestSuite) TestCancelRestocks() {
+
+	status, body := suite.placeOrder(dto.OrderLineRequest{ProductID: "product-1", Quantity: 7})
+	suite.Require().Equal(http.StatusCreated, status)
+	suite.Equal(3, suite.stock["product-1"])
+
+	var order dto.Order
+	suite.Require().NoError(json.Unmarshal(body, &order))
+
+	resp := suite.post("/api/v1/orders/"+order.ID+"/cancel", nil)
+	resp.Body.Close()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal(10, suite.stock["product-1"])
+
+	resp = suite.post("/api/v1/orders/"+order.ID+"/cancel", nil)
+	resp.Body.Close()
+	suite.Equal(http.StatusConflict, resp.StatusCode)
+	suite.Equal(10, suite.stock["product-1"])
+}
+
+func (suite *OrderFlowTestSuite) TestShortLineTakesNoStock() {
+
+	status, body := suite.placeOrder(
+		dto.OrderLineRequest{ProductID: "product-1", Quantity: 2},
+		dto.OrderLineRequest{ProductID: "product-2", Quantity: 5},
+	)
+
+	suite.Equal(http.StatusConflict, status)
+	suite.Equal(10, suite.stock["product-1"])
+	suite.Equal(3, suite.stock["product-2"])
+	suite.Empty(suite.orders)
+
+	var response struct {
+		ErrorCode string                 `json:"error_code"`
+		Details   []entity.StockShortage `json:"details"`
+	}
+	suite.Require().NoError(json.Unmarshal(body, &response))
+	suite.Equal("INSUFFICIENT_STOCK", response.ErrorCode)
+	suite.Require().Len(response.Details, 1)
+	suite.Equal(1, response.Details[0].Index)
+	suite.Equal(3, response.Details[0].Available)
+}
+
+func TestOrderFlowTestSuite(t *testing.T) {
+	suite.Run(t, new(OrderFlowTestSuite))
+}
@@ -0,0 +1,166 @@
+package order
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/order/dto"
+	orderSrv "github.com/sirawong/crud-arise/internal/services/order"
+)
+
+type OrderHandler struct {
+	orderService orderSrv.OrderService
+}
+
+func NewOrderHandler(orderService orderSrv.OrderService) *OrderHandler {
+	return &OrderHandler{orderService: orderService}
+}
+
+// Place godoc
+//
+//	@Summary		Place an order
+//	@Description	Place an order, snapshotting product data and taking stock for every line
+//	@Tags			orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			order	body		dto.OrderCreateRequest	true	"Order lines"
+//	@Success		201		{object}	dto.Order				"Placed order"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error		description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error description", "details": [...]}"
+//	@Failure		409		{object}	map[string]interface{}	"{"error_code": "INSUFFICIENT_STOCK", "message": "error description", "details": [...]}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error			description"}"
+//	@Router			/orders [post]
+func (h OrderHandler) Place(c *gin.Context) {
+	var req dto.OrderCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	order, err := h.orderService.Place(c, req.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.OrderFromDomain(order))
+}
+
+// GetByID godoc
+//
+//	@Summary		Get an order by ID
+//	@Description	Get a single order with its lines
+//	@Tags			orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Order ID"
+//	@Success		200	{object}	dto.Order				"Order information"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/orders/{id} [get]
+func (h OrderHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	order, err := h.orderService.GetByID(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.OrderFromDomain(order))
+}
+
+// ListAll godoc
+//
+//	@Summary		Get all orders
+//	@Description	Get a list of orders, newest first
+//	@Tags			orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			status	query		string					false	"Filter by status"
+//	@Param			limit	query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset	query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200		{array}		dto.Order				"List of orders"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error	description"}"
+//	@Router			/orders [get]
+func (h OrderHandler) ListAll(c *gin.Context) {
+	var query dto.FilterOrdersRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	orders, err := h.orderService.GetAll(c, query.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.OrdersFromDomain(orders))
+}
+
+// Pay godoc
+//
+//	@Summary		Mark an order as paid
+//	@Tags			orders
+//	@Produce		json
+//	@Param			id	path		string					true	"Order ID"
+//	@Success		200	{object}	map[string]interface{}	"{"status": "paid"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Router			/orders/{id}/pay [post]
+func (h OrderHandler) Pay(c *gin.Context) {
+	h.changeStatus(c, h.orderService.Pay, "paid")
+}
+
+// Ship godoc
+//
+//	@Summary		Mark an order as shipped
+//	@Tags			orders
+//	@Produce		json
+//	@Param			id	path		string					true	"Order ID"
+//	@Success		200	{object}	map[string]interface{}	"{"status": "shipped"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Router			/orders/{id}/ship [post]
+func (h OrderHandler) Ship(c *gin.Context) {
+	h.changeStatus(c, h.orderService.Ship, "shipped")
+}
+
+// Cancel godoc
+//
+//	@Summary		Cancel an order
+//	@Description	Cancel a pending or paid order and put its stock back
+//	@Tags			orders
+//	@Produce		json
+//	@Param			id	path		string					true	"Order ID"
+//	@Success		200	{object}	map[string]interface{}	"{"status": "cancelled"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Router			/orders/{id}/cancel [post]
+func (h OrderHandler) Cancel(c *gin.Context) {
+	h.changeStatus(c, h.orderService.Cancel, "cancelled")
+}
+
+func (h OrderHandler) changeStatus(c *gin.Context, change func(ctx context.Context, id string) error, status string) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	err := change(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": status})
+}
@@ -0,0 +1,188 @@
+package order
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/order/dto"
+	"github.com/sirawong/crud-arise/internal/services/order/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type OrderHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockOrderService
+	handler     *OrderHandler
+	router      *gin.Engine
+}
+
+func (suite *OrderHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockOrderService(suite.mockCtrl)
+	suite.handler = NewOrderHandler(suite.mockService)
+	suite.router = gin.New()
+	registerRoutes(suite.router, suite.handler)
+}
+
+func (suite *OrderHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func registerRoutes(router *gin.Engine, handler *OrderHandler) {
+	v1 := router.Group("/api/v1")
+	ord := v1.Group("/orders")
+	{
+		ord.POST("/", handler.Place)
+		ord.GET("/", handler.ListAll)
+		ord.GET("/:id", handler.GetByID)
+		ord.POST("/:id/pay", handler.Pay)
+		ord.POST("/:id/ship", handler.Ship)
+		ord.POST("/:id/cancel", handler.Cancel)
+	}
+}
+
+func (suite *OrderHandlerTestSuite) TestPlace_Success() {
+
+	request := dto.OrderCreateRequest{
+		CustomerRef: "customer-1",
+		Lines:       []dto.OrderLineRequest{{ProductID: "product-1", Quantity: 2}},
+	}
+
+	suite.mockService.EXPECT().
+		Place(gomock.Any(), request.ToDomain()).
+		Return(&entity.Order{
+			ID:     "order-123",
+			Status: entity.OrderStatusPending,
+			Lines:  []entity.OrderLine{{ProductID: "product-1", SKU: "W-1", Quantity: 2, UnitPrice: 2.5, LineTotal: 5}},
+		}, nil).
+		Times(1)
+
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/api/v1/orders/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+
+	var response dto.Order
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("order-123", response.ID)
+	suite.Equal("pending", response.Status)
+	suite.Equal("W-1", response.Lines[0].SKU)
+}
+
+func (suite *OrderHandlerTestSuite) TestPlace_InvalidLine() {
+
+	invalidJSON := `{"lines": [{"productId": "product-1", "quantity": 0}]}`
+
+	req, _ := http.NewRequest("POST", "/api/v1/orders/", bytes.NewBufferString(invalidJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *OrderHandlerTestSuite) TestPlace_InsufficientStock() {
+
+	shortages := []entity.StockShortage{
+		{Index: 0, ProductID: "product-1", Requested: 5, Available: 1, Reason: entity.StockShortageInsufficient},
+	}
+
+	suite.mockService.EXPECT().
+		Place(gomock.Any(), gomock.Any()).
+		Return(nil, apperr.ErrInsufficientStock.WithDetails(shortages)).
+		Times(1)
+
+	body := `{"lines": [{"productId": "product-1", "quantity": 5}]}`
+	req, _ := http.NewRequest("POST", "/api/v1/orders/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("INSUFFICIENT_STOCK", response["error_code"])
+	suite.Len(response["details"], 1)
+}
+
+func (suite *OrderHandlerTestSuite) TestGetByID_NotFound() {
+
+	suite.mockService.EXPECT().
+		GetByID(gomock.Any(), "missing").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/orders/missing", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *OrderHandlerTestSuite) TestListAll_StatusFilter() {
+
+	status := entity.OrderStatusPaid
+	suite.mockService.EXPECT().
+		GetAll(gomock.Any(), entity.OrdersFilter{Status: &status}).
+		Return([]entity.Order{{ID: "order-1", Status: status}}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/orders/?status=paid", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response []dto.Order
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Len(response, 1)
+}
+
+func (suite *OrderHandlerTestSuite) TestCancel_Conflict() {
+
+	suite.mockService.EXPECT().
+		Cancel(gomock.Any(), "order-123").
+		Return(apperr.ErrConflict).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/orders/order-123/cancel", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *OrderHandlerTestSuite) TestPay_Success() {
+
+	suite.mockService.EXPECT().
+		Pay(gomock.Any(), "order-123").
+		Return(nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/orders/order-123/pay", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func TestOrderHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(OrderHandlerTestSuite))
+}
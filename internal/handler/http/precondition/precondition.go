@@ -0,0 +1,123 @@
+package precondition
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+)
+
+// ETag formats a resource version as a strong entity tag. The versions of resources embedded in
+// the representation follow it, dot-separated, so changing one of them changes the tag too.
+func ETag(version int, embedded ...int) string {
+	tag := strconv.Itoa(version)
+	for _, v := range embedded {
+		tag += "." + strconv.Itoa(v)
+	}
+	return `"` + tag + `"`
+}
+
+// ListETag derives a strong entity tag for a list page from the filter that produced it and the
+// stats of everything the filter matches.
+func ListETag(filter interface{}, stats entity.ListStats) string {
+	raw, _ := json.Marshal(filter)
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%s", raw, stats.Count, stats.LastModified.UTC().Format(time.RFC3339Nano))))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// CacheControl sets the Cache-Control header on every response of the routes it guards.
+func CacheControl(value string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if value != "" {
+			c.Header("Cache-Control", value)
+		}
+		c.Next()
+	}
+}
+
+// NotModified writes the validators of the current representation and reports whether the
+// client's copy is still current, in which case it has already answered 304 Not Modified.
+// If-None-Match takes precedence over If-Modified-Since.
+func NotModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if !fresh(c.Request, etag, lastModified) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+func fresh(r *http.Request, etag string, lastModified time.Time) bool {
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		return anyETagMatches(header, etag)
+	}
+
+	header := r.Header.Get("If-Modified-Since")
+	if header == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(header)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// anyETagMatches compares an If-None-Match list against etag. The comparison is weak, as the
+// header requires, so W/ prefixes are ignored.
+func anyETagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireIfMatch rejects requests without an If-Match header with 428 Precondition Required
+// when required is true. It lets every request through otherwise.
+func RequireIfMatch(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if required && c.GetHeader("If-Match") == "" {
+			handlererr.RespondWithError(c, apperr.ErrPreconditionRequired.WithMessage("If-Match header is required"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// ExpectedVersion reads the version a write is conditional on from If-Match. It returns 0 when
+// the header is absent or "*", meaning the write is unconditional. Only the resource's own
+// version counts; the versions of embedded resources are ignored. A tag that is not one of our
+// versions can never match, so it fails the precondition.
+func ExpectedVersion(c *gin.Context) (int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if strings.Contains(header, ",") {
+		return 0, apperr.ErrInvalidArgument.WithMessage("If-Match must contain a single entity tag")
+	}
+
+	own, _, _ := strings.Cut(strings.Trim(header, `"`), ".")
+	version, err := strconv.Atoi(own)
+	if err != nil || version <= 0 || !strings.HasPrefix(header, `"`) {
+		return 0, apperr.ErrPreconditionFailed.WithMessage("If-Match does not match the current version")
+	}
+	return version, nil
+}
@@ -0,0 +1,118 @@
+package precondition
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContext(ifMatch string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest("PUT", "/", nil)
+	if ifMatch != "" {
+		c.Request.Header.Set("If-Match", ifMatch)
+	}
+	return c
+}
+
+func TestExpectedVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	version, err := ExpectedVersion(newContext(`"3"`))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+
+	version, err = ExpectedVersion(newContext(`"3.2"`))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+
+	version, err = ExpectedVersion(newContext(""))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, version)
+
+	version, err = ExpectedVersion(newContext("*"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, version)
+
+	_, err = ExpectedVersion(newContext(`W/"3"`))
+	assert.Equal(t, apperr.ErrPreconditionFailed.Code, apperr.GetCode(err))
+
+	_, err = ExpectedVersion(newContext(`"abc"`))
+	assert.Equal(t, apperr.ErrPreconditionFailed.Code, apperr.GetCode(err))
+
+	_, err = ExpectedVersion(newContext(`"1", "2"`))
+	assert.Equal(t, apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, `"3"`, ETag(3))
+	assert.Equal(t, `"3.2"`, ETag(3, 2))
+}
+
+func TestRequireIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.PUT("/required", RequireIfMatch(true), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.PUT("/optional", RequireIfMatch(false), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/required", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("PUT", "/required", nil)
+	req.Header.Set("If-Match", `"1"`)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("PUT", "/optional", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	lastModified := time.Date(2026, 5, 1, 12, 0, 0, 500, time.UTC)
+
+	c := newContext("")
+	c.Request.Header.Set("If-Modified-Since", "Fri, 01 May 2026 12:00:00 GMT")
+	assert.True(t, NotModified(c, `"1"`, lastModified))
+
+	c = newContext("")
+	c.Request.Header.Set("If-Modified-Since", "Fri, 01 May 2026 11:59:59 GMT")
+	assert.False(t, NotModified(c, `"1"`, lastModified))
+
+	c = newContext("")
+	c.Request.Header.Set("If-None-Match", `"2", "1"`)
+	assert.True(t, NotModified(c, `"1"`, lastModified))
+
+	c = newContext("")
+	c.Request.Header.Set("If-None-Match", `"2"`)
+	c.Request.Header.Set("If-Modified-Since", "Fri, 01 May 2026 12:00:00 GMT")
+	assert.False(t, NotModified(c, `"1"`, lastModified))
+	assert.Equal(t, "Fri, 01 May 2026 12:00:00 GMT", c.Writer.Header().Get("Last-Modified"))
+}
+
+func TestListETag(t *testing.T) {
+	type filter struct {
+		Name  *string `json:"name"`
+		Limit int     `json:"limit"`
+	}
+	name := "shoe"
+	stats := entity.ListStats{Count: 3, LastModified: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	etag := ListETag(filter{Name: &name, Limit: 10}, stats)
+	assert.Equal(t, etag, ListETag(filter{Name: &name, Limit: 10}, stats))
+	assert.NotEqual(t, etag, ListETag(filter{Name: &name, Limit: 20}, stats))
+	assert.NotEqual(t, etag, ListETag(filter{Name: &name, Limit: 10}, entity.ListStats{Count: 2, LastModified: stats.LastModified}))
+	assert.NotEqual(t, etag, ListETag(filter{Name: &name, Limit: 10}, entity.ListStats{Count: 3, LastModified: stats.LastModified.Add(time.Millisecond)}))
+}
@@ -0,0 +1,38 @@
+// Package prefer honours the return preference of the Prefer request header (RFC 7240).
+package prefer
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReturnMinimal reports whether the client sent Prefer: return=minimal.
+func ReturnMinimal(c *gin.Context) bool {
+	for _, header := range c.Request.Header.Values("Prefer") {
+		for _, preference := range strings.Split(header, ",") {
+			token, _, _ := strings.Cut(preference, ";")
+			name, value, _ := strings.Cut(strings.TrimSpace(token), "=")
+			if strings.EqualFold(name, "return") && strings.EqualFold(strings.Trim(value, `"`), "minimal") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Respond writes body as JSON with status, unless the client prefers a minimal response. Then the
+// body is left out, and 200 OK becomes 204 No Content.
+func Respond(c *gin.Context, status int, body interface{}) {
+	if !ReturnMinimal(c) {
+		c.JSON(status, body)
+		return
+	}
+
+	c.Header("Preference-Applied", "return=minimal")
+	if status == http.StatusOK {
+		status = http.StatusNoContent
+	}
+	c.Status(status)
+}
@@ -0,0 +1,43 @@
+package prefer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func respond(prefer string, status int) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("PUT", "/", nil)
+	if prefer != "" {
+		c.Request.Header.Set("Prefer", prefer)
+	}
+	Respond(c, status, gin.H{"id": "1"})
+	c.Writer.WriteHeaderNow()
+	return w
+}
+
+func TestRespond(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := respond("", http.StatusOK)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": "1"}`, w.Body.String())
+
+	w = respond("return=minimal", http.StatusOK)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, "return=minimal", w.Header().Get("Preference-Applied"))
+
+	w = respond(`respond-async, RETURN="minimal"; foo=bar`, http.StatusCreated)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = respond("return=representation", http.StatusCreated)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"id": "1"}`, w.Body.String())
+}
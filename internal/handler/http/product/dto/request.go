@@ -1,8 +1,10 @@
 package dto
 
 import (
+	"strings"
+
 	"github.com/sirawong/crud-arise/internal/domain/entity"
-	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/sirawong/crud-arise/internal/handler/http/mergepatch"
 )
 
 // ProductCreateRequest represents the request payload for creating a product
@@ -21,37 +23,153 @@ func (r ProductCreateRequest) ToDomain() entity.Product {
 		Name:        r.Name,
 		Description: r.Description,
 		SKU:         r.SKU,
-		Price:       utils.SetPtr(r.Price),
-		Stock:       utils.SetPtr(r.Stock),
-		ImageURL:    utils.SetPtr(r.ImageURL),
+		Price:       &r.Price,
+		Stock:       &r.Stock,
+		ImageURL:    &r.ImageURL,
 		CategoryID:  r.CategoryID,
 	}
 }
 
-// ProductUpdateRequest represents the request payload for updating a product
+// ProductUpdateRequest represents the request payload for replacing a product. Optional fields
+// left out are cleared.
 type ProductUpdateRequest struct {
-	Name        *string  `json:"name,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	SKU         *string  `json:"sku,omitempty"`
-	Price       *float64 `json:"price,omitempty"`
-	Stock       *int     `json:"stock,omitempty"`
-	ImageURL    *string  `json:"imageUrl,omitempty"`
-	CategoryID  *string  `json:"categoryId,omitempty"`
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	SKU         string   `json:"sku" binding:"required"`
+	Price       *float64 `json:"price" binding:"required,min=0"`
+	Stock       *int     `json:"stock" binding:"required,min=0"`
+	ImageURL    string   `json:"imageUrl"`
+	CategoryID  string   `json:"categoryId" binding:"required"`
 } //	@name	ProductUpdateRequest
 
 func (r ProductUpdateRequest) ToDomain() entity.Product {
 	return entity.Product{
-		Name:        utils.GetValue(r.Name),
-		Description: utils.GetValue(r.Description),
-		SKU:         utils.GetValue(r.SKU),
+		Name:        r.Name,
+		Description: r.Description,
+		SKU:         r.SKU,
+		Price:       r.Price,
+		Stock:       r.Stock,
+		ImageURL:    &r.ImageURL,
+		CategoryID:  r.CategoryID,
+	}
+}
+
+// ProductUpsertRequest represents the request payload for creating or replacing the product with
+// the SKU in the path. Optional fields left out are cleared.
+type ProductUpsertRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Price       *float64 `json:"price" binding:"required,min=0"`
+	Stock       *int     `json:"stock" binding:"required,min=0"`
+	ImageURL    string   `json:"imageUrl"`
+	CategoryID  string   `json:"categoryId" binding:"required"`
+} //	@name	ProductUpsertRequest
+
+func (r ProductUpsertRequest) ToDomain(sku string) entity.Product {
+	return entity.Product{
+		Name:        r.Name,
+		Description: r.Description,
+		SKU:         sku,
 		Price:       r.Price,
 		Stock:       r.Stock,
-		ImageURL:    r.ImageURL,
-		CategoryID:  utils.GetValue(r.CategoryID),
+		ImageURL:    &r.ImageURL,
+		CategoryID:  r.CategoryID,
+	}
+}
+
+// ProductPatchRequest represents a JSON Merge Patch for a product. Members left out are not
+// changed and null clears description or imageUrl.
+type ProductPatchRequest struct {
+	Name        mergepatch.Field[string]  `json:"name" swaggertype:"string"`
+	Description mergepatch.Field[string]  `json:"description" swaggertype:"string"`
+	SKU         mergepatch.Field[string]  `json:"sku" swaggertype:"string"`
+	Price       mergepatch.Field[float64] `json:"price" swaggertype:"number"`
+	Stock       mergepatch.Field[int]     `json:"stock" swaggertype:"integer"`
+	ImageURL    mergepatch.Field[string]  `json:"imageUrl" swaggertype:"string"`
+	CategoryID  mergepatch.Field[string]  `json:"categoryId" swaggertype:"string"`
+} //	@name	ProductPatchRequest
+
+func (r ProductPatchRequest) ToDomain() (entity.ProductPatch, error) {
+	var patch entity.ProductPatch
+	var err error
+
+	if patch.Name, err = mergepatch.Required(r.Name, "name"); err != nil {
+		return patch, err
+	}
+	if patch.SKU, err = mergepatch.Required(r.SKU, "sku"); err != nil {
+		return patch, err
 	}
+	if patch.Price, err = mergepatch.Required(r.Price, "price"); err != nil {
+		return patch, err
+	}
+	if patch.Stock, err = mergepatch.Required(r.Stock, "stock"); err != nil {
+		return patch, err
+	}
+	if patch.CategoryID, err = mergepatch.Required(r.CategoryID, "categoryId"); err != nil {
+		return patch, err
+	}
+	patch.Description = mergepatch.Clearable(r.Description)
+	patch.ImageURL = mergepatch.Clearable(r.ImageURL)
+
+	return patch, nil
+}
+
+// ProductBatchCreateRequest represents the request payload for creating several products at once
+type ProductBatchCreateRequest struct {
+	Mode  string                 `json:"mode" enums:"atomic,best_effort"`
+	Items []ProductCreateRequest `json:"items" binding:"required"`
+} //	@name	ProductBatchCreateRequest
+
+func (r ProductBatchCreateRequest) ToDomain() []entity.Product {
+	products := make([]entity.Product, 0, len(r.Items))
+	for _, item := range r.Items {
+		products = append(products, item.ToDomain())
+	}
+	return products
+}
+
+// ProductBatchUpdateItem replaces one product of a batch. A non-zero version makes its update
+// conditional.
+type ProductBatchUpdateItem struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+	ProductUpdateRequest
+} //	@name	ProductBatchUpdateItem
+
+// ProductBatchUpdateRequest represents the request payload for replacing several products at once
+type ProductBatchUpdateRequest struct {
+	Mode  string                   `json:"mode" enums:"atomic,best_effort"`
+	Items []ProductBatchUpdateItem `json:"items" binding:"required"`
+} //	@name	ProductBatchUpdateRequest
+
+func (r ProductBatchUpdateRequest) ToDomain() []entity.Product {
+	products := make([]entity.Product, 0, len(r.Items))
+	for _, item := range r.Items {
+		product := item.ProductUpdateRequest.ToDomain()
+		product.ID = item.ID
+		product.Version = item.Version
+		products = append(products, product)
+	}
+	return products
+}
+
+// ProductBatchUpsertRequest represents the request payload for creating or replacing several
+// products by SKU at once
+type ProductBatchUpsertRequest struct {
+	Mode  string                 `json:"mode" enums:"atomic,best_effort"`
+	Items []ProductUpdateRequest `json:"items" binding:"required"`
+} //	@name	ProductBatchUpsertRequest
+
+func (r ProductBatchUpsertRequest) ToDomain() []entity.Product {
+	products := make([]entity.Product, 0, len(r.Items))
+	for _, item := range r.Items {
+		products = append(products, item.ToDomain())
+	}
+	return products
 }
 
 type FilterProductRequest struct {
+	IDs        string   `form:"ids,omitempty"`
 	Name       *string  `form:"name,omitempty"`
 	CategoryID *string  `form:"categoryId,omitempty"`
 	MaxPrice   *float64 `form:"maxPrice,omitempty"`
@@ -60,6 +178,22 @@ type FilterProductRequest struct {
 	Offset     int      `form:"offset"`
 }
 
+// ProductBatchGetRequest represents the request payload for looking up several products by ID
+type ProductBatchGetRequest struct {
+	IDs []string `json:"ids" binding:"required"`
+} //	@name	ProductBatchGetRequest
+
+// SplitIDs returns the comma-separated ids query parameter as a list, without blanks.
+func (r FilterProductRequest) SplitIDs() []string {
+	var ids []string
+	for _, id := range strings.Split(r.IDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func (r FilterProductRequest) ToDomain() entity.ProductFilter {
 	return entity.ProductFilter{
 		Name:       r.Name,
@@ -72,3 +206,40 @@ func (r FilterProductRequest) ToDomain() entity.ProductFilter {
 		},
 	}
 }
+
+// ProductRestoreRequest represents the optional request payload for restoring a deleted product.
+// CategoryID moves the product to another category, which is needed when its own is deleted.
+type ProductRestoreRequest struct {
+	CategoryID string `json:"categoryId"`
+} //	@name	ProductRestoreRequest
+
+type FilterTrashRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+func (r FilterTrashRequest) ToPagination() entity.Pagination {
+	return entity.Pagination{
+		Limit:  r.Limit,
+		Offset: r.Offset,
+	}
+}
+
+// FilterRevisionsRequest pages through the revisions of a product.
+type FilterRevisionsRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+func (r FilterRevisionsRequest) ToPagination() entity.Pagination {
+	return entity.Pagination{
+		Limit:  r.Limit,
+		Offset: r.Offset,
+	}
+}
+
+// RevisionURI names one revision of a product in the path.
+type RevisionURI struct {
+	ID       string `uri:"id" binding:"required"`
+	Revision int    `uri:"rev" binding:"required,min=1"`
+}
@@ -9,15 +9,17 @@ import (
 
 // Product represents the response payload for a product
 type Product struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	SKU         string    `json:"sku"`
-	Price       float64   `json:"price"`
-	Stock       int       `json:"stock"`
-	ImageURL    string    `json:"imageUrl"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt,omitempty"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	SKU         string     `json:"sku"`
+	Price       float64    `json:"price"`
+	Stock       int        `json:"stock"`
+	ImageURL    string     `json:"imageUrl"`
+	Version     int        `json:"version"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt,omitempty"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
 
 	Category *Category `json:"category,omitempty"`
 } //	@name	Product
@@ -48,12 +50,21 @@ func ProductFromDomain(product *entity.Product) *Product {
 		Price:       utils.GetValue(product.Price),
 		Stock:       utils.GetValue(product.Stock),
 		ImageURL:    utils.GetValue(product.ImageURL),
+		Version:     product.Version,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
+		DeletedAt:   product.DeletedAt,
 		Category:    category,
 	}
 }
 
+// ProductBatchGetResponse represents the products found by ID, in the order they were asked for,
+// and the IDs that matched no product
+type ProductBatchGetResponse struct {
+	Products []Product `json:"products"`
+	Missing  []string  `json:"missing"`
+} //	@name	ProductBatchGetResponse
+
 func ProductsFromDomain(products []entity.Product) []Product {
 	productsRes := make([]Product, 0, len(products))
 	for _, user := range products {
@@ -66,3 +77,46 @@ func ProductsFromDomain(products []entity.Product) []Product {
 
 	return productsRes
 }
+
+// Revision represents one stored state of a product, without the product itself
+type Revision struct {
+	Revision  int       `json:"revision"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	RequestID string    `json:"requestId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+} //	@name	ProductRevisionSummary
+
+// ProductRevision represents a revision together with the product as it was stored in it
+type ProductRevision struct {
+	Revision
+	Product *Product `json:"product"`
+} //	@name	ProductRevision
+
+func RevisionFromDomain(revision entity.Revision) Revision {
+	return Revision{
+		Revision:  revision.Number,
+		Action:    string(revision.Action),
+		Actor:     revision.Actor,
+		RequestID: revision.RequestID,
+		CreatedAt: revision.CreatedAt,
+	}
+}
+
+func RevisionsFromDomain(revisions []entity.Revision) []Revision {
+	result := make([]Revision, 0, len(revisions))
+	for _, revision := range revisions {
+		result = append(result, RevisionFromDomain(revision))
+	}
+	return result
+}
+
+func ProductRevisionFromDomain(revision *entity.ProductRevision) *ProductRevision {
+	if revision == nil {
+		return nil
+	}
+	return &ProductRevision{
+		Revision: RevisionFromDomain(revision.Revision),
+		Product:  ProductFromDomain(&revision.Product),
+	}
+}
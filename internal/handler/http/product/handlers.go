@@ -1,11 +1,20 @@
 package product
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"path"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
 	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/batch"
 	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/mergepatch"
+	"github.com/sirawong/crud-arise/internal/handler/http/precondition"
+	"github.com/sirawong/crud-arise/internal/handler/http/prefer"
 	"github.com/sirawong/crud-arise/internal/handler/http/product/dto"
 	productSrv "github.com/sirawong/crud-arise/internal/services/product"
 )
@@ -26,7 +35,10 @@ func NewProductHandler(productService productSrv.ProductService) *ProductHandler
 //	@Accept			json
 //	@Produce		json
 //	@Param			product	body		dto.ProductCreateRequest	true	"Product creation information"
-//	@Success		201		{object}	map[string]interface{}		"{"id": "product_id"}"
+//	@Param			Prefer	header		string	false	"return=minimal to leave out the response body"
+//	@Success		201		{object}	dto.Product	"Created product"
+//	@Header			201	{string}	Location	"URL of the created product"
+//	@Header			201	{string}	ETag	"Entity tag of the created version"
 //	@Failure		400		{object}	map[string]interface{}		"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
 //	@Failure		500		{object}	map[string]interface{}		"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
 //	@Router			/products [post]
@@ -37,27 +49,35 @@ func (h ProductHandler) Create(c *gin.Context) {
 		return
 	}
 
-	id, err := h.productService.Create(c, req.ToDomain())
+	product, err := h.productService.Create(c, req.ToDomain())
 	if err != nil {
 		handlererr.RespondWithError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"id": id})
+	c.Header("Location", path.Join(c.Request.URL.Path, product.ID))
+	c.Header("ETag", productETag(product))
+	prefer.Respond(c, http.StatusCreated, dto.ProductFromDomain(product))
 }
 
 // Update godoc
 //
-//	@Summary		Update a product
-//	@Description	Update an existing product by ID
+//	@Summary		Replace a product
+//	@Description	Replace an existing product by ID. Optional fields left out are cleared; use PATCH to change single fields
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json
 //	@Param			id		path		string						true	"Product ID"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
 //	@Param			product	body		dto.ProductUpdateRequest	true	"Product update information"
-//	@Success		200		{object}	map[string]interface{}		"{"status": "updated"}"
+//	@Param			Prefer	header		string	false	"return=minimal to leave out the response body"
+//	@Success		200		{object}	dto.Product	"Product as stored"
+//	@Success		204		"No body with Prefer: return=minimal"
+//	@Header			200,204	{string}	ETag	"Entity tag of the new version"
 //	@Failure		400		{object}	map[string]interface{}		"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
 //	@Failure		404		{object}	map[string]interface{}		"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		412		{object}	map[string]interface{}		"{"error_code": "PRECONDITION_FAILED", "message": "error description", "details": {"currentVersion": 3}}"
+//	@Failure		428		{object}	map[string]interface{}		"{"error_code": "PRECONDITION_REQUIRED", "message": "error	description"}"
 //	@Failure		500		{object}	map[string]interface{}		"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
 //	@Router			/products/{id} [put]
 func (h ProductHandler) Update(c *gin.Context) {
@@ -73,13 +93,121 @@ func (h ProductHandler) Update(c *gin.Context) {
 		return
 	}
 
-	err := h.productService.Update(c, id, req.ToDomain())
+	version, err := precondition.ExpectedVersion(c)
 	if err != nil {
 		handlererr.RespondWithError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": "updated"})
+	product := req.ToDomain()
+	product.Version = version
+	updated, err := h.productService.Update(c, id, product)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", productETag(updated))
+	prefer.Respond(c, http.StatusOK, dto.ProductFromDomain(updated))
+}
+
+// Upsert godoc
+//
+//	@Summary		Create or replace a product by SKU
+//	@Description	Create the product with the SKU in the path if there is none, or replace it as a whole. The write is unconditional and the category is checked in the same transaction.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			sku		path		string						true	"Product SKU"
+//	@Param			product	body		dto.ProductUpsertRequest	true	"Product information"
+//	@Param			Prefer	header		string	false	"return=minimal to leave out the response body"
+//	@Success		200		{object}	dto.Product	"Product as replaced"
+//	@Success		201		{object}	dto.Product	"Product as created"
+//	@Success		204		"No body with Prefer: return=minimal"
+//	@Header			201	{string}	Location	"URL of the created product"
+//	@Header			200,201,204	{string}	ETag	"Entity tag of the new version"
+//	@Failure		400		{object}	map[string]interface{}		"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}		"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409		{object}	map[string]interface{}		"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}		"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/by-sku/{sku} [put]
+func (h ProductHandler) Upsert(c *gin.Context) {
+	var req dto.ProductUpsertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	product, created, err := h.productService.Upsert(c, req.ToDomain(c.Param("sku")))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", productETag(product))
+	if !created {
+		prefer.Respond(c, http.StatusOK, dto.ProductFromDomain(product))
+		return
+	}
+
+	// The product lives at /products/:id, two segments up from /products/by-sku/:sku.
+	c.Header("Location", path.Join(path.Dir(path.Dir(c.Request.URL.Path)), product.ID))
+	prefer.Respond(c, http.StatusCreated, dto.ProductFromDomain(product))
+}
+
+// Patch godoc
+//
+//	@Summary		Patch a product
+//	@Description	Change some fields of a product with a JSON Merge Patch (RFC 7396). Members left out are not changed and null clears description or imageUrl.
+//	@Tags			products
+//	@Accept			application/merge-patch+json,json
+//	@Produce		json
+//	@Param			id			path		string					true	"Product ID"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
+//	@Param			product	body		dto.ProductPatchRequest	true	"Fields to change"
+//	@Param			Prefer	header		string	false	"return=minimal to leave out the response body"
+//	@Success		200		{object}	dto.Product	"Product as stored"
+//	@Success		204		"No body with Prefer: return=minimal"
+//	@Header			200,204	{string}	ETag	"Entity tag of the new version"
+//	@Failure		400			{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404			{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		412			{object}	map[string]interface{}	"{"error_code": "PRECONDITION_FAILED", "message": "error description", "details": {"currentVersion": 3}}"
+//	@Failure		428			{object}	map[string]interface{}	"{"error_code": "PRECONDITION_REQUIRED", "message": "error	description"}"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id} [patch]
+func (h ProductHandler) Patch(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	var req dto.ProductPatchRequest
+	if err := mergepatch.Bind(c, &req); err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	patch, err := req.ToDomain()
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	patch.Version, err = precondition.ExpectedVersion(c)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	patched, err := h.productService.Patch(c, id, patch)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", productETag(patched))
+	prefer.Respond(c, http.StatusOK, dto.ProductFromDomain(patched))
 }
 
 // GetByID godoc
@@ -90,7 +218,12 @@ func (h ProductHandler) Update(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string					true	"Product ID"
+//	@Param			If-None-Match	header		string	false	"ETag from an earlier response"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified from an earlier response"
 //	@Success		200	{object}	dto.Product				"Product information"
+//	@Success		304	"Not modified"
+//	@Header			200,304	{string}	ETag	"Entity tag of the current representation"
+//	@Header			200,304	{string}	Last-Modified	"Time of the latest change"
 //	@Failure		400	{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
 //	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
 //	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
@@ -108,9 +241,79 @@ func (h ProductHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	if precondition.NotModified(c, productETag(product), lastModified(product)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ProductFromDomain(product))
+}
+
+// GetBySKU godoc
+//
+//	@Summary		Get a product by SKU
+//	@Description	Get a single product by its SKU
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			sku	path		string					true	"Product SKU"
+//	@Param			If-None-Match	header		string	false	"ETag from an earlier response"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified from an earlier response"
+//	@Success		200	{object}	dto.Product				"Product information"
+//	@Success		304	"Not modified"
+//	@Header			200,304	{string}	ETag	"Entity tag of the current representation"
+//	@Header			200,304	{string}	Last-Modified	"Time of the latest change"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/by-sku/{sku} [get]
+func (h ProductHandler) GetBySKU(c *gin.Context) {
+	product, err := h.productService.GetBySKU(c, c.Param("sku"))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	if precondition.NotModified(c, productETag(product), lastModified(product)) {
+		return
+	}
+
 	c.JSON(http.StatusOK, dto.ProductFromDomain(product))
 }
 
+// BatchGet godoc
+//
+//	@Summary		Get several products by ID
+//	@Description	Get up to BATCH_MAX_ITEMS products in the order their IDs were asked for, each once, and the IDs that matched no product
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			ids	body		dto.ProductBatchGetRequest	true	"Product IDs"
+//	@Success		200	{object}	dto.ProductBatchGetResponse	"Products found and IDs missing"
+//	@Failure		400	{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products:batchGet [post]
+func (h ProductHandler) BatchGet(c *gin.Context) {
+	var req dto.ProductBatchGetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	h.respondByIDs(c, req.IDs)
+}
+
+func (h ProductHandler) respondByIDs(c *gin.Context, ids []string) {
+	products, missing, err := h.productService.GetByIDs(c, ids)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ProductBatchGetResponse{
+		Products: dto.ProductsFromDomain(products),
+		Missing:  missing,
+	})
+}
+
 // ListAll godoc
 //
 //	@Summary		Get all products
@@ -118,13 +321,19 @@ func (h ProductHandler) GetByID(c *gin.Context) {
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json
+//	@Param			ids			query		string					false	"Comma-separated product IDs; answers like POST /products:batchGet and ignores the other filters"
 //	@Param			name		query		string					false	"Search insensitive by products name"
 //	@Param			categoryId	query		string					false	"Filter by category ID"
 //	@Param			minPrice	query		number					false	"Minimum price filter"
 //	@Param			maxPrice	query		number					false	"Maximum price filter"
 //	@Param			limit		query		int						false	"Limit number of results (default: 10, limit: 100)"
 //	@Param			offset		query		int						false	"Offset for pagination (default: 0)"
+//	@Param			If-None-Match	header		string	false	"ETag from an earlier response"
+//	@Param			If-Modified-Since	header		string	false	"Last-Modified from an earlier response"
 //	@Success		200			{array}		dto.Product				"List of products"
+//	@Success		304	"Not modified"
+//	@Header			200,304	{string}	ETag	"Entity tag of the current representation"
+//	@Header			200,304	{string}	Last-Modified	"Time of the latest change"
 //	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error	description"}"
 //	@Router			/products [get]
 func (h ProductHandler) ListAll(c *gin.Context) {
@@ -134,7 +343,22 @@ func (h ProductHandler) ListAll(c *gin.Context) {
 		return
 	}
 
-	products, err := h.productService.GetAll(c, query.ToDomain())
+	if query.IDs != "" {
+		h.respondByIDs(c, query.SplitIDs())
+		return
+	}
+
+	filter := query.ToDomain()
+	stats, err := h.productService.GetListStats(c, filter)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+	if precondition.NotModified(c, precondition.ListETag(query, *stats), stats.LastModified) {
+		return
+	}
+
+	products, err := h.productService.GetAll(c, filter)
 	if err != nil {
 		handlererr.RespondWithError(c, err)
 		return
@@ -151,9 +375,12 @@ func (h ProductHandler) ListAll(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string					true	"Product ID"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
 //	@Success		200	{object}	map[string]interface{}	"{"status": "deleted"}"
 //	@Failure		400	{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
 //	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		412	{object}	map[string]interface{}	"{"error_code": "PRECONDITION_FAILED", "message": "error description", "details": {"currentVersion": 3}}"
+//	@Failure		428	{object}	map[string]interface{}	"{"error_code": "PRECONDITION_REQUIRED", "message": "error	description"}"
 //	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
 //	@Router			/products/{id} [delete]
 func (h ProductHandler) Delete(c *gin.Context) {
@@ -163,7 +390,13 @@ func (h ProductHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err := h.productService.Delete(c, id)
+	version, err := precondition.ExpectedVersion(c)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	err = h.productService.Delete(c, id, version)
 	if err != nil {
 		handlererr.RespondWithError(c, err)
 		return
@@ -171,3 +404,333 @@ func (h ProductHandler) Delete(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 }
+
+// ListTrash godoc
+//
+//	@Summary		List deleted products
+//	@Description	Get deleted products, most recently deleted first, with their category even if it is deleted too
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset	query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200		{array}		dto.Product				"List of deleted products"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/trash [get]
+func (h ProductHandler) ListTrash(c *gin.Context) {
+	var query dto.FilterTrashRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	products, err := h.productService.ListTrash(c, query.ToPagination())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ProductsFromDomain(products))
+}
+
+// Restore godoc
+//
+//	@Summary		Restore a deleted product
+//	@Description	Bring a deleted product back. It fails with CONFLICT when a live product has taken its SKU, or when its category is deleted and no other categoryId is given.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Product ID"
+//	@Param			product	body		dto.ProductRestoreRequest	false	"Category to restore the product in"
+//	@Param			Prefer	header		string	false	"return=minimal to leave out the response body"
+//	@Success		200		{object}	dto.Product	"Product as restored"
+//	@Success		204		"No body with Prefer: return=minimal"
+//	@Header			200,204	{string}	ETag	"Entity tag of the new version"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409		{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id}/restore [post]
+func (h ProductHandler) Restore(c *gin.Context) {
+	// The body is optional; a chunked request may still turn out to be empty.
+	var req dto.ProductRestoreRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+			return
+		}
+	}
+
+	product, err := h.productService.Restore(c, c.Param("id"), req.CategoryID)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", productETag(product))
+	prefer.Respond(c, http.StatusOK, dto.ProductFromDomain(product))
+}
+
+// Purge godoc
+//
+//	@Summary		Purge a deleted product
+//	@Description	Remove a deleted product for good. Products still referenced by lots, suppliers, orders or returns cannot be purged.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Product ID"
+//	@Success		200	{object}	map[string]interface{}	"{"status": "purged"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id}/purge [delete]
+func (h ProductHandler) Purge(c *gin.Context) {
+	err := h.productService.Purge(c, c.Param("id"))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "purged"})
+}
+
+// ListRevisions godoc
+//
+//	@Summary		List the revisions of a product
+//	@Description	Get the revisions of a product, deleted or not, newest first. Each write that changes the product stores its full state as the next revision.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Product ID"
+//	@Param			limit	query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset	query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200		{array}		dto.Revision			"Revisions, newest first"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id}/revisions [get]
+func (h ProductHandler) ListRevisions(c *gin.Context) {
+	var query dto.FilterRevisionsRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	revisions, err := h.productService.ListRevisions(c, c.Param("id"), query.ToPagination())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RevisionsFromDomain(revisions))
+}
+
+// GetRevision godoc
+//
+//	@Summary		Get a product as of a revision
+//	@Description	Get the product as it was stored in one of its revisions
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Product ID"
+//	@Param			rev	path		int						true	"Revision number"
+//	@Success		200	{object}	dto.ProductRevision		"Product as of the revision"
+//	@Failure		400	{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id}/revisions/{rev} [get]
+func (h ProductHandler) GetRevision(c *gin.Context) {
+	var uri dto.RevisionURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	revision, err := h.productService.GetRevision(c, uri.ID, uri.Revision)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ProductRevisionFromDomain(revision))
+}
+
+// Revert godoc
+//
+//	@Summary		Revert a product to a revision
+//	@Description	Bring a live product's name, description, SKU, price, image and category back to what they were in a revision. Stock is kept. The revert is stored as a new revision; a revert that would change nothing leaves the product untouched. It fails with CONFLICT when the revision's category is deleted or its SKU is taken.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Product ID"
+//	@Param			rev			path		int		true	"Revision number"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
+//	@Param			Prefer		header		string	false	"return=minimal to leave out the response body"
+//	@Success		200		{object}	dto.Product	"Product as stored"
+//	@Success		204		"No body with Prefer: return=minimal"
+//	@Header			200,204	{string}	ETag	"Entity tag of the new version"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409		{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		412		{object}	map[string]interface{}	"{"error_code": "PRECONDITION_FAILED", "message": "error description", "details": {"currentVersion": 3}}"
+//	@Failure		428		{object}	map[string]interface{}	"{"error_code": "PRECONDITION_REQUIRED", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id}/revisions/{rev}/revert [post]
+func (h ProductHandler) Revert(c *gin.Context) {
+	var uri dto.RevisionURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	version, err := precondition.ExpectedVersion(c)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	reverted, err := h.productService.Revert(c, uri.ID, uri.Revision, version)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", productETag(reverted))
+	prefer.Respond(c, http.StatusOK, dto.ProductFromDomain(reverted))
+}
+
+// BatchCreate godoc
+//
+//	@Summary		Create several products
+//	@Description	Create up to BATCH_MAX_ITEMS products with multi-row inserts. In atomic mode (the default) nothing is created unless every product can be and a failure answers with the first failing item's error and every failing item in details; in best_effort mode the result of each item is reported.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			products	body		dto.ProductBatchCreateRequest	true	"Product creation information"
+//	@Success		200		{object}	batch.Response	"Result of each item"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error description", "details": [{"index": 1, "error_code": "NOT_FOUND", "message": "error description"}]}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products:batchCreate [post]
+func (h ProductHandler) BatchCreate(c *gin.Context) {
+	var req dto.ProductBatchCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	results, err := h.productService.BatchCreate(c, req.ToDomain(), batch.Mode(req.Mode))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	batch.Respond(c, batch.StatusCreated, results)
+}
+
+// BatchUpdate godoc
+//
+//	@Summary		Replace several products
+//	@Description	Replace up to BATCH_MAX_ITEMS products with multi-row updates. An item's version makes its update conditional. Modes work as for batchCreate.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			products	body		dto.ProductBatchUpdateRequest	true	"Product update information"
+//	@Success		200		{object}	batch.Response	"Result of each item"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error description", "details": [{"index": 1, "id": "id", "error_code": "NOT_FOUND", "message": "error description"}]}"
+//	@Failure		412		{object}	map[string]interface{}	"{"error_code": "PRECONDITION_FAILED", "message": "error description", "details": [{"index": 1, "id": "id", "error_code": "PRECONDITION_FAILED", "message": "error description"}]}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products:batchUpdate [post]
+func (h ProductHandler) BatchUpdate(c *gin.Context) {
+	var req dto.ProductBatchUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	results, err := h.productService.BatchUpdate(c, req.ToDomain(), batch.Mode(req.Mode))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	batch.Respond(c, batch.StatusUpdated, results)
+}
+
+// BatchUpsert godoc
+//
+//	@Summary		Create or replace several products by SKU
+//	@Description	Create or replace up to BATCH_MAX_ITEMS products by SKU with multi-row upserts. Each SKU may appear once. Modes work as for batchCreate; each written item is reported as created or updated.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			products	body		dto.ProductBatchUpsertRequest	true	"Product information"
+//	@Success		200		{object}	batch.Response	"Result of each item"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error description", "details": [{"index": 1, "error_code": "NOT_FOUND", "message": "error description"}]}"
+//	@Failure		409		{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error description", "details": [{"index": 1, "error_code": "CONFLICT", "message": "error description"}]}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products:batchUpsert [post]
+func (h ProductHandler) BatchUpsert(c *gin.Context) {
+	var req dto.ProductBatchUpsertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	results, err := h.productService.BatchUpsert(c, req.ToDomain(), batch.Mode(req.Mode))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	batch.RespondUpserted(c, results)
+}
+
+// BatchDelete godoc
+//
+//	@Summary		Delete several products
+//	@Description	Delete up to BATCH_MAX_ITEMS products with multi-row updates. An item's version makes its delete conditional. Modes work as for batchCreate.
+//	@Tags			products
+//	@Accept			json
+//	@Produce		json
+//	@Param			products	body		batch.DeleteRequest	true	"Product IDs"
+//	@Success		200		{object}	batch.Response	"Result of each item"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error description", "details": [{"index": 1, "id": "id", "error_code": "NOT_FOUND", "message": "error description"}]}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products:batchDelete [post]
+func (h ProductHandler) BatchDelete(c *gin.Context) {
+	var req batch.DeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	results, err := h.productService.BatchDelete(c, req.ToDomain(), batch.Mode(req.Mode))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	batch.Respond(c, batch.StatusDeleted, results)
+}
+
+// productETag tags a product together with the version of the category embedded in it, so
+// renaming the category invalidates cached copies of the product.
+func productETag(product *entity.Product) string {
+	if product.Category == nil {
+		return precondition.ETag(product.Version)
+	}
+	return precondition.ETag(product.Version, product.Category.Version)
+}
+
+// lastModified is the latest change of a product or of the category embedded in it.
+func lastModified(product *entity.Product) time.Time {
+	if product.Category != nil && product.Category.UpdatedAt.After(product.UpdatedAt) {
+		return product.Category.UpdatedAt
+	}
+	return product.UpdatedAt
+}
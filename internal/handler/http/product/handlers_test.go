@@ -15,6 +15,8 @@ import (
 
 	"github.com/sirawong/crud-arise/internal/domain/entity"
 	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/batch"
+	"github.com/sirawong/crud-arise/internal/handler/http/custommethod"
 	"github.com/sirawong/crud-arise/internal/handler/http/product/dto"
 	"github.com/sirawong/crud-arise/internal/services/product/mocks"
 	"github.com/stretchr/testify/suite"
@@ -41,9 +43,25 @@ func (suite *ProductHandlerTestSuite) SetupTest() {
 		prd.POST("/", suite.handler.Create)
 		prd.GET("/", suite.handler.ListAll)
 		prd.GET("/:id", suite.handler.GetByID)
+		prd.GET("/by-sku/:sku", suite.handler.GetBySKU)
+		prd.GET("/trash", suite.handler.ListTrash)
 		prd.PUT("/:id", suite.handler.Update)
+		prd.PUT("/by-sku/:sku", suite.handler.Upsert)
+		prd.PATCH("/:id", suite.handler.Patch)
 		prd.DELETE("/:id", suite.handler.Delete)
+		prd.POST("/:id/restore", suite.handler.Restore)
+		prd.DELETE("/:id/purge", suite.handler.Purge)
+		prd.GET("/:id/revisions", suite.handler.ListRevisions)
+		prd.GET("/:id/revisions/:rev", suite.handler.GetRevision)
+		prd.POST("/:id/revisions/:rev/revert", suite.handler.Revert)
 	}
+	v1.POST("/products:method", custommethod.Dispatch(map[string]gin.HandlerFunc{
+		"batchGet":    suite.handler.BatchGet,
+		"batchCreate": suite.handler.BatchCreate,
+		"batchUpdate": suite.handler.BatchUpdate,
+		"batchDelete": suite.handler.BatchDelete,
+		"batchUpsert": suite.handler.BatchUpsert,
+	}))
 }
 
 func (suite *ProductHandlerTestSuite) TearDownTest() {
@@ -65,7 +83,11 @@ func (suite *ProductHandlerTestSuite) TestCreate_Success() {
 
 	suite.mockService.EXPECT().
 		Create(gomock.Any(), gomock.Any()).
-		Return(expectedID, nil).
+		DoAndReturn(func(ctx interface{}, product entity.Product) (*entity.Product, error) {
+			product.ID = expectedID
+			product.Version = 1
+			return &product, nil
+		}).
 		Times(1)
 
 	body, _ := json.Marshal(request)
@@ -75,11 +97,35 @@ func (suite *ProductHandlerTestSuite) TestCreate_Success() {
 	suite.router.ServeHTTP(w, req)
 
 	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal("/api/v1/products/"+expectedID, w.Header().Get("Location"))
+	suite.Equal(`"1"`, w.Header().Get("ETag"))
 
-	var response map[string]interface{}
+	var response dto.Product
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	suite.NoError(err)
-	suite.Equal(expectedID, response["id"])
+	suite.Equal(expectedID, response.ID)
+	suite.Equal(request.SKU, response.SKU)
+	suite.Equal(request.Price, response.Price)
+	suite.Equal(request.Stock, response.Stock)
+}
+
+func (suite *ProductHandlerTestSuite) TestCreate_PreferReturnMinimal() {
+
+	suite.mockService.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(&entity.Product{ID: "product-123", Version: 1}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products/", bytes.NewBufferString(`{"name": "Test", "description": "Test", "sku": "TEST-001", "price": 1, "stock": 1, "categoryId": "category-123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Prefer", "return=minimal")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal("/api/v1/products/product-123", w.Header().Get("Location"))
+	suite.Equal("return=minimal", w.Header().Get("Preference-Applied"))
+	suite.Empty(w.Body.Bytes())
 }
 
 func (suite *ProductHandlerTestSuite) TestCreate_InvalidJSON() {
@@ -108,7 +154,7 @@ func (suite *ProductHandlerTestSuite) TestCreate_ServiceError() {
 
 	suite.mockService.EXPECT().
 		Create(gomock.Any(), gomock.Any()).
-		Return("", expectedErr).
+		Return(nil, expectedErr).
 		Times(1)
 
 	body, _ := json.Marshal(request)
@@ -124,13 +170,23 @@ func (suite *ProductHandlerTestSuite) TestUpdate_Success() {
 
 	productID := "product-123"
 	request := dto.ProductUpdateRequest{
-		Name:  utils.SetPtr("Updated Product"),
-		Price: utils.SetPtr(149.99),
+		Name:       "Updated Product",
+		SKU:        "TEST-001",
+		Price:      utils.SetPtr(149.99),
+		Stock:      new(int),
+		CategoryID: "category-123",
 	}
 
 	suite.mockService.EXPECT().
 		Update(gomock.Any(), productID, gomock.Any()).
-		Return(nil).
+		DoAndReturn(func(ctx interface{}, id string, product entity.Product) (*entity.Product, error) {
+			suite.Equal(0, *product.Stock)
+			suite.Equal("", product.Description)
+			suite.Equal("", *product.ImageURL)
+			product.ID = id
+			product.Version = 2
+			return &product, nil
+		}).
 		Times(1)
 
 	body, _ := json.Marshal(request)
@@ -140,24 +196,52 @@ func (suite *ProductHandlerTestSuite) TestUpdate_Success() {
 	suite.router.ServeHTTP(w, req)
 
 	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"2"`, w.Header().Get("ETag"))
 
-	var response map[string]interface{}
+	var response dto.Product
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	suite.NoError(err)
-	suite.Equal("updated", response["status"])
+	suite.Equal(productID, response.ID)
+	suite.Equal(request.Name, response.Name)
+	suite.Equal(149.99, response.Price)
+}
+
+func (suite *ProductHandlerTestSuite) TestUpdate_PreferReturnMinimal() {
+
+	productID := "product-123"
+
+	suite.mockService.EXPECT().
+		Update(gomock.Any(), productID, gomock.Any()).
+		Return(&entity.Product{ID: productID, Version: 2}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/products/%s", productID), bytes.NewBufferString(`{"name": "Renamed", "sku": "TEST-001", "price": 10, "stock": 0, "categoryId": "category-123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Prefer", "return=minimal")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Equal(`"2"`, w.Header().Get("ETag"))
+	suite.Equal("return=minimal", w.Header().Get("Preference-Applied"))
+	suite.Empty(w.Body.Bytes())
 }
 
 func (suite *ProductHandlerTestSuite) TestUpdate_ServiceError() {
 
 	productID := "product-123"
 	request := dto.ProductUpdateRequest{
-		Name: utils.SetPtr("Updated Product"),
+		Name:       "Updated Product",
+		SKU:        "TEST-001",
+		Price:      utils.SetPtr(149.99),
+		Stock:      utils.SetPtr(5),
+		CategoryID: "category-123",
 	}
 	expectedErr := apperr.ErrNotFound.WithMessage("product not found")
 
 	suite.mockService.EXPECT().
 		Update(gomock.Any(), productID, gomock.Any()).
-		Return(expectedErr).
+		Return(nil, expectedErr).
 		Times(1)
 
 	body, _ := json.Marshal(request)
@@ -169,6 +253,78 @@ func (suite *ProductHandlerTestSuite) TestUpdate_ServiceError() {
 	suite.Equal(http.StatusNotFound, w.Code)
 }
 
+const replacement = `{"name": "Renamed", "sku": "TEST-001", "price": 10, "stock": 0, "categoryId": "category-123"}`
+
+func (suite *ProductHandlerTestSuite) TestUpdate_MissingField() {
+
+	req, _ := http.NewRequest("PUT", "/api/v1/products/product-123", bytes.NewBufferString(`{"name": "Renamed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestUpdate_IfMatch() {
+
+	productID := "product-123"
+
+	suite.mockService.EXPECT().
+		Update(gomock.Any(), productID, gomock.Any()).
+		DoAndReturn(func(ctx interface{}, id string, product entity.Product) (*entity.Product, error) {
+			suite.Equal(4, product.Version)
+			product.ID = id
+			product.Version = 5
+			return &product, nil
+		}).
+		Times(1)
+
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/products/%s", productID), bytes.NewBufferString(replacement))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"4"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"5"`, w.Header().Get("ETag"))
+}
+
+func (suite *ProductHandlerTestSuite) TestUpdate_VersionMismatch() {
+
+	productID := "product-123"
+	expectedErr := apperr.ErrPreconditionFailed.WithDetails(entity.VersionConflict{CurrentVersion: 5})
+
+	suite.mockService.EXPECT().
+		Update(gomock.Any(), productID, gomock.Any()).
+		Return(nil, expectedErr).
+		Times(1)
+
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/products/%s", productID), bytes.NewBufferString(replacement))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"4"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusPreconditionFailed, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("PRECONDITION_FAILED", response["error_code"])
+	suite.Equal(map[string]interface{}{"currentVersion": float64(5)}, response["details"])
+}
+
+func (suite *ProductHandlerTestSuite) TestUpdate_InvalidIfMatch() {
+
+	req, _ := http.NewRequest("PUT", "/api/v1/products/product-123", bytes.NewBufferString(replacement))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `W/"4"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusPreconditionFailed, w.Code)
+}
+
 func (suite *ProductHandlerTestSuite) TestGetByID_Success() {
 
 	productID := "product-123"
@@ -179,12 +335,14 @@ func (suite *ProductHandlerTestSuite) TestGetByID_Success() {
 		SKU:         "TEST-001",
 		Price:       utils.SetPtr(99.99),
 		Stock:       utils.SetPtr(100),
+		Version:     3,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		CategoryID:  "category-123",
 		Category: &entity.Category{
-			ID:   "category-123",
-			Name: "Test Category",
+			ID:      "category-123",
+			Name:    "Test Category",
+			Version: 2,
 		},
 	}
 
@@ -204,7 +362,40 @@ func (suite *ProductHandlerTestSuite) TestGetByID_Success() {
 	suite.NoError(err)
 	suite.Equal(expectedProduct.ID, response.ID)
 	suite.Equal(expectedProduct.Name, response.Name)
+	suite.Equal(3, response.Version)
 	suite.NotNil(response.Category)
+	suite.Equal(`"3.2"`, w.Header().Get("ETag"))
+}
+
+func (suite *ProductHandlerTestSuite) TestGetByID_CategoryChangeChangesValidators() {
+	productUpdated := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	product := &entity.Product{
+		ID:         "product-123",
+		Name:       "Test Product",
+		Version:    3,
+		UpdatedAt:  productUpdated,
+		CategoryID: "category-123",
+		Category: &entity.Category{
+			ID:        "category-123",
+			Name:      "Renamed Category",
+			Version:   3,
+			UpdatedAt: productUpdated.Add(time.Hour),
+		},
+	}
+
+	suite.mockService.EXPECT().
+		GetByID(gomock.Any(), product.ID).
+		Return(product, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/product-123", nil)
+	req.Header.Set("If-None-Match", `"3.2"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"3.3"`, w.Header().Get("ETag"))
+	suite.Equal("Fri, 01 May 2026 13:00:00 GMT", w.Header().Get("Last-Modified"))
 }
 
 func (suite *ProductHandlerTestSuite) TestGetByID_ServiceError() {
@@ -243,6 +434,11 @@ func (suite *ProductHandlerTestSuite) TestListAll_Success() {
 		},
 	}
 
+	suite.mockService.EXPECT().
+		GetListStats(gomock.Any(), gomock.Any()).
+		Return(&entity.ListStats{Count: 2, LastModified: time.Now()}, nil).
+		Times(1)
+
 	suite.mockService.EXPECT().
 		GetAll(gomock.Any(), gomock.Any()).
 		Return(expectedProducts, nil).
@@ -262,6 +458,11 @@ func (suite *ProductHandlerTestSuite) TestListAll_Success() {
 
 func (suite *ProductHandlerTestSuite) TestListAll_WithFilters() {
 
+	suite.mockService.EXPECT().
+		GetListStats(gomock.Any(), gomock.Any()).
+		Return(&entity.ListStats{Count: 2, LastModified: time.Now()}, nil).
+		Times(1)
+
 	suite.mockService.EXPECT().
 		GetAll(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(ctx interface{}, filter entity.ProductFilter) ([]entity.Product, error) {
@@ -278,12 +479,97 @@ func (suite *ProductHandlerTestSuite) TestListAll_WithFilters() {
 	suite.Equal(http.StatusOK, w.Code)
 }
 
+func (suite *ProductHandlerTestSuite) TestListAll_NotModified() {
+
+	stats := &entity.ListStats{Count: 2, LastModified: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	suite.mockService.EXPECT().
+		GetListStats(gomock.Any(), gomock.Any()).
+		Return(stats, nil).
+		Times(2)
+
+	suite.mockService.EXPECT().
+		GetAll(gomock.Any(), gomock.Any()).
+		Return([]entity.Product{}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/?limit=5", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	suite.NotEmpty(etag)
+	suite.Equal("Fri, 01 May 2026 12:00:00 GMT", w.Header().Get("Last-Modified"))
+
+	req, _ = http.NewRequest("GET", "/api/v1/products/?limit=5", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotModified, w.Code)
+	suite.Empty(w.Body.String())
+}
+
+func (suite *ProductHandlerTestSuite) TestListAll_DifferentFilterChangesETag() {
+
+	stats := &entity.ListStats{Count: 2, LastModified: time.Now()}
+
+	suite.mockService.EXPECT().
+		GetListStats(gomock.Any(), gomock.Any()).
+		Return(stats, nil).
+		Times(2)
+
+	suite.mockService.EXPECT().
+		GetAll(gomock.Any(), gomock.Any()).
+		Return([]entity.Product{}, nil).
+		Times(2)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/?limit=5", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	first := w.Header().Get("ETag")
+
+	req, _ = http.NewRequest("GET", "/api/v1/products/?limit=5&offset=5", nil)
+	req.Header.Set("If-None-Match", first)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.NotEqual(first, w.Header().Get("ETag"))
+}
+
+func (suite *ProductHandlerTestSuite) TestGetByID_NotModified() {
+
+	updatedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.mockService.EXPECT().
+		GetByID(gomock.Any(), "id-1").
+		Return(&entity.Product{ID: "id-1", Version: 4, UpdatedAt: updatedAt}, nil).
+		Times(2)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/id-1", nil)
+	req.Header.Set("If-None-Match", `W/"4"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotModified, w.Code)
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
+
+	req, _ = http.NewRequest("GET", "/api/v1/products/id-1", nil)
+	req.Header.Set("If-Modified-Since", "Fri, 01 May 2026 11:00:00 GMT")
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
 func (suite *ProductHandlerTestSuite) TestDelete_Success() {
 
 	productID := "product-123"
 
 	suite.mockService.EXPECT().
-		Delete(gomock.Any(), productID).
+		Delete(gomock.Any(), productID, 0).
 		Return(nil).
 		Times(1)
 
@@ -299,13 +585,30 @@ func (suite *ProductHandlerTestSuite) TestDelete_Success() {
 	suite.Equal("deleted", response["status"])
 }
 
+func (suite *ProductHandlerTestSuite) TestDelete_IfMatch() {
+
+	productID := "product-123"
+
+	suite.mockService.EXPECT().
+		Delete(gomock.Any(), productID, 2).
+		Return(nil).
+		Times(1)
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/products/%s", productID), nil)
+	req.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
 func (suite *ProductHandlerTestSuite) TestDelete_ServiceError() {
 
 	productID := "product-123"
 	expectedErr := apperr.ErrNotFound.WithMessage("product not found")
 
 	suite.mockService.EXPECT().
-		Delete(gomock.Any(), productID).
+		Delete(gomock.Any(), productID, 0).
 		Return(expectedErr).
 		Times(1)
 
@@ -316,6 +619,576 @@ func (suite *ProductHandlerTestSuite) TestDelete_ServiceError() {
 	suite.Equal(http.StatusNotFound, w.Code)
 }
 
+func (suite *ProductHandlerTestSuite) TestPatch_Success() {
+
+	productID := "product-123"
+	cleared := ""
+
+	suite.mockService.EXPECT().
+		Patch(gomock.Any(), productID, entity.ProductPatch{
+			Description: &cleared,
+			Price:       new(float64),
+			Stock:       new(int),
+			Version:     3,
+		}).
+		Return(&entity.Product{ID: productID, Name: "Test Product", Price: new(float64), Stock: new(int), Version: 4}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/products/%s", productID), bytes.NewBufferString(`{"description": null, "price": 0, "stock": 0}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", `"3"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
+
+	var response dto.Product
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("Test Product", response.Name)
+	suite.Equal("", response.Description)
+}
+
+func (suite *ProductHandlerTestSuite) TestPatch_PreferReturnMinimal() {
+
+	suite.mockService.EXPECT().
+		Patch(gomock.Any(), "product-123", gomock.Any()).
+		Return(&entity.Product{ID: "product-123", Version: 4}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("PATCH", "/api/v1/products/product-123", bytes.NewBufferString(`{"name": "Renamed"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("Prefer", "return=minimal")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
+	suite.Empty(w.Body.Bytes())
+}
+
+func (suite *ProductHandlerTestSuite) TestPatch_NullRequiredField() {
+
+	req, _ := http.NewRequest("PATCH", "/api/v1/products/product-123", bytes.NewBufferString(`{"name": null}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestPatch_UnknownField() {
+
+	req, _ := http.NewRequest("PATCH", "/api/v1/products/product-123", bytes.NewBufferString(`{"colour": "red"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestPatch_ServiceError() {
+
+	suite.mockService.EXPECT().
+		Patch(gomock.Any(), "product-123", gomock.Any()).
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	req, _ := http.NewRequest("PATCH", "/api/v1/products/product-123", bytes.NewBufferString(`{"name": "Renamed"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchCreate_BestEffort() {
+
+	body := `{"mode": "best_effort", "items": [
+		{"name": "A", "sku": "A-1", "price": 1, "stock": 2, "categoryId": "category-123"},
+		{"name": "B", "sku": "B-1", "price": 3, "stock": 0, "categoryId": "missing"}
+	]}`
+
+	suite.mockService.EXPECT().
+		BatchCreate(gomock.Any(), gomock.Any(), entity.BatchBestEffort).
+		DoAndReturn(func(ctx interface{}, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error) {
+			suite.Len(products, 2)
+			suite.Equal("A-1", products[0].SKU)
+			suite.Equal(0, *products[1].Stock)
+			return []entity.BatchResult{
+				{Index: 0, ID: "product-1"},
+				{Index: 1, Err: apperr.ErrNotFound.WithMessage("category not found")},
+			}, nil
+		}).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products:batchCreate", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response batch.Response
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal(batch.Response{
+		Results: []batch.Result{
+			{Index: 0, ID: "product-1", Status: batch.StatusCreated},
+			{Index: 1, Status: batch.StatusFailed, ErrorCode: "NOT_FOUND", Message: "[NOT_FOUND] category not found"},
+		},
+		Succeeded: 1,
+		Failed:    1,
+	}, response)
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchCreate_AtomicFailure() {
+
+	failed := []entity.BatchItemError{{Index: 1, ErrorCode: "NOT_FOUND", Message: "[NOT_FOUND] data not found"}}
+
+	suite.mockService.EXPECT().
+		BatchCreate(gomock.Any(), gomock.Any(), entity.BatchAtomic).
+		Return(nil, apperr.ErrNotFound.WithMessage("batch not applied: 1 of 2 items failed").WithDetails(failed)).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products:batchCreate", bytes.NewBufferString(`{"items": [{"name": "A"}, {"name": "B"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal([]interface{}{map[string]interface{}{
+		"index":      float64(1),
+		"error_code": "NOT_FOUND",
+		"message":    "[NOT_FOUND] data not found",
+	}}, response["details"])
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchUpdate_Success() {
+
+	body := `{"items": [{"id": "product-1", "version": 3, "name": "A", "sku": "A-1", "price": 1, "stock": 2, "categoryId": "category-123"}]}`
+
+	suite.mockService.EXPECT().
+		BatchUpdate(gomock.Any(), gomock.Any(), entity.BatchAtomic).
+		DoAndReturn(func(ctx interface{}, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error) {
+			suite.Equal("product-1", products[0].ID)
+			suite.Equal(3, products[0].Version)
+			suite.Equal("A", products[0].Name)
+			return []entity.BatchResult{{Index: 0, ID: "product-1"}}, nil
+		}).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products:batchUpdate", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"results": [{"index": 0, "id": "product-1", "status": "updated"}], "succeeded": 1, "failed": 0}`, w.Body.String())
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchDelete_Success() {
+
+	suite.mockService.EXPECT().
+		BatchDelete(gomock.Any(), []entity.VersionedID{{ID: "product-1", Version: 2}, {ID: "product-2"}}, entity.BatchBestEffort).
+		Return([]entity.BatchResult{{Index: 0, ID: "product-1"}, {Index: 1, ID: "product-2"}}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products:batchDelete", bytes.NewBufferString(`{"mode": "best_effort", "items": [{"id": "product-1", "version": 2}, {"id": "product-2"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"succeeded":2`)
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchCreate_MissingItems() {
+
+	req, _ := http.NewRequest("POST", "/api/v1/products:batchCreate", bytes.NewBufferString(`{"mode": "atomic"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestListAll_ByIDs() {
+
+	suite.mockService.EXPECT().
+		GetByIDs(gomock.Any(), []string{"product-2", "product-1", "product-9"}).
+		Return([]entity.Product{{ID: "product-2"}, {ID: "product-1"}}, []string{"product-9"}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/?ids=product-2,product-1,%20product-9,", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.ProductBatchGetResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Len(response.Products, 2)
+	suite.Equal("product-2", response.Products[0].ID)
+	suite.Equal("product-1", response.Products[1].ID)
+	suite.Equal([]string{"product-9"}, response.Missing)
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchGet_Success() {
+
+	suite.mockService.EXPECT().
+		GetByIDs(gomock.Any(), []string{"product-1", "product-2"}).
+		Return([]entity.Product{{ID: "product-1", Category: &entity.Category{ID: "category-1", Name: "Phones"}}}, []string{"product-2"}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products:batchGet", bytes.NewBufferString(`{"ids": ["product-1", "product-2"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.ProductBatchGetResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("Phones", response.Products[0].Category.Name)
+	suite.Equal([]string{"product-2"}, response.Missing)
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchGet_MissingIDs() {
+
+	req, _ := http.NewRequest("POST", "/api/v1/products:batchGet", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetBySKU_Success() {
+
+	suite.mockService.EXPECT().
+		GetBySKU(gomock.Any(), "TEST-001").
+		Return(&entity.Product{ID: "product-1", SKU: "TEST-001", Version: 3}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/by-sku/TEST-001", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"3"`, w.Header().Get("ETag"))
+
+	var response dto.Product
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("product-1", response.ID)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetBySKU_NotFound() {
+
+	suite.mockService.EXPECT().
+		GetBySKU(gomock.Any(), "NOPE").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/by-sku/NOPE", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestUpsert_Created() {
+
+	body := `{"name": "A", "price": 1, "stock": 2, "categoryId": "category-123"}`
+
+	suite.mockService.EXPECT().
+		Upsert(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx interface{}, product entity.Product) (*entity.Product, bool, error) {
+			suite.Equal("A-1", product.SKU)
+			suite.Equal("A", product.Name)
+			return &entity.Product{ID: "product-1", SKU: "A-1", Version: 1}, true, nil
+		}).
+		Times(1)
+
+	req, _ := http.NewRequest("PUT", "/api/v1/products/by-sku/A-1", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal("/api/v1/products/product-1", w.Header().Get("Location"))
+	suite.Equal(`"1"`, w.Header().Get("ETag"))
+}
+
+func (suite *ProductHandlerTestSuite) TestUpsert_Updated() {
+
+	body := `{"name": "A", "price": 1, "stock": 2, "categoryId": "category-123"}`
+
+	suite.mockService.EXPECT().
+		Upsert(gomock.Any(), gomock.Any()).
+		Return(&entity.Product{ID: "product-1", SKU: "A-1", Version: 4}, false, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("PUT", "/api/v1/products/by-sku/A-1", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Empty(w.Header().Get("Location"))
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
+}
+
+func (suite *ProductHandlerTestSuite) TestUpsert_MissingField() {
+
+	req, _ := http.NewRequest("PUT", "/api/v1/products/by-sku/A-1", bytes.NewBufferString(`{"name": "A"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestUpsert_Conflict() {
+
+	body := `{"name": "A", "price": 1, "stock": 2, "categoryId": "category-123"}`
+
+	suite.mockService.EXPECT().
+		Upsert(gomock.Any(), gomock.Any()).
+		Return(nil, false, apperr.ErrConflict.WithMessage("sku is already taken by another product")).
+		Times(1)
+
+	req, _ := http.NewRequest("PUT", "/api/v1/products/by-sku/A-1", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchUpsert_Success() {
+
+	body := `{"mode": "best_effort", "items": [
+		{"name": "A", "sku": "A-1", "price": 1, "stock": 2, "categoryId": "category-123"},
+		{"name": "B", "sku": "B-1", "price": 1, "stock": 2, "categoryId": "category-123"},
+		{"name": "C", "sku": "C-1", "price": 1, "stock": 2, "categoryId": "category-123"}
+	]}`
+
+	suite.mockService.EXPECT().
+		BatchUpsert(gomock.Any(), gomock.Any(), entity.BatchBestEffort).
+		DoAndReturn(func(ctx interface{}, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error) {
+			suite.Len(products, 3)
+			suite.Equal("B-1", products[1].SKU)
+			return []entity.BatchResult{
+				{Index: 0, ID: "product-1", Created: true},
+				{Index: 1, ID: "product-2"},
+				{Index: 2, Err: apperr.ErrConflict.WithMessage("sku is already taken by another product")},
+			}, nil
+		}).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products:batchUpsert", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"results": [
+		{"index": 0, "id": "product-1", "status": "created"},
+		{"index": 1, "id": "product-2", "status": "updated"},
+		{"index": 2, "status": "failed", "error_code": "CONFLICT", "message": "[CONFLICT] sku is already taken by another product"}
+	], "succeeded": 2, "failed": 1}`, w.Body.String())
+}
+
+func (suite *ProductHandlerTestSuite) TestListTrash_Success() {
+
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	suite.mockService.EXPECT().
+		ListTrash(gomock.Any(), entity.Pagination{}).
+		Return([]entity.Product{{ID: "product-1", DeletedAt: &deletedAt}}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/trash", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"deletedAt":"2026-01-02T03:04:05Z"`)
+}
+
+func (suite *ProductHandlerTestSuite) TestRestore_WithoutBody() {
+
+	suite.mockService.EXPECT().
+		Restore(gomock.Any(), "product-1", "").
+		Return(&entity.Product{ID: "product-1", Version: 3}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products/product-1/restore", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"3"`, w.Header().Get("ETag"))
+}
+
+func (suite *ProductHandlerTestSuite) TestRestore_IntoAnotherCategory() {
+
+	suite.mockService.EXPECT().
+		Restore(gomock.Any(), "product-1", "category-2").
+		Return(&entity.Product{ID: "product-1", CategoryID: "category-2", Version: 3}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products/product-1/restore", bytes.NewBufferString(`{"categoryId": "category-2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestRestore_Conflict() {
+
+	suite.mockService.EXPECT().
+		Restore(gomock.Any(), "product-1", "").
+		Return(nil, apperr.ErrConflict.WithMessage("sku \"A-1\" is taken by product product-2")).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products/product-1/restore", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestPurge_Success() {
+
+	suite.mockService.EXPECT().
+		Purge(gomock.Any(), "product-1").
+		Return(nil).
+		Times(1)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/products/product-1/purge", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"status": "purged"}`, w.Body.String())
+}
+
+func (suite *ProductHandlerTestSuite) TestListRevisions_Success() {
+
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	suite.mockService.EXPECT().
+		ListRevisions(gomock.Any(), "product-1", entity.Pagination{Limit: 5, Offset: 5}).
+		Return([]entity.Revision{
+			{EntityID: "product-1", Number: 7, Action: entity.AuditActionUpdate, Actor: "alice", RequestID: "req-1", CreatedAt: createdAt},
+		}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/product-1/revisions?limit=5&offset=5", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response []dto.Revision
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal([]dto.Revision{{Revision: 7, Action: "update", Actor: "alice", RequestID: "req-1", CreatedAt: createdAt}}, response)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetRevision_Success() {
+
+	suite.mockService.EXPECT().
+		GetRevision(gomock.Any(), "product-1", 2).
+		Return(&entity.ProductRevision{
+			Revision: entity.Revision{EntityID: "product-1", Number: 2, Action: entity.AuditActionUpdate, Actor: "alice"},
+			Product: entity.Product{ID: "product-1", Name: "Old", Price: utils.SetPtr(5.0), Version: 2,
+				CategoryID: "category-1", Category: &entity.Category{ID: "category-1", Name: "Tools"}},
+		}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/product-1/revisions/2", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.ProductRevision
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(2, response.Revision.Revision)
+	suite.Equal("Old", response.Product.Name)
+	suite.Equal(5.0, response.Product.Price)
+	suite.Equal("Tools", response.Product.Category.Name)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetRevision_InvalidNumber() {
+
+	for _, rev := range []string{"0", "-1", "latest"} {
+		req, _ := http.NewRequest("GET", "/api/v1/products/product-1/revisions/"+rev, nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		suite.Equal(http.StatusBadRequest, w.Code, rev)
+	}
+}
+
+func (suite *ProductHandlerTestSuite) TestGetRevision_NotFound() {
+
+	suite.mockService.EXPECT().
+		GetRevision(gomock.Any(), "product-1", 9).
+		Return(nil, apperr.ErrNotFound.WithMessage("revision 9 not found")).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/product-1/revisions/9", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *ProductHandlerTestSuite) TestRevert_Success() {
+
+	suite.mockService.EXPECT().
+		Revert(gomock.Any(), "product-1", 2, 4).
+		Return(&entity.Product{ID: "product-1", Name: "Old", Version: 5}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products/product-1/revisions/2/revert", nil)
+	req.Header.Set("If-Match", `"4"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"5"`, w.Header().Get("ETag"))
+
+	var response dto.Product
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal("Old", response.Name)
+}
+
+func (suite *ProductHandlerTestSuite) TestRevert_Conflict() {
+
+	suite.mockService.EXPECT().
+		Revert(gomock.Any(), "product-1", 1, 0).
+		Return(nil, apperr.ErrConflict.WithMessage("category category-9 of revision 1 is deleted; restore it first")).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/products/product-1/revisions/1/revert", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
 func TestProductHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(ProductHandlerTestSuite))
 }
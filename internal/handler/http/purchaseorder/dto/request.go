@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// PurchaseOrderCreateRequest represents the request payload for creating a purchase order
+type PurchaseOrderCreateRequest struct {
+	SupplierID *string                    `json:"supplierId,omitempty"`
+	Notes      string                     `json:"notes"`
+	Lines      []PurchaseOrderLineRequest `json:"lines" binding:"required,min=1,dive"`
+} //	@name	PurchaseOrderCreateRequest
+
+// PurchaseOrderLineRequest represents a single line of a purchase order
+type PurchaseOrderLineRequest struct {
+	ProductID string  `json:"productId" binding:"required"`
+	Quantity  int     `json:"quantity" binding:"required,min=1"`
+	UnitCost  float64 `json:"unitCost" binding:"min=0"`
+} //	@name	PurchaseOrderLineRequest
+
+func (r PurchaseOrderCreateRequest) ToDomain() entity.PurchaseOrder {
+	lines := make([]entity.PurchaseOrderLine, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, entity.PurchaseOrderLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitCost:  line.UnitCost,
+		})
+	}
+
+	return entity.PurchaseOrder{
+		SupplierID: r.SupplierID,
+		Notes:      r.Notes,
+		Lines:      lines,
+	}
+}
+
+// PurchaseOrderReceiveRequest represents the request payload for receiving stock against a purchase order
+type PurchaseOrderReceiveRequest struct {
+	Lines []PurchaseOrderReceiptRequest `json:"lines" binding:"required,min=1,dive"`
+} //	@name	PurchaseOrderReceiveRequest
+
+// PurchaseOrderReceiptRequest represents the quantity received for one purchase order line
+type PurchaseOrderReceiptRequest struct {
+	LineID    string     `json:"lineId" binding:"required"`
+	Quantity  int        `json:"quantity" binding:"required,min=1"`
+	LotNumber string     `json:"lotNumber,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+} //	@name	PurchaseOrderReceiptRequest
+
+func (r PurchaseOrderReceiveRequest) ToDomain() []entity.PurchaseOrderReceipt {
+	receipts := make([]entity.PurchaseOrderReceipt, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		receipts = append(receipts, entity.PurchaseOrderReceipt{
+			LineID:    line.LineID,
+			Quantity:  line.Quantity,
+			LotNumber: line.LotNumber,
+			ExpiresAt: line.ExpiresAt,
+		})
+	}
+	return receipts
+}
+
+type FilterPurchaseOrdersRequest struct {
+	Status     *string `form:"status,omitempty"`
+	SupplierID *string `form:"supplierId,omitempty"`
+	Limit      int     `form:"limit"`
+	Offset     int     `form:"offset"`
+}
+
+func (r FilterPurchaseOrdersRequest) ToDomain() entity.PurchaseOrdersFilter {
+	var status *entity.PurchaseOrderStatus
+	if r.Status != nil {
+		value := entity.PurchaseOrderStatus(*r.Status)
+		status = &value
+	}
+
+	return entity.PurchaseOrdersFilter{
+		Status:     status,
+		SupplierID: r.SupplierID,
+		Pagination: entity.Pagination{
+			Limit:  r.Limit,
+			Offset: r.Offset,
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// PurchaseOrder represents the response payload for a purchase order
+type PurchaseOrder struct {
+	ID          string              `json:"id"`
+	SupplierID  *string             `json:"supplierId,omitempty"`
+	Status      string              `json:"status"`
+	Notes       string              `json:"notes"`
+	Lines       []PurchaseOrderLine `json:"lines"`
+	SubmittedAt *time.Time          `json:"submittedAt,omitempty"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt,omitempty"`
+} //	@name	PurchaseOrder
+
+// PurchaseOrderLine represents a line of a purchase order response
+type PurchaseOrderLine struct {
+	ID               string  `json:"id"`
+	ProductID        string  `json:"productId"`
+	ProductName      string  `json:"productName,omitempty"`
+	SKU              string  `json:"sku,omitempty"`
+	Quantity         int     `json:"quantity"`
+	ReceivedQuantity int     `json:"receivedQuantity"`
+	UnitCost         float64 `json:"unitCost"`
+} //	@name	PurchaseOrderLine
+
+func PurchaseOrderFromDomain(order *entity.PurchaseOrder) *PurchaseOrder {
+	if order == nil {
+		return nil
+	}
+
+	lines := make([]PurchaseOrderLine, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		item := PurchaseOrderLine{
+			ID:               line.ID,
+			ProductID:        line.ProductID,
+			Quantity:         line.Quantity,
+			ReceivedQuantity: line.ReceivedQuantity,
+			UnitCost:         line.UnitCost,
+		}
+		if line.Product != nil {
+			item.ProductName = line.Product.Name
+			item.SKU = line.Product.SKU
+		}
+		lines = append(lines, item)
+	}
+
+	return &PurchaseOrder{
+		ID:          order.ID,
+		SupplierID:  order.SupplierID,
+		Status:      string(order.Status),
+		Notes:       order.Notes,
+		Lines:       lines,
+		SubmittedAt: order.SubmittedAt,
+		CreatedAt:   order.CreatedAt,
+		UpdatedAt:   order.UpdatedAt,
+	}
+}
+
+func PurchaseOrdersFromDomain(orders []entity.PurchaseOrder) []PurchaseOrder {
+	result := make([]PurchaseOrder, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, *PurchaseOrderFromDomain(&order))
+	}
+
+	return result
+}
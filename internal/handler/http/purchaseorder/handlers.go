@@ -0,0 +1,201 @@
+package purchaseorder
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/purchaseorder/dto"
+	purchaseOrderSrv "github.com/sirawong/crud-arise/internal/services/purchaseorder"
+)
+
+type PurchaseOrderHandler struct {
+	purchaseOrderService purchaseOrderSrv.PurchaseOrderService
+}
+
+func NewPurchaseOrderHandler(purchaseOrderService purchaseOrderSrv.PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{purchaseOrderService: purchaseOrderService}
+}
+
+// Create godoc
+//
+//	@Summary		Create a purchase order
+//	@Description	Create a new purchase order in draft status
+//	@Tags			purchase-orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			order	body		dto.PurchaseOrderCreateRequest	true	"Purchase order information"
+//	@Success		201		{object}	map[string]interface{}			"{"id": "purchase_order_id"}"
+//	@Failure		400		{object}	map[string]interface{}			"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}			"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}			"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/purchase-orders [post]
+func (h PurchaseOrderHandler) Create(c *gin.Context) {
+	var req dto.PurchaseOrderCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	id, err := h.purchaseOrderService.Create(c, req.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// GetByID godoc
+//
+//	@Summary		Get a purchase order by ID
+//	@Description	Get a single purchase order with its lines
+//	@Tags			purchase-orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Purchase order ID"
+//	@Success		200	{object}	dto.PurchaseOrder		"Purchase order information"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/purchase-orders/{id} [get]
+func (h PurchaseOrderHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	order, err := h.purchaseOrderService.GetByID(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PurchaseOrderFromDomain(order))
+}
+
+// ListAll godoc
+//
+//	@Summary		Get all purchase orders
+//	@Description	Get a list of purchase orders, newest first
+//	@Tags			purchase-orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			status		query		string					false	"Filter by status"
+//	@Param			supplierId	query		string					false	"Filter by supplier ID"
+//	@Param			limit		query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset		query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200			{array}		dto.PurchaseOrder		"List of purchase orders"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error	description"}"
+//	@Router			/purchase-orders [get]
+func (h PurchaseOrderHandler) ListAll(c *gin.Context) {
+	var query dto.FilterPurchaseOrdersRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	orders, err := h.purchaseOrderService.GetAll(c, query.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PurchaseOrdersFromDomain(orders))
+}
+
+// Submit godoc
+//
+//	@Summary		Submit a purchase order
+//	@Description	Move a draft purchase order to submitted
+//	@Tags			purchase-orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Purchase order ID"
+//	@Success		200	{object}	map[string]interface{}	"{"status": "submitted"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/purchase-orders/{id}/submit [post]
+func (h PurchaseOrderHandler) Submit(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	err := h.purchaseOrderService.Submit(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "submitted"})
+}
+
+// Cancel godoc
+//
+//	@Summary		Cancel a purchase order
+//	@Description	Cancel a purchase order that is not yet fully received
+//	@Tags			purchase-orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Purchase order ID"
+//	@Success		200	{object}	map[string]interface{}	"{"status": "cancelled"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/purchase-orders/{id}/cancel [post]
+func (h PurchaseOrderHandler) Cancel(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	err := h.purchaseOrderService.Cancel(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "cancelled"})
+}
+
+// Receive godoc
+//
+//	@Summary		Receive stock against a purchase order
+//	@Description	Record received quantities per line and add them to product stock
+//	@Tags			purchase-orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Purchase order ID"
+//	@Param			receipt	body		dto.PurchaseOrderReceiveRequest	true	"Received quantities"
+//	@Success		200		{object}	dto.PurchaseOrder				"Updated purchase order"
+//	@Failure		400		{object}	map[string]interface{}			"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}			"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409		{object}	map[string]interface{}			"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}			"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/purchase-orders/{id}/receive [post]
+func (h PurchaseOrderHandler) Receive(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	var req dto.PurchaseOrderReceiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	order, err := h.purchaseOrderService.Receive(c, id, req.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PurchaseOrderFromDomain(order))
+}
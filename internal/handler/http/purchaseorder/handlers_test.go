@@ -0,0 +1,189 @@
+package purchaseorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/purchaseorder/dto"
+	"github.com/sirawong/crud-arise/internal/services/purchaseorder/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type PurchaseOrderHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockPurchaseOrderService
+	handler     *PurchaseOrderHandler
+	router      *gin.Engine
+}
+
+func (suite *PurchaseOrderHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockPurchaseOrderService(suite.mockCtrl)
+	suite.handler = NewPurchaseOrderHandler(suite.mockService)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	po := v1.Group("/purchase-orders")
+	{
+		po.POST("/", suite.handler.Create)
+		po.GET("/", suite.handler.ListAll)
+		po.GET("/:id", suite.handler.GetByID)
+		po.POST("/:id/submit", suite.handler.Submit)
+		po.POST("/:id/cancel", suite.handler.Cancel)
+		po.POST("/:id/receive", suite.handler.Receive)
+	}
+}
+
+func (suite *PurchaseOrderHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *PurchaseOrderHandlerTestSuite) TestCreate_Success() {
+
+	request := dto.PurchaseOrderCreateRequest{
+		Lines: []dto.PurchaseOrderLineRequest{
+			{ProductID: "product-1", Quantity: 10, UnitCost: 2.5},
+		},
+	}
+
+	suite.mockService.EXPECT().
+		Create(gomock.Any(), request.ToDomain()).
+		Return("po-123", nil).
+		Times(1)
+
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/api/v1/purchase-orders/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("po-123", response["id"])
+}
+
+func (suite *PurchaseOrderHandlerTestSuite) TestCreate_InvalidLine() {
+
+	invalidJSON := `{"lines": [{"productId": "product-1", "quantity": 0}]}`
+
+	req, _ := http.NewRequest("POST", "/api/v1/purchase-orders/", bytes.NewBufferString(invalidJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *PurchaseOrderHandlerTestSuite) TestGetByID_Success() {
+
+	order := &entity.PurchaseOrder{
+		ID:     "po-123",
+		Status: entity.PurchaseOrderStatusSubmitted,
+		Lines: []entity.PurchaseOrderLine{
+			{ID: "line-1", ProductID: "product-1", Quantity: 10, ReceivedQuantity: 4},
+		},
+	}
+
+	suite.mockService.EXPECT().
+		GetByID(gomock.Any(), "po-123").
+		Return(order, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/purchase-orders/po-123", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.PurchaseOrder
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("submitted", response.Status)
+	suite.Equal(4, response.Lines[0].ReceivedQuantity)
+}
+
+func (suite *PurchaseOrderHandlerTestSuite) TestSubmit_Conflict() {
+
+	suite.mockService.EXPECT().
+		Submit(gomock.Any(), "po-123").
+		Return(apperr.ErrConflict.WithMessage("cannot move purchase order from received to submitted")).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/purchase-orders/po-123/submit", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *PurchaseOrderHandlerTestSuite) TestCancel_Success() {
+
+	suite.mockService.EXPECT().
+		Cancel(gomock.Any(), "po-123").
+		Return(nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/purchase-orders/po-123/cancel", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *PurchaseOrderHandlerTestSuite) TestReceive_Success() {
+
+	request := dto.PurchaseOrderReceiveRequest{
+		Lines: []dto.PurchaseOrderReceiptRequest{{LineID: "line-1", Quantity: 4}},
+	}
+	order := &entity.PurchaseOrder{ID: "po-123", Status: entity.PurchaseOrderStatusPartiallyReceived}
+
+	suite.mockService.EXPECT().
+		Receive(gomock.Any(), "po-123", request.ToDomain()).
+		Return(order, nil).
+		Times(1)
+
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/api/v1/purchase-orders/po-123/receive", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.PurchaseOrder
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("partially_received", response.Status)
+}
+
+func (suite *PurchaseOrderHandlerTestSuite) TestReceive_OverReceiving() {
+
+	suite.mockService.EXPECT().
+		Receive(gomock.Any(), "po-123", gomock.Any()).
+		Return(nil, apperr.ErrInvalidArgument.WithMessage("line line-1: receiving 20 exceeds outstanding quantity 6")).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/purchase-orders/po-123/receive", bytes.NewBufferString(`{"lines": [{"lineId": "line-1", "quantity": 20}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func TestPurchaseOrderHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PurchaseOrderHandlerTestSuite))
+}
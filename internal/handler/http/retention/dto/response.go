@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// RetentionRun represents the outcome of one run of the retention job
+type RetentionRun struct {
+	StartedAt     time.Time       `json:"startedAt"`
+	FinishedAt    time.Time       `json:"finishedAt"`
+	DeletedBefore time.Time       `json:"deletedBefore"`
+	DryRun        bool            `json:"dryRun"`
+	Products      RetentionResult `json:"products"`
+	Categories    RetentionResult `json:"categories"`
+	Error         string          `json:"error,omitempty"`
+} //	@name	RetentionRun
+
+// RetentionResult represents the rows of one table a retention run purged, or would have purged
+// in a dry run, and the referenced ones it kept
+type RetentionResult struct {
+	Purged     int      `json:"purged"`
+	Skipped    int      `json:"skipped"`
+	PurgedIDs  []string `json:"purgedIds"`
+	SkippedIDs []string `json:"skippedIds"`
+} //	@name	RetentionResult
+
+func RetentionRunFromDomain(run *entity.RetentionRun) *RetentionRun {
+	if run == nil {
+		return nil
+	}
+
+	response := &RetentionRun{
+		StartedAt:     run.StartedAt,
+		FinishedAt:    run.FinishedAt,
+		DeletedBefore: run.DeletedBefore,
+		DryRun:        run.DryRun,
+		Products:      retentionResultFromDomain(run.Products),
+		Categories:    retentionResultFromDomain(run.Categories),
+	}
+	if run.Err != nil {
+		response.Error = run.Err.Error()
+	}
+	return response
+}
+
+func retentionResultFromDomain(result entity.RetentionResult) RetentionResult {
+	purged := result.Purged
+	if purged == nil {
+		purged = []string{}
+	}
+	skipped := result.Skipped
+	if skipped == nil {
+		skipped = []string{}
+	}
+
+	return RetentionResult{
+		Purged:     len(purged),
+		Skipped:    len(skipped),
+		PurgedIDs:  purged,
+		SkippedIDs: skipped,
+	}
+}
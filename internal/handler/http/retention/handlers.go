@@ -0,0 +1,38 @@
+package retention
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/retention/dto"
+	retentionSrv "github.com/sirawong/crud-arise/internal/services/retention"
+)
+
+type RetentionHandler struct {
+	retentionService retentionSrv.RetentionService
+}
+
+func NewRetentionHandler(retentionService retentionSrv.RetentionService) *RetentionHandler {
+	return &RetentionHandler{retentionService: retentionService}
+}
+
+// LastRun godoc
+//
+//	@Summary		Last retention run
+//	@Description	Outcome of the latest run of the job purging products and categories soft-deleted longer than the retention period
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	dto.RetentionRun		"Last retention run"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/admin/retention [get]
+func (h RetentionHandler) LastRun(c *gin.Context) {
+	run, err := h.retentionService.LastRun(c)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RetentionRunFromDomain(run))
+}
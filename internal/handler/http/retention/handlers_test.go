@@ -0,0 +1,91 @@
+package retention
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/retention/dto"
+	"github.com/sirawong/crud-arise/internal/services/retention/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type RetentionHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockRetentionService
+	handler     *RetentionHandler
+	router      *gin.Engine
+}
+
+func (suite *RetentionHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockRetentionService(suite.mockCtrl)
+	suite.handler = NewRetentionHandler(suite.mockService)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	v1.GET("/admin/retention", suite.handler.LastRun)
+}
+
+func (suite *RetentionHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *RetentionHandlerTestSuite) TestLastRun_Success() {
+	startedAt := time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)
+
+	suite.mockService.EXPECT().
+		LastRun(gomock.Any()).
+		Return(&entity.RetentionRun{
+			StartedAt:     startedAt,
+			FinishedAt:    startedAt.Add(time.Second),
+			DeletedBefore: startedAt.AddDate(0, 0, -30),
+			Products:      entity.RetentionResult{Purged: []string{"p1", "p2"}, Skipped: []string{"p3"}},
+			Err:           apperr.ErrInternal,
+		}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/retention", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response dto.RetentionRun
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal(startedAt, response.StartedAt)
+	suite.Equal(2, response.Products.Purged)
+	suite.Equal(1, response.Products.Skipped)
+	suite.Equal([]string{"p1", "p2"}, response.Products.PurgedIDs)
+	suite.Equal(0, response.Categories.Purged)
+	suite.Equal([]string{}, response.Categories.PurgedIDs)
+	suite.Equal(apperr.ErrInternal.Error(), response.Error)
+}
+
+func (suite *RetentionHandlerTestSuite) TestLastRun_NotRunYet() {
+
+	suite.mockService.EXPECT().
+		LastRun(gomock.Any()).
+		Return(nil, apperr.ErrNotFound.WithMessage("retention has not run yet")).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/retention", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func TestRetentionHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(RetentionHandlerTestSuite))
+}
@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// ReturnCreateRequest represents the request payload for authorizing a return
+type ReturnCreateRequest struct {
+	OrderID *string             `json:"orderId"`
+	Reason  string              `json:"reason"`
+	Lines   []ReturnLineRequest `json:"lines" binding:"required,min=1,dive"`
+} //	@name	ReturnCreateRequest
+
+// ReturnLineRequest represents returned units of one product sharing a disposition
+type ReturnLineRequest struct {
+	ProductID   string `json:"productId" binding:"required"`
+	Quantity    int    `json:"quantity" binding:"required,min=1"`
+	Disposition string `json:"disposition" binding:"required,oneof=restock refurbish scrap"`
+} //	@name	ReturnLineRequest
+
+func (r ReturnCreateRequest) ToDomain() entity.ReturnAuthorization {
+	lines := make([]entity.ReturnLine, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, entity.ReturnLine{
+			ProductID:   line.ProductID,
+			Quantity:    line.Quantity,
+			Disposition: entity.ReturnDisposition(line.Disposition),
+		})
+	}
+
+	return entity.ReturnAuthorization{
+		OrderID: r.OrderID,
+		Reason:  r.Reason,
+		Lines:   lines,
+	}
+}
+
+type FilterReturnsRequest struct {
+	Status    *string `form:"status,omitempty"`
+	OrderID   *string `form:"orderId,omitempty"`
+	ProductID *string `form:"productId,omitempty"`
+	Limit     int     `form:"limit"`
+	Offset    int     `form:"offset"`
+}
+
+func (r FilterReturnsRequest) ToDomain() entity.ReturnsFilter {
+	var status *entity.ReturnStatus
+	if r.Status != nil {
+		value := entity.ReturnStatus(*r.Status)
+		status = &value
+	}
+
+	return entity.ReturnsFilter{
+		Status:    status,
+		OrderID:   r.OrderID,
+		ProductID: r.ProductID,
+		Pagination: entity.Pagination{
+			Limit:  r.Limit,
+			Offset: r.Offset,
+		},
+	}
+}
+
+type ReturnRateRequest struct {
+	GroupBy string     `form:"groupBy"`
+	From    *time.Time `form:"from" time_format:"2006-01-02"`
+	To      *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+func (r ReturnRateRequest) ToDomain() entity.ReturnRateFilter {
+	return entity.ReturnRateFilter{
+		GroupBy: entity.ReturnRateGroup(r.GroupBy),
+		From:    r.From,
+		To:      r.To,
+	}
+}
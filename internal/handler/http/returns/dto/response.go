@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// Return represents the response payload for a return authorization
+type Return struct {
+	ID         string       `json:"id"`
+	OrderID    *string      `json:"orderId,omitempty"`
+	Status     string       `json:"status"`
+	Reason     string       `json:"reason,omitempty"`
+	Lines      []ReturnLine `json:"lines"`
+	ReceivedAt *time.Time   `json:"receivedAt,omitempty"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	UpdatedAt  time.Time    `json:"updatedAt,omitempty"`
+} //	@name	Return
+
+// ReturnLine represents returned units of one product and what happens to them
+type ReturnLine struct {
+	ID          string `json:"id"`
+	ProductID   string `json:"productId"`
+	Quantity    int    `json:"quantity"`
+	Disposition string `json:"disposition"`
+} //	@name	ReturnLine
+
+// ReturnRate represents returned against sold units for one product or category
+type ReturnRate struct {
+	ID               string  `json:"id"`
+	Name             string  `json:"name"`
+	SoldQuantity     int     `json:"soldQuantity"`
+	ReturnedQuantity int     `json:"returnedQuantity"`
+	ReturnRate       float64 `json:"returnRate"`
+} //	@name	ReturnRate
+
+func ReturnFromDomain(rma *entity.ReturnAuthorization) *Return {
+	if rma == nil {
+		return nil
+	}
+
+	lines := make([]ReturnLine, 0, len(rma.Lines))
+	for _, line := range rma.Lines {
+		lines = append(lines, ReturnLine{
+			ID:          line.ID,
+			ProductID:   line.ProductID,
+			Quantity:    line.Quantity,
+			Disposition: string(line.Disposition),
+		})
+	}
+
+	return &Return{
+		ID:         rma.ID,
+		OrderID:    rma.OrderID,
+		Status:     string(rma.Status),
+		Reason:     rma.Reason,
+		Lines:      lines,
+		ReceivedAt: rma.ReceivedAt,
+		CreatedAt:  rma.CreatedAt,
+		UpdatedAt:  rma.UpdatedAt,
+	}
+}
+
+func ReturnsFromDomain(rmas []entity.ReturnAuthorization) []Return {
+	result := make([]Return, 0, len(rmas))
+	for _, rma := range rmas {
+		result = append(result, *ReturnFromDomain(&rma))
+	}
+
+	return result
+}
+
+func ReturnRatesFromDomain(rates []entity.ReturnRate) []ReturnRate {
+	result := make([]ReturnRate, 0, len(rates))
+	for _, rate := range rates {
+		result = append(result, ReturnRate{
+			ID:               rate.ID,
+			Name:             rate.Name,
+			SoldQuantity:     rate.SoldQuantity,
+			ReturnedQuantity: rate.ReturnedQuantity,
+			ReturnRate:       rate.Rate,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,187 @@
+package returns
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/returns/dto"
+	returnSrv "github.com/sirawong/crud-arise/internal/services/returns"
+)
+
+type ReturnHandler struct {
+	returnService returnSrv.ReturnService
+}
+
+func NewReturnHandler(returnService returnSrv.ReturnService) *ReturnHandler {
+	return &ReturnHandler{returnService: returnService}
+}
+
+// Create godoc
+//
+//	@Summary		Authorize a return
+//	@Description	Create a return authorization (RMA) with a disposition for every returned unit
+//	@Tags			returns
+//	@Accept			json
+//	@Produce		json
+//	@Param			return	body		dto.ReturnCreateRequest	true	"Returned products"
+//	@Success		201		{object}	dto.Return				"Created return"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409		{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/returns [post]
+func (h ReturnHandler) Create(c *gin.Context) {
+	var req dto.ReturnCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	rma, err := h.returnService.Create(c, req.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ReturnFromDomain(rma))
+}
+
+// GetByID godoc
+//
+//	@Summary		Get a return by ID
+//	@Tags			returns
+//	@Produce		json
+//	@Param			id	path		string					true	"Return ID"
+//	@Success		200	{object}	dto.Return				"Return information"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/returns/{id} [get]
+func (h ReturnHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	rma, err := h.returnService.GetByID(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReturnFromDomain(rma))
+}
+
+// ListAll godoc
+//
+//	@Summary		Get all returns
+//	@Description	Get a list of returns, newest first
+//	@Tags			returns
+//	@Produce		json
+//	@Param			status		query		string					false	"Filter by status"
+//	@Param			orderId		query		string					false	"Filter by order ID"
+//	@Param			productId	query		string					false	"Filter by returned product ID"
+//	@Param			limit		query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset		query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200			{array}		dto.Return				"List of returns"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error	description"}"
+//	@Router			/returns [get]
+func (h ReturnHandler) ListAll(c *gin.Context) {
+	var query dto.FilterReturnsRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	rmas, err := h.returnService.GetAll(c, query.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReturnsFromDomain(rmas))
+}
+
+// Receive godoc
+//
+//	@Summary		Receive a return
+//	@Description	Mark returned goods as arrived; restock lines are added back to stock
+//	@Tags			returns
+//	@Produce		json
+//	@Param			id	path		string					true	"Return ID"
+//	@Success		200	{object}	dto.Return				"Received return"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Router			/returns/{id}/receive [post]
+func (h ReturnHandler) Receive(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	rma, err := h.returnService.Receive(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReturnFromDomain(rma))
+}
+
+// Cancel godoc
+//
+//	@Summary		Cancel a return
+//	@Tags			returns
+//	@Produce		json
+//	@Param			id	path		string					true	"Return ID"
+//	@Success		200	{object}	map[string]interface{}	"{"status": "cancelled"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409	{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Router			/returns/{id}/cancel [post]
+func (h ReturnHandler) Cancel(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	err := h.returnService.Cancel(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "cancelled"})
+}
+
+// Report godoc
+//
+//	@Summary		Return rate report
+//	@Description	Received returns against units sold on paid or shipped orders, per product or per category
+//	@Tags			returns
+//	@Produce		json
+//	@Param			groupBy	query		string					false	"product (default) or category"
+//	@Param			from	query		string					false	"Start date (YYYY-MM-DD, inclusive)"
+//	@Param			to		query		string					false	"End date (YYYY-MM-DD, exclusive)"
+//	@Success		200		{array}		dto.ReturnRate			"Return rates"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/returns/report [get]
+func (h ReturnHandler) Report(c *gin.Context) {
+	var query dto.ReturnRateRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	rates, err := h.returnService.ReturnRates(c, query.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReturnRatesFromDomain(rates))
+}
@@ -0,0 +1,176 @@
+package returns
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/returns/dto"
+	"github.com/sirawong/crud-arise/internal/services/returns/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReturnHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockReturnService
+	handler     *ReturnHandler
+	router      *gin.Engine
+}
+
+func (suite *ReturnHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockReturnService(suite.mockCtrl)
+	suite.handler = NewReturnHandler(suite.mockService)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	rma := v1.Group("/returns")
+	{
+		rma.POST("/", suite.handler.Create)
+		rma.GET("/", suite.handler.ListAll)
+		rma.GET("/report", suite.handler.Report)
+		rma.GET("/:id", suite.handler.GetByID)
+		rma.POST("/:id/receive", suite.handler.Receive)
+		rma.POST("/:id/cancel", suite.handler.Cancel)
+	}
+}
+
+func (suite *ReturnHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *ReturnHandlerTestSuite) TestCreate_Success() {
+
+	request := dto.ReturnCreateRequest{
+		Reason: "wrong size",
+		Lines:  []dto.ReturnLineRequest{{ProductID: "product-1", Quantity: 1, Disposition: "restock"}},
+	}
+
+	suite.mockService.EXPECT().
+		Create(gomock.Any(), request.ToDomain()).
+		Return(&entity.ReturnAuthorization{
+			ID:     "rma-123",
+			Status: entity.ReturnStatusAuthorized,
+			Lines:  []entity.ReturnLine{{ProductID: "product-1", Quantity: 1, Disposition: entity.ReturnDispositionRestock}},
+		}, nil).
+		Times(1)
+
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/api/v1/returns/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+
+	var response dto.Return
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("rma-123", response.ID)
+	suite.Equal("authorized", response.Status)
+	suite.Equal("restock", response.Lines[0].Disposition)
+}
+
+func (suite *ReturnHandlerTestSuite) TestCreate_InvalidDisposition() {
+
+	invalidJSON := `{"lines": [{"productId": "product-1", "quantity": 1, "disposition": "resell"}]}`
+
+	req, _ := http.NewRequest("POST", "/api/v1/returns/", bytes.NewBufferString(invalidJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *ReturnHandlerTestSuite) TestGetByID_NotFound() {
+
+	suite.mockService.EXPECT().
+		GetByID(gomock.Any(), "missing").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/returns/missing", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *ReturnHandlerTestSuite) TestReceive_Conflict() {
+
+	suite.mockService.EXPECT().
+		Receive(gomock.Any(), "rma-123").
+		Return(nil, apperr.ErrConflict).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/returns/rma-123/receive", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *ReturnHandlerTestSuite) TestCancel_Success() {
+
+	suite.mockService.EXPECT().
+		Cancel(gomock.Any(), "rma-123").
+		Return(nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/returns/rma-123/cancel", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *ReturnHandlerTestSuite) TestReport_ByCategory() {
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.mockService.EXPECT().
+		ReturnRates(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, filter entity.ReturnRateFilter) ([]entity.ReturnRate, error) {
+			suite.Equal(entity.ReturnRateByCategory, filter.GroupBy)
+			suite.Require().NotNil(filter.From)
+			suite.True(from.Equal(*filter.From))
+			suite.Nil(filter.To)
+			return []entity.ReturnRate{{ID: "category-1", Name: "Shoes", SoldQuantity: 20, ReturnedQuantity: 5, Rate: 0.25}}, nil
+		}).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/returns/report?groupBy=category&from=2026-01-01", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response []dto.ReturnRate
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Len(response, 1)
+	suite.Equal(0.25, response[0].ReturnRate)
+}
+
+func (suite *ReturnHandlerTestSuite) TestReport_InvalidDate() {
+
+	req, _ := http.NewRequest("GET", "/api/v1/returns/report?from=yesterday", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func TestReturnHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReturnHandlerTestSuite))
+}
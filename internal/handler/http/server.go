@@ -6,8 +6,24 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirawong/crud-arise/internal/handler/http/audit"
 	"github.com/sirawong/crud-arise/internal/handler/http/category"
+	"github.com/sirawong/crud-arise/internal/handler/http/change"
+	"github.com/sirawong/crud-arise/internal/handler/http/custommethod"
+	"github.com/sirawong/crud-arise/internal/handler/http/idempotency"
+	"github.com/sirawong/crud-arise/internal/handler/http/identity"
+	"github.com/sirawong/crud-arise/internal/handler/http/job"
+	"github.com/sirawong/crud-arise/internal/handler/http/lot"
+	"github.com/sirawong/crud-arise/internal/handler/http/order"
+	"github.com/sirawong/crud-arise/internal/handler/http/precondition"
 	"github.com/sirawong/crud-arise/internal/handler/http/product"
+	"github.com/sirawong/crud-arise/internal/handler/http/purchaseorder"
+	"github.com/sirawong/crud-arise/internal/handler/http/retention"
+	"github.com/sirawong/crud-arise/internal/handler/http/returns"
+	"github.com/sirawong/crud-arise/internal/handler/http/stock"
+	"github.com/sirawong/crud-arise/internal/handler/http/stream"
+	"github.com/sirawong/crud-arise/internal/handler/http/supplier"
+	"github.com/sirawong/crud-arise/internal/handler/http/webhook"
 	"github.com/sirawong/crud-arise/pkg/config"
 
 	swaggerFiles "github.com/swaggo/files"
@@ -18,27 +34,160 @@ type HttpServer struct {
 	*gin.Engine
 }
 
-func NewRouter(productHandler *product.ProductHandler, categoryHandler *category.CategoryHandler) *HttpServer {
+func NewRouter(
+	cfg *config.Config,
+	productHandler *product.ProductHandler,
+	categoryHandler *category.CategoryHandler,
+	lotHandler *lot.LotHandler,
+	supplierHandler *supplier.SupplierHandler,
+	purchaseOrderHandler *purchaseorder.PurchaseOrderHandler,
+	orderHandler *order.OrderHandler,
+	returnHandler *returns.ReturnHandler,
+	idempotencyHandler *idempotency.IdempotencyHandler,
+	retentionHandler *retention.RetentionHandler,
+	auditHandler *audit.AuditHandler,
+	changeHandler *change.ChangeHandler,
+	webhookHandler *webhook.WebhookHandler,
+	streamHandler *stream.StreamHandler,
+	stockHandler *stock.StockHandler,
+	jobHandler *job.JobHandler,
+) *HttpServer {
 	router := gin.New()
+	// Let values on the request context, such as the actor set by identity.Attach, reach the
+	// services through the gin.Context they are given.
+	router.ContextWithFallback = true
 	router.Use(gin.Recovery())
 
+	ifMatch := precondition.RequireIfMatch(cfg.RequireIfMatch)
+	cacheControl := precondition.CacheControl(cfg.CacheControl)
+
 	v1 := router.Group("/api/v1")
+	v1.Use(identity.Attach, idempotencyHandler.Handle)
 	{
 		prd := v1.Group("/products")
 		{
 			prd.POST("/", productHandler.Create)
-			prd.GET("/", productHandler.ListAll)
-			prd.GET("/:id", productHandler.GetByID)
-			prd.PUT("/:id", productHandler.Update)
-			prd.DELETE("/:id", productHandler.Delete)
+			prd.GET("/", cacheControl, productHandler.ListAll)
+			prd.GET("/:id", cacheControl, productHandler.GetByID)
+			prd.GET("/by-sku/:sku", cacheControl, productHandler.GetBySKU)
+			prd.GET("/trash", productHandler.ListTrash)
+			prd.PUT("/:id", ifMatch, productHandler.Update)
+			prd.PUT("/by-sku/:sku", productHandler.Upsert)
+			prd.PATCH("/:id", ifMatch, productHandler.Patch)
+			prd.DELETE("/:id", ifMatch, productHandler.Delete)
+			prd.POST("/:id/restore", productHandler.Restore)
+			prd.DELETE("/:id/purge", productHandler.Purge)
+			prd.GET("/:id/revisions", productHandler.ListRevisions)
+			prd.GET("/:id/revisions/:rev", productHandler.GetRevision)
+			prd.POST("/:id/revisions/:rev/revert", ifMatch, productHandler.Revert)
+
+			prd.POST("/:id/lots", lotHandler.Receive)
+			prd.GET("/:id/lots", lotHandler.ListByProduct)
+			prd.POST("/:id/lots/consume", lotHandler.Consume)
+
+			prd.GET("/:id/suppliers", supplierHandler.ListProductSuppliers)
+			prd.PUT("/:id/suppliers/:supplierId", supplierHandler.LinkProduct)
+			prd.DELETE("/:id/suppliers/:supplierId", supplierHandler.UnlinkProduct)
 		}
+		v1.POST("/products:method", custommethod.Dispatch(map[string]gin.HandlerFunc{
+			"batchGet":    productHandler.BatchGet,
+			"batchCreate": productHandler.BatchCreate,
+			"batchUpdate": productHandler.BatchUpdate,
+			"batchDelete": productHandler.BatchDelete,
+			"batchUpsert": productHandler.BatchUpsert,
+
+			"import":       jobHandler.ImportProducts,
+			"export":       jobHandler.ExportProducts,
+			"changePrices": jobHandler.ChangePrices,
+		}))
+
 		cate := v1.Group("/categories")
 		{
 			cate.POST("/", categoryHandler.Create)
-			cate.GET("/", categoryHandler.ListAll)
-			cate.GET("/:id", categoryHandler.GetByID)
-			cate.PUT("/:id", categoryHandler.Update)
-			cate.DELETE("/:id", categoryHandler.Delete)
+			cate.GET("/", cacheControl, categoryHandler.ListAll)
+			cate.GET("/:id", cacheControl, categoryHandler.GetByID)
+			cate.GET("/trash", categoryHandler.ListTrash)
+			cate.PUT("/:id", ifMatch, categoryHandler.Update)
+			cate.PATCH("/:id", ifMatch, categoryHandler.Patch)
+			cate.DELETE("/:id", ifMatch, categoryHandler.Delete)
+			cate.POST("/:id/restore", categoryHandler.Restore)
+			cate.DELETE("/:id/purge", categoryHandler.Purge)
+			cate.GET("/:id/revisions", categoryHandler.ListRevisions)
+			cate.GET("/:id/revisions/:rev", categoryHandler.GetRevision)
+			cate.POST("/:id/revisions/:rev/revert", ifMatch, categoryHandler.Revert)
+		}
+		v1.POST("/categories:method", custommethod.Dispatch(map[string]gin.HandlerFunc{
+			"batchCreate": categoryHandler.BatchCreate,
+			"batchUpdate": categoryHandler.BatchUpdate,
+			"batchDelete": categoryHandler.BatchDelete,
+		}))
+
+		sup := v1.Group("/suppliers")
+		{
+			sup.POST("/", supplierHandler.Create)
+			sup.GET("/", supplierHandler.ListAll)
+			sup.GET("/:id", supplierHandler.GetByID)
+			sup.PUT("/:id", supplierHandler.Update)
+			sup.DELETE("/:id", supplierHandler.Delete)
+			sup.GET("/:id/products", supplierHandler.ListProducts)
+		}
+		po := v1.Group("/purchase-orders")
+		{
+			po.POST("/", purchaseOrderHandler.Create)
+			po.GET("/", purchaseOrderHandler.ListAll)
+			po.GET("/:id", purchaseOrderHandler.GetByID)
+			po.POST("/:id/submit", purchaseOrderHandler.Submit)
+			po.POST("/:id/cancel", purchaseOrderHandler.Cancel)
+			po.POST("/:id/receive", purchaseOrderHandler.Receive)
+		}
+		ord := v1.Group("/orders")
+		{
+			ord.POST("/", orderHandler.Place)
+			ord.GET("/", orderHandler.ListAll)
+			ord.GET("/:id", orderHandler.GetByID)
+			ord.POST("/:id/pay", orderHandler.Pay)
+			ord.POST("/:id/ship", orderHandler.Ship)
+			ord.POST("/:id/cancel", orderHandler.Cancel)
+		}
+		rma := v1.Group("/returns")
+		{
+			rma.POST("/", returnHandler.Create)
+			rma.GET("/", returnHandler.ListAll)
+			rma.GET("/report", returnHandler.Report)
+			rma.GET("/:id", returnHandler.GetByID)
+			rma.POST("/:id/receive", returnHandler.Receive)
+			rma.POST("/:id/cancel", returnHandler.Cancel)
+		}
+		lots := v1.Group("/lots")
+		{
+			lots.GET("/expiring", lotHandler.ListExpiring)
+		}
+		v1.GET("/audit", auditHandler.ListAll)
+		v1.GET("/changes", changeHandler.ListSince)
+		v1.GET("/stream/products", streamHandler.Products)
+		v1.GET("/ws/stock", stockHandler.Connect)
+		hooks := v1.Group("/webhooks")
+		{
+			hooks.POST("/", webhookHandler.Create)
+			hooks.GET("/", webhookHandler.ListAll)
+			hooks.GET("/:id", webhookHandler.GetByID)
+			hooks.PUT("/:id", webhookHandler.Update)
+			hooks.DELETE("/:id", webhookHandler.Delete)
+			hooks.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+			hooks.GET("/:id/deliveries/:deliveryId", webhookHandler.GetDelivery)
+			hooks.POST("/:id/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+		}
+
+		jobs := v1.Group("/jobs")
+		{
+			jobs.GET("/:id", jobHandler.GetByID)
+			jobs.POST("/:id/cancel", jobHandler.Cancel)
+			jobs.GET("/:id/pages/:page", jobHandler.GetExportPage)
+		}
+
+		admin := v1.Group("/admin")
+		{
+			admin.GET("/retention", retentionHandler.LastRun)
 		}
 	}
 
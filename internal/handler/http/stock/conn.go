@@ -0,0 +1,63 @@
+package stock
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// pingCodec sends a ping frame. Clients answer with a pong, which the websocket package reads
+// and drops without handing it on; liveConn is what notices it arrived.
+var pingCodec = websocket.Codec{
+	Marshal: func(interface{}) ([]byte, byte, error) {
+		return nil, websocket.PingFrame, nil
+	},
+}
+
+// liveConn pushes the read deadline back each time it is read from, so reading fails once the
+// client has sent nothing at all, pongs included, for timeout.
+type liveConn struct {
+	net.Conn
+	r       io.Reader
+	timeout time.Duration
+}
+
+func (c *liveConn) Read(p []byte) (int, error) {
+	if err := c.Conn.SetReadDeadline(time.Now().Add(c.timeout)); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+// liveWriter hands the websocket server a liveConn when it takes over the connection.
+type liveWriter struct {
+	http.ResponseWriter
+	timeout time.Duration
+}
+
+func (w liveWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := w.ResponseWriter.(http.Hijacker).Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The client may have sent frames right behind the upgrade request, which are already
+	// buffered.
+	pending, err := buf.Reader.Peek(buf.Reader.Buffered())
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+
+	live := &liveConn{
+		Conn:    conn,
+		r:       io.MultiReader(bytes.NewReader(bytes.Clone(pending)), conn),
+		timeout: w.timeout,
+	}
+	return live, bufio.NewReadWriter(bufio.NewReader(live), bufio.NewWriter(live)), nil
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin/binding"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+// Commands a client sends over the stock channel.
+const (
+	CommandSubscribe   = "subscribe"
+	CommandUnsubscribe = "unsubscribe"
+	CommandReserve     = "reserve"
+	CommandRelease     = "release"
+)
+
+// Command is a message from the client. ID is chosen by the client and echoed on the reply, so
+// replies can be matched to commands while stock updates arrive in between.
+type Command struct {
+	Type          string   `json:"type" binding:"required,oneof=subscribe unsubscribe reserve release"`
+	ID            string   `json:"id" binding:"max=100"`
+	ProductIDs    []string `json:"productIds" binding:"required_if=Type subscribe,required_if=Type unsubscribe,omitempty,dive,uuid"`
+	ProductID     string   `json:"productId" binding:"required_if=Type reserve,omitempty,uuid"`
+	Quantity      int      `json:"quantity" binding:"required_if=Type reserve,omitempty,gt=0"`
+	ReservationID string   `json:"reservationId" binding:"required_if=Type release,omitempty,uuid"`
+}
+
+// ParseCommand decodes and validates a message from the client. The ID is kept as far as the
+// message could be read, so that an invalid command can still be answered.
+func ParseCommand(data []byte) (Command, error) {
+	var command Command
+	if err := json.Unmarshal(data, &command); err != nil {
+		return command, apperr.ErrInvalidArgument.WithMessage("message is not a JSON command")
+	}
+	if err := binding.Validator.ValidateStruct(command); err != nil {
+		return command, apperr.ErrInvalidArgument.Wrap(err)
+	}
+	return command, nil
+}
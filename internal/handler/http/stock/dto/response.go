@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/pkg/utils"
+)
+
+// Types of the messages the server sends over the stock channel.
+const (
+	MessageSnapshot     = "snapshot"
+	MessageUnsubscribed = "unsubscribed"
+	MessageDelta        = "delta"
+	MessageRemoved      = "removed"
+	MessageReserved     = "reserved"
+	MessageReleased     = "released"
+	MessageError        = "error"
+)
+
+// StockLevel represents the stock of a product
+type StockLevel struct {
+	ProductID string `json:"productId"`
+	Stock     int    `json:"stock"`
+} //	@name	StockLevel
+
+// Snapshot answers a subscribe command with the current stock of the products subscribed to.
+// NotFound lists the requested products that do not exist, which are not subscribed to.
+type Snapshot struct {
+	Type     string       `json:"type"`
+	ID       string       `json:"id,omitempty"`
+	Levels   []StockLevel `json:"levels"`
+	NotFound []string     `json:"notFound,omitempty"`
+} //	@name	StockSnapshot
+
+// Unsubscribed answers an unsubscribe command
+type Unsubscribed struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id,omitempty"`
+	ProductIDs []string `json:"productIds"`
+} //	@name	StockUnsubscribed
+
+// Delta announces a new stock level of a subscribed product. Delta is the difference from the
+// level last sent, missing when none was.
+type Delta struct {
+	Type      string `json:"type"`
+	ProductID string `json:"productId"`
+	Stock     int    `json:"stock"`
+	Delta     *int   `json:"delta,omitempty"`
+} //	@name	StockDelta
+
+// Removed announces that a subscribed product was deleted. The subscription is kept, so the
+// product's stock is sent again should it be restored.
+type Removed struct {
+	Type      string `json:"type"`
+	ProductID string `json:"productId"`
+} //	@name	StockRemoved
+
+// ReservationReply answers a reserve or release command
+type ReservationReply struct {
+	Type        string      `json:"type"`
+	ID          string      `json:"id,omitempty"`
+	Reservation Reservation `json:"reservation"`
+} //	@name	StockReservationReply
+
+// Reservation represents a stock reservation
+type Reservation struct {
+	ID         string     `json:"id"`
+	ProductID  string     `json:"productId"`
+	Quantity   int        `json:"quantity"`
+	Status     string     `json:"status"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ReleasedAt *time.Time `json:"releasedAt,omitempty"`
+} //	@name	StockReservation
+
+// Error answers a command that failed, or tells why the server is closing the connection. Its
+// fields other than ID match the body of an HTTP error response.
+type Error struct {
+	Type      string      `json:"type"`
+	ID        string      `json:"id,omitempty"`
+	ErrorCode string      `json:"error_code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+} //	@name	StockError
+
+func SnapshotFromDomain(id string, products []entity.Product, notFound []string) Snapshot {
+	levels := make([]StockLevel, 0, len(products))
+	for _, product := range products {
+		levels = append(levels, StockLevel{ProductID: product.ID, Stock: utils.GetValue(product.Stock)})
+	}
+	return Snapshot{Type: MessageSnapshot, ID: id, Levels: levels, NotFound: notFound}
+}
+
+func ReservationReplyFromDomain(messageType, id string, reservation *entity.Reservation) ReservationReply {
+	return ReservationReply{
+		Type: messageType,
+		ID:   id,
+		Reservation: Reservation{
+			ID:         reservation.ID,
+			ProductID:  reservation.ProductID,
+			Quantity:   reservation.Quantity,
+			Status:     string(reservation.Status),
+			ExpiresAt:  reservation.ExpiresAt,
+			CreatedAt:  reservation.CreatedAt,
+			ReleasedAt: reservation.ReleasedAt,
+		},
+	}
+}
+
+func ErrorFromDomain(id string, err error) Error {
+	return Error{
+		Type:      MessageError,
+		ID:        id,
+		ErrorCode: apperr.GetCode(err),
+		Message:   err.Error(),
+		Details:   apperr.GetDetails(err),
+	}
+}
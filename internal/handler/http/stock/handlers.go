@@ -0,0 +1,139 @@
+package stock
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/services/product"
+	"github.com/sirawong/crud-arise/internal/services/reservation"
+	streamSrv "github.com/sirawong/crud-arise/internal/services/stream"
+	"golang.org/x/net/websocket"
+)
+
+// maxMessageBytes caps a message from the client; a larger one closes the connection.
+const maxMessageBytes = 64 << 10
+
+// Config limits and paces the connections of the stock channel.
+type Config struct {
+	// Tokens are the bearer tokens a client may connect with. No client can connect while there
+	// are none.
+	Tokens         []string
+	MaxConnections int
+	// MaxSubscriptions caps the products a connection is subscribed to at a time.
+	MaxSubscriptions int
+	// PingInterval is how often the client is pinged. A client that has sent nothing, not even
+	// a pong, for PingInterval and PongTimeout together is disconnected.
+	PingInterval time.Duration
+	PongTimeout  time.Duration
+}
+
+type StockHandler struct {
+	productService     product.ProductService
+	reservationService reservation.ReservationService
+	streamService      streamSrv.StreamService
+	config             Config
+	connections        atomic.Int64
+}
+
+func NewStockHandler(
+	productService product.ProductService,
+	reservationService reservation.ReservationService,
+	streamService streamSrv.StreamService,
+	config Config,
+) *StockHandler {
+	return &StockHandler{
+		productService:     productService,
+		reservationService: reservationService,
+		streamService:      streamService,
+		config:             config,
+	}
+}
+
+// Connect godoc
+//
+//	@Summary		Real-time stock channel
+//	@Description	Upgrades to a WebSocket carrying JSON messages. The client sends commands, each with an optional id echoed on its reply: subscribe and unsubscribe with productIds, reserve with productId and quantity, and release with reservationId. A subscribe is answered by a snapshot of the current stock; after that a delta is sent whenever the stock of a subscribed product changes and removed when it is deleted. reserve and release are answered by reserved and released, and a failed command by error. The server pings the client and disconnects it when it stops answering.
+//	@Tags			stock
+//	@Param			Authorization	header	string	false	"Bearer token"
+//	@Param			access_token	query	string	false	"Bearer token for clients that cannot set headers"
+//	@Success		101				"Switching Protocols"
+//	@Failure		401				{object}	map[string]interface{}	"{"error_code": "UNAUTHENTICATED", "message": "error	description"}"
+//	@Failure		503				{object}	map[string]interface{}	"{"error_code": "UNAVAILABLE", "message": "error		description"}"
+//	@Router			/ws/stock [get]
+func (h *StockHandler) Connect(c *gin.Context) {
+	principal, ok := h.authenticate(c)
+	if !ok {
+		c.Header("WWW-Authenticate", "Bearer")
+		handlererr.RespondWithError(c, apperr.ErrUnauthenticated)
+		return
+	}
+
+	if h.connections.Add(1) > int64(h.config.MaxConnections) {
+		h.connections.Add(-1)
+		handlererr.RespondWithError(c, apperr.ErrUnavailable.WithMessage("too many stock channel connections"))
+		return
+	}
+	defer h.connections.Add(-1)
+
+	// Every product change is watched for; the session keeps the ones of the products it is
+	// subscribed to, which change as the client sends commands.
+	ctx := entity.WithPrincipal(c.Request.Context(), principal)
+	sub, err := h.streamService.Subscribe(ctx, entity.StreamFilter{}, nil)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+	defer sub.Close()
+
+	// The Origin header is not checked: clients authenticate with a token rather than a cookie,
+	// so a page on another site cannot connect on a user's behalf.
+	server := websocket.Server{
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = maxMessageBytes
+			newSession(ctx, h, ws, sub).run()
+		},
+	}
+	server.ServeHTTP(liveWriter{
+		ResponseWriter: c.Writer,
+		timeout:        h.config.PingInterval + h.config.PongTimeout,
+	}, c.Request)
+}
+
+// authenticate tells whether the request carries one of the configured tokens, as a bearer
+// token or, for browsers, which cannot set headers on a WebSocket, as access_token, and returns
+// the principal the token stands for.
+func (h *StockHandler) authenticate(c *gin.Context) (string, bool) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok {
+		token = c.Query("access_token")
+	}
+	if token == "" {
+		return "", false
+	}
+
+	valid := false
+	for _, candidate := range h.config.Tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(candidate)) == 1 {
+			valid = true
+		}
+	}
+	if !valid {
+		return "", false
+	}
+	return tokenPrincipal(token), true
+}
+
+// tokenPrincipal names the client holding token by a prefix of the token's SHA-256, so the
+// token itself is never stored.
+func tokenPrincipal(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,431 @@
+package stock
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/net/websocket"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/identity"
+	"github.com/sirawong/crud-arise/internal/handler/http/stock/dto"
+	productMocks "github.com/sirawong/crud-arise/internal/services/product/mocks"
+	reservationMocks "github.com/sirawong/crud-arise/internal/services/reservation/mocks"
+	streamMocks "github.com/sirawong/crud-arise/internal/services/stream/mocks"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	token      = "secret"
+	actor      = "pos-1"
+	productID1 = "0b9c4f3e-6d1a-4c8e-9f2b-7a5d3e1c2b40"
+	productID2 = "4e7a2d1c-9b3f-4a6e-8c5d-1f0e2b3a4c50"
+	productID3 = "8d2f5a1b-3c4e-4f6a-9b7c-2e1d0f3a5b60"
+)
+
+type StockHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl               *gomock.Controller
+	mockProductService     *productMocks.MockProductService
+	mockReservationService *reservationMocks.MockReservationService
+	mockStreamService      *streamMocks.MockStreamService
+	config                 Config
+	server                 *httptest.Server
+	changes                chan entity.Change
+	closed                 chan struct{}
+}
+
+func (suite *StockHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockProductService = productMocks.NewMockProductService(suite.mockCtrl)
+	suite.mockReservationService = reservationMocks.NewMockReservationService(suite.mockCtrl)
+	suite.mockStreamService = streamMocks.NewMockStreamService(suite.mockCtrl)
+	suite.config = Config{
+		Tokens:           []string{"other", token},
+		MaxConnections:   1,
+		MaxSubscriptions: 2,
+		PingInterval:     time.Hour,
+		PongTimeout:      time.Hour,
+	}
+	suite.changes = make(chan entity.Change, 10)
+	suite.closed = make(chan struct{})
+	suite.server = nil
+}
+
+func (suite *StockHandlerTestSuite) TearDownTest() {
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	suite.mockCtrl.Finish()
+}
+
+// start serves the channel with the suite's config.
+func (suite *StockHandlerTestSuite) start() {
+	handler := NewStockHandler(suite.mockProductService, suite.mockReservationService, suite.mockStreamService, suite.config)
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	v1.Use(identity.Attach)
+	v1.GET("/ws/stock", handler.Connect)
+	suite.server = httptest.NewServer(router)
+}
+
+// expectSubscription expects a connection to watch every product change through a
+// subscription delivering the suite's changes, which ends with err once they are closed.
+func (suite *StockHandlerTestSuite) expectSubscription(err error) {
+	sub := streamMocks.NewMockSubscription(suite.mockCtrl)
+	sub.EXPECT().Changes().Return((<-chan entity.Change)(suite.changes)).AnyTimes()
+	sub.EXPECT().Err().Return(err).AnyTimes()
+	sub.EXPECT().Close().Do(func() { close(suite.closed) }).Times(1)
+	suite.mockStreamService.EXPECT().
+		Subscribe(gomock.Any(), entity.StreamFilter{}, nil).
+		Return(sub, nil).
+		Times(1)
+}
+
+func (suite *StockHandlerTestSuite) dial() *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(suite.server.URL, "http") + "/api/v1/ws/stock"
+	config, err := websocket.NewConfig(url, suite.server.URL)
+	suite.Require().NoError(err)
+	config.Header.Set("Authorization", "Bearer "+token)
+	config.Header.Set(identity.HeaderActor, actor)
+
+	ws, err := websocket.DialConfig(config)
+	suite.Require().NoError(err)
+	suite.Require().NoError(ws.SetDeadline(time.Now().Add(5 * time.Second)))
+	return ws
+}
+
+// hangUp closes the connection and waits for the server to let go of the subscription.
+func (suite *StockHandlerTestSuite) hangUp(ws *websocket.Conn) {
+	suite.Require().NoError(ws.Close())
+	select {
+	case <-suite.closed:
+	case <-time.After(5 * time.Second):
+		suite.Fail("subscription was not closed")
+	}
+}
+
+func (suite *StockHandlerTestSuite) send(ws *websocket.Conn, command string) {
+	suite.Require().NoError(websocket.Message.Send(ws, command))
+}
+
+func (suite *StockHandlerTestSuite) receive(ws *websocket.Conn, message interface{}) {
+	suite.Require().NoError(websocket.JSON.Receive(ws, message))
+}
+
+func (suite *StockHandlerTestSuite) TestConnect_Unauthenticated() {
+	suite.start()
+
+	for _, header := range []string{"", "Bearer wrong", "Basic " + token} {
+		req, _ := http.NewRequest("GET", suite.server.URL+"/api/v1/ws/stock", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		suite.Require().NoError(err)
+		resp.Body.Close()
+
+		suite.Equal(http.StatusUnauthorized, resp.StatusCode, header)
+		suite.Equal("Bearer", resp.Header.Get("WWW-Authenticate"))
+	}
+}
+
+func (suite *StockHandlerTestSuite) TestConnect_TokenInQuery() {
+	suite.expectSubscription(nil)
+	suite.start()
+
+	url := "ws" + strings.TrimPrefix(suite.server.URL, "http") + "/api/v1/ws/stock?access_token=" + token
+	ws, err := websocket.Dial(url, "", suite.server.URL)
+	suite.Require().NoError(err)
+
+	suite.hangUp(ws)
+}
+
+func (suite *StockHandlerTestSuite) TestConnect_TooManyConnections() {
+	suite.expectSubscription(nil)
+	suite.start()
+	ws := suite.dial()
+
+	req, _ := http.NewRequest("GET", suite.server.URL+"/api/v1/ws/stock", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	resp.Body.Close()
+	suite.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+
+	suite.hangUp(ws)
+}
+
+func (suite *StockHandlerTestSuite) TestConnect_StreamNotRunning() {
+	suite.mockStreamService.EXPECT().
+		Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, apperr.ErrUnavailable).
+		Times(1)
+	suite.start()
+
+	req, _ := http.NewRequest("GET", suite.server.URL+"/api/v1/ws/stock", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	resp.Body.Close()
+	suite.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func (suite *StockHandlerTestSuite) TestSubscribe_SnapshotThenDeltas() {
+	suite.expectSubscription(nil)
+	suite.mockProductService.EXPECT().
+		GetByIDs(gomock.Any(), []string{productID1, productID2}).
+		Return([]entity.Product{{ID: productID1, Stock: utils.SetPtr(5), Version: 3}}, []string{productID2}, nil).
+		Times(1)
+	suite.start()
+	ws := suite.dial()
+
+	suite.send(ws, `{"type":"subscribe","id":"c1","productIds":["`+productID1+`","`+productID2+`"]}`)
+	var snapshot dto.Snapshot
+	suite.receive(ws, &snapshot)
+	suite.Equal(dto.Snapshot{
+		Type:     dto.MessageSnapshot,
+		ID:       "c1",
+		Levels:   []dto.StockLevel{{ProductID: productID1, Stock: 5}},
+		NotFound: []string{productID2},
+	}, snapshot)
+
+	product := func(stock, version int) *entity.Product {
+		return &entity.Product{ID: productID1, Stock: utils.SetPtr(stock), Version: version}
+	}
+	update := entity.Change{EntityType: entity.AuditEntityProduct, EntityID: productID1, Action: entity.AuditActionUpdate}
+	// Already in the snapshot.
+	stale := update
+	stale.Product = product(9, 3)
+	suite.changes <- stale
+	// Not subscribed to.
+	suite.changes <- entity.Change{EntityType: entity.AuditEntityProduct, EntityID: productID2, Action: entity.AuditActionUpdate, Product: &entity.Product{ID: productID2, Version: 2}}
+	suite.changes <- entity.Change{EntityType: entity.AuditEntityCategory, EntityID: productID1, Action: entity.AuditActionUpdate}
+	taken := update
+	taken.Product = product(4, 4)
+	suite.changes <- taken
+	// Stock left as it was.
+	renamed := update
+	renamed.Product = product(4, 5)
+	suite.changes <- renamed
+	suite.changes <- entity.Change{EntityType: entity.AuditEntityProduct, EntityID: productID1, Action: entity.AuditActionDelete, Product: product(4, 5)}
+	suite.changes <- entity.Change{EntityType: entity.AuditEntityProduct, EntityID: productID1, Action: entity.AuditActionRestore, Product: product(4, 6)}
+
+	var delta dto.Delta
+	suite.receive(ws, &delta)
+	suite.Equal(dto.Delta{Type: dto.MessageDelta, ProductID: productID1, Stock: 4, Delta: utils.SetPtr(-1)}, delta)
+
+	var removed dto.Removed
+	suite.receive(ws, &removed)
+	suite.Equal(dto.Removed{Type: dto.MessageRemoved, ProductID: productID1}, removed)
+
+	var restored dto.Delta
+	suite.receive(ws, &restored)
+	suite.Equal(dto.Delta{Type: dto.MessageDelta, ProductID: productID1, Stock: 4}, restored)
+
+	suite.send(ws, `{"type":"unsubscribe","id":"c2","productIds":["`+productID1+`"]}`)
+	var unsubscribed dto.Unsubscribed
+	suite.receive(ws, &unsubscribed)
+	suite.Equal(dto.Unsubscribed{Type: dto.MessageUnsubscribed, ID: "c2", ProductIDs: []string{productID1}}, unsubscribed)
+
+	suite.hangUp(ws)
+}
+
+func (suite *StockHandlerTestSuite) TestSubscribe_Limit() {
+	suite.expectSubscription(nil)
+	suite.mockProductService.EXPECT().
+		GetByIDs(gomock.Any(), gomock.Any()).
+		Return([]entity.Product{{ID: productID1}, {ID: productID2}, {ID: productID3}}, nil, nil).
+		Times(1)
+	suite.start()
+	ws := suite.dial()
+
+	suite.send(ws, `{"type":"subscribe","id":"c1","productIds":["`+productID1+`","`+productID2+`","`+productID3+`"]}`)
+	var reply dto.Error
+	suite.receive(ws, &reply)
+	suite.Equal("c1", reply.ID)
+	suite.Equal(apperr.ErrInvalidArgument.Code, reply.ErrorCode)
+
+	suite.hangUp(ws)
+}
+
+func (suite *StockHandlerTestSuite) TestReserveAndRelease() {
+	suite.expectSubscription(nil)
+	reservation := &entity.Reservation{
+		ID:        "reservation-1",
+		ProductID: productID1,
+		Quantity:  2,
+		Status:    entity.ReservationStatusHeld,
+		ExpiresAt: time.Date(2025, 1, 1, 12, 15, 0, 0, time.UTC),
+		CreatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	suite.mockReservationService.EXPECT().Reserve(gomock.Any(), productID1, 2).Return(reservation, nil).Times(1)
+	suite.mockReservationService.EXPECT().
+		Release(gomock.Any(), "7c1e2d3f-4a5b-4c6d-8e7f-9a0b1c2d3e40").
+		Return(nil, apperr.ErrConflict.WithMessage("reservation is already released")).
+		Times(1)
+	suite.start()
+	ws := suite.dial()
+
+	suite.send(ws, `{"type":"reserve","id":"c1","productId":"`+productID1+`","quantity":2}`)
+	var reserved dto.ReservationReply
+	suite.receive(ws, &reserved)
+	suite.Equal(dto.MessageReserved, reserved.Type)
+	suite.Equal("c1", reserved.ID)
+	suite.Equal("reservation-1", reserved.Reservation.ID)
+	suite.Equal("held", reserved.Reservation.Status)
+	suite.Equal(reservation.ExpiresAt, reserved.Reservation.ExpiresAt)
+
+	suite.send(ws, `{"type":"release","id":"c2","reservationId":"7c1e2d3f-4a5b-4c6d-8e7f-9a0b1c2d3e40"}`)
+	var failed dto.Error
+	suite.receive(ws, &failed)
+	suite.Equal("c2", failed.ID)
+	suite.Equal(apperr.ErrConflict.Code, failed.ErrorCode)
+
+	suite.hangUp(ws)
+}
+
+func (suite *StockHandlerTestSuite) TestRelease_OtherPrincipalsReservation() {
+	suite.expectSubscription(nil)
+	suite.mockReservationService.EXPECT().
+		Release(gomock.Any(), "7c1e2d3f-4a5b-4c6d-8e7f-9a0b1c2d3e40").
+		DoAndReturn(func(ctx context.Context, _ string) (*entity.Reservation, error) {
+			// The reservation was made with another token; the actor header is the client's own
+			// claim and is not what ownership is checked against.
+			suite.Equal(tokenPrincipal(token), entity.PrincipalFrom(ctx))
+			suite.Equal(actor, entity.ActorFrom(ctx).Name)
+			return nil, apperr.ErrNotFound.WithMessage("reservation not found")
+		}).
+		Times(1)
+	suite.start()
+	ws := suite.dial()
+
+	suite.send(ws, `{"type":"release","id":"c1","reservationId":"7c1e2d3f-4a5b-4c6d-8e7f-9a0b1c2d3e40"}`)
+	var failed dto.Error
+	suite.receive(ws, &failed)
+	suite.Equal(dto.MessageError, failed.Type)
+	suite.Equal("c1", failed.ID)
+	suite.Equal(apperr.ErrNotFound.Code, failed.ErrorCode)
+
+	suite.hangUp(ws)
+}
+
+func (suite *StockHandlerTestSuite) TestInvalidCommands() {
+	suite.expectSubscription(nil)
+	suite.start()
+	ws := suite.dial()
+
+	for _, command := range []string{
+		`not json`,
+		`{"type":"restock","id":"c1"}`,
+		`{"type":"reserve","id":"c2","productId":"` + productID1 + `"}`,
+		`{"type":"subscribe","id":"c3","productIds":["not-a-uuid"]}`,
+		`{"type":"release","id":"c4"}`,
+	} {
+		suite.send(ws, command)
+		var reply dto.Error
+		suite.receive(ws, &reply)
+		suite.Equal(dto.MessageError, reply.Type, command)
+		suite.Equal(apperr.ErrInvalidArgument.Code, reply.ErrorCode, command)
+	}
+
+	suite.hangUp(ws)
+}
+
+func (suite *StockHandlerTestSuite) TestStreamEnds() {
+	suite.expectSubscription(apperr.ErrUnavailable.WithMessage("product stream is shutting down"))
+	suite.start()
+	ws := suite.dial()
+
+	close(suite.changes)
+	var reply dto.Error
+	suite.receive(ws, &reply)
+	suite.Equal(apperr.ErrUnavailable.Code, reply.ErrorCode)
+
+	var raw json.RawMessage
+	suite.Equal(io.EOF, websocket.JSON.Receive(ws, &raw))
+	suite.hangUp(ws)
+}
+
+func (suite *StockHandlerTestSuite) TestPing_KeepsAnsweringClientConnected() {
+	suite.config.PingInterval = 20 * time.Millisecond
+	suite.config.PongTimeout = 30 * time.Millisecond
+	suite.expectSubscription(nil)
+	suite.mockProductService.EXPECT().
+		GetByIDs(gomock.Any(), gomock.Any()).
+		Return([]entity.Product{{ID: productID1, Stock: utils.SetPtr(1)}}, nil, nil).
+		Times(1)
+	suite.start()
+	ws := suite.dial()
+
+	// Receiving answers the pings that arrive in the meantime.
+	received := make(chan dto.Snapshot, 1)
+	go func() {
+		var snapshot dto.Snapshot
+		_ = websocket.JSON.Receive(ws, &snapshot)
+		received <- snapshot
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	suite.send(ws, `{"type":"subscribe","productIds":["`+productID1+`"]}`)
+	suite.Equal(dto.MessageSnapshot, (<-received).Type)
+
+	suite.hangUp(ws)
+}
+
+func (suite *StockHandlerTestSuite) TestPing_DropsSilentClient() {
+	suite.config.PingInterval = 20 * time.Millisecond
+	suite.config.PongTimeout = 30 * time.Millisecond
+	suite.expectSubscription(nil)
+	suite.start()
+	ws := suite.dial()
+
+	// Not reading, the client answers no pings.
+	select {
+	case <-suite.closed:
+	case <-time.After(5 * time.Second):
+		suite.Fail("silent client was not disconnected")
+	}
+	ws.Close()
+}
+
+func TestLiveConn_PushesBackDeadline(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	live := &liveConn{Conn: server, r: server, timeout: 50 * time.Millisecond}
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(30 * time.Millisecond)
+			_, _ = client.Write([]byte{byte(i)})
+		}
+	}()
+
+	buf := make([]byte, 1)
+	for i := 0; i < 3; i++ {
+		_, err := live.Read(buf)
+		assert.NoError(t, err)
+	}
+
+	_, err := live.Read(buf)
+	var netErr net.Error
+	assert.ErrorAs(t, err, &netErr)
+	assert.True(t, netErr.Timeout())
+}
+
+func TestStockHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(StockHandlerTestSuite))
+}
@@ -0,0 +1,209 @@
+package stock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/stock/dto"
+	streamSrv "github.com/sirawong/crud-arise/internal/services/stream"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"golang.org/x/net/websocket"
+)
+
+// writeTimeout is how long a message may take to be sent before the client is given up on.
+const writeTimeout = 10 * time.Second
+
+// level is the stock of a product last sent to the client, with the version of the product it
+// was read from.
+type level struct {
+	stock   int
+	version int
+}
+
+// session serves one connection. A single goroutine runs the session and is the only one to
+// write to the connection; another reads the client's messages and hands them over.
+type session struct {
+	ctx     context.Context
+	handler *StockHandler
+	ws      *websocket.Conn
+	sub     streamSrv.Subscription
+	// levels has an entry for each product subscribed to, nil while the product is deleted.
+	levels map[string]*level
+}
+
+func newSession(ctx context.Context, handler *StockHandler, ws *websocket.Conn, sub streamSrv.Subscription) *session {
+	return &session{
+		ctx:     ctx,
+		handler: handler,
+		ws:      ws,
+		sub:     sub,
+		levels:  make(map[string]*level),
+	}
+}
+
+// run serves the connection until the client goes away or stops answering pings, a message
+// cannot be sent, or the change stream ends. In the last case the client is told why.
+func (s *session) run() {
+	defer s.ws.Close()
+
+	messages := make(chan []byte)
+	done := make(chan struct{})
+	defer close(done)
+	go s.read(messages, done)
+
+	ping := time.NewTicker(s.handler.config.PingInterval)
+	defer ping.Stop()
+
+	for {
+		var err error
+		select {
+		case <-s.ctx.Done():
+			return
+		case data, ok := <-messages:
+			if !ok {
+				return
+			}
+			err = s.handle(data)
+		case change, ok := <-s.sub.Changes():
+			if !ok {
+				if err := s.sub.Err(); err != nil {
+					_ = s.send(dto.ErrorFromDomain("", err))
+				}
+				return
+			}
+			err = s.changed(change)
+		case <-ping.C:
+			err = s.write(pingCodec, nil)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *session) read(messages chan<- []byte, done <-chan struct{}) {
+	defer close(messages)
+	for {
+		var data []byte
+		if err := websocket.Message.Receive(s.ws, &data); err != nil {
+			return
+		}
+		select {
+		case messages <- data:
+		case <-done:
+			return
+		}
+	}
+}
+
+// handle runs a command and answers it. Only a failure to send the answer is returned; a
+// failed command is answered with an error message.
+func (s *session) handle(data []byte) error {
+	command, err := dto.ParseCommand(data)
+	if err != nil {
+		return s.send(dto.ErrorFromDomain(command.ID, err))
+	}
+
+	var reply interface{}
+	switch command.Type {
+	case dto.CommandSubscribe:
+		reply, err = s.subscribe(command)
+	case dto.CommandUnsubscribe:
+		for _, id := range command.ProductIDs {
+			delete(s.levels, id)
+		}
+		reply = dto.Unsubscribed{Type: dto.MessageUnsubscribed, ID: command.ID, ProductIDs: command.ProductIDs}
+	case dto.CommandReserve:
+		var reserved *entity.Reservation
+		reserved, err = s.handler.reservationService.Reserve(s.ctx, command.ProductID, command.Quantity)
+		if err == nil {
+			reply = dto.ReservationReplyFromDomain(dto.MessageReserved, command.ID, reserved)
+		}
+	case dto.CommandRelease:
+		var released *entity.Reservation
+		released, err = s.handler.reservationService.Release(s.ctx, command.ReservationID)
+		if err == nil {
+			reply = dto.ReservationReplyFromDomain(dto.MessageReleased, command.ID, released)
+		}
+	}
+	if err != nil {
+		return s.send(dto.ErrorFromDomain(command.ID, err))
+	}
+	return s.send(reply)
+}
+
+// subscribe adds the products that exist to the subscriptions and returns their stock. Changes
+// already read before the snapshot was taken are recognized by the product version and skipped.
+func (s *session) subscribe(command dto.Command) (dto.Snapshot, error) {
+	products, notFound, err := s.handler.productService.GetByIDs(s.ctx, command.ProductIDs)
+	if err != nil {
+		return dto.Snapshot{}, err
+	}
+
+	added := 0
+	for _, product := range products {
+		if _, ok := s.levels[product.ID]; !ok {
+			added++
+		}
+	}
+	if limit := s.handler.config.MaxSubscriptions; len(s.levels)+added > limit {
+		return dto.Snapshot{}, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("a connection can be subscribed to at most %d products", limit))
+	}
+
+	for _, product := range products {
+		s.levels[product.ID] = &level{stock: utils.GetValue(product.Stock), version: product.Version}
+	}
+	return dto.SnapshotFromDomain(command.ID, products, notFound), nil
+}
+
+// changed sends the stock a change of a subscribed product left, as a delta from the stock last
+// sent, or tells the client the product was deleted. Changes that leave the stock as it was, and
+// ones older than what was last sent, are not passed on.
+func (s *session) changed(change entity.Change) error {
+	if change.EntityType != entity.AuditEntityProduct {
+		return nil
+	}
+	known, ok := s.levels[change.EntityID]
+	if !ok {
+		return nil
+	}
+	product := change.Product
+
+	if change.IsTombstone() {
+		// A delete leaves the version as it was, so only an older one is stale.
+		if known == nil || (product != nil && product.Version < known.version) {
+			return nil
+		}
+		s.levels[change.EntityID] = nil
+		return s.send(dto.Removed{Type: dto.MessageRemoved, ProductID: change.EntityID})
+	}
+	// A nil product has been purged since; the purge is still to come.
+	if product == nil || (known != nil && product.Version <= known.version) {
+		return nil
+	}
+
+	stock := utils.GetValue(product.Stock)
+	s.levels[change.EntityID] = &level{stock: stock, version: product.Version}
+	delta := dto.Delta{Type: dto.MessageDelta, ProductID: change.EntityID, Stock: stock}
+	if known != nil {
+		if stock == known.stock {
+			return nil
+		}
+		delta.Delta = utils.SetPtr(stock - known.stock)
+	}
+	return s.send(delta)
+}
+
+func (s *session) send(message interface{}) error {
+	return s.write(websocket.JSON, message)
+}
+
+func (s *session) write(codec websocket.Codec, v interface{}) error {
+	if err := s.ws.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	return codec.Send(s.ws, v)
+}
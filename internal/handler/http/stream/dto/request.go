@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	changedto "github.com/sirawong/crud-arise/internal/handler/http/change/dto"
+)
+
+type StreamProductsRequest struct {
+	CategoryIDs []string `form:"categoryId" binding:"omitempty,dive,uuid"`
+	ProductIDs  []string `form:"productId" binding:"omitempty,dive,uuid"`
+	// LastEventID stands in for the Last-Event-ID header for clients that cannot set headers.
+	LastEventID string `form:"lastEventId"`
+}
+
+func (r StreamProductsRequest) ToDomain() entity.StreamFilter {
+	return entity.StreamFilter{
+		CategoryIDs: r.CategoryIDs,
+		ProductIDs:  r.ProductIDs,
+	}
+}
+
+// LastSeq decodes the ID of the last event a reconnecting client received, taken from the
+// Last-Event-ID header or else from LastEventID, into the sequence number to resume after. It
+// is nil for a client connecting afresh.
+func (r StreamProductsRequest) LastSeq(header string) (*int64, error) {
+	id := header
+	if id == "" {
+		id = r.LastEventID
+	}
+	if id == "" {
+		return nil, nil
+	}
+
+	seq, ok := changedto.ParseToken(id)
+	if !ok {
+		return nil, apperr.ErrInvalidArgument.WithMessage("Last-Event-ID is not an event ID of this stream")
+	}
+	return &seq, nil
+}
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"github.com/gin-contrib/sse"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	changedto "github.com/sirawong/crud-arise/internal/handler/http/change/dto"
+)
+
+// Names of the events of the product stream.
+const (
+	EventProductCreated  = "product.created"
+	EventProductUpdated  = "product.updated"
+	EventProductDeleted  = "product.deleted"
+	EventProductRestored = "product.restored"
+	EventProductPurged   = "product.purged"
+	EventStockChanged    = "stock.changed"
+)
+
+var productEvents = map[entity.AuditAction]string{
+	entity.AuditActionCreate:  EventProductCreated,
+	entity.AuditActionUpdate:  EventProductUpdated,
+	entity.AuditActionDelete:  EventProductDeleted,
+	entity.AuditActionRestore: EventProductRestored,
+	entity.AuditActionPurge:   EventProductPurged,
+}
+
+// ProductEvent represents a product change with the product as the change left it, which is
+// missing for a purge and once the product has been purged since
+type ProductEvent struct {
+	ProductID string             `json:"productId"`
+	Product   *changedto.Product `json:"product,omitempty"`
+} //	@name	StreamProductEvent
+
+// StockEvent represents a change of a product's stock level
+type StockEvent struct {
+	ProductID  string `json:"productId"`
+	CategoryID string `json:"categoryId"`
+	Stock      int    `json:"stock"`
+} //	@name	StreamStockEvent
+
+// EventsFromChange turns a product change into the events announcing it: an update of the stock
+// is announced by stock.changed, and by product.updated as well only when other fields changed
+// too. Only the last event carries the change's ID, so a client reconnecting in between is sent
+// the whole change again.
+func EventsFromChange(change entity.Change) []sse.Event {
+	product := changedto.ChangeFromDomain(change).Product
+
+	var events []sse.Event
+	stockOnly := change.Changed("stock") && len(change.Fields) == 1
+	if !stockOnly || product == nil {
+		events = append(events, sse.Event{
+			Event: productEvents[change.Action],
+			Data:  ProductEvent{ProductID: change.EntityID, Product: product},
+		})
+	}
+	if change.Changed("stock") && product != nil {
+		events = append(events, sse.Event{
+			Event: EventStockChanged,
+			Data: StockEvent{
+				ProductID:  change.EntityID,
+				CategoryID: product.CategoryID,
+				Stock:      product.Stock,
+			},
+		})
+	}
+
+	events[len(events)-1].Id = changedto.Token(change.Seq)
+	return events
+}
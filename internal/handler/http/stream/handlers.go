@@ -0,0 +1,98 @@
+package stream
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/stream/dto"
+	streamSrv "github.com/sirawong/crud-arise/internal/services/stream"
+)
+
+// reconnectDelay is how long clients wait before reconnecting to a stream that ended.
+const reconnectDelay = 3 * time.Second
+
+type StreamHandler struct {
+	streamService streamSrv.StreamService
+	heartbeat     time.Duration
+}
+
+func NewStreamHandler(streamService streamSrv.StreamService, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{streamService: streamService, heartbeat: heartbeat}
+}
+
+// Products godoc
+//
+//	@Summary		Stream product changes
+//	@Description	Server-Sent Events of product changes as they commit: product.created, product.updated, product.deleted, product.restored, product.purged and stock.changed. Reconnecting with Last-Event-ID resumes after the last event received. A comment is sent as a heartbeat while nothing changes. A client that falls behind is sent an error event and disconnected, and resumes by reconnecting.
+//	@Tags			stream
+//	@Produce		text/event-stream
+//	@Param			categoryId		query		[]string				false	"Only products in these categories"	collectionFormat(multi)
+//	@Param			productId		query		[]string				false	"Only these products"					collectionFormat(multi)
+//	@Param			Last-Event-ID	header		string					false	"ID of the last event received"
+//	@Param			lastEventId		query		string					false	"Last-Event-ID for clients that cannot set headers"
+//	@Success		200				{object}	dto.ProductEvent		"Stream of events"
+//	@Failure		400				{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		503				{object}	map[string]interface{}	"{"error_code": "UNAVAILABLE", "message": "error		description"}"
+//	@Router			/stream/products [get]
+func (h StreamHandler) Products(c *gin.Context) {
+	var query dto.StreamProductsRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+	lastSeq, err := query.LastSeq(c.GetHeader("Last-Event-ID"))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	sub, err := h.streamService.Subscribe(ctx, query.ToDomain(), lastSeq)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", sse.ContentType)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Keeps proxies such as nginx from buffering the stream.
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	_, _ = fmt.Fprintf(c.Writer, "retry: %d\n\n", reconnectDelay.Milliseconds())
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case change, ok := <-sub.Changes():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					c.Render(-1, sse.Event{
+						Event: "error",
+						Data:  gin.H{"error_code": apperr.GetCode(err), "message": err.Error()},
+					})
+					c.Writer.Flush()
+				}
+				return
+			}
+			for _, event := range dto.EventsFromChange(change) {
+				c.Render(-1, event)
+			}
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			_, _ = io.WriteString(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
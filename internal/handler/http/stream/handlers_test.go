@@ -0,0 +1,166 @@
+package stream
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	changedto "github.com/sirawong/crud-arise/internal/handler/http/change/dto"
+	"github.com/sirawong/crud-arise/internal/services/stream/mocks"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type StreamHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockStreamService
+	handler     *StreamHandler
+	router      *gin.Engine
+}
+
+func (suite *StreamHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockStreamService(suite.mockCtrl)
+	suite.handler = NewStreamHandler(suite.mockService, time.Hour)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	v1.GET("/stream/products", suite.handler.Products)
+}
+
+func (suite *StreamHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+// subscription returns a subscription that delivers changes and then ends with err.
+func (suite *StreamHandlerTestSuite) subscription(err error, changes ...entity.Change) *mocks.MockSubscription {
+	ch := make(chan entity.Change, len(changes))
+	for _, change := range changes {
+		ch <- change
+	}
+	close(ch)
+
+	sub := mocks.NewMockSubscription(suite.mockCtrl)
+	sub.EXPECT().Changes().Return(ch).AnyTimes()
+	sub.EXPECT().Err().Return(err).AnyTimes()
+	sub.EXPECT().Close().Times(1)
+	return sub
+}
+
+func (suite *StreamHandlerTestSuite) TestProducts_StreamsEvents() {
+	categoryID := "5f0c7b4e-8f7c-4a53-9a5e-2f4d1c7e6b10"
+	sub := suite.subscription(nil,
+		entity.Change{
+			Seq:        7,
+			EntityType: entity.AuditEntityProduct,
+			EntityID:   "product-1",
+			Action:     entity.AuditActionUpdate,
+			Fields:     []string{"price", "stock"},
+			Product:    &entity.Product{ID: "product-1", CategoryID: categoryID, Stock: utils.SetPtr(4)},
+		},
+		entity.Change{
+			Seq:        8,
+			EntityType: entity.AuditEntityProduct,
+			EntityID:   "product-1",
+			Action:     entity.AuditActionPurge,
+		},
+	)
+	suite.mockService.EXPECT().
+		Subscribe(gomock.Any(), entity.StreamFilter{CategoryIDs: []string{categoryID}}, nil).
+		Return(sub, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/stream/products?categoryId="+categoryID, nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.True(strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream"))
+
+	body := w.Body.String()
+	updated := strings.Index(body, "event:product.updated\n")
+	stock := strings.Index(body, "id:"+changedto.Token(7)+"\nevent:stock.changed\n")
+	purged := strings.Index(body, "id:"+changedto.Token(8)+"\nevent:product.purged\n")
+	suite.True(strings.HasPrefix(body, "retry: 3000\n\n"))
+	suite.True(updated > 0 && updated < stock && stock < purged, body)
+	suite.Contains(body, `"stock":4`)
+	suite.Equal(2, strings.Count(body, "id:"))
+}
+
+func (suite *StreamHandlerTestSuite) TestProducts_StockOnlyUpdate() {
+	sub := suite.subscription(nil, entity.Change{
+		Seq:        7,
+		EntityType: entity.AuditEntityProduct,
+		EntityID:   "product-1",
+		Action:     entity.AuditActionUpdate,
+		Fields:     []string{"stock"},
+		Product:    &entity.Product{ID: "product-1", Stock: utils.SetPtr(4)},
+	})
+	suite.mockService.EXPECT().Subscribe(gomock.Any(), gomock.Any(), nil).Return(sub, nil).Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/stream/products", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Contains(w.Body.String(), "event:stock.changed\n")
+	suite.NotContains(w.Body.String(), "event:product.updated\n")
+}
+
+func (suite *StreamHandlerTestSuite) TestProducts_ResumesFromLastEventID() {
+	sub := suite.subscription(apperr.ErrUnavailable.WithMessage("subscriber fell too far behind; reconnect to resume"))
+	suite.mockService.EXPECT().
+		Subscribe(gomock.Any(), entity.StreamFilter{}, utils.SetPtr(int64(42))).
+		Return(sub, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/stream/products", nil)
+	req.Header.Set("Last-Event-ID", changedto.Token(42))
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), "event:error\n")
+	suite.Contains(w.Body.String(), "UNAVAILABLE")
+}
+
+func (suite *StreamHandlerTestSuite) TestProducts_InvalidLastEventID() {
+	req, _ := http.NewRequest("GET", "/api/v1/stream/products?lastEventId=42", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *StreamHandlerTestSuite) TestProducts_InvalidFilter() {
+	req, _ := http.NewRequest("GET", "/api/v1/stream/products?productId=tea", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *StreamHandlerTestSuite) TestProducts_Unavailable() {
+	suite.mockService.EXPECT().
+		Subscribe(gomock.Any(), gomock.Any(), nil).
+		Return(nil, apperr.ErrUnavailable.WithMessage("product stream is not running")).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/stream/products", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusServiceUnavailable, w.Code)
+}
+
+func TestStreamHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(StreamHandlerTestSuite))
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/pkg/utils"
+)
+
+// SupplierRequest represents the request payload for creating/updating a supplier
+type SupplierRequest struct {
+	Name         string `json:"name" binding:"required"`
+	ContactName  string `json:"contactName"`
+	ContactEmail string `json:"contactEmail" binding:"omitempty,email"`
+	ContactPhone string `json:"contactPhone"`
+	LeadTimeDays int    `json:"leadTimeDays" binding:"min=0"`
+	Currency     string `json:"currency" binding:"required,iso4217"`
+} //	@name	SupplierRequest
+
+func (r SupplierRequest) ToDomain() entity.Supplier {
+	return entity.Supplier{
+		Name:         r.Name,
+		ContactName:  r.ContactName,
+		ContactEmail: r.ContactEmail,
+		ContactPhone: r.ContactPhone,
+		LeadTimeDays: r.LeadTimeDays,
+		Currency:     r.Currency,
+	}
+}
+
+type FilterSuppliersRequest struct {
+	Name   string `form:"name,omitempty"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+func (r FilterSuppliersRequest) ToDomain() entity.SuppliersFilter {
+	return entity.SuppliersFilter{
+		Name: utils.SetPtr(r.Name),
+		Pagination: entity.Pagination{
+			Limit:  r.Limit,
+			Offset: r.Offset,
+		},
+	}
+}
+
+// ProductSupplierRequest represents the request payload for linking a product to a supplier
+type ProductSupplierRequest struct {
+	SupplierSKU      string  `json:"supplierSku"`
+	CostPrice        float64 `json:"costPrice" binding:"min=0"`
+	MinOrderQuantity int     `json:"minOrderQuantity" binding:"min=0"`
+	Preferred        bool    `json:"preferred"`
+} //	@name	ProductSupplierRequest
+
+func (r ProductSupplierRequest) ToDomain(productID, supplierID string) entity.ProductSupplier {
+	return entity.ProductSupplier{
+		ProductID:        productID,
+		SupplierID:       supplierID,
+		SupplierSKU:      r.SupplierSKU,
+		CostPrice:        r.CostPrice,
+		MinOrderQuantity: r.MinOrderQuantity,
+		Preferred:        r.Preferred,
+	}
+}
@@ -0,0 +1,100 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// Supplier represents the response payload for a supplier
+type Supplier struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	ContactName  string    `json:"contactName"`
+	ContactEmail string    `json:"contactEmail"`
+	ContactPhone string    `json:"contactPhone"`
+	LeadTimeDays int       `json:"leadTimeDays"`
+	Currency     string    `json:"currency"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt,omitempty"`
+} //	@name	Supplier
+
+// ProductSupplier represents the link between a product and one of its suppliers
+type ProductSupplier struct {
+	ProductID        string  `json:"productId"`
+	SupplierID       string  `json:"supplierId"`
+	SupplierSKU      string  `json:"supplierSku"`
+	CostPrice        float64 `json:"costPrice"`
+	MinOrderQuantity int     `json:"minOrderQuantity"`
+	Preferred        bool    `json:"preferred"`
+
+	Product  *Product  `json:"product,omitempty"`
+	Supplier *Supplier `json:"supplier,omitempty"`
+} //	@name	ProductSupplier
+
+// Product represents product information in product supplier response
+type Product struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	SKU  string `json:"sku"`
+} //	@name	SupplierProduct
+
+func SupplierFromDomain(supplier *entity.Supplier) *Supplier {
+	if supplier == nil {
+		return nil
+	}
+	return &Supplier{
+		ID:           supplier.ID,
+		Name:         supplier.Name,
+		ContactName:  supplier.ContactName,
+		ContactEmail: supplier.ContactEmail,
+		ContactPhone: supplier.ContactPhone,
+		LeadTimeDays: supplier.LeadTimeDays,
+		Currency:     supplier.Currency,
+		CreatedAt:    supplier.CreatedAt,
+		UpdatedAt:    supplier.UpdatedAt,
+	}
+}
+
+func SuppliersFromDomain(suppliers []entity.Supplier) []Supplier {
+	result := make([]Supplier, 0, len(suppliers))
+	for _, supplier := range suppliers {
+		result = append(result, *SupplierFromDomain(&supplier))
+	}
+
+	return result
+}
+
+func ProductSupplierFromDomain(link *entity.ProductSupplier) *ProductSupplier {
+	if link == nil {
+		return nil
+	}
+
+	var product *Product
+	if link.Product != nil {
+		product = &Product{
+			ID:   link.Product.ID,
+			Name: link.Product.Name,
+			SKU:  link.Product.SKU,
+		}
+	}
+	return &ProductSupplier{
+		ProductID:        link.ProductID,
+		SupplierID:       link.SupplierID,
+		SupplierSKU:      link.SupplierSKU,
+		CostPrice:        link.CostPrice,
+		MinOrderQuantity: link.MinOrderQuantity,
+		Preferred:        link.Preferred,
+		Product:          product,
+		Supplier:         SupplierFromDomain(link.Supplier),
+	}
+}
+
+func ProductSuppliersFromDomain(links []entity.ProductSupplier) []ProductSupplier {
+	result := make([]ProductSupplier, 0, len(links))
+	for _, link := range links {
+		result = append(result, *ProductSupplierFromDomain(&link))
+	}
+
+	return result
+}
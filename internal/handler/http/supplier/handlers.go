@@ -0,0 +1,292 @@
+package supplier
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/supplier/dto"
+	supplierSrv "github.com/sirawong/crud-arise/internal/services/supplier"
+)
+
+type SupplierHandler struct {
+	supplierService supplierSrv.SupplierService
+}
+
+func NewSupplierHandler(supplierService supplierSrv.SupplierService) *SupplierHandler {
+	return &SupplierHandler{supplierService: supplierService}
+}
+
+// Create godoc
+//
+//	@Summary		Create a new supplier
+//	@Description	Create a new supplier with the provided information
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			supplier	body		dto.SupplierRequest		true	"Supplier information"
+//	@Success		201			{object}	map[string]interface{}	"{"id": "supplier_id"}"
+//	@Failure		400			{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/suppliers [post]
+func (h SupplierHandler) Create(c *gin.Context) {
+	var req dto.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	id, err := h.supplierService.Create(c, req.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// Update godoc
+//
+//	@Summary		Update a supplier
+//	@Description	Update an existing supplier by ID
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Supplier ID"
+//	@Param			supplier	body		dto.SupplierRequest		true	"Supplier information"
+//	@Success		200			{object}	map[string]interface{}	"{"status": "updated"}"
+//	@Failure		400			{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404			{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/suppliers/{id} [put]
+func (h SupplierHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	var req dto.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	err := h.supplierService.Update(c, id, req.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "updated"})
+}
+
+// GetByID godoc
+//
+//	@Summary		Get a supplier by ID
+//	@Description	Get a single supplier by its ID
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Supplier ID"
+//	@Success		200	{object}	dto.Supplier			"Supplier information"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/suppliers/{id} [get]
+func (h SupplierHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	supplier, err := h.supplierService.GetByID(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SupplierFromDomain(supplier))
+}
+
+// ListAll godoc
+//
+//	@Summary		Get all suppliers
+//	@Description	Get a list of all suppliers
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	query		string					false	"Search insensitive by supplier name"
+//	@Param			limit	query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset	query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200		{array}		dto.Supplier			"List of suppliers"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error	description"}"
+//	@Router			/suppliers [get]
+func (h SupplierHandler) ListAll(c *gin.Context) {
+	var query dto.FilterSuppliersRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	suppliers, err := h.supplierService.GetAll(c, query.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuppliersFromDomain(suppliers))
+}
+
+// Delete godoc
+//
+//	@Summary		Delete a supplier
+//	@Description	Delete a supplier by ID together with its product links
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Supplier ID"
+//	@Success		200	{object}	map[string]interface{}	"{"status": "deleted"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/suppliers/{id} [delete]
+func (h SupplierHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	err := h.supplierService.Delete(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// ListProducts godoc
+//
+//	@Summary		Get products of a supplier
+//	@Description	Get all products a supplier is linked to, with supplier SKU and cost
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Supplier ID"
+//	@Success		200	{array}		dto.ProductSupplier		"List of product links"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/suppliers/{id}/products [get]
+func (h SupplierHandler) ListProducts(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	links, err := h.supplierService.GetSupplierProducts(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ProductSuppliersFromDomain(links))
+}
+
+// ListProductSuppliers godoc
+//
+//	@Summary		Get suppliers of a product
+//	@Description	Get all suppliers of a product, preferred supplier first
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Product ID"
+//	@Success		200	{array}		dto.ProductSupplier		"List of supplier links"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id}/suppliers [get]
+func (h SupplierHandler) ListProductSuppliers(c *gin.Context) {
+	productID := c.Param("id")
+	if productID == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	links, err := h.supplierService.GetProductSuppliers(c, productID)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ProductSuppliersFromDomain(links))
+}
+
+// LinkProduct godoc
+//
+//	@Summary		Link a product to a supplier
+//	@Description	Create or replace the cost and ordering terms of a product from a supplier
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string						true	"Product ID"
+//	@Param			supplierId	path		string						true	"Supplier ID"
+//	@Param			link		body		dto.ProductSupplierRequest	true	"Link information"
+//	@Success		200			{object}	map[string]interface{}		"{"status": "linked"}"
+//	@Failure		400			{object}	map[string]interface{}		"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404			{object}	map[string]interface{}		"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500			{object}	map[string]interface{}		"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id}/suppliers/{supplierId} [put]
+func (h SupplierHandler) LinkProduct(c *gin.Context) {
+	productID := c.Param("id")
+	supplierID := c.Param("supplierId")
+	if productID == "" || supplierID == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id and supplierId are required"))
+		return
+	}
+
+	var req dto.ProductSupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	err := h.supplierService.LinkProduct(c, req.ToDomain(productID, supplierID))
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "linked"})
+}
+
+// UnlinkProduct godoc
+//
+//	@Summary		Unlink a product from a supplier
+//	@Description	Remove the link between a product and a supplier
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Product ID"
+//	@Param			supplierId	path		string					true	"Supplier ID"
+//	@Success		200			{object}	map[string]interface{}	"{"status": "unlinked"}"
+//	@Failure		404			{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/products/{id}/suppliers/{supplierId} [delete]
+func (h SupplierHandler) UnlinkProduct(c *gin.Context) {
+	productID := c.Param("id")
+	supplierID := c.Param("supplierId")
+	if productID == "" || supplierID == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id and supplierId are required"))
+		return
+	}
+
+	err := h.supplierService.UnlinkProduct(c, productID, supplierID)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "unlinked"})
+}
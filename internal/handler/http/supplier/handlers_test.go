@@ -0,0 +1,214 @@
+package supplier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/supplier/dto"
+	"github.com/sirawong/crud-arise/internal/services/supplier/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type SupplierHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockSupplierService
+	handler     *SupplierHandler
+	router      *gin.Engine
+}
+
+func (suite *SupplierHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockSupplierService(suite.mockCtrl)
+	suite.handler = NewSupplierHandler(suite.mockService)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	sup := v1.Group("/suppliers")
+	{
+		sup.POST("/", suite.handler.Create)
+		sup.GET("/", suite.handler.ListAll)
+		sup.GET("/:id", suite.handler.GetByID)
+		sup.PUT("/:id", suite.handler.Update)
+		sup.DELETE("/:id", suite.handler.Delete)
+		sup.GET("/:id/products", suite.handler.ListProducts)
+	}
+	prd := v1.Group("/products")
+	{
+		prd.GET("/:id/suppliers", suite.handler.ListProductSuppliers)
+		prd.PUT("/:id/suppliers/:supplierId", suite.handler.LinkProduct)
+		prd.DELETE("/:id/suppliers/:supplierId", suite.handler.UnlinkProduct)
+	}
+}
+
+func (suite *SupplierHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *SupplierHandlerTestSuite) TestCreate_Success() {
+
+	request := dto.SupplierRequest{
+		Name:         "Acme Seeds",
+		ContactEmail: "sales@acme.example",
+		LeadTimeDays: 14,
+		Currency:     "EUR",
+	}
+
+	suite.mockService.EXPECT().
+		Create(gomock.Any(), request.ToDomain()).
+		Return("supplier-123", nil).
+		Times(1)
+
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/api/v1/suppliers/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("supplier-123", response["id"])
+}
+
+func (suite *SupplierHandlerTestSuite) TestCreate_InvalidCurrency() {
+
+	invalidJSON := `{"name": "Acme Seeds", "currency": "EURO"}`
+
+	req, _ := http.NewRequest("POST", "/api/v1/suppliers/", bytes.NewBufferString(invalidJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *SupplierHandlerTestSuite) TestGetByID_NotFound() {
+
+	suite.mockService.EXPECT().
+		GetByID(gomock.Any(), "missing").
+		Return(nil, apperr.ErrNotFound.WithMessage("supplier not found")).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/suppliers/missing", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *SupplierHandlerTestSuite) TestDelete_Success() {
+
+	suite.mockService.EXPECT().
+		Delete(gomock.Any(), "supplier-123").
+		Return(nil).
+		Times(1)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/suppliers/supplier-123", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *SupplierHandlerTestSuite) TestListProducts_Success() {
+
+	links := []entity.ProductSupplier{
+		{
+			ProductID:   "product-123",
+			SupplierID:  "supplier-123",
+			SupplierSKU: "ACME-42",
+			CostPrice:   12.5,
+			Product:     &entity.Product{ID: "product-123", Name: "Tomato Seeds", SKU: "SEED-TOM-001"},
+		},
+	}
+
+	suite.mockService.EXPECT().
+		GetSupplierProducts(gomock.Any(), "supplier-123").
+		Return(links, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/suppliers/supplier-123/products", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response []dto.ProductSupplier
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Len(response, 1)
+	suite.Equal("SEED-TOM-001", response[0].Product.SKU)
+}
+
+func (suite *SupplierHandlerTestSuite) TestListProductSuppliers_Success() {
+
+	suite.mockService.EXPECT().
+		GetProductSuppliers(gomock.Any(), "product-123").
+		Return([]entity.ProductSupplier{{ProductID: "product-123", SupplierID: "supplier-123", Preferred: true}}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/products/product-123/suppliers", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response []dto.ProductSupplier
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.True(response[0].Preferred)
+}
+
+func (suite *SupplierHandlerTestSuite) TestLinkProduct_Success() {
+
+	request := dto.ProductSupplierRequest{
+		SupplierSKU:      "ACME-42",
+		CostPrice:        12.5,
+		MinOrderQuantity: 100,
+		Preferred:        true,
+	}
+
+	suite.mockService.EXPECT().
+		LinkProduct(gomock.Any(), request.ToDomain("product-123", "supplier-123")).
+		Return(nil).
+		Times(1)
+
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/products/%s/suppliers/%s", "product-123", "supplier-123"), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *SupplierHandlerTestSuite) TestUnlinkProduct_NotFound() {
+
+	suite.mockService.EXPECT().
+		UnlinkProduct(gomock.Any(), "product-123", "supplier-123").
+		Return(apperr.ErrNotFound.WithMessage("product supplier link not found")).
+		Times(1)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/products/product-123/suppliers/supplier-123", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func TestSupplierHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(SupplierHandlerTestSuite))
+}
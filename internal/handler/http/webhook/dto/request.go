@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/pkg/utils"
+)
+
+// WebhookRequest represents the request payload for creating/updating a webhook
+type WebhookRequest struct {
+	URL string `json:"url" binding:"required,url,max=2048"`
+	// EventTypes lists the events to deliver; all of them when empty.
+	EventTypes []string             `json:"eventTypes"`
+	Filter     WebhookFilterRequest `json:"filter"`
+	// Secret signs deliveries. It is generated on create and kept on update when empty.
+	Secret  string `json:"secret" binding:"omitempty,min=16,max=255"`
+	Enabled *bool  `json:"enabled"`
+} //	@name	WebhookRequest
+
+// WebhookFilterRequest narrows the events of a webhook to some categories and products
+type WebhookFilterRequest struct {
+	CategoryIDs []string `json:"categoryIds" binding:"omitempty,dive,uuid"`
+	ProductIDs  []string `json:"productIds" binding:"omitempty,dive,uuid"`
+} //	@name	WebhookFilterRequest
+
+func (r WebhookRequest) ToDomain() entity.Webhook {
+	eventTypes := make([]entity.EventType, 0, len(r.EventTypes))
+	for _, eventType := range r.EventTypes {
+		eventTypes = append(eventTypes, entity.EventType(eventType))
+	}
+	return entity.Webhook{
+		URL:        r.URL,
+		EventTypes: eventTypes,
+		Filter: entity.WebhookFilter{
+			CategoryIDs: r.Filter.CategoryIDs,
+			ProductIDs:  r.Filter.ProductIDs,
+		},
+		Secret:  r.Secret,
+		Enabled: r.Enabled == nil || *r.Enabled,
+	}
+}
+
+type FilterWebhooksRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+func (r FilterWebhooksRequest) ToDomain() entity.WebhooksFilter {
+	return entity.WebhooksFilter{
+		Pagination: entity.Pagination{
+			Limit:  r.Limit,
+			Offset: r.Offset,
+		},
+	}
+}
+
+type FilterDeliveriesRequest struct {
+	Status string `form:"status,omitempty"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+func (r FilterDeliveriesRequest) ToDomain() entity.WebhookDeliveriesFilter {
+	return entity.WebhookDeliveriesFilter{
+		Status: utils.SetPtr(entity.WebhookDeliveryStatus(r.Status)),
+		Pagination: entity.Pagination{
+			Limit:  r.Limit,
+			Offset: r.Offset,
+		},
+	}
+}
+
+// DeliveryURI names one delivery of a webhook in the path.
+type DeliveryURI struct {
+	ID         string `uri:"id" binding:"required"`
+	DeliveryID int64  `uri:"deliveryId" binding:"required,min=1"`
+}
@@ -0,0 +1,127 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// Webhook represents the response payload for a webhook. Its secret is only shown on create.
+type Webhook struct {
+	ID                  string        `json:"id"`
+	URL                 string        `json:"url"`
+	EventTypes          []string      `json:"eventTypes"`
+	Filter              WebhookFilter `json:"filter"`
+	Enabled             bool          `json:"enabled"`
+	DisabledReason      string        `json:"disabledReason,omitempty"`
+	ConsecutiveFailures int           `json:"consecutiveFailures"`
+	CreatedAt           time.Time     `json:"createdAt"`
+	UpdatedAt           time.Time     `json:"updatedAt"`
+} //	@name	Webhook
+
+// WebhookFilter represents the categories and products a webhook is narrowed to
+type WebhookFilter struct {
+	CategoryIDs []string `json:"categoryIds,omitempty"`
+	ProductIDs  []string `json:"productIds,omitempty"`
+} //	@name	WebhookFilter
+
+// CreatedWebhook represents a webhook just created, with the secret its deliveries are signed with
+type CreatedWebhook struct {
+	Webhook
+	Secret string `json:"secret"`
+} //	@name	CreatedWebhook
+
+// WebhookDelivery represents one event sent, or to be sent, to a webhook
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      string          `json:"webhookId"`
+	EventID        int64           `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"responseStatus,omitempty"`
+	ResponseBody   string          `json:"responseBody,omitempty"`
+	LastError      string          `json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"`
+	RedeliveryOf   *int64          `json:"redeliveryOf,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+} //	@name	WebhookDelivery
+
+func WebhookFromDomain(webhook *entity.Webhook) *Webhook {
+	if webhook == nil {
+		return nil
+	}
+	eventTypes := make([]string, 0, len(webhook.EventTypes))
+	for _, eventType := range webhook.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	return &Webhook{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		EventTypes: eventTypes,
+		Filter: WebhookFilter{
+			CategoryIDs: webhook.Filter.CategoryIDs,
+			ProductIDs:  webhook.Filter.ProductIDs,
+		},
+		Enabled:             webhook.Enabled,
+		DisabledReason:      webhook.DisabledReason,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		CreatedAt:           webhook.CreatedAt,
+		UpdatedAt:           webhook.UpdatedAt,
+	}
+}
+
+func WebhooksFromDomain(webhooks []entity.Webhook) []Webhook {
+	result := make([]Webhook, 0, len(webhooks))
+	for i := range webhooks {
+		result = append(result, *WebhookFromDomain(&webhooks[i]))
+	}
+	return result
+}
+
+func CreatedWebhookFromDomain(webhook *entity.Webhook) *CreatedWebhook {
+	if webhook == nil {
+		return nil
+	}
+	return &CreatedWebhook{
+		Webhook: *WebhookFromDomain(webhook),
+		Secret:  webhook.Secret,
+	}
+}
+
+func WebhookDeliveryFromDomain(delivery *entity.WebhookDelivery) *WebhookDelivery {
+	if delivery == nil {
+		return nil
+	}
+	var nextAttemptAt *time.Time
+	if delivery.Status == entity.WebhookDeliveryPending {
+		nextAttemptAt = &delivery.NextAttemptAt
+	}
+	return &WebhookDelivery{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  nextAttemptAt,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		Payload:        delivery.Payload,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func WebhookDeliveriesFromDomain(deliveries []entity.WebhookDelivery) []WebhookDelivery {
+	result := make([]WebhookDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		result = append(result, *WebhookDeliveryFromDomain(&deliveries[i]))
+	}
+	return result
+}
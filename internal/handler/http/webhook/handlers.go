@@ -0,0 +1,266 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	handlererr "github.com/sirawong/crud-arise/internal/handler/http/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/webhook/dto"
+	webhookSrv "github.com/sirawong/crud-arise/internal/services/webhook"
+)
+
+type WebhookHandler struct {
+	webhookService webhookSrv.WebhookService
+}
+
+func NewWebhookHandler(webhookService webhookSrv.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// Create godoc
+//
+//	@Summary		Create a webhook
+//	@Description	Subscribe a URL to product and category events. Deliveries are signed with the secret, which is generated when none is given and only shown in this response.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook	body		dto.WebhookRequest		true	"Webhook settings"
+//	@Success		201		{object}	dto.CreatedWebhook		"Created webhook with its secret"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/webhooks [post]
+func (h WebhookHandler) Create(c *gin.Context) {
+	var req dto.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	webhook, err := h.webhookService.Create(c, req.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreatedWebhookFromDomain(webhook))
+}
+
+// Update godoc
+//
+//	@Summary		Update a webhook
+//	@Description	Replace a webhook's settings. An empty secret keeps the current one; enabling a webhook clears its failures.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Webhook ID"
+//	@Param			webhook	body		dto.WebhookRequest		true	"Webhook settings"
+//	@Success		200		{object}	map[string]interface{}	"{"status": "updated"}"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/webhooks/{id} [put]
+func (h WebhookHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	var req dto.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	err := h.webhookService.Update(c, id, req.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "updated"})
+}
+
+// GetByID godoc
+//
+//	@Summary		Get a webhook by ID
+//	@Description	Get a single webhook by its ID, without its secret
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Webhook ID"
+//	@Success		200	{object}	dto.Webhook				"Webhook"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/webhooks/{id} [get]
+func (h WebhookHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	webhook, err := h.webhookService.GetByID(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.WebhookFromDomain(webhook))
+}
+
+// ListAll godoc
+//
+//	@Summary		Get all webhooks
+//	@Description	Get a list of all webhooks, oldest first
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset	query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200		{array}		dto.Webhook				"List of webhooks"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error	description"}"
+//	@Router			/webhooks [get]
+func (h WebhookHandler) ListAll(c *gin.Context) {
+	var query dto.FilterWebhooksRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	webhooks, err := h.webhookService.GetAll(c, query.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.WebhooksFromDomain(webhooks))
+}
+
+// Delete godoc
+//
+//	@Summary		Delete a webhook
+//	@Description	Delete a webhook by ID together with its deliveries
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Webhook ID"
+//	@Success		200	{object}	map[string]interface{}	"{"status": "deleted"}"
+//	@Failure		404	{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500	{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/webhooks/{id} [delete]
+func (h WebhookHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	err := h.webhookService.Delete(c, id)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// ListDeliveries godoc
+//
+//	@Summary		Get the deliveries of a webhook
+//	@Description	Get the delivery log of a webhook, newest first, with the outcome of each delivery's latest attempt
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Webhook ID"
+//	@Param			status	query		string					false	"Filter by status (pending, succeeded, failed)"
+//	@Param			limit	query		int						false	"Limit number of results (default: 10, limit: 100)"
+//	@Param			offset	query		int						false	"Offset for pagination (default: 0)"
+//	@Success		200		{array}		dto.WebhookDelivery		"List of deliveries"
+//	@Failure		400		{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404		{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500		{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/webhooks/{id}/deliveries [get]
+func (h WebhookHandler) ListDeliveries(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.WithMessage("id is required"))
+		return
+	}
+
+	var query dto.FilterDeliveriesRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c, id, query.ToDomain())
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.WebhookDeliveriesFromDomain(deliveries))
+}
+
+// GetDelivery godoc
+//
+//	@Summary		Get a delivery of a webhook
+//	@Description	Get one delivery of a webhook with the payload it sends
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Webhook ID"
+//	@Param			deliveryId	path		int						true	"Delivery ID"
+//	@Success		200			{object}	dto.WebhookDelivery		"Delivery"
+//	@Failure		400			{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404			{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/webhooks/{id}/deliveries/{deliveryId} [get]
+func (h WebhookHandler) GetDelivery(c *gin.Context) {
+	var uri dto.DeliveryURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	delivery, err := h.webhookService.GetDelivery(c, uri.ID, uri.DeliveryID)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.WebhookDeliveryFromDomain(delivery))
+}
+
+// Redeliver godoc
+//
+//	@Summary		Redeliver an event to a webhook
+//	@Description	Queue the event of a past delivery to be sent again, as a new delivery with the same payload. It fails with CONFLICT while the webhook is disabled.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Webhook ID"
+//	@Param			deliveryId	path		int						true	"Delivery ID"
+//	@Success		202			{object}	dto.WebhookDelivery		"Queued redelivery"
+//	@Failure		400			{object}	map[string]interface{}	"{"error_code": "INVALID_ARGUMENT", "message": "error	description"}"
+//	@Failure		404			{object}	map[string]interface{}	"{"error_code": "NOT_FOUND", "message": "error			description"}"
+//	@Failure		409			{object}	map[string]interface{}	"{"error_code": "CONFLICT", "message": "error			description"}"
+//	@Failure		500			{object}	map[string]interface{}	"{"error_code": "INTERNAL_ERROR", "message": "error		description"}"
+//	@Router			/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h WebhookHandler) Redeliver(c *gin.Context) {
+	var uri dto.DeliveryURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		handlererr.RespondWithError(c, apperr.ErrInvalidArgument.Wrap(err))
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(c, uri.ID, uri.DeliveryID)
+	if err != nil {
+		handlererr.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.WebhookDeliveryFromDomain(delivery))
+}
@@ -0,0 +1,204 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/handler/http/webhook/dto"
+	"github.com/sirawong/crud-arise/internal/services/webhook/mocks"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockService *mocks.MockWebhookService
+	handler     *WebhookHandler
+	router      *gin.Engine
+}
+
+func (suite *WebhookHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockService = mocks.NewMockWebhookService(suite.mockCtrl)
+	suite.handler = NewWebhookHandler(suite.mockService)
+	suite.router = gin.New()
+
+	v1 := suite.router.Group("/api/v1")
+	hooks := v1.Group("/webhooks")
+	{
+		hooks.POST("/", suite.handler.Create)
+		hooks.GET("/", suite.handler.ListAll)
+		hooks.GET("/:id", suite.handler.GetByID)
+		hooks.PUT("/:id", suite.handler.Update)
+		hooks.DELETE("/:id", suite.handler.Delete)
+		hooks.GET("/:id/deliveries", suite.handler.ListDeliveries)
+		hooks.GET("/:id/deliveries/:deliveryId", suite.handler.GetDelivery)
+		hooks.POST("/:id/deliveries/:deliveryId/redeliver", suite.handler.Redeliver)
+	}
+}
+
+func (suite *WebhookHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *WebhookHandlerTestSuite) TestCreate_Success() {
+	request := dto.WebhookRequest{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{"StockChanged"},
+		Filter:     dto.WebhookFilterRequest{CategoryIDs: []string{"5f0c7b4e-8f7c-4a53-9a5e-2f4d1c7e6b10"}},
+	}
+
+	suite.mockService.EXPECT().
+		Create(gomock.Any(), entity.Webhook{
+			URL:        "https://partner.example.com/hooks",
+			EventTypes: []entity.EventType{entity.EventStockChanged},
+			Filter:     entity.WebhookFilter{CategoryIDs: []string{"5f0c7b4e-8f7c-4a53-9a5e-2f4d1c7e6b10"}},
+			Enabled:    true,
+		}).
+		Return(&entity.Webhook{ID: "webhook-1", URL: request.URL, Secret: "generated", Enabled: true}, nil).
+		Times(1)
+
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/api/v1/webhooks/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+
+	var response dto.CreatedWebhook
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal("webhook-1", response.ID)
+	suite.Equal("generated", response.Secret)
+}
+
+func (suite *WebhookHandlerTestSuite) TestCreate_InvalidFilter() {
+	body := []byte(`{"url": "https://partner.example.com/hooks", "filter": {"categoryIds": ["drinks"]}}`)
+	req, _ := http.NewRequest("POST", "/api/v1/webhooks/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *WebhookHandlerTestSuite) TestUpdate_Disable() {
+	suite.mockService.EXPECT().
+		Update(gomock.Any(), "webhook-1", entity.Webhook{
+			URL:        "https://partner.example.com/hooks",
+			EventTypes: []entity.EventType{},
+			Enabled:    false,
+		}).
+		Return(nil).
+		Times(1)
+
+	body := []byte(`{"url": "https://partner.example.com/hooks", "enabled": false}`)
+	req, _ := http.NewRequest("PUT", "/api/v1/webhooks/webhook-1", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *WebhookHandlerTestSuite) TestGetByID_HidesSecret() {
+	suite.mockService.EXPECT().
+		GetByID(gomock.Any(), "webhook-1").
+		Return(&entity.Webhook{ID: "webhook-1", Secret: "shh"}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks/webhook-1", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.NotContains(w.Body.String(), "shh")
+}
+
+func (suite *WebhookHandlerTestSuite) TestDelete_NotFound() {
+	suite.mockService.EXPECT().
+		Delete(gomock.Any(), "webhook-1").
+		Return(apperr.ErrNotFound.WithMessage("webhook not found")).
+		Times(1)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/webhooks/webhook-1", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *WebhookHandlerTestSuite) TestListDeliveries_ByStatus() {
+	suite.mockService.EXPECT().
+		ListDeliveries(gomock.Any(), "webhook-1", entity.WebhookDeliveriesFilter{
+			Status:     utils.SetPtr(entity.WebhookDeliveryFailed),
+			Pagination: entity.Pagination{Limit: 5},
+		}).
+		Return([]entity.WebhookDelivery{{ID: 3, WebhookID: "webhook-1", Status: entity.WebhookDeliveryFailed, Attempts: 8, ResponseStatus: 500}}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks/webhook-1/deliveries?status=failed&limit=5", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response []dto.WebhookDelivery
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Len(response, 1)
+	suite.Equal(500, response[0].ResponseStatus)
+	suite.Nil(response[0].NextAttemptAt)
+}
+
+func (suite *WebhookHandlerTestSuite) TestGetDelivery_InvalidID() {
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks/webhook-1/deliveries/abc", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *WebhookHandlerTestSuite) TestRedeliver_Accepted() {
+	suite.mockService.EXPECT().
+		Redeliver(gomock.Any(), "webhook-1", int64(3)).
+		Return(&entity.WebhookDelivery{ID: 4, WebhookID: "webhook-1", Status: entity.WebhookDeliveryPending, RedeliveryOf: utils.SetPtr(int64(3))}, nil).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/webhooks/webhook-1/deliveries/3/redeliver", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusAccepted, w.Code)
+
+	var response dto.WebhookDelivery
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(int64(4), response.ID)
+	suite.Equal(utils.SetPtr(int64(3)), response.RedeliveryOf)
+}
+
+func (suite *WebhookHandlerTestSuite) TestRedeliver_Disabled() {
+	suite.mockService.EXPECT().
+		Redeliver(gomock.Any(), "webhook-1", int64(3)).
+		Return(nil, apperr.ErrConflict.WithMessage("webhook webhook-1 is disabled; enable it before redelivering")).
+		Times(1)
+
+	req, _ := http.NewRequest("POST", "/api/v1/webhooks/webhook-1/deliveries/3/redeliver", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func TestWebhookHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookHandlerTestSuite))
+}
@@ -0,0 +1,145 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+)
+
+// Dispatcher delivers the events of the outbox through a Publisher.
+type Dispatcher struct {
+	repo         repository.OutboxRepository
+	publisher    Publisher
+	policy       entity.OutboxPolicy
+	pollInterval time.Duration
+	now          func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher(repo repository.OutboxRepository, publisher Publisher, policy entity.OutboxPolicy, pollInterval time.Duration) *Dispatcher {
+	return &Dispatcher{
+		repo:         repo,
+		publisher:    publisher,
+		policy:       policy,
+		pollInterval: pollInterval,
+		now:          time.Now,
+	}
+}
+
+// Start delivers due events until Stop is called. A full batch is followed by the next one
+// right away; otherwise the dispatcher polls the outbox again after its poll interval.
+func (d *Dispatcher) Start() {
+	if d.pollInterval <= 0 {
+		log.Println("outbox: dispatcher disabled")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.loop(ctx)
+	}()
+}
+
+func (d *Dispatcher) loop(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		count, err := d.DispatchOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("outbox: dispatch failed: %v", err)
+		}
+
+		if err == nil && count == d.policy.BatchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(d.pollInterval)
+		}
+	}
+}
+
+// DispatchOnce claims a batch of due events, publishes each of them and records the outcome.
+// A failed delivery is retried after a backoff until the event runs out of attempts and is
+// dead-lettered. It returns how many events were claimed.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	events, err := d.repo.ClaimDue(ctx, d.policy.BatchSize, d.policy.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if ctx.Err() != nil {
+			// Unpublished events are claimed again once their lease has passed.
+			return len(events), ctx.Err()
+		}
+
+		if err := d.publisher.Publish(ctx, event); err != nil {
+			if err := d.fail(ctx, event, err); err != nil {
+				return len(events), err
+			}
+			continue
+		}
+
+		if err := d.repo.MarkPublished(ctx, event.ID); err != nil {
+			return len(events), err
+		}
+	}
+
+	return len(events), nil
+}
+
+func (d *Dispatcher) fail(ctx context.Context, event entity.DomainEvent, cause error) error {
+	attempts := event.Attempts + 1
+	if attempts >= d.policy.MaxAttempts {
+		log.Printf("outbox: event %d %s dead-lettered after %d attempts: %v", event.ID, event.Type, attempts, cause)
+		return d.repo.MarkDead(ctx, event.ID, cause.Error())
+	}
+
+	return d.repo.MarkFailed(ctx, event.ID, cause.Error(), d.now().Add(d.backoff(attempts)))
+}
+
+// backoff returns the wait after the given number of failed deliveries.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.policy.Backoff
+	for i := 1; i < attempts && backoff < d.policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, d.policy.MaxBackoff)
+}
+
+// Stop cancels delivery and waits for the dispatcher to return or for ctx to expire.
+func (d *Dispatcher) Stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+	d.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
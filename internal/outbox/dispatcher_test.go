@@ -0,0 +1,170 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type publisherFunc func(ctx context.Context, event entity.DomainEvent) error
+
+func (f publisherFunc) Publish(ctx context.Context, event entity.DomainEvent) error {
+	return f(ctx, event)
+}
+
+type DispatcherTestSuite struct {
+	suite.Suite
+	mockCtrl  *gomock.Controller
+	mockRepo  *mocks.MockOutboxRepository
+	publisher *MemoryPublisher
+	failing   map[int64]error
+	policy    entity.OutboxPolicy
+	now       time.Time
+	ctx       context.Context
+}
+
+func (suite *DispatcherTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockRepo = mocks.NewMockOutboxRepository(suite.mockCtrl)
+	suite.publisher = NewMemoryPublisher()
+	suite.failing = map[int64]error{}
+	suite.policy = entity.OutboxPolicy{
+		BatchSize:   10,
+		Lease:       time.Minute,
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		MaxBackoff:  5 * time.Second,
+	}
+	suite.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.ctx = context.Background()
+}
+
+func (suite *DispatcherTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *DispatcherTestSuite) dispatcher() *Dispatcher {
+	d := NewDispatcher(suite.mockRepo, publisherFunc(func(ctx context.Context, event entity.DomainEvent) error {
+		if err, ok := suite.failing[event.ID]; ok {
+			return err
+		}
+		return suite.publisher.Publish(ctx, event)
+	}), suite.policy, time.Second)
+	d.now = func() time.Time { return suite.now }
+	return d
+}
+
+func (suite *DispatcherTestSuite) TestDispatchOnce_PublishesInOrder() {
+	events := []entity.DomainEvent{
+		{ID: 1, Type: entity.EventProductCreated},
+		{ID: 2, Type: entity.EventStockChanged},
+	}
+	gomock.InOrder(
+		suite.mockRepo.EXPECT().ClaimDue(suite.ctx, 10, time.Minute).Return(events, nil).Times(1),
+		suite.mockRepo.EXPECT().MarkPublished(suite.ctx, int64(1)).Return(nil).Times(1),
+		suite.mockRepo.EXPECT().MarkPublished(suite.ctx, int64(2)).Return(nil).Times(1),
+	)
+
+	count, err := suite.dispatcher().DispatchOnce(suite.ctx)
+
+	suite.NoError(err)
+	suite.Equal(2, count)
+	suite.Equal(events, suite.publisher.Events())
+}
+
+func (suite *DispatcherTestSuite) TestDispatchOnce_RetriesWithBackoff() {
+	suite.failing[1] = errors.New("broker unavailable")
+	suite.mockRepo.EXPECT().
+		ClaimDue(suite.ctx, 10, time.Minute).
+		Return([]entity.DomainEvent{{ID: 1, Attempts: 2}, {ID: 2}}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		MarkFailed(suite.ctx, int64(1), "broker unavailable", suite.now.Add(4*time.Second)).
+		Return(nil).
+		Times(1)
+	suite.mockRepo.EXPECT().MarkPublished(suite.ctx, int64(2)).Return(nil).Times(1)
+
+	count, err := suite.dispatcher().DispatchOnce(suite.ctx)
+
+	suite.NoError(err)
+	suite.Equal(2, count)
+	suite.Len(suite.publisher.Events(), 1)
+}
+
+func (suite *DispatcherTestSuite) TestDispatchOnce_BackoffIsCapped() {
+	suite.failing[1] = errors.New("broker unavailable")
+	suite.mockRepo.EXPECT().
+		ClaimDue(suite.ctx, 10, time.Minute).
+		Return([]entity.DomainEvent{{ID: 1, Attempts: 3}}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		MarkFailed(suite.ctx, int64(1), "broker unavailable", suite.now.Add(5*time.Second)).
+		Return(nil).
+		Times(1)
+
+	_, err := suite.dispatcher().DispatchOnce(suite.ctx)
+
+	suite.NoError(err)
+}
+
+func (suite *DispatcherTestSuite) TestDispatchOnce_DeadLettersLastAttempt() {
+	suite.failing[1] = errors.New("rejected")
+	suite.mockRepo.EXPECT().
+		ClaimDue(suite.ctx, 10, time.Minute).
+		Return([]entity.DomainEvent{{ID: 1, Attempts: 4}}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().MarkDead(suite.ctx, int64(1), "rejected").Return(nil).Times(1)
+
+	_, err := suite.dispatcher().DispatchOnce(suite.ctx)
+
+	suite.NoError(err)
+	suite.Empty(suite.publisher.Events())
+}
+
+func (suite *DispatcherTestSuite) TestDispatchOnce_ClaimError() {
+	suite.mockRepo.EXPECT().
+		ClaimDue(suite.ctx, 10, time.Minute).
+		Return(nil, apperr.ErrInternal).
+		Times(1)
+
+	count, err := suite.dispatcher().DispatchOnce(suite.ctx)
+
+	suite.Error(err)
+	suite.Equal(0, count)
+}
+
+func (suite *DispatcherTestSuite) TestDispatchOnce_StopsOnMarkError() {
+	suite.mockRepo.EXPECT().
+		ClaimDue(suite.ctx, 10, time.Minute).
+		Return([]entity.DomainEvent{{ID: 1}, {ID: 2}}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().MarkPublished(suite.ctx, int64(1)).Return(apperr.ErrInternal).Times(1)
+
+	_, err := suite.dispatcher().DispatchOnce(suite.ctx)
+
+	suite.Error(err)
+	suite.Len(suite.publisher.Events(), 1)
+}
+
+func (suite *DispatcherTestSuite) TestStartStop() {
+	suite.mockRepo.EXPECT().ClaimDue(gomock.Any(), 10, time.Minute).Return(nil, nil).MinTimes(1)
+	d := suite.dispatcher()
+
+	d.Start()
+	suite.Eventually(func() bool { return suite.mockCtrl.Satisfied() }, time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(suite.ctx, time.Second)
+	defer cancel()
+	suite.NoError(d.Stop(ctx))
+}
+
+func TestDispatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherTestSuite))
+}
@@ -0,0 +1,67 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+// Publisher delivers domain events out of the outbox. Delivery is at least once, so an event
+// may be published again after a failure or a restart; consumers deduplicate on its ID.
+type Publisher interface {
+	Publish(ctx context.Context, event entity.DomainEvent) error
+}
+
+// LogPublisher publishes events by writing them to the standard logger.
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, event entity.DomainEvent) error {
+	log.Printf("outbox: event %d %s %s %s by %s: %s",
+		event.ID, event.Type, event.AggregateType, event.AggregateID, event.Actor, event.Payload)
+	return nil
+}
+
+// MemoryPublisher keeps published events in memory, for tests and single-process setups.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []entity.DomainEvent
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, event entity.DomainEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events returns the events published so far, in the order they were published.
+func (p *MemoryPublisher) Events() []entity.DomainEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]entity.DomainEvent(nil), p.events...)
+}
+
+// Publishers publishes every event through each of its publishers in turn. When one fails the
+// event is published through all of them again, so each must tolerate duplicates.
+type Publishers []Publisher
+
+func (p Publishers) Publish(ctx context.Context, event entity.DomainEvent) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+// auditedTables are the tables whose changes are audited, by the entity type they hold.
+var auditedTables = map[entity.AuditEntityType]string{
+	entity.AuditEntityProduct:  models.ProductModel{}.TableName(),
+	entity.AuditEntityCategory: models.CategoryModel{}.TableName(),
+}
+
+// auditIgnoredColumns change along with every write and would only add noise to a diff.
+var auditIgnoredColumns = map[string]bool{"created_at": true, "updated_at": true, "version": true}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) repository.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (a auditRepository) FindAll(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	query := dbFrom(ctx, a.db).Model(&models.AuditEntryModel{})
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("occurred_at < ?", *filter.To)
+	}
+
+	var entries []models.AuditEntryModel
+	err := query.Order("occurred_at DESC, id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&entries).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToAuditEntriesEntity(entries), nil
+}
+
+// recorded runs write in a transaction, or a savepoint of the one ctx carries, and records an audit
+// entry, a revision, an entry of the changes feed and the outbox events announcing the change for
+// every row of entityType's table that write changed. ids names the rows known beforehand; write
+// returns the ones it created or only learnt of while writing.
+func recorded(ctx context.Context, db *gorm.DB, entityType entity.AuditEntityType, ids []string, write func(ctx context.Context) ([]string, error)) error {
+	err := dbFrom(ctx, db).Transaction(func(tx *gorm.DB) error {
+		return recordChanges(tx, entityType, ids, func(tx *gorm.DB) ([]string, error) {
+			return write(context.WithValue(ctx, txKey{}, tx))
+		})
+	})
+	if err != nil {
+		var appErr *apperr.AppError
+		if errors.As(err, &appErr) {
+			return err
+		}
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
+
+// recordChanges is recorded for writes that are already part of transaction tx. The actor is
+// taken from the context tx was started with.
+func recordChanges(tx *gorm.DB, entityType entity.AuditEntityType, ids []string, write func(tx *gorm.DB) ([]string, error)) error {
+	table := auditedTables[entityType]
+	before, err := snapshot(tx, table, ids)
+	if err != nil {
+		return err
+	}
+
+	written, err := write(tx)
+	if err != nil {
+		return err
+	}
+
+	ids = slices.Compact(slices.Sorted(slices.Values(slices.Concat(ids, written))))
+	after, err := snapshot(tx, table, ids)
+	if err != nil {
+		return err
+	}
+
+	actor := entity.ActorFrom(tx.Statement.Context)
+	now := time.Now()
+	var entries []*models.AuditEntryModel
+	var revised, purged []string
+	var actions []entity.AuditAction
+	var events []*models.OutboxEventModel
+	fields := make(map[string][]string)
+	for _, id := range ids {
+		prev, next := before[id], after[id]
+		if prev == nil && next == nil {
+			continue
+		}
+		action, changes := auditDiff(prev, next)
+		if action == entity.AuditActionUpdate && len(changes) == 0 {
+			continue
+		}
+		if action == entity.AuditActionPurge {
+			purged = append(purged, id)
+		} else {
+			revised = append(revised, id)
+			actions = append(actions, action)
+		}
+		announced, err := domainEvents(entityType, id, action, changes, prev, next, actor, now)
+		if err != nil {
+			return err
+		}
+		events = append(events, announced...)
+		if action == entity.AuditActionUpdate {
+			fields[id] = slices.Sorted(maps.Keys(changes))
+		}
+		entries = append(entries, models.ToAuditEntryModel(&entity.AuditEntry{
+			Actor:      actor.Name,
+			RequestID:  actor.RequestID,
+			OccurredAt: now,
+			EntityType: entityType,
+			EntityID:   id,
+			Action:     action,
+			Changes:    changes,
+		}))
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if err := tx.CreateInBatches(entries, batchChunkSize).Error; err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	numbers, err := recordRevisions(tx, entityType, revised, actions, actor, now)
+	if err != nil {
+		return err
+	}
+	if err := forgetRevisions(tx, entityType, purged); err != nil {
+		return err
+	}
+
+	changes := make([]*models.ChangeModel, 0, len(entries))
+	for _, entry := range entries {
+		changes = append(changes, models.ToChangeModel(&entity.Change{
+			EntityType: entityType,
+			EntityID:   entry.EntityID,
+			Action:     entity.AuditAction(entry.Action),
+			Revision:   numbers[entry.EntityID],
+			Fields:     fields[entry.EntityID],
+			OccurredAt: now,
+		}))
+	}
+	if err := tx.CreateInBatches(changes, batchChunkSize).Error; err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	if err := tx.CreateInBatches(events, batchChunkSize).Error; err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
+
+// snapshot locks and loads the rows of table with ids, soft-deleted ones included, as JSON objects
+// keyed by column name. Missing rows are left out. Locking keeps the state read before a write
+// from going stale before the write gets to the rows.
+func snapshot(tx *gorm.DB, table string, ids []string) (map[string]map[string]interface{}, error) {
+	rows := make(map[string]map[string]interface{}, len(ids))
+	for start := 0; start < len(ids); start += batchChunkSize {
+		var found []struct {
+			ID   string
+			Data string
+		}
+		err := tx.Raw(fmt.Sprintf("SELECT t.id::text AS id, to_jsonb(t)::text AS data FROM %s AS t WHERE t.id IN ? ORDER BY t.id FOR UPDATE", table),
+			ids[start:min(start+batchChunkSize, len(ids))]).Scan(&found).Error
+		if err != nil {
+			return nil, apperr.ErrInternal.Wrap(err)
+		}
+
+		for _, row := range found {
+			var data map[string]interface{}
+			if err := json.Unmarshal([]byte(row.Data), &data); err != nil {
+				return nil, apperr.ErrInternal.Wrap(err)
+			}
+			rows[row.ID] = data
+		}
+	}
+	return rows, nil
+}
+
+// auditDiff tells what a write did to a row from its state before and after, nil where the row
+// did not exist, and lists the columns it changed.
+func auditDiff(prev, next map[string]interface{}) (entity.AuditAction, map[string]entity.FieldChange) {
+	changes := make(map[string]entity.FieldChange)
+	for column := range mergedKeys(prev, next) {
+		if auditIgnoredColumns[column] || reflect.DeepEqual(prev[column], next[column]) {
+			continue
+		}
+		changes[column] = entity.FieldChange{Old: prev[column], New: next[column]}
+	}
+
+	switch {
+	case prev == nil:
+		return entity.AuditActionCreate, changes
+	case next == nil:
+		return entity.AuditActionPurge, changes
+	case prev["deleted_at"] == nil && next["deleted_at"] != nil:
+		return entity.AuditActionDelete, changes
+	case prev["deleted_at"] != nil && next["deleted_at"] == nil:
+		return entity.AuditActionRestore, changes
+	default:
+		return entity.AuditActionUpdate, changes
+	}
+}
+
+func mergedKeys(maps ...map[string]interface{}) map[string]bool {
+	keys := make(map[string]bool)
+	for _, m := range maps {
+		for key := range m {
+			keys[key] = true
+		}
+	}
+	return keys
+}
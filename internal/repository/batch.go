@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// batchChunkSize caps the rows of a single multi-row statement, which keeps it well below the
+// 65535 bind parameters Postgres accepts.
+const batchChunkSize = 500
+
+// softDeleteManySQL soft-deletes the rows named in a VALUES list of (id, version), honouring a
+// non-zero version the same way a single conditional delete does.
+const softDeleteManySQL = `UPDATE %s AS t SET deleted_at = ?
+FROM (VALUES %%s) AS v(id, version)
+WHERE t.id = v.id AND t.deleted_at IS NULL AND (v.version = 0 OR t.version = v.version)
+RETURNING t.id::text`
+
+// writeMany runs query, an UPDATE ... FROM (VALUES %s) statement that returns the id of every
+// row it wrote, once per chunk of rows. The first argument of query is now. It returns one error
+// per ref, nil for the rows that were written, and fails with CONFLICT, saying duplicate, when a
+// chunk breaks a unique index.
+func writeMany(ctx context.Context, db *gorm.DB, model interface{}, query, placeholders, duplicate string, refs []entity.VersionedID, rows [][]interface{}) ([]error, error) {
+	now := time.Now()
+	written := make(map[string]bool, len(rows))
+	for start := 0; start < len(rows); start += batchChunkSize {
+		values, args := valuesList(placeholders, rows[start:min(start+batchChunkSize, len(rows))])
+
+		var ids []string
+		err := dbFrom(ctx, db).Raw(fmt.Sprintf(query, values), append([]interface{}{now}, args...)...).Scan(&ids).Error
+		if err != nil {
+			return nil, writeFailed(err, duplicate)
+		}
+		for _, id := range ids {
+			written[id] = true
+		}
+	}
+
+	errs := make([]error, len(refs))
+	for i, ref := range refs {
+		if !written[ref.ID] {
+			errs[i] = notWritten(ctx, db, model, ref.ID, ref.Version)
+		}
+	}
+	return errs, nil
+}
+
+// softDeleteMany soft-deletes the rows of model's table named by refs.
+func softDeleteMany(ctx context.Context, db *gorm.DB, model interface{}, table string, refs []entity.VersionedID) ([]error, error) {
+	rows := make([][]interface{}, 0, len(refs))
+	for _, ref := range refs {
+		rows = append(rows, []interface{}{ref.ID, ref.Version})
+	}
+
+	// Deleting cannot break a partial unique index on live rows.
+	return writeMany(ctx, db, model, fmt.Sprintf(softDeleteManySQL, table), "(?::uuid, ?::int)", "", refs, rows)
+}
+
+// valuesList repeats placeholders, one parenthesised row such as "(?::uuid, ?)", for every row
+// and returns the rows' arguments flattened in the same order.
+func valuesList(placeholders string, rows [][]interface{}) (string, []interface{}) {
+	list := make([]string, 0, len(rows))
+	var args []interface{}
+	for _, row := range rows {
+		list = append(list, placeholders)
+		args = append(args, row...)
+	}
+	return strings.Join(list, ", "), args
+}
+
+// refIDs returns the ids refs name, in the same order.
+func refIDs(refs []entity.VersionedID) []string {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, ref.ID)
+	}
+	return ids
+}
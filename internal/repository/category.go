@@ -3,14 +3,22 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/sirawong/crud-arise/internal/domain/entity"
 	"github.com/sirawong/crud-arise/internal/domain/repository"
 	apperr "github.com/sirawong/crud-arise/internal/errors"
 	"github.com/sirawong/crud-arise/internal/repository/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// duplicateName explains a write that would give two live categories the same name.
+const duplicateName = "name is already taken by another category"
+
+// categoryReferenced explains why a soft-deleted category cannot be purged.
+const categoryReferenced = "category still has products, deleted ones included"
+
 type categoryRepository struct {
 	db *gorm.DB
 }
@@ -28,18 +36,56 @@ func (c categoryRepository) Create(ctx context.Context, category *entity.Categor
 	if createModel == nil {
 		return "", apperr.ErrInvalidArgument.WithMessage("createModel cannot be nil")
 	}
-	err := c.db.WithContext(ctx).Create(&createModel).Error
+	err := recorded(ctx, c.db, entity.AuditEntityCategory, nil, func(ctx context.Context) ([]string, error) {
+		err := dbFrom(ctx, c.db).Create(&createModel).Error
+		if err != nil {
+			return nil, writeFailed(err, duplicateName)
+		}
+		return []string{createModel.ID}, nil
+	})
 	if err != nil {
-		return "", apperr.ErrInternal.Wrap(err)
+		return "", err
 	}
 
 	return createModel.ID, nil
 }
 
+// CreateMany inserts the categories with multi-row INSERTs and returns their IDs in the same order.
+func (c categoryRepository) CreateMany(ctx context.Context, categories []entity.Category) ([]string, error) {
+	values := make([]*models.CategoryModel, 0, len(categories))
+	for i := range categories {
+		values = append(values, models.ToCategoryModel(&categories[i]))
+	}
+
+	var ids []string
+	err := recorded(ctx, c.db, entity.AuditEntityCategory, nil, func(ctx context.Context) ([]string, error) {
+		err := dbFrom(ctx, c.db).CreateInBatches(values, batchChunkSize).Error
+		if err != nil {
+			return nil, writeFailed(err, duplicateName)
+		}
+
+		ids = make([]string, 0, len(values))
+		for _, value := range values {
+			ids = append(ids, value.ID)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FindByID share-locks the category when called inside a transaction, so it cannot be deleted
+// or changed before the transaction ends.
 func (c categoryRepository) FindByID(ctx context.Context, id string) (*entity.Category, error) {
 	var category models.CategoryModel
 
-	err := c.db.WithContext(ctx).First(&category, "id = ?", id).Error
+	query := dbFrom(ctx, c.db)
+	if inTx(ctx) {
+		query = query.Clauses(clause.Locking{Strength: "SHARE"})
+	}
+	err := query.First(&category, "id = ?", id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, apperr.ErrNotFound.Wrap(err)
@@ -49,12 +95,22 @@ func (c categoryRepository) FindByID(ctx context.Context, id string) (*entity.Ca
 	return models.ToCategoryEntity(&category), nil
 }
 
-func (c categoryRepository) FindAll(ctx context.Context, filter entity.CategoriesFilter) ([]entity.Category, error) {
-	query := c.db.WithContext(ctx).Model(&models.CategoryModel{})
-	if filter.Name != nil {
-		searchPattern := "%" + *filter.Name + "%"
-		query.Where("name ILIKE ?", searchPattern)
+// FindByName loads the live category whose name matches name once both are normalized the way the
+// unique index compares them.
+func (c categoryRepository) FindByName(ctx context.Context, name string) (*entity.Category, error) {
+	var category models.CategoryModel
+	err := dbFrom(ctx, c.db).First(&category, normalized("name")+" = "+normalized("?"), name).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.Wrap(err)
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
 	}
+	return models.ToCategoryEntity(&category), nil
+}
+
+func (c categoryRepository) FindAll(ctx context.Context, filter entity.CategoriesFilter) ([]entity.Category, error) {
+	query := c.filtered(ctx, filter)
 	query = query.Limit(filter.Limit).Offset(filter.Offset)
 
 	var categories []models.CategoryModel
@@ -66,27 +122,189 @@ func (c categoryRepository) FindAll(ctx context.Context, filter entity.Categorie
 	return models.ToCategoriesEntity(categories), nil
 }
 
+func (c categoryRepository) Stats(ctx context.Context, filter entity.CategoriesFilter) (*entity.ListStats, error) {
+	var stats models.ListStatsRow
+	err := c.filtered(ctx, filter).
+		Select("COUNT(*) AS count, MAX(updated_at) AS last_modified").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToListStatsEntity(stats), nil
+}
+
+func (c categoryRepository) filtered(ctx context.Context, filter entity.CategoriesFilter) *gorm.DB {
+	query := dbFrom(ctx, c.db).Model(&models.CategoryModel{})
+	if filter.Name != nil {
+		searchPattern := "%" + *filter.Name + "%"
+		query = query.Where("name ILIKE ?", searchPattern)
+	}
+	return query
+}
+
 func (c categoryRepository) Update(ctx context.Context, category *entity.Category) error {
 	if category == nil {
 		return apperr.ErrInvalidArgument.WithMessage("category cannot be nil")
 	}
 
-	err := c.db.WithContext(ctx).Model(&models.CategoryModel{}).
-		Where("id = ?", category.ID).
-		Updates(map[string]interface{}{
-			"name": category.Name,
-		}).Error
+	return c.update(ctx, category.ID, category.Version, map[string]interface{}{
+		"name": category.Name,
+	})
+}
+
+func (c categoryRepository) Patch(ctx context.Context, id string, patch entity.CategoryPatch) error {
+	update := make(map[string]interface{})
+	if patch.Name != nil {
+		update["name"] = *patch.Name
+	}
+	if len(update) == 0 {
+		return apperr.ErrInvalidArgument.WithMessage("category patch cannot be empty")
+	}
+
+	return c.update(ctx, id, patch.Version, update)
+}
+
+// update writes values to the category and bumps its version. A non-zero version makes the
+// write conditional on the category still being at that version.
+func (c categoryRepository) update(ctx context.Context, id string, version int, values map[string]interface{}) error {
+	values["version"] = gorm.Expr("version + 1")
+
+	return recorded(ctx, c.db, entity.AuditEntityCategory, []string{id}, func(ctx context.Context) ([]string, error) {
+		query := dbFrom(ctx, c.db).Model(&models.CategoryModel{}).Where("id = ?", id)
+		if version > 0 {
+			query = query.Where("version = ?", version)
+		}
+
+		result := query.Updates(values)
+		if result.Error != nil {
+			return nil, writeFailed(result.Error, duplicateName)
+		}
+		if result.RowsAffected == 0 {
+			return nil, notWritten(ctx, c.db, &models.CategoryModel{}, id, version)
+		}
+		return nil, nil
+	})
+}
+
+// updateCategoriesSQL renames the categories named in a VALUES list, the same way Update does for
+// a single category.
+const updateCategoriesSQL = `UPDATE categories AS c
+SET name = v.name, version = c.version + 1, updated_at = ?
+FROM (VALUES %s) AS v(id, version, name)
+WHERE c.id = v.id AND c.deleted_at IS NULL AND (v.version = 0 OR c.version = v.version)
+RETURNING c.id::text`
+
+// UpdateMany replaces the categories with multi-row UPDATEs. It returns one error per category,
+// nil for the categories that were written; the error is NOT_FOUND or PRECONDITION_FAILED as for
+// Update.
+func (c categoryRepository) UpdateMany(ctx context.Context, categories []entity.Category) ([]error, error) {
+	refs := make([]entity.VersionedID, 0, len(categories))
+	rows := make([][]interface{}, 0, len(categories))
+	for _, category := range categories {
+		refs = append(refs, entity.VersionedID{ID: category.ID, Version: category.Version})
+		rows = append(rows, []interface{}{category.ID, category.Version, category.Name})
+	}
+
+	var errs []error
+	err := recorded(ctx, c.db, entity.AuditEntityCategory, refIDs(refs), func(ctx context.Context) ([]string, error) {
+		var err error
+		errs, err = writeMany(ctx, c.db, &models.CategoryModel{}, updateCategoriesSQL, "(?::uuid, ?::int, ?::text)", duplicateName, refs, rows)
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+// Delete soft-deletes the category, failing with NOT_FOUND when there is no such category. A non-zero
+// version makes the delete conditional on the category still being at that version.
+func (c categoryRepository) Delete(ctx context.Context, id string, version int) error {
+	return recorded(ctx, c.db, entity.AuditEntityCategory, []string{id}, func(ctx context.Context) ([]string, error) {
+		query := dbFrom(ctx, c.db)
+		if version > 0 {
+			query = query.Where("version = ?", version)
+		}
+
+		result := query.Delete(&models.CategoryModel{}, "id = ?", id)
+		if result.Error != nil {
+			return nil, apperr.ErrInternal.Wrap(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil, notWritten(ctx, c.db, &models.CategoryModel{}, id, version)
+		}
+		return nil, nil
+	})
+}
+
+// DeleteMany soft-deletes the categories with multi-row UPDATEs. It returns one error per ref, nil
+// for the categories that were deleted.
+func (c categoryRepository) DeleteMany(ctx context.Context, refs []entity.VersionedID) ([]error, error) {
+	var errs []error
+	err := recorded(ctx, c.db, entity.AuditEntityCategory, refIDs(refs), func(ctx context.Context) ([]string, error) {
+		var err error
+		errs, err = softDeleteMany(ctx, c.db, &models.CategoryModel{}, models.CategoryModel{}.TableName(), refs)
+		return nil, err
+	})
 	if err != nil {
-		return apperr.ErrInternal.Wrap(err)
+		return nil, err
 	}
+	return errs, nil
+}
 
-	return nil
+// FindDeleted lists soft-deleted categories, most recently deleted first.
+func (c categoryRepository) FindDeleted(ctx context.Context, pagination entity.Pagination) ([]entity.Category, error) {
+	var categories []models.CategoryModel
+	err := findDeleted(dbFrom(ctx, c.db), &categories, pagination.Limit, pagination.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return models.ToCategoriesEntity(categories), nil
 }
 
-func (c categoryRepository) Delete(ctx context.Context, id string) error {
-	err := c.db.WithContext(ctx).Delete(&models.CategoryModel{}, "id = ?", id).Error
+// FindDeletedByID loads a soft-deleted category.
+func (c categoryRepository) FindDeletedByID(ctx context.Context, id string) (*entity.Category, error) {
+	var category models.CategoryModel
+	err := firstDeleted(dbFrom(ctx, c.db), &category, id)
 	if err != nil {
-		return apperr.ErrInternal.Wrap(err)
+		return nil, err
+	}
+	return models.ToCategoryEntity(&category), nil
+}
+
+// Restore brings a soft-deleted category back.
+func (c categoryRepository) Restore(ctx context.Context, id string) error {
+	return recorded(ctx, c.db, entity.AuditEntityCategory, []string{id}, func(ctx context.Context) ([]string, error) {
+		return nil, restore(ctx, c.db, &models.CategoryModel{}, id, map[string]interface{}{}, duplicateName)
+	})
+}
+
+// Purge removes a soft-deleted category for good. Products, deleted ones included, keep it alive.
+func (c categoryRepository) Purge(ctx context.Context, id string) error {
+	return recorded(ctx, c.db, entity.AuditEntityCategory, []string{id}, func(ctx context.Context) ([]string, error) {
+		return nil, purge(ctx, c.db, &models.CategoryModel{}, id, categoryReferenced)
+	})
+}
+
+func (c categoryRepository) FindExpiredIDs(ctx context.Context, deletedBefore time.Time, afterID string, limit int) ([]string, error) {
+	return findExpiredIDs(ctx, c.db, &models.CategoryModel{}, deletedBefore, afterID, limit)
+}
+
+func (c categoryRepository) PurgeMany(ctx context.Context, ids []string) error {
+	return recorded(ctx, c.db, entity.AuditEntityCategory, ids, func(ctx context.Context) ([]string, error) {
+		return nil, purgeMany(ctx, c.db, &models.CategoryModel{}, ids, categoryReferenced)
+	})
+}
+
+func (c categoryRepository) FindRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error) {
+	return findRevisions(ctx, c.db, entity.AuditEntityCategory, id, pagination)
+}
+
+func (c categoryRepository) FindRevision(ctx context.Context, id string, number int) (*entity.CategoryRevision, error) {
+	var revision models.CategoryRevisionModel
+	if err := findRevision(ctx, c.db, entity.AuditEntityCategory, id, number, &revision); err != nil {
+		return nil, err
 	}
-	return nil
+	return models.ToCategoryRevisionEntity(&revision), nil
 }
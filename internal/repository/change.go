@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type changeRepository struct {
+	db *gorm.DB
+}
+
+func NewChangeRepository(db *gorm.DB) repository.ChangeRepository {
+	return &changeRepository{db: db}
+}
+
+// FindSince reads the changes and then the revisions they stored. Uncommitted changes have no
+// sequence number yet, so they are not read until they are final.
+func (c changeRepository) FindSince(ctx context.Context, since int64, limit int) ([]entity.Change, error) {
+	var rows []models.ChangeModel
+	err := dbFrom(ctx, c.db).Where("seq > ?", since).Order("seq").Limit(limit).Find(&rows).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	keys := make(map[entity.AuditEntityType][][]interface{})
+	for _, row := range rows {
+		if row.Revision != nil {
+			entityType := entity.AuditEntityType(row.EntityType)
+			keys[entityType] = append(keys[entityType], []interface{}{row.EntityID, *row.Revision})
+		}
+	}
+
+	var products []models.ProductRevisionModel
+	if err := findRevisionsByKeys(ctx, c.db, entity.AuditEntityProduct, keys[entity.AuditEntityProduct], &products); err != nil {
+		return nil, err
+	}
+	var categories []models.CategoryRevisionModel
+	if err := findRevisionsByKeys(ctx, c.db, entity.AuditEntityCategory, keys[entity.AuditEntityCategory], &categories); err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		id       string
+		revision int
+	}
+	productsByKey := make(map[key]*entity.Product, len(products))
+	for i := range products {
+		revision := products[i].RevisionHeader
+		productsByKey[key{revision.EntityID, revision.Revision}] = models.ToProductEntity(&products[i].ProductModel)
+	}
+	categoriesByKey := make(map[key]*entity.Category, len(categories))
+	for i := range categories {
+		revision := categories[i].RevisionHeader
+		categoriesByKey[key{revision.EntityID, revision.Revision}] = models.ToCategoryEntity(&categories[i].CategoryModel)
+	}
+
+	changes := make([]entity.Change, 0, len(rows))
+	for i := range rows {
+		change := *models.ToChangeEntity(&rows[i])
+		switch change.EntityType {
+		case entity.AuditEntityProduct:
+			change.Product = productsByKey[key{change.EntityID, change.Revision}]
+		case entity.AuditEntityCategory:
+			change.Category = categoriesByKey[key{change.EntityID, change.Revision}]
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func (c changeRepository) LastSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	err := dbFrom(ctx, c.db).Model(&models.ChangeModel{}).Select("COALESCE(MAX(seq), 0)").Scan(&seq).Error
+	if err != nil {
+		return 0, apperr.ErrInternal.Wrap(err)
+	}
+	return seq, nil
+}
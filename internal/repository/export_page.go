@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type exportPageRepository struct {
+	db *gorm.DB
+}
+
+func NewExportPageRepository(db *gorm.DB) repository.ExportPageRepository {
+	return &exportPageRepository{db: db}
+}
+
+func (e exportPageRepository) Save(ctx context.Context, jobID string, page int, products json.RawMessage) error {
+	err := dbFrom(ctx, e.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "job_id"}, {Name: "page"}},
+			DoUpdates: clause.AssignmentColumns([]string{"products"}),
+		}).
+		Create(&models.ExportPageModel{JobID: jobID, Page: page, Products: string(products)}).Error
+	if err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
+
+func (e exportPageRepository) Find(ctx context.Context, jobID string, page int) (json.RawMessage, error) {
+	var value models.ExportPageModel
+	err := dbFrom(ctx, e.db).First(&value, "job_id = ? AND page = ?", jobID, page).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.WithMessage("export page not found")
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return json.RawMessage(value.Products), nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) repository.IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+func (i idempotencyRepository) Reserve(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error) {
+	if record == nil {
+		return nil, apperr.ErrInvalidArgument.WithMessage("idempotency record cannot be nil")
+	}
+
+	// An expired record is taken over in place, so the insert and the takeover are a single
+	// statement and two concurrent requests can never both win the key.
+	value := models.ToIdempotencyKeyModel(record)
+	result := dbFrom(ctx, i.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "client_id"}, {Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"token", "request_hash", "status", "response_status", "response_headers", "response_body", "created_at", "expires_at",
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "idempotency_keys.expires_at <= ?", Vars: []interface{}{record.CreatedAt}},
+			}},
+		}).
+		Create(value)
+	if result.Error != nil {
+		return nil, apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	var existing models.IdempotencyKeyModel
+	err := dbFrom(ctx, i.db).
+		Where("client_id = ? AND key = ?", record.ClientID, record.Key).
+		First(&existing).Error
+	if err != nil {
+		// The record that blocked the insert was released or purged before it could be read.
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrConflict.WithMessage("idempotency key was released while it was being reserved, retry the request")
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToIdempotencyRecordEntity(&existing), nil
+}
+
+func (i idempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	if record == nil {
+		return apperr.ErrInvalidArgument.WithMessage("idempotency record cannot be nil")
+	}
+
+	value := models.ToIdempotencyKeyModel(record)
+	result := dbFrom(ctx, i.db).
+		Model(&models.IdempotencyKeyModel{}).
+		Where("client_id = ? AND key = ? AND token = ? AND status = ?",
+			record.ClientID, record.Key, record.Token, entity.IdempotencyStatusInProgress).
+		Updates(map[string]interface{}{
+			"status":           value.Status,
+			"response_status":  value.ResponseStatus,
+			"response_headers": value.ResponseHeaders,
+			"response_body":    value.ResponseBody,
+			"expires_at":       value.ExpiresAt,
+		})
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
+
+func (i idempotencyRepository) Delete(ctx context.Context, clientID, key, token string) error {
+	err := dbFrom(ctx, i.db).
+		Where("client_id = ? AND key = ? AND token = ?", clientID, key, token).
+		Delete(&models.IdempotencyKeyModel{}).Error
+	if err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
+
+func (i idempotencyRepository) DeleteExpired(ctx context.Context, asOf time.Time) (int64, error) {
+	result := dbFrom(ctx, i.db).
+		Where("expires_at <= ?", asOf).
+		Delete(&models.IdempotencyKeyModel{})
+	if result.Error != nil {
+		return 0, apperr.ErrInternal.Wrap(result.Error)
+	}
+	return result.RowsAffected, nil
+}
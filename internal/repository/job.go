@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// claimJobSQL starts the oldest job that is queued, or running under a lease that has passed
+// because its worker went away. Skipping locked rows lets several workers claim side by side
+// without waiting on each other.
+const claimJobSQL = `UPDATE jobs SET status = 'running', attempts = attempts + 1, lease_until = ?,
+	started_at = COALESCE(started_at, ?), updated_at = ?
+WHERE id = (
+	SELECT id FROM jobs
+	WHERE status = 'queued' OR (status = 'running' AND lease_until < ?)
+	ORDER BY created_at, id LIMIT 1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// errJobNotRunning reports a write of a running job's state to a job that has stopped running,
+// or that another worker took over after its lease passed. Each claim increments attempts, so
+// matching on it keeps the worker of an earlier claim from writing over the current one.
+var errJobNotRunning = apperr.ErrConflict.WithMessage("job is no longer running")
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) repository.JobRepository {
+	return &jobRepository{db: db}
+}
+
+func (j jobRepository) Create(ctx context.Context, job *entity.Job) (string, error) {
+	if job == nil {
+		return "", apperr.ErrInvalidArgument.WithMessage("job cannot be nil")
+	}
+
+	value := models.ToJobModel(job)
+	err := dbFrom(ctx, j.db).Create(value).Error
+	if err != nil {
+		return "", apperr.ErrInternal.Wrap(err)
+	}
+	return value.ID, nil
+}
+
+func (j jobRepository) FindByID(ctx context.Context, id string) (*entity.Job, error) {
+	var job models.JobModel
+	err := dbFrom(ctx, j.db).First(&job, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.Wrap(err)
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToJobEntity(&job), nil
+}
+
+func (j jobRepository) Claim(ctx context.Context, lease time.Duration) (*entity.Job, error) {
+	now := time.Now()
+	var jobs []models.JobModel
+	err := dbFrom(ctx, j.db).Raw(claimJobSQL, now.Add(lease), now, now, now).Scan(&jobs).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return models.ToJobEntity(&jobs[0]), nil
+}
+
+func (j jobRepository) Heartbeat(ctx context.Context, id string, attempts int, lease time.Duration) (bool, error) {
+	var job models.JobModel
+	result := dbFrom(ctx, j.db).Model(&job).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "cancel_requested"}}}).
+		Where("id = ? AND status = ? AND attempts = ?", id, entity.JobStatusRunning, attempts).
+		Update("lease_until", time.Now().Add(lease))
+	if result.Error != nil {
+		return false, apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, errJobNotRunning
+	}
+	return job.CancelRequested, nil
+}
+
+func (j jobRepository) SaveProgress(ctx context.Context, id string, attempts int, progress entity.JobProgress, checkpoint json.RawMessage) error {
+	return j.updateRunning(ctx, id, attempts, map[string]interface{}{
+		"progress_done":  progress.Done,
+		"progress_total": progress.Total,
+		"checkpoint":     models.NullableJSON(checkpoint),
+	})
+}
+
+func (j jobRepository) Finish(ctx context.Context, id string, attempts int, status entity.JobStatus, result json.RawMessage, errMsg string) error {
+	return j.updateRunning(ctx, id, attempts, map[string]interface{}{
+		"status":      status,
+		"result":      models.NullableJSON(result),
+		"error":       errMsg,
+		"lease_until": nil,
+		"finished_at": time.Now(),
+	})
+}
+
+func (j jobRepository) Requeue(ctx context.Context, id string, attempts int) error {
+	return j.updateRunning(ctx, id, attempts, map[string]interface{}{
+		"status":      entity.JobStatusQueued,
+		"lease_until": nil,
+	})
+}
+
+func (j jobRepository) updateRunning(ctx context.Context, id string, attempts int, values map[string]interface{}) error {
+	result := dbFrom(ctx, j.db).Model(&models.JobModel{}).
+		Where("id = ? AND status = ? AND attempts = ?", id, entity.JobStatusRunning, attempts).
+		Updates(values)
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errJobNotRunning
+	}
+	return nil
+}
+
+func (j jobRepository) RequestCancel(ctx context.Context, id string) (*entity.Job, error) {
+	var job models.JobModel
+	err := dbFrom(ctx, j.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&job, "id = ?", id).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperr.ErrNotFound.Wrap(err)
+			}
+			return apperr.ErrInternal.Wrap(err)
+		}
+
+		values := map[string]interface{}{"cancel_requested": true}
+		switch entity.JobStatus(job.Status) {
+		case entity.JobStatusQueued:
+			values["status"] = entity.JobStatusCancelled
+			values["finished_at"] = time.Now()
+		case entity.JobStatusRunning:
+		default:
+			return apperr.ErrConflict.WithMessage("job is already " + job.Status)
+		}
+
+		err = tx.Model(&job).Clauses(clause.Returning{}).Updates(values).Error
+		if err != nil {
+			return apperr.ErrInternal.Wrap(err)
+		}
+		return nil
+	})
+	if err != nil {
+		var appErr *apperr.AppError
+		if errors.As(err, &appErr) {
+			return nil, err
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToJobEntity(&job), nil
+}
+
+func (j jobRepository) PurgeFinished(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	result := dbFrom(ctx, j.db).Where("finished_at < ?", finishedBefore).Delete(&models.JobModel{})
+	if result.Error != nil {
+		return 0, apperr.ErrInternal.Wrap(result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type lotRepository struct {
+	db *gorm.DB
+}
+
+func NewLotRepository(db *gorm.DB) repository.LotRepository {
+	return &lotRepository{db: db}
+}
+
+// Create stores the lot and re-syncs the product's stock from its lots.
+func (l lotRepository) Create(ctx context.Context, lot *entity.Lot) (string, error) {
+	if lot == nil {
+		return "", apperr.ErrInvalidArgument.WithMessage("lot cannot be nil")
+	}
+
+	value := models.ToLotModel(lot)
+	err := dbFrom(ctx, l.db).Transaction(func(tx *gorm.DB) error {
+		if err := lockForLot(tx, value.ProductID); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&value).Error; err != nil {
+			return err
+		}
+		return syncProductStock(tx, []string{value.ProductID}, time.Now())
+	})
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return "", apperr.ErrNotFound.WithMessage("product not found")
+	}
+	if err != nil {
+		var appErr *apperr.AppError
+		if errors.As(err, &appErr) {
+			return "", err
+		}
+		return "", apperr.ErrInternal.Wrap(err)
+	}
+	return value.ID, nil
+}
+
+func (l lotRepository) FindByProductID(ctx context.Context, productID string) ([]entity.Lot, error) {
+	var lots []models.LotModel
+	err := dbFrom(ctx, l.db).
+		Where("product_id = ?", productID).
+		Order("expires_at ASC, received_at ASC").
+		Find(&lots).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToLotsEntity(lots), nil
+}
+
+func (l lotRepository) FindExpiring(ctx context.Context, filter entity.ExpiringLotsFilter) ([]entity.Lot, error) {
+	var lots []models.LotModel
+	err := dbFrom(ctx, l.db).
+		Where("status = ? AND quantity > 0 AND expires_at <= ?", entity.LotStatusActive, filter.Before).
+		Order("expires_at ASC").
+		Limit(filter.Limit).Offset(filter.Offset).
+		Preload("Product").
+		Find(&lots).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToLotsEntity(lots), nil
+}
+
+// Consume takes quantity out of the product's sellable lots, earliest expiry first.
+func (l lotRepository) Consume(ctx context.Context, productID string, quantity int, asOf time.Time) ([]entity.LotConsumption, error) {
+	var consumed []entity.LotConsumption
+
+	err := dbFrom(ctx, l.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		consumed, err = consumeLots(tx, productID, quantity, asOf)
+		if err != nil {
+			return err
+		}
+
+		if err = syncProductStock(tx, []string{productID}, asOf); err != nil {
+			return apperr.ErrInternal.Wrap(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return consumed, nil
+}
+
+func (l lotRepository) QuarantineExpired(ctx context.Context, asOf time.Time) (int64, error) {
+	var affected int64
+
+	err := dbFrom(ctx, l.db).Transaction(func(tx *gorm.DB) error {
+		var expired []models.LotModel
+		result := tx.Model(&expired).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "product_id"}}}).
+			Where("status = ? AND expires_at <= ?", entity.LotStatusActive, asOf).
+			Update("status", entity.LotStatusQuarantined)
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+
+		productIDs := make([]string, 0, len(expired))
+		for _, lot := range expired {
+			productIDs = append(productIDs, lot.ProductID)
+		}
+		return syncProductStock(tx, productIDs, asOf)
+	})
+	if err != nil {
+		return 0, apperr.ErrInternal.Wrap(err)
+	}
+
+	return affected, nil
+}
+
+// consumeLots locks the product's sellable lots and takes quantity from them, earliest expiry first.
+// The caller is responsible for re-syncing the product's stock afterwards.
+func consumeLots(tx *gorm.DB, productID string, quantity int, asOf time.Time) ([]entity.LotConsumption, error) {
+	var lots []models.LotModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND status = ? AND quantity > 0 AND expires_at > ?", productID, entity.LotStatusActive, asOf).
+		Order("expires_at ASC, received_at ASC").
+		Find(&lots).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	var consumed []entity.LotConsumption
+	remaining := quantity
+	for _, lot := range lots {
+		if remaining == 0 {
+			break
+		}
+
+		take := min(lot.Quantity, remaining)
+		err = tx.Model(&models.LotModel{}).Where("id = ?", lot.ID).
+			Update("quantity", gorm.Expr("quantity - ?", take)).Error
+		if err != nil {
+			return nil, apperr.ErrInternal.Wrap(err)
+		}
+
+		consumed = append(consumed, entity.LotConsumption{
+			LotID:     lot.ID,
+			LotNumber: lot.LotNumber,
+			Quantity:  take,
+		})
+		remaining -= take
+	}
+	if remaining > 0 {
+		return nil, apperr.ErrInsufficientStock.WithMessage("not enough unexpired stock in lots")
+	}
+
+	return consumed, nil
+}
+
+// lockForLot locks the product a lot is about to be received for. A product's first lot is refused
+// while the product holds stock outside of lots, which re-syncing its stock from lots would drop.
+func lockForLot(tx *gorm.DB, productID string) error {
+	var product models.ProductModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "stock").
+		First(&product, "id = ?", productID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperr.ErrNotFound.WithMessage("product not found")
+		}
+		return err
+	}
+
+	tracked, err := lotTracked(tx, product.ID)
+	if err != nil {
+		return err
+	}
+	if !tracked && product.Stock != 0 {
+		msg := fmt.Sprintf("product %s has %d units of stock outside of lots; set its stock to 0 before receiving its first lot", product.ID, product.Stock)
+		return apperr.ErrConflict.WithMessage(msg)
+	}
+	return nil
+}
+
+// lotTracked tells whether the product has lots, which makes its stock the sum of its lots.
+func lotTracked(tx *gorm.DB, productID string) (bool, error) {
+	var count int64
+	err := tx.Model(&models.LotModel{}).Where("product_id = ?", productID).Count(&count).Error
+	return count > 0, err
+}
+
+// lotStockSQL is the stock to write to the product aliased as table: value, unless the product is
+// lot-tracked, in which case its stock is left to the lots.
+func lotStockSQL(table, value string) string {
+	return fmt.Sprintf("CASE WHEN EXISTS (SELECT 1 FROM lots WHERE lots.product_id = %[1]s.id AND lots.deleted_at IS NULL) "+
+		"THEN %[1]s.stock ELSE %[2]s END", table, value)
+}
+
+// syncProductStock recomputes products.stock from the active, unexpired lots of the given products.
+// Only products whose stock actually changes get a new version.
+func syncProductStock(tx *gorm.DB, productIDs []string, asOf time.Time) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	return recordChanges(tx, entity.AuditEntityProduct, productIDs, func(tx *gorm.DB) ([]string, error) {
+		return nil, recomputeProductStock(tx, productIDs, asOf)
+	})
+}
+
+// recomputeProductStock is syncProductStock for callers that audit the change themselves.
+func recomputeProductStock(tx *gorm.DB, productIDs []string, asOf time.Time) error {
+	available := tx.Model(&models.LotModel{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("lots.product_id = products.id AND lots.status = ? AND lots.expires_at > ?", entity.LotStatusActive, asOf)
+
+	return tx.Model(&models.ProductModel{}).
+		Where("id IN ? AND stock <> (?)", productIDs, available).
+		Updates(map[string]interface{}{
+			"stock":   available,
+			"version": gorm.Expr("version + 1"),
+		}).Error
+}
@@ -0,0 +1,90 @@
+// Package memory holds in-process implementations of repository interfaces for single-instance
+// deployments and tests.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type idempotencyKey struct {
+	clientID string
+	key      string
+}
+
+type idempotencyRepository struct {
+	mu      sync.Mutex
+	records map[idempotencyKey]entity.IdempotencyRecord
+}
+
+func NewIdempotencyRepository() repository.IdempotencyRepository {
+	return &idempotencyRepository{records: make(map[idempotencyKey]entity.IdempotencyRecord)}
+}
+
+func (i *idempotencyRepository) Reserve(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error) {
+	if record == nil {
+		return nil, apperr.ErrInvalidArgument.WithMessage("idempotency record cannot be nil")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	id := idempotencyKey{clientID: record.ClientID, key: record.Key}
+	if existing, ok := i.records[id]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		return &existing, nil
+	}
+	i.records[id] = *record
+	return nil, nil
+}
+
+func (i *idempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	if record == nil {
+		return apperr.ErrInvalidArgument.WithMessage("idempotency record cannot be nil")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	id := idempotencyKey{clientID: record.ClientID, key: record.Key}
+	existing, ok := i.records[id]
+	if !ok || existing.Token != record.Token || existing.Status != entity.IdempotencyStatusInProgress {
+		return apperr.ErrNotFound
+	}
+	existing.Status = record.Status
+	existing.ResponseStatus = record.ResponseStatus
+	existing.ResponseHeaders = record.ResponseHeaders
+	existing.ResponseBody = record.ResponseBody
+	existing.ExpiresAt = record.ExpiresAt
+	i.records[id] = existing
+	return nil
+}
+
+func (i *idempotencyRepository) Delete(ctx context.Context, clientID, key, token string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	id := idempotencyKey{clientID: clientID, key: key}
+	if existing, ok := i.records[id]; ok && existing.Token == token {
+		delete(i.records, id)
+	}
+	return nil
+}
+
+func (i *idempotencyRepository) DeleteExpired(ctx context.Context, asOf time.Time) (int64, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var count int64
+	for id, record := range i.records {
+		if !record.ExpiresAt.After(asOf) {
+			delete(i.records, id)
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+type AuditEntryModel struct {
+	ID         int64     `gorm:"primaryKey"`
+	Actor      string    `gorm:"size:255;not null"`
+	RequestID  string    `gorm:"size:255"`
+	OccurredAt time.Time `gorm:"not null"`
+	EntityType string    `gorm:"size:50;not null"`
+	EntityID   string    `gorm:"type:uuid;not null"`
+	Action     string    `gorm:"size:20;not null"`
+	Changes    string    `gorm:"type:jsonb;not null;default:'{}'"`
+}
+
+func (AuditEntryModel) TableName() string {
+	return "audit_log"
+}
+
+func ToAuditEntryEntity(model *AuditEntryModel) *entity.AuditEntry {
+	if model == nil {
+		return nil
+	}
+	changes := map[string]entity.FieldChange{}
+	_ = json.Unmarshal([]byte(model.Changes), &changes)
+	return &entity.AuditEntry{
+		ID:         model.ID,
+		Actor:      model.Actor,
+		RequestID:  model.RequestID,
+		OccurredAt: model.OccurredAt,
+		EntityType: entity.AuditEntityType(model.EntityType),
+		EntityID:   model.EntityID,
+		Action:     entity.AuditAction(model.Action),
+		Changes:    changes,
+	}
+}
+
+func ToAuditEntriesEntity(models []AuditEntryModel) []entity.AuditEntry {
+	entries := make([]entity.AuditEntry, 0, len(models))
+	for i := range models {
+		entries = append(entries, *ToAuditEntryEntity(&models[i]))
+	}
+	return entries
+}
+
+func ToAuditEntryModel(entity *entity.AuditEntry) *AuditEntryModel {
+	if entity == nil {
+		return nil
+	}
+	changes, _ := json.Marshal(entity.Changes)
+	if entity.Changes == nil {
+		changes = []byte("{}")
+	}
+	return &AuditEntryModel{
+		ID:         entity.ID,
+		Actor:      entity.Actor,
+		RequestID:  entity.RequestID,
+		OccurredAt: entity.OccurredAt,
+		EntityType: string(entity.EntityType),
+		EntityID:   entity.EntityID,
+		Action:     string(entity.Action),
+		Changes:    string(changes),
+	}
+}
@@ -10,8 +10,9 @@ import (
 
 type CategoryModel struct {
 	ID        string         `gorm:"type:uuid;primaryKey"`
-	Name      string         `gorm:"size:100;unique;not null"`
+	Name      string         `gorm:"size:100;not null"`
 	Products  []ProductModel `gorm:"foreignKey:CategoryID"`
+	Version   int            `gorm:"not null;default:1"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -35,11 +36,21 @@ func ToCategoryEntity(model *CategoryModel) *entity.Category {
 	return &entity.Category{
 		ID:        model.ID,
 		Name:      model.Name,
+		Version:   model.Version,
 		CreatedAt: model.CreatedAt,
 		UpdatedAt: model.UpdatedAt,
+		DeletedAt: deletedAt(model.DeletedAt),
 	}
 }
 
+// deletedAt returns when a soft-deleted row was deleted, or nil for a live row.
+func deletedAt(value gorm.DeletedAt) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
 func ToCategoriesEntity(models []CategoryModel) []entity.Category {
 	result := make([]entity.Category, 0, len(models))
 	for _, model := range models {
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/pkg/utils"
+)
+
+type ChangeModel struct {
+	ID         int64  `gorm:"primaryKey"`
+	Seq        *int64 `gorm:"uniqueIndex"`
+	EntityType string `gorm:"size:50;not null"`
+	EntityID   string `gorm:"type:uuid;not null"`
+	Action     string `gorm:"size:20;not null"`
+	Revision   *int
+	Fields     []string  `gorm:"type:jsonb;serializer:json"`
+	OccurredAt time.Time `gorm:"not null"`
+}
+
+func (ChangeModel) TableName() string {
+	return "changes"
+}
+
+func ToChangeEntity(model *ChangeModel) *entity.Change {
+	if model == nil {
+		return nil
+	}
+	return &entity.Change{
+		Seq:        utils.GetValue(model.Seq),
+		EntityType: entity.AuditEntityType(model.EntityType),
+		EntityID:   model.EntityID,
+		Action:     entity.AuditAction(model.Action),
+		Revision:   utils.GetValue(model.Revision),
+		Fields:     model.Fields,
+		OccurredAt: model.OccurredAt,
+	}
+}
+
+// ToChangeModel leaves Seq unset; it is assigned as the transaction writing the change commits.
+func ToChangeModel(entity *entity.Change) *ChangeModel {
+	if entity == nil {
+		return nil
+	}
+	var revision *int
+	if entity.Revision > 0 {
+		revision = &entity.Revision
+	}
+	return &ChangeModel{
+		EntityType: string(entity.EntityType),
+		EntityID:   entity.EntityID,
+		Action:     string(entity.Action),
+		Revision:   revision,
+		Fields:     entity.Fields,
+		OccurredAt: entity.OccurredAt,
+	}
+}
@@ -0,0 +1,11 @@
+package models
+
+type ExportPageModel struct {
+	JobID    string `gorm:"type:uuid;primaryKey"`
+	Page     int    `gorm:"primaryKey"`
+	Products string `gorm:"type:jsonb;not null"`
+}
+
+func (ExportPageModel) TableName() string {
+	return "job_export_pages"
+}
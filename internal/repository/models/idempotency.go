@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+type IdempotencyKeyModel struct {
+	ClientID        string `gorm:"size:255;primaryKey"`
+	Key             string `gorm:"size:255;primaryKey"`
+	Token           string `gorm:"size:36;not null"`
+	RequestHash     string `gorm:"size:64;not null"`
+	Status          string `gorm:"size:20;not null"`
+	ResponseStatus  int
+	ResponseHeaders string `gorm:"type:jsonb;default:'{}'"`
+	ResponseBody    []byte `gorm:"type:bytea"`
+	CreatedAt       time.Time
+	ExpiresAt       time.Time `gorm:"not null;index"`
+}
+
+func (IdempotencyKeyModel) TableName() string {
+	return "idempotency_keys"
+}
+
+func ToIdempotencyRecordEntity(model *IdempotencyKeyModel) *entity.IdempotencyRecord {
+	if model == nil {
+		return nil
+	}
+	headers := map[string]string{}
+	_ = json.Unmarshal([]byte(model.ResponseHeaders), &headers)
+	return &entity.IdempotencyRecord{
+		ClientID:        model.ClientID,
+		Key:             model.Key,
+		Token:           model.Token,
+		RequestHash:     model.RequestHash,
+		Status:          entity.IdempotencyStatus(model.Status),
+		ResponseStatus:  model.ResponseStatus,
+		ResponseHeaders: headers,
+		ResponseBody:    model.ResponseBody,
+		CreatedAt:       model.CreatedAt,
+		ExpiresAt:       model.ExpiresAt,
+	}
+}
+
+func ToIdempotencyKeyModel(entity *entity.IdempotencyRecord) *IdempotencyKeyModel {
+	if entity == nil {
+		return nil
+	}
+	headers, _ := json.Marshal(entity.ResponseHeaders)
+	if entity.ResponseHeaders == nil {
+		headers = []byte("{}")
+	}
+	return &IdempotencyKeyModel{
+		ClientID:        entity.ClientID,
+		Key:             entity.Key,
+		Token:           entity.Token,
+		RequestHash:     entity.RequestHash,
+		Status:          string(entity.Status),
+		ResponseStatus:  entity.ResponseStatus,
+		ResponseHeaders: string(headers),
+		ResponseBody:    entity.ResponseBody,
+		CreatedAt:       entity.CreatedAt,
+		ExpiresAt:       entity.ExpiresAt,
+	}
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type JobModel struct {
+	ID              string  `gorm:"type:uuid;primaryKey"`
+	Type            string  `gorm:"size:100;not null"`
+	Params          string  `gorm:"type:jsonb;not null"`
+	Status          string  `gorm:"size:20;not null;default:queued"`
+	ProgressDone    int     `gorm:"not null;default:0"`
+	ProgressTotal   int     `gorm:"not null;default:0"`
+	Checkpoint      *string `gorm:"type:jsonb"`
+	Result          *string `gorm:"type:jsonb"`
+	Error           string  `gorm:"type:text"`
+	Attempts        int     `gorm:"not null;default:0"`
+	CancelRequested bool    `gorm:"not null;default:false"`
+	Actor           string  `gorm:"size:255;not null"`
+	RequestID       string  `gorm:"size:255"`
+	LeaseUntil      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+}
+
+func (JobModel) TableName() string {
+	return "jobs"
+}
+
+func (j *JobModel) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == "" {
+		j.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func ToJobEntity(model *JobModel) *entity.Job {
+	if model == nil {
+		return nil
+	}
+	return &entity.Job{
+		ID:              model.ID,
+		Type:            entity.JobType(model.Type),
+		Params:          json.RawMessage(model.Params),
+		Status:          entity.JobStatus(model.Status),
+		Progress:        entity.JobProgress{Done: model.ProgressDone, Total: model.ProgressTotal},
+		Checkpoint:      rawJSON(model.Checkpoint),
+		Result:          rawJSON(model.Result),
+		Error:           model.Error,
+		Attempts:        model.Attempts,
+		CancelRequested: model.CancelRequested,
+		Actor:           model.Actor,
+		RequestID:       model.RequestID,
+		LeaseUntil:      model.LeaseUntil,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
+		StartedAt:       model.StartedAt,
+		FinishedAt:      model.FinishedAt,
+	}
+}
+
+func ToJobModel(entity *entity.Job) *JobModel {
+	if entity == nil {
+		return nil
+	}
+	return &JobModel{
+		ID:            entity.ID,
+		Type:          string(entity.Type),
+		Params:        string(entity.Params),
+		Status:        string(entity.Status),
+		ProgressDone:  entity.Progress.Done,
+		ProgressTotal: entity.Progress.Total,
+		Checkpoint:    NullableJSON(entity.Checkpoint),
+		Result:        NullableJSON(entity.Result),
+		Error:         entity.Error,
+		Attempts:      entity.Attempts,
+		Actor:         entity.Actor,
+		RequestID:     entity.RequestID,
+	}
+}
+
+// rawJSON returns the JSON document of a nullable jsonb column, nil for NULL.
+func rawJSON(value *string) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	return json.RawMessage(*value)
+}
+
+// NullableJSON returns the value of a nullable jsonb column, NULL for an empty document.
+func NullableJSON(value json.RawMessage) *string {
+	if len(value) == 0 {
+		return nil
+	}
+	s := string(value)
+	return &s
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type LotModel struct {
+	ID         string    `gorm:"type:uuid;primaryKey"`
+	ProductID  string    `gorm:"type:uuid;not null;index"`
+	LotNumber  string    `gorm:"size:100;not null"`
+	Quantity   int       `gorm:"not null;default:0"`
+	ReceivedAt time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	Status     string    `gorm:"size:20;not null;default:active"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+
+	Product *ProductModel `gorm:"foreignKey:ProductID"`
+}
+
+func (LotModel) TableName() string {
+	return "lots"
+}
+
+func (l *LotModel) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func ToLotEntity(model *LotModel) *entity.Lot {
+	if model == nil {
+		return nil
+	}
+	return &entity.Lot{
+		ID:         model.ID,
+		ProductID:  model.ProductID,
+		LotNumber:  model.LotNumber,
+		Quantity:   model.Quantity,
+		ReceivedAt: model.ReceivedAt,
+		ExpiresAt:  model.ExpiresAt,
+		Status:     entity.LotStatus(model.Status),
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+		Product:    ToProductEntity(model.Product),
+	}
+}
+
+func ToLotsEntity(models []LotModel) []entity.Lot {
+	result := make([]entity.Lot, 0, len(models))
+	for _, model := range models {
+		result = append(result, *ToLotEntity(&model))
+	}
+	return result
+}
+
+func ToLotModel(entity *entity.Lot) *LotModel {
+	if entity == nil {
+		return nil
+	}
+	return &LotModel{
+		ID:         entity.ID,
+		ProductID:  entity.ProductID,
+		LotNumber:  entity.LotNumber,
+		Quantity:   entity.Quantity,
+		ReceivedAt: entity.ReceivedAt,
+		ExpiresAt:  entity.ExpiresAt,
+		Status:     string(entity.Status),
+	}
+}
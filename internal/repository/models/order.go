@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type OrderModel struct {
+	ID            string  `gorm:"type:uuid;primaryKey"`
+	Status        string  `gorm:"size:20;not null;index"`
+	CustomerRef   string  `gorm:"size:255"`
+	TotalQuantity int     `gorm:"not null;default:0"`
+	TotalAmount   float64 `gorm:"type:decimal(12,2);not null;default:0"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+
+	Lines []OrderLineModel `gorm:"foreignKey:OrderID"`
+}
+
+func (OrderModel) TableName() string {
+	return "orders"
+}
+
+func (o *OrderModel) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = uuid.New().String()
+	}
+	return nil
+}
+
+type OrderLineModel struct {
+	ID          string  `gorm:"type:uuid;primaryKey"`
+	OrderID     string  `gorm:"type:uuid;not null;index"`
+	ProductID   string  `gorm:"type:uuid;not null;index"`
+	ProductName string  `gorm:"size:255;not null"`
+	SKU         string  `gorm:"size:100;not null"`
+	UnitPrice   float64 `gorm:"type:decimal(10,2);not null"`
+	Quantity    int     `gorm:"not null"`
+	LineTotal   float64 `gorm:"type:decimal(12,2);not null"`
+	CreatedAt   time.Time
+}
+
+func (OrderLineModel) TableName() string {
+	return "order_lines"
+}
+
+func (o *OrderLineModel) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func ToOrderEntity(model *OrderModel) *entity.Order {
+	if model == nil {
+		return nil
+	}
+
+	lines := make([]entity.OrderLine, 0, len(model.Lines))
+	for _, line := range model.Lines {
+		lines = append(lines, entity.OrderLine{
+			ID:          line.ID,
+			OrderID:     line.OrderID,
+			ProductID:   line.ProductID,
+			ProductName: line.ProductName,
+			SKU:         line.SKU,
+			UnitPrice:   line.UnitPrice,
+			Quantity:    line.Quantity,
+			LineTotal:   line.LineTotal,
+		})
+	}
+	return &entity.Order{
+		ID:            model.ID,
+		Status:        entity.OrderStatus(model.Status),
+		CustomerRef:   model.CustomerRef,
+		TotalQuantity: model.TotalQuantity,
+		TotalAmount:   model.TotalAmount,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+		Lines:         lines,
+	}
+}
+
+func ToOrdersEntity(models []OrderModel) []entity.Order {
+	result := make([]entity.Order, 0, len(models))
+	for _, model := range models {
+		result = append(result, *ToOrderEntity(&model))
+	}
+	return result
+}
+
+func ToOrderModel(entity *entity.Order) *OrderModel {
+	if entity == nil {
+		return nil
+	}
+
+	lines := make([]OrderLineModel, 0, len(entity.Lines))
+	for _, line := range entity.Lines {
+		lines = append(lines, OrderLineModel{
+			ID:          line.ID,
+			ProductID:   line.ProductID,
+			ProductName: line.ProductName,
+			SKU:         line.SKU,
+			UnitPrice:   line.UnitPrice,
+			Quantity:    line.Quantity,
+			LineTotal:   line.LineTotal,
+		})
+	}
+	return &OrderModel{
+		ID:            entity.ID,
+		Status:        string(entity.Status),
+		CustomerRef:   entity.CustomerRef,
+		TotalQuantity: entity.TotalQuantity,
+		TotalAmount:   entity.TotalAmount,
+		Lines:         lines,
+	}
+}
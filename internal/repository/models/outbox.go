@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+type OutboxEventModel struct {
+	ID            int64     `gorm:"primaryKey"`
+	EventType     string    `gorm:"size:100;not null"`
+	AggregateType string    `gorm:"size:50;not null"`
+	AggregateID   string    `gorm:"type:uuid;not null"`
+	Actor         string    `gorm:"size:255;not null"`
+	RequestID     string    `gorm:"size:255"`
+	OccurredAt    time.Time `gorm:"not null"`
+	Payload       string    `gorm:"type:jsonb;not null"`
+	Attempts      int       `gorm:"not null;default:0"`
+	LastError     string    `gorm:"type:text"`
+	NextAttemptAt time.Time `gorm:"not null"`
+	PublishedAt   *time.Time
+	DeadAt        *time.Time
+}
+
+func (OutboxEventModel) TableName() string {
+	return "outbox"
+}
+
+func ToDomainEventEntity(model *OutboxEventModel) *entity.DomainEvent {
+	if model == nil {
+		return nil
+	}
+	return &entity.DomainEvent{
+		ID:            model.ID,
+		Type:          entity.EventType(model.EventType),
+		AggregateType: entity.AuditEntityType(model.AggregateType),
+		AggregateID:   model.AggregateID,
+		Actor:         model.Actor,
+		RequestID:     model.RequestID,
+		OccurredAt:    model.OccurredAt,
+		Payload:       json.RawMessage(model.Payload),
+		Attempts:      model.Attempts,
+		LastError:     model.LastError,
+		NextAttemptAt: model.NextAttemptAt,
+		PublishedAt:   model.PublishedAt,
+		DeadAt:        model.DeadAt,
+	}
+}
+
+func ToDomainEventsEntity(models []OutboxEventModel) []entity.DomainEvent {
+	events := make([]entity.DomainEvent, 0, len(models))
+	for i := range models {
+		events = append(events, *ToDomainEventEntity(&models[i]))
+	}
+	return events
+}
+
+func ToOutboxEventModel(entity *entity.DomainEvent) *OutboxEventModel {
+	if entity == nil {
+		return nil
+	}
+	return &OutboxEventModel{
+		ID:            entity.ID,
+		EventType:     string(entity.Type),
+		AggregateType: string(entity.AggregateType),
+		AggregateID:   entity.AggregateID,
+		Actor:         entity.Actor,
+		RequestID:     entity.RequestID,
+		OccurredAt:    entity.OccurredAt,
+		Payload:       string(entity.Payload),
+		Attempts:      entity.Attempts,
+		LastError:     entity.LastError,
+		NextAttemptAt: entity.NextAttemptAt,
+		PublishedAt:   entity.PublishedAt,
+		DeadAt:        entity.DeadAt,
+	}
+}
@@ -13,10 +13,11 @@ type ProductModel struct {
 	ID          string  `gorm:"type:uuid;primaryKey"`
 	Name        string  `gorm:"size:255;not null;index"`
 	Description string  `gorm:"type:text"`
-	SKU         string  `gorm:"size:100;unique;not null"`
+	SKU         string  `gorm:"size:100;not null"`
 	Price       float64 `gorm:"type:decimal(10,2);not null;default:0"`
 	Stock       int     `gorm:"not null;default:0"`
 	ImageURL    string  `gorm:"size:255"`
+	Version     int     `gorm:"not null;default:1"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   gorm.DeletedAt `gorm:"index"`
@@ -29,6 +30,13 @@ func (ProductModel) TableName() string {
 	return "products"
 }
 
+// UpsertedRow is a product written by an upsert; Created is false when an existing row was updated.
+type UpsertedRow struct {
+	ID      string
+	SKU     string
+	Created bool
+}
+
 func (p *ProductModel) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == "" {
 		p.ID = uuid.New().String()
@@ -45,11 +53,13 @@ func ToProductEntity(model *ProductModel) *entity.Product {
 		Name:        model.Name,
 		Description: model.Description,
 		SKU:         model.SKU,
-		Price:       utils.SetPtr(model.Price),
-		Stock:       utils.SetPtr(model.Stock),
+		Price:       &model.Price,
+		Stock:       &model.Stock,
 		ImageURL:    utils.SetPtr(model.ImageURL),
+		Version:     model.Version,
 		CreatedAt:   model.CreatedAt,
 		UpdatedAt:   model.UpdatedAt,
+		DeletedAt:   deletedAt(model.DeletedAt),
 		CategoryID:  model.CategoryID,
 		Category:    ToCategoryEntity(model.Category),
 	}
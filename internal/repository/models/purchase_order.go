@@ -0,0 +1,128 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type PurchaseOrderModel struct {
+	ID          string  `gorm:"type:uuid;primaryKey"`
+	SupplierID  *string `gorm:"type:uuid;index"`
+	Status      string  `gorm:"size:30;not null;index"`
+	Notes       string  `gorm:"type:text"`
+	SubmittedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	Lines    []PurchaseOrderLineModel `gorm:"foreignKey:PurchaseOrderID"`
+	Supplier *SupplierModel           `gorm:"foreignKey:SupplierID"`
+}
+
+func (PurchaseOrderModel) TableName() string {
+	return "purchase_orders"
+}
+
+func (p *PurchaseOrderModel) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+	return nil
+}
+
+type PurchaseOrderLineModel struct {
+	ID               string  `gorm:"type:uuid;primaryKey"`
+	PurchaseOrderID  string  `gorm:"type:uuid;not null;index"`
+	ProductID        string  `gorm:"type:uuid;not null;index"`
+	Quantity         int     `gorm:"not null"`
+	ReceivedQuantity int     `gorm:"not null;default:0"`
+	UnitCost         float64 `gorm:"type:decimal(10,2);not null;default:0"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+
+	Product *ProductModel `gorm:"foreignKey:ProductID"`
+}
+
+func (PurchaseOrderLineModel) TableName() string {
+	return "purchase_order_lines"
+}
+
+func (p *PurchaseOrderLineModel) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func ToPurchaseOrderEntity(model *PurchaseOrderModel) *entity.PurchaseOrder {
+	if model == nil {
+		return nil
+	}
+
+	lines := make([]entity.PurchaseOrderLine, 0, len(model.Lines))
+	for _, line := range model.Lines {
+		lines = append(lines, *ToPurchaseOrderLineEntity(&line))
+	}
+	return &entity.PurchaseOrder{
+		ID:          model.ID,
+		SupplierID:  model.SupplierID,
+		Status:      entity.PurchaseOrderStatus(model.Status),
+		Notes:       model.Notes,
+		SubmittedAt: model.SubmittedAt,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+		Lines:       lines,
+		Supplier:    ToSupplierEntity(model.Supplier),
+	}
+}
+
+func ToPurchaseOrdersEntity(models []PurchaseOrderModel) []entity.PurchaseOrder {
+	result := make([]entity.PurchaseOrder, 0, len(models))
+	for _, model := range models {
+		result = append(result, *ToPurchaseOrderEntity(&model))
+	}
+	return result
+}
+
+func ToPurchaseOrderLineEntity(model *PurchaseOrderLineModel) *entity.PurchaseOrderLine {
+	if model == nil {
+		return nil
+	}
+	return &entity.PurchaseOrderLine{
+		ID:               model.ID,
+		PurchaseOrderID:  model.PurchaseOrderID,
+		ProductID:        model.ProductID,
+		Quantity:         model.Quantity,
+		ReceivedQuantity: model.ReceivedQuantity,
+		UnitCost:         model.UnitCost,
+		Product:          ToProductEntity(model.Product),
+	}
+}
+
+func ToPurchaseOrderModel(entity *entity.PurchaseOrder) *PurchaseOrderModel {
+	if entity == nil {
+		return nil
+	}
+
+	lines := make([]PurchaseOrderLineModel, 0, len(entity.Lines))
+	for _, line := range entity.Lines {
+		lines = append(lines, PurchaseOrderLineModel{
+			ID:               line.ID,
+			ProductID:        line.ProductID,
+			Quantity:         line.Quantity,
+			ReceivedQuantity: line.ReceivedQuantity,
+			UnitCost:         line.UnitCost,
+		})
+	}
+	return &PurchaseOrderModel{
+		ID:          entity.ID,
+		SupplierID:  entity.SupplierID,
+		Status:      string(entity.Status),
+		Notes:       entity.Notes,
+		SubmittedAt: entity.SubmittedAt,
+		Lines:       lines,
+	}
+}
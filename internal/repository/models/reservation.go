@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type ReservationModel struct {
+	ID         string    `gorm:"type:uuid;primaryKey"`
+	ProductID  string    `gorm:"type:uuid;not null;index"`
+	Quantity   int       `gorm:"not null"`
+	Status     string    `gorm:"size:20;not null;default:held"`
+	Actor      string    `gorm:"size:255;not null"`
+	Owner      string    `gorm:"size:64;not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	CreatedAt  time.Time
+	ReleasedAt *time.Time
+}
+
+func (ReservationModel) TableName() string {
+	return "stock_reservations"
+}
+
+func (r *ReservationModel) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func ToReservationEntity(model *ReservationModel) *entity.Reservation {
+	if model == nil {
+		return nil
+	}
+	return &entity.Reservation{
+		ID:         model.ID,
+		ProductID:  model.ProductID,
+		Quantity:   model.Quantity,
+		Status:     entity.ReservationStatus(model.Status),
+		Actor:      model.Actor,
+		Owner:      model.Owner,
+		ExpiresAt:  model.ExpiresAt,
+		CreatedAt:  model.CreatedAt,
+		ReleasedAt: model.ReleasedAt,
+	}
+}
+
+func ToReservationsEntity(models []ReservationModel) []entity.Reservation {
+	result := make([]entity.Reservation, 0, len(models))
+	for _, model := range models {
+		result = append(result, *ToReservationEntity(&model))
+	}
+	return result
+}
+
+func ToReservationModel(entity *entity.Reservation) *ReservationModel {
+	if entity == nil {
+		return nil
+	}
+	return &ReservationModel{
+		ID:         entity.ID,
+		ProductID:  entity.ProductID,
+		Quantity:   entity.Quantity,
+		Status:     string(entity.Status),
+		Actor:      entity.Actor,
+		Owner:      entity.Owner,
+		ExpiresAt:  entity.ExpiresAt,
+		ReleasedAt: entity.ReleasedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type RetentionRunModel struct {
+	ID                string `gorm:"type:uuid;primaryKey"`
+	StartedAt         time.Time
+	FinishedAt        time.Time
+	DeletedBefore     time.Time
+	DryRun            bool   `gorm:"not null;default:false"`
+	ProductsPurged    string `gorm:"type:jsonb;not null"`
+	ProductsSkipped   string `gorm:"type:jsonb;not null"`
+	CategoriesPurged  string `gorm:"type:jsonb;not null"`
+	CategoriesSkipped string `gorm:"type:jsonb;not null"`
+	Error             string `gorm:"type:text"`
+}
+
+func (RetentionRunModel) TableName() string {
+	return "retention_runs"
+}
+
+func (r *RetentionRunModel) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func ToRetentionRunEntity(model *RetentionRunModel) *entity.RetentionRun {
+	if model == nil {
+		return nil
+	}
+	run := &entity.RetentionRun{
+		StartedAt:     model.StartedAt,
+		FinishedAt:    model.FinishedAt,
+		DeletedBefore: model.DeletedBefore,
+		DryRun:        model.DryRun,
+		Products:      toRetentionResult(model.ProductsPurged, model.ProductsSkipped),
+		Categories:    toRetentionResult(model.CategoriesPurged, model.CategoriesSkipped),
+	}
+	if model.Error != "" {
+		run.Err = errors.New(model.Error)
+	}
+	return run
+}
+
+func ToRetentionRunModel(entity *entity.RetentionRun) *RetentionRunModel {
+	if entity == nil {
+		return nil
+	}
+	model := &RetentionRunModel{
+		StartedAt:         entity.StartedAt,
+		FinishedAt:        entity.FinishedAt,
+		DeletedBefore:     entity.DeletedBefore,
+		DryRun:            entity.DryRun,
+		ProductsPurged:    idsJSON(entity.Products.Purged),
+		ProductsSkipped:   idsJSON(entity.Products.Skipped),
+		CategoriesPurged:  idsJSON(entity.Categories.Purged),
+		CategoriesSkipped: idsJSON(entity.Categories.Skipped),
+	}
+	if entity.Err != nil {
+		model.Error = entity.Err.Error()
+	}
+	return model
+}
+
+func toRetentionResult(purged, skipped string) entity.RetentionResult {
+	var result entity.RetentionResult
+	_ = json.Unmarshal([]byte(purged), &result.Purged)
+	_ = json.Unmarshal([]byte(skipped), &result.Skipped)
+	return result
+}
+
+func idsJSON(ids []string) string {
+	if ids == nil {
+		return "[]"
+	}
+	raw, _ := json.Marshal(ids)
+	return string(raw)
+}
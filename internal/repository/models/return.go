@@ -0,0 +1,133 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type ReturnAuthorizationModel struct {
+	ID         string  `gorm:"type:uuid;primaryKey"`
+	OrderID    *string `gorm:"type:uuid;index"`
+	Status     string  `gorm:"size:20;not null;index"`
+	Reason     string  `gorm:"type:text"`
+	ReceivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+
+	Lines []ReturnLineModel `gorm:"foreignKey:ReturnID"`
+}
+
+func (ReturnAuthorizationModel) TableName() string {
+	return "return_authorizations"
+}
+
+func (r *ReturnAuthorizationModel) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+type ReturnLineModel struct {
+	ID          string `gorm:"type:uuid;primaryKey"`
+	ReturnID    string `gorm:"type:uuid;not null;index"`
+	ProductID   string `gorm:"type:uuid;not null;index"`
+	Quantity    int    `gorm:"not null"`
+	Disposition string `gorm:"size:20;not null"`
+	CreatedAt   time.Time
+}
+
+func (ReturnLineModel) TableName() string {
+	return "return_lines"
+}
+
+func (r *ReturnLineModel) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+type ReturnRateRow struct {
+	ID               string
+	Name             string
+	SoldQuantity     int
+	ReturnedQuantity int
+}
+
+func ToReturnEntity(model *ReturnAuthorizationModel) *entity.ReturnAuthorization {
+	if model == nil {
+		return nil
+	}
+
+	lines := make([]entity.ReturnLine, 0, len(model.Lines))
+	for _, line := range model.Lines {
+		lines = append(lines, entity.ReturnLine{
+			ID:          line.ID,
+			ReturnID:    line.ReturnID,
+			ProductID:   line.ProductID,
+			Quantity:    line.Quantity,
+			Disposition: entity.ReturnDisposition(line.Disposition),
+		})
+	}
+	return &entity.ReturnAuthorization{
+		ID:         model.ID,
+		OrderID:    model.OrderID,
+		Status:     entity.ReturnStatus(model.Status),
+		Reason:     model.Reason,
+		ReceivedAt: model.ReceivedAt,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+		Lines:      lines,
+	}
+}
+
+func ToReturnsEntity(models []ReturnAuthorizationModel) []entity.ReturnAuthorization {
+	result := make([]entity.ReturnAuthorization, 0, len(models))
+	for _, model := range models {
+		result = append(result, *ToReturnEntity(&model))
+	}
+	return result
+}
+
+func ToReturnModel(entity *entity.ReturnAuthorization) *ReturnAuthorizationModel {
+	if entity == nil {
+		return nil
+	}
+
+	lines := make([]ReturnLineModel, 0, len(entity.Lines))
+	for _, line := range entity.Lines {
+		lines = append(lines, ReturnLineModel{
+			ID:          line.ID,
+			ProductID:   line.ProductID,
+			Quantity:    line.Quantity,
+			Disposition: string(line.Disposition),
+		})
+	}
+	return &ReturnAuthorizationModel{
+		ID:      entity.ID,
+		OrderID: entity.OrderID,
+		Status:  string(entity.Status),
+		Reason:  entity.Reason,
+		Lines:   lines,
+	}
+}
+
+func ToReturnRatesEntity(rows []ReturnRateRow) []entity.ReturnRate {
+	result := make([]entity.ReturnRate, 0, len(rows))
+	for _, row := range rows {
+		rate := entity.ReturnRate{
+			ID:               row.ID,
+			Name:             row.Name,
+			SoldQuantity:     row.SoldQuantity,
+			ReturnedQuantity: row.ReturnedQuantity,
+		}
+		rate.CalculateRate()
+		result = append(result, rate)
+	}
+	return result
+}
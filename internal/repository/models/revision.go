@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+type RevisionModel struct {
+	EntityType string    `gorm:"size:50;primaryKey"`
+	EntityID   string    `gorm:"type:uuid;primaryKey"`
+	Revision   int       `gorm:"primaryKey"`
+	Action     string    `gorm:"size:20;not null"`
+	Actor      string    `gorm:"size:255;not null"`
+	RequestID  string    `gorm:"size:255"`
+	CreatedAt  time.Time `gorm:"not null"`
+	Data       string    `gorm:"type:jsonb;not null"`
+}
+
+func (RevisionModel) TableName() string {
+	return "revisions"
+}
+
+// RevisionHeader is a revision without its data. CreatedAt is read as revised_at so it does not
+// clash with the column of the record a revision is read together with.
+type RevisionHeader struct {
+	EntityType string
+	EntityID   string
+	Revision   int
+	Action     string
+	Actor      string
+	RequestID  string
+	RevisedAt  time.Time
+}
+
+// ProductRevisionModel is a revision read together with the product stored in it.
+type ProductRevisionModel struct {
+	RevisionHeader `gorm:"embedded"`
+	ProductModel   `gorm:"embedded"`
+}
+
+// CategoryRevisionModel is a revision read together with the category stored in it.
+type CategoryRevisionModel struct {
+	RevisionHeader `gorm:"embedded"`
+	CategoryModel  `gorm:"embedded"`
+}
+
+func ToRevisionEntity(model *RevisionHeader) *entity.Revision {
+	if model == nil {
+		return nil
+	}
+	return &entity.Revision{
+		EntityType: entity.AuditEntityType(model.EntityType),
+		EntityID:   model.EntityID,
+		Number:     model.Revision,
+		Action:     entity.AuditAction(model.Action),
+		Actor:      model.Actor,
+		RequestID:  model.RequestID,
+		CreatedAt:  model.RevisedAt,
+	}
+}
+
+func ToRevisionsEntity(models []RevisionHeader) []entity.Revision {
+	revisions := make([]entity.Revision, 0, len(models))
+	for i := range models {
+		revisions = append(revisions, *ToRevisionEntity(&models[i]))
+	}
+	return revisions
+}
+
+func ToProductRevisionEntity(model *ProductRevisionModel) *entity.ProductRevision {
+	if model == nil {
+		return nil
+	}
+	return &entity.ProductRevision{
+		Revision: *ToRevisionEntity(&model.RevisionHeader),
+		Product:  *ToProductEntity(&model.ProductModel),
+	}
+}
+
+func ToCategoryRevisionEntity(model *CategoryRevisionModel) *entity.CategoryRevision {
+	if model == nil {
+		return nil
+	}
+	return &entity.CategoryRevision{
+		Revision: *ToRevisionEntity(&model.RevisionHeader),
+		Category: *ToCategoryEntity(&model.CategoryModel),
+	}
+}
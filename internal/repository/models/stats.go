@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+)
+
+type ListStatsRow struct {
+	Count        int64
+	LastModified *time.Time
+}
+
+func ToListStatsEntity(row ListStatsRow) *entity.ListStats {
+	stats := &entity.ListStats{Count: row.Count}
+	if row.LastModified != nil {
+		stats.LastModified = *row.LastModified
+	}
+	return stats
+}
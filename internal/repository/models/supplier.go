@@ -0,0 +1,131 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type SupplierModel struct {
+	ID           string `gorm:"type:uuid;primaryKey"`
+	Name         string `gorm:"size:255;not null;index"`
+	ContactName  string `gorm:"size:255"`
+	ContactEmail string `gorm:"size:255"`
+	ContactPhone string `gorm:"size:50"`
+	LeadTimeDays int    `gorm:"not null;default:0"`
+	Currency     string `gorm:"size:3;not null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+func (SupplierModel) TableName() string {
+	return "suppliers"
+}
+
+func (s *SupplierModel) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func ToSupplierEntity(model *SupplierModel) *entity.Supplier {
+	if model == nil {
+		return nil
+	}
+	return &entity.Supplier{
+		ID:           model.ID,
+		Name:         model.Name,
+		ContactName:  model.ContactName,
+		ContactEmail: model.ContactEmail,
+		ContactPhone: model.ContactPhone,
+		LeadTimeDays: model.LeadTimeDays,
+		Currency:     model.Currency,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+	}
+}
+
+func ToSuppliersEntity(models []SupplierModel) []entity.Supplier {
+	result := make([]entity.Supplier, 0, len(models))
+	for _, model := range models {
+		result = append(result, *ToSupplierEntity(&model))
+	}
+	return result
+}
+
+func ToSupplierModel(entity *entity.Supplier) *SupplierModel {
+	if entity == nil {
+		return nil
+	}
+	return &SupplierModel{
+		ID:           entity.ID,
+		Name:         entity.Name,
+		ContactName:  entity.ContactName,
+		ContactEmail: entity.ContactEmail,
+		ContactPhone: entity.ContactPhone,
+		LeadTimeDays: entity.LeadTimeDays,
+		Currency:     entity.Currency,
+	}
+}
+
+type ProductSupplierModel struct {
+	ProductID        string  `gorm:"type:uuid;primaryKey"`
+	SupplierID       string  `gorm:"type:uuid;primaryKey;index"`
+	SupplierSKU      string  `gorm:"size:100"`
+	CostPrice        float64 `gorm:"type:decimal(10,2);not null;default:0"`
+	MinOrderQuantity int     `gorm:"not null;default:1"`
+	Preferred        bool    `gorm:"not null;default:false"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+
+	Product  *ProductModel  `gorm:"foreignKey:ProductID"`
+	Supplier *SupplierModel `gorm:"foreignKey:SupplierID"`
+}
+
+func (ProductSupplierModel) TableName() string {
+	return "product_suppliers"
+}
+
+func ToProductSupplierEntity(model *ProductSupplierModel) *entity.ProductSupplier {
+	if model == nil {
+		return nil
+	}
+	return &entity.ProductSupplier{
+		ProductID:        model.ProductID,
+		SupplierID:       model.SupplierID,
+		SupplierSKU:      model.SupplierSKU,
+		CostPrice:        model.CostPrice,
+		MinOrderQuantity: model.MinOrderQuantity,
+		Preferred:        model.Preferred,
+		CreatedAt:        model.CreatedAt,
+		UpdatedAt:        model.UpdatedAt,
+		Product:          ToProductEntity(model.Product),
+		Supplier:         ToSupplierEntity(model.Supplier),
+	}
+}
+
+func ToProductSuppliersEntity(models []ProductSupplierModel) []entity.ProductSupplier {
+	result := make([]entity.ProductSupplier, 0, len(models))
+	for _, model := range models {
+		result = append(result, *ToProductSupplierEntity(&model))
+	}
+	return result
+}
+
+func ToProductSupplierModel(entity *entity.ProductSupplier) *ProductSupplierModel {
+	if entity == nil {
+		return nil
+	}
+	return &ProductSupplierModel{
+		ProductID:        entity.ProductID,
+		SupplierID:       entity.SupplierID,
+		SupplierSKU:      entity.SupplierSKU,
+		CostPrice:        entity.CostPrice,
+		MinOrderQuantity: entity.MinOrderQuantity,
+		Preferred:        entity.Preferred,
+	}
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type WebhookModel struct {
+	ID                  string             `gorm:"type:uuid;primaryKey"`
+	URL                 string             `gorm:"size:2048;not null"`
+	EventTypes          []string           `gorm:"type:jsonb;serializer:json;not null"`
+	Filter              WebhookFilterModel `gorm:"type:jsonb;serializer:json;not null"`
+	Secret              string             `gorm:"size:255;not null"`
+	Enabled             bool               `gorm:"not null;default:true"`
+	DisabledReason      string             `gorm:"type:text"`
+	ConsecutiveFailures int                `gorm:"not null;default:0"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (WebhookModel) TableName() string {
+	return "webhooks"
+}
+
+func (w *WebhookModel) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = uuid.New().String()
+	}
+	return nil
+}
+
+type WebhookFilterModel struct {
+	CategoryIDs []string `json:"categoryIds,omitempty"`
+	ProductIDs  []string `json:"productIds,omitempty"`
+}
+
+func ToWebhookEntity(model *WebhookModel) *entity.Webhook {
+	if model == nil {
+		return nil
+	}
+	eventTypes := make([]entity.EventType, 0, len(model.EventTypes))
+	for _, eventType := range model.EventTypes {
+		eventTypes = append(eventTypes, entity.EventType(eventType))
+	}
+	return &entity.Webhook{
+		ID:         model.ID,
+		URL:        model.URL,
+		EventTypes: eventTypes,
+		Filter: entity.WebhookFilter{
+			CategoryIDs: model.Filter.CategoryIDs,
+			ProductIDs:  model.Filter.ProductIDs,
+		},
+		Secret:              model.Secret,
+		Enabled:             model.Enabled,
+		DisabledReason:      model.DisabledReason,
+		ConsecutiveFailures: model.ConsecutiveFailures,
+		CreatedAt:           model.CreatedAt,
+		UpdatedAt:           model.UpdatedAt,
+	}
+}
+
+func ToWebhooksEntity(models []WebhookModel) []entity.Webhook {
+	webhooks := make([]entity.Webhook, 0, len(models))
+	for i := range models {
+		webhooks = append(webhooks, *ToWebhookEntity(&models[i]))
+	}
+	return webhooks
+}
+
+func ToWebhookModel(entity *entity.Webhook) *WebhookModel {
+	if entity == nil {
+		return nil
+	}
+	eventTypes := make([]string, 0, len(entity.EventTypes))
+	for _, eventType := range entity.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	return &WebhookModel{
+		ID:         entity.ID,
+		URL:        entity.URL,
+		EventTypes: eventTypes,
+		Filter: WebhookFilterModel{
+			CategoryIDs: entity.Filter.CategoryIDs,
+			ProductIDs:  entity.Filter.ProductIDs,
+		},
+		Secret:              entity.Secret,
+		Enabled:             entity.Enabled,
+		DisabledReason:      entity.DisabledReason,
+		ConsecutiveFailures: entity.ConsecutiveFailures,
+		CreatedAt:           entity.CreatedAt,
+		UpdatedAt:           entity.UpdatedAt,
+	}
+}
+
+type WebhookDeliveryModel struct {
+	ID             int64  `gorm:"primaryKey"`
+	WebhookID      string `gorm:"type:uuid;not null"`
+	EventID        int64  `gorm:"not null"`
+	EventType      string `gorm:"size:100;not null"`
+	Payload        string `gorm:"type:jsonb;not null"`
+	Status         string `gorm:"size:20;not null;default:pending"`
+	Attempts       int    `gorm:"not null;default:0"`
+	ResponseStatus *int
+	ResponseBody   string    `gorm:"type:text"`
+	LastError      string    `gorm:"type:text"`
+	NextAttemptAt  time.Time `gorm:"not null"`
+	RedeliveryOf   *int64
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+func ToWebhookDeliveryEntity(model *WebhookDeliveryModel) *entity.WebhookDelivery {
+	if model == nil {
+		return nil
+	}
+	var responseStatus int
+	if model.ResponseStatus != nil {
+		responseStatus = *model.ResponseStatus
+	}
+	return &entity.WebhookDelivery{
+		ID:             model.ID,
+		WebhookID:      model.WebhookID,
+		EventID:        model.EventID,
+		EventType:      entity.EventType(model.EventType),
+		Payload:        json.RawMessage(model.Payload),
+		Status:         entity.WebhookDeliveryStatus(model.Status),
+		Attempts:       model.Attempts,
+		ResponseStatus: responseStatus,
+		ResponseBody:   model.ResponseBody,
+		LastError:      model.LastError,
+		NextAttemptAt:  model.NextAttemptAt,
+		RedeliveryOf:   model.RedeliveryOf,
+		CreatedAt:      model.CreatedAt,
+		DeliveredAt:    model.DeliveredAt,
+	}
+}
+
+func ToWebhookDeliveriesEntity(models []WebhookDeliveryModel) []entity.WebhookDelivery {
+	deliveries := make([]entity.WebhookDelivery, 0, len(models))
+	for i := range models {
+		deliveries = append(deliveries, *ToWebhookDeliveryEntity(&models[i]))
+	}
+	return deliveries
+}
+
+func ToWebhookDeliveryModel(entity *entity.WebhookDelivery) *WebhookDeliveryModel {
+	if entity == nil {
+		return nil
+	}
+	var responseStatus *int
+	if entity.ResponseStatus != 0 {
+		responseStatus = &entity.ResponseStatus
+	}
+	return &WebhookDeliveryModel{
+		ID:             entity.ID,
+		WebhookID:      entity.WebhookID,
+		EventID:        entity.EventID,
+		EventType:      string(entity.EventType),
+		Payload:        string(entity.Payload),
+		Status:         string(entity.Status),
+		Attempts:       entity.Attempts,
+		ResponseStatus: responseStatus,
+		ResponseBody:   entity.ResponseBody,
+		LastError:      entity.LastError,
+		NextAttemptAt:  entity.NextAttemptAt,
+		RedeliveryOf:   entity.RedeliveryOf,
+		CreatedAt:      entity.CreatedAt,
+		DeliveredAt:    entity.DeliveredAt,
+	}
+}
@@ -1,35 +1,53 @@
 package operation
 
-import "github.com/sirawong/crud-arise/internal/domain/entity"
+import (
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/pkg/utils"
+)
 
+// ToUpdateProductModel maps every editable column, so an update replaces the product as a whole.
+// Unset optional fields are written as their zero value.
 func ToUpdateProductModel(product *entity.Product) map[string]interface{} {
 	if product == nil {
 		return nil
 	}
 
+	return map[string]interface{}{
+		"name":        product.Name,
+		"description": product.Description,
+		"sku":         product.SKU,
+		"price":       utils.GetValue(product.Price),
+		"stock":       utils.GetValue(product.Stock),
+		"image_url":   utils.GetValue(product.ImageURL),
+		"category_id": product.CategoryID,
+	}
+}
+
+// ToPatchProductModel maps only the columns the patch sets. Zero values are kept, so a patch can
+// set a price or stock of 0 or clear the description.
+func ToPatchProductModel(patch entity.ProductPatch) map[string]interface{} {
 	result := make(map[string]interface{})
 
-	if product.Name != "" {
-		result["name"] = product.Name
+	if patch.Name != nil {
+		result["name"] = *patch.Name
 	}
-	if product.Description != "" {
-		result["description"] = product.Description
+	if patch.Description != nil {
+		result["description"] = *patch.Description
 	}
-	if product.SKU != "" {
-		result["sku"] = product.SKU
+	if patch.SKU != nil {
+		result["sku"] = *patch.SKU
 	}
-	if product.Price != nil {
-		result["price"] = product.Price
+	if patch.Price != nil {
+		result["price"] = *patch.Price
 	}
-	if product.Stock != nil {
-		result["stock"] = product.Stock
+	if patch.Stock != nil {
+		result["stock"] = *patch.Stock
 	}
-	if product.ImageURL != nil {
-		result["image_url"] = product.ImageURL
+	if patch.ImageURL != nil {
+		result["image_url"] = *patch.ImageURL
 	}
-
-	if product.CategoryID != "" {
-		result["category_id"] = product.CategoryID
+	if patch.CategoryID != nil {
+		result["category_id"] = *patch.CategoryID
 	}
 
 	return result
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type orderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) repository.OrderRepository {
+	return &orderRepository{db: db}
+}
+
+func (o orderRepository) Create(ctx context.Context, order *entity.Order) (string, error) {
+	if order == nil {
+		return "", apperr.ErrInvalidArgument.WithMessage("order cannot be nil")
+	}
+
+	value := models.ToOrderModel(order)
+	err := dbFrom(ctx, o.db).Create(&value).Error
+	if err != nil {
+		return "", apperr.ErrInternal.Wrap(err)
+	}
+	return value.ID, nil
+}
+
+// FindByID locks the order when called inside a transaction, so its status and its returns
+// cannot change before the transaction ends.
+func (o orderRepository) FindByID(ctx context.Context, id string) (*entity.Order, error) {
+	var order models.OrderModel
+
+	query := dbFrom(ctx, o.db)
+	if inTx(ctx) {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	err := query.Preload("Lines").First(&order, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.Wrap(err)
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToOrderEntity(&order), nil
+}
+
+func (o orderRepository) FindAll(ctx context.Context, filter entity.OrdersFilter) ([]entity.Order, error) {
+	query := dbFrom(ctx, o.db).Model(&models.OrderModel{})
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	query = query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset)
+
+	var orders []models.OrderModel
+	err := query.Preload("Lines").Find(&orders).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToOrdersEntity(orders), nil
+}
+
+func (o orderRepository) UpdateStatus(ctx context.Context, id string, from, to entity.OrderStatus) error {
+	result := dbFrom(ctx, o.db).Model(&models.OrderModel{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrConflict.WithMessage("order status was changed by another request")
+	}
+
+	return nil
+}
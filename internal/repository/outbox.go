@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+// claimOutboxSQL leases the oldest due events by pushing their next attempt past the lease.
+// Skipping locked rows lets several dispatchers claim side by side without waiting on each other.
+const claimOutboxSQL = `UPDATE outbox SET next_attempt_at = ?
+WHERE id IN (
+	SELECT id FROM outbox
+	WHERE published_at IS NULL AND dead_at IS NULL AND next_attempt_at <= ?
+	ORDER BY id LIMIT ?
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// lifecycleEvents are the events announcing what a write did to a record, by entity type and
+// action. Updates of a product may announce more; see domainEvents.
+var lifecycleEvents = map[entity.AuditEntityType]map[entity.AuditAction]entity.EventType{
+	entity.AuditEntityProduct: {
+		entity.AuditActionCreate:  entity.EventProductCreated,
+		entity.AuditActionUpdate:  entity.EventProductUpdated,
+		entity.AuditActionDelete:  entity.EventProductDeleted,
+		entity.AuditActionRestore: entity.EventProductRestored,
+		entity.AuditActionPurge:   entity.EventProductPurged,
+	},
+	entity.AuditEntityCategory: {
+		entity.AuditActionCreate:  entity.EventCategoryCreated,
+		entity.AuditActionUpdate:  entity.EventCategoryUpdated,
+		entity.AuditActionDelete:  entity.EventCategoryDeleted,
+		entity.AuditActionRestore: entity.EventCategoryRestored,
+		entity.AuditActionPurge:   entity.EventCategoryPurged,
+	},
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) repository.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (o outboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]entity.DomainEvent, error) {
+	now := time.Now()
+	var events []models.OutboxEventModel
+	err := dbFrom(ctx, o.db).Raw(claimOutboxSQL, now.Add(lease), now, limit).Scan(&events).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	// RETURNING does not keep the order of the subquery.
+	slices.SortFunc(events, func(a, b models.OutboxEventModel) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return models.ToDomainEventsEntity(events), nil
+}
+
+func (o outboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	return o.mark(ctx, id, map[string]interface{}{
+		"published_at": time.Now(),
+		"last_error":   "",
+	})
+}
+
+func (o outboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	return o.mark(ctx, id, map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	})
+}
+
+func (o outboxRepository) MarkDead(ctx context.Context, id int64, lastError string) error {
+	return o.mark(ctx, id, map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": lastError,
+		"dead_at":    time.Now(),
+	})
+}
+
+func (o outboxRepository) mark(ctx context.Context, id int64, values map[string]interface{}) error {
+	result := dbFrom(ctx, o.db).Model(&models.OutboxEventModel{}).Where("id = ?", id).Updates(values)
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
+
+func (o outboxRepository) PurgePublished(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	result := dbFrom(ctx, o.db).Where("published_at < ?", publishedBefore).Delete(&models.OutboxEventModel{})
+	if result.Error != nil {
+		return 0, apperr.ErrInternal.Wrap(result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// domainEvents builds the outbox events announcing a recorded change to the entityType record
+// with id. Besides the lifecycle event, an update of a product announces a change of its price
+// or stock with an event of its own, and only announces ProductUpdated when something else
+// changed too.
+func domainEvents(entityType entity.AuditEntityType, id string, action entity.AuditAction, changes map[string]entity.FieldChange, prev, next map[string]interface{}, actor entity.Actor, now time.Time) ([]*models.OutboxEventModel, error) {
+	state := next
+	if action == entity.AuditActionPurge {
+		state = prev
+	}
+
+	type announcement struct {
+		eventType entity.EventType
+		changes   map[string]entity.FieldChange
+	}
+	lifecycle := lifecycleEvents[entityType][action]
+	announcements := []announcement{{lifecycle, changes}}
+	if entityType == entity.AuditEntityProduct && action == entity.AuditActionUpdate {
+		rest := make(map[string]entity.FieldChange, len(changes))
+		for column, change := range changes {
+			if column != "stock" {
+				rest[column] = change
+			}
+		}
+		announcements = announcements[:0]
+		if len(rest) > 0 {
+			announcements = append(announcements, announcement{lifecycle, rest})
+		}
+		if change, ok := changes["price"]; ok {
+			announcements = append(announcements, announcement{entity.EventProductPriceChanged, map[string]entity.FieldChange{"price": change}})
+		}
+		if change, ok := changes["stock"]; ok {
+			announcements = append(announcements, announcement{entity.EventStockChanged, map[string]entity.FieldChange{"stock": change}})
+		}
+	}
+
+	events := make([]*models.OutboxEventModel, 0, len(announcements))
+	for _, announced := range announcements {
+		payload, err := json.Marshal(map[string]interface{}{
+			"state":   state,
+			"changes": announced.changes,
+		})
+		if err != nil {
+			return nil, apperr.ErrInternal.Wrap(err)
+		}
+		events = append(events, models.ToOutboxEventModel(&entity.DomainEvent{
+			Type:          announced.eventType,
+			AggregateType: entityType,
+			AggregateID:   id,
+			Actor:         actor.Name,
+			RequestID:     actor.RequestID,
+			OccurredAt:    now,
+			Payload:       payload,
+			NextAttemptAt: now,
+		}))
+	}
+	return events, nil
+}
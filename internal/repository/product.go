@@ -3,15 +3,26 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirawong/crud-arise/internal/domain/entity"
 	"github.com/sirawong/crud-arise/internal/domain/repository"
 	apperr "github.com/sirawong/crud-arise/internal/errors"
 	"github.com/sirawong/crud-arise/internal/repository/models"
 	"github.com/sirawong/crud-arise/internal/repository/operation"
+	"github.com/sirawong/crud-arise/pkg/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// duplicateSKU explains a write that would give two live products the same SKU.
+const duplicateSKU = "sku is already taken by another product"
+
+// productReferenced explains why a soft-deleted product cannot be purged.
+const productReferenced = "product is still referenced by lots, suppliers, orders or returns"
+
 type productRepository struct {
 	db *gorm.DB
 }
@@ -26,16 +37,62 @@ func (p productRepository) Create(ctx context.Context, product *entity.Product)
 	}
 
 	value := models.ToProductModel(product)
-	err := p.db.WithContext(ctx).Create(&value).Error
+	err := recorded(ctx, p.db, entity.AuditEntityProduct, nil, func(ctx context.Context) ([]string, error) {
+		err := dbFrom(ctx, p.db).Create(&value).Error
+		if err != nil {
+			return nil, writeFailed(err, duplicateSKU)
+		}
+		return []string{value.ID}, nil
+	})
 	if err != nil {
-		return "", apperr.ErrInternal.Wrap(err)
+		return "", err
 	}
 	return value.ID, nil
 }
 
+// CreateMany inserts the products with multi-row INSERTs and returns their IDs in the same order.
+func (p productRepository) CreateMany(ctx context.Context, products []entity.Product) ([]string, error) {
+	values := make([]*models.ProductModel, 0, len(products))
+	for i := range products {
+		values = append(values, models.ToProductModel(&products[i]))
+	}
+
+	var ids []string
+	err := recorded(ctx, p.db, entity.AuditEntityProduct, nil, func(ctx context.Context) ([]string, error) {
+		err := dbFrom(ctx, p.db).CreateInBatches(values, batchChunkSize).Error
+		if err != nil {
+			return nil, writeFailed(err, duplicateSKU)
+		}
+
+		ids = make([]string, 0, len(values))
+		for _, value := range values {
+			ids = append(ids, value.ID)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 func (p productRepository) FindByID(ctx context.Context, id string) (*entity.Product, error) {
 	var product models.ProductModel
-	err := p.db.WithContext(ctx).Preload("Category").First(&product, "id = ?", id).Error
+	err := dbFrom(ctx, p.db).Preload("Category").First(&product, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.Wrap(err)
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToProductEntity(&product), nil
+}
+
+// FindBySKU loads the live product whose SKU matches sku once both are normalized, with its
+// category in a single joined query. The comparison uses the partial unique SKU index.
+func (p productRepository) FindBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	var product models.ProductModel
+	err := dbFrom(ctx, p.db).Joins("Category").First(&product, normalized("products.sku")+" = "+normalized("?"), sku).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, apperr.ErrNotFound.Wrap(err)
@@ -46,7 +103,7 @@ func (p productRepository) FindByID(ctx context.Context, id string) (*entity.Pro
 }
 
 func (p productRepository) FindAll(ctx context.Context, filter entity.ProductFilter) ([]entity.Product, error) {
-	query := p.db.WithContext(ctx).Model([]*models.ProductModel{})
+	query := dbFrom(ctx, p.db).Model([]*models.ProductModel{})
 	query = operation.BuildQuery(query, filter)
 	query = query.Limit(filter.Limit).Offset(filter.Offset)
 
@@ -59,29 +116,448 @@ func (p productRepository) FindAll(ctx context.Context, filter entity.ProductFil
 	return models.ToProductsEntity(products), nil
 }
 
+func (p productRepository) FindAfter(ctx context.Context, filter entity.ProductFilter, afterID string, limit int) ([]entity.Product, error) {
+	query := dbFrom(ctx, p.db).Model([]*models.ProductModel{})
+	query = operation.BuildQuery(query, filter)
+	if afterID != "" {
+		query = query.Where("id > ?", afterID)
+	}
+
+	var products []models.ProductModel
+	err := query.Order("id").Limit(limit).Preload("Category").Find(&products).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToProductsEntity(products), nil
+}
+
+// Stats counts the products matching filter. The latest change includes their categories, whose
+// names are embedded in the products' representation.
+func (p productRepository) Stats(ctx context.Context, filter entity.ProductFilter) (*entity.ListStats, error) {
+	query := dbFrom(ctx, p.db).Model(&models.ProductModel{})
+	query = operation.BuildQuery(query, filter)
+
+	var stats models.ListStatsRow
+	err := query.Select("COUNT(*) AS count, MAX(GREATEST(products.updated_at, " +
+		"(SELECT categories.updated_at FROM categories WHERE categories.id = products.category_id))) AS last_modified").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToListStatsEntity(stats), nil
+}
+
 func (p productRepository) Update(ctx context.Context, product *entity.Product) error {
 	if product == nil {
 		return apperr.ErrInvalidArgument.WithMessage("product cannot be nil")
 	}
 
-	update := operation.ToUpdateProductModel(product)
+	return p.update(ctx, product.ID, product.Version, operation.ToUpdateProductModel(product))
+}
+
+func (p productRepository) Patch(ctx context.Context, id string, patch entity.ProductPatch) error {
+	update := operation.ToPatchProductModel(patch)
 	if len(update) == 0 {
-		return apperr.ErrInvalidArgument.WithMessage("product update cannot be nil")
+		return apperr.ErrInvalidArgument.WithMessage("product patch cannot be empty")
+	}
+
+	return p.update(ctx, id, patch.Version, update)
+}
+
+// update writes values to the product and bumps its version. A non-zero version makes the write
+// conditional on the product still being at that version. The stock of a lot-tracked product is
+// kept as its lots make it.
+func (p productRepository) update(ctx context.Context, id string, version int, values map[string]interface{}) error {
+	values["version"] = gorm.Expr("version + 1")
+	if stock, ok := values["stock"]; ok {
+		values["stock"] = gorm.Expr(lotStockSQL("products", "?"), stock)
+	}
+
+	return recorded(ctx, p.db, entity.AuditEntityProduct, []string{id}, func(ctx context.Context) ([]string, error) {
+		query := dbFrom(ctx, p.db).Model(&models.ProductModel{}).Where("id = ?", id)
+		if version > 0 {
+			query = query.Where("version = ?", version)
+		}
+
+		result := query.Updates(values)
+		if result.Error != nil {
+			return nil, writeFailed(result.Error, duplicateSKU)
+		}
+		if result.RowsAffected == 0 {
+			return nil, notWritten(ctx, p.db, &models.ProductModel{}, id, version)
+		}
+		return nil, nil
+	})
+}
+
+// updateProductsSQL replaces every editable column of the products named in a VALUES list, the
+// same way Update does for a single product.
+var updateProductsSQL = `UPDATE products AS p
+SET name = v.name, description = v.description, sku = v.sku, price = v.price, stock = ` + lotStockSQL("p", "v.stock") + `,
+	image_url = v.image_url, category_id = v.category_id, version = p.version + 1, updated_at = ?
+FROM (VALUES %s) AS v(id, version, name, description, sku, price, stock, image_url, category_id)
+WHERE p.id = v.id AND p.deleted_at IS NULL AND (v.version = 0 OR p.version = v.version)
+RETURNING p.id::text`
+
+// UpdateMany replaces the products with multi-row UPDATEs. It returns one error per product, nil
+// for the products that were written; the error is NOT_FOUND or PRECONDITION_FAILED as for Update.
+func (p productRepository) UpdateMany(ctx context.Context, products []entity.Product) ([]error, error) {
+	refs := make([]entity.VersionedID, 0, len(products))
+	rows := make([][]interface{}, 0, len(products))
+	for _, product := range products {
+		refs = append(refs, entity.VersionedID{ID: product.ID, Version: product.Version})
+		rows = append(rows, []interface{}{
+			product.ID, product.Version, product.Name, product.Description, product.SKU,
+			utils.GetValue(product.Price), utils.GetValue(product.Stock), utils.GetValue(product.ImageURL), product.CategoryID,
+		})
 	}
 
-	err := p.db.WithContext(ctx).Model(&models.ProductModel{}).
-		Where("id = ?", product.ID).Updates(update).Error
+	var errs []error
+	err := recorded(ctx, p.db, entity.AuditEntityProduct, refIDs(refs), func(ctx context.Context) ([]string, error) {
+		var err error
+		errs, err = writeMany(ctx, p.db, &models.ProductModel{}, updateProductsSQL,
+			"(?::uuid, ?::int, ?::text, ?::text, ?::text, ?::numeric, ?::int, ?::text, ?::uuid)", duplicateSKU, refs, rows)
+		return nil, err
+	})
 	if err != nil {
-		return apperr.ErrInternal.Wrap(err)
+		return nil, err
 	}
+	return errs, nil
+}
 
-	return nil
+// upsertProductsSQL inserts the products of a VALUES list, replacing every editable column of those
+// whose SKU a live product already has. Its conflict target is the partial unique SKU index, so a
+// SKU held only by deleted products is free and gets a new product. The stored SKU takes the
+// spelling sent, which lets the returned sku identify the input row. A lot-tracked product keeps
+// its stock. xmax is 0 only for a freshly inserted row.
+var upsertProductsSQL = `INSERT INTO products AS p
+	(id, name, description, sku, price, stock, image_url, category_id, version, created_at, updated_at)
+VALUES %s
+ON CONFLICT (` + normalized("sku") + `) WHERE deleted_at IS NULL DO UPDATE
+SET sku = EXCLUDED.sku, name = EXCLUDED.name, description = EXCLUDED.description, price = EXCLUDED.price,
+	stock = ` + lotStockSQL("p", "EXCLUDED.stock") + `, image_url = EXCLUDED.image_url, category_id = EXCLUDED.category_id,
+	version = p.version + 1, updated_at = EXCLUDED.updated_at
+RETURNING p.id::text AS id, p.sku, (p.xmax = 0) AS created`
+
+// UpsertBySKU creates the products whose SKU no live product has and replaces the others, with
+// multi-row INSERT ... ON CONFLICT statements. It returns one result per product, in the same
+// order. The SKUs must be distinct once normalized; a statement cannot write the same row twice.
+func (p productRepository) UpsertBySKU(ctx context.Context, products []entity.Product) ([]entity.BatchResult, error) {
+	now := time.Now()
+	rows := make([][]interface{}, 0, len(products))
+	for _, product := range products {
+		rows = append(rows, []interface{}{
+			uuid.New().String(), product.Name, product.Description, product.SKU, utils.GetValue(product.Price),
+			utils.GetValue(product.Stock), utils.GetValue(product.ImageURL), product.CategoryID, now, now,
+		})
+	}
+
+	skus := make([]string, 0, len(products))
+	for _, product := range products {
+		skus = append(skus, product.SKU)
+	}
+	existing, err := p.liveIDsBySKU(ctx, skus)
+	if err != nil {
+		return nil, err
+	}
+
+	written := make(map[string]models.UpsertedRow, len(rows))
+	err = recorded(ctx, p.db, entity.AuditEntityProduct, existing, func(ctx context.Context) ([]string, error) {
+		var ids []string
+		for start := 0; start < len(rows); start += batchChunkSize {
+			values, args := valuesList("(?, ?, ?, ?, ?, ?, ?, ?, 1, ?, ?)", rows[start:min(start+batchChunkSize, len(rows))])
+
+			var upserted []models.UpsertedRow
+			err := dbFrom(ctx, p.db).Raw(fmt.Sprintf(upsertProductsSQL, values), args...).Scan(&upserted).Error
+			if err != nil {
+				return nil, writeFailed(err, duplicateSKU)
+			}
+			for _, row := range upserted {
+				written[row.SKU] = row
+				ids = append(ids, row.ID)
+			}
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]entity.BatchResult, len(products))
+	for i, product := range products {
+		row, ok := written[product.SKU]
+		if !ok {
+			results[i] = entity.BatchResult{Index: i, Err: apperr.ErrInternal.WithMessage("upsert returned no row for sku " + product.SKU)}
+			continue
+		}
+		results[i] = entity.BatchResult{Index: i, ID: row.ID, Created: row.Created}
+	}
+	return results, nil
+}
+
+// liveIDsBySKU lists the ids of the live products holding any of skus once both are normalized.
+func (p productRepository) liveIDsBySKU(ctx context.Context, skus []string) ([]string, error) {
+	var ids []string
+	for start := 0; start < len(skus); start += batchChunkSize {
+		rows := make([][]interface{}, 0, batchChunkSize)
+		for _, sku := range skus[start:min(start+batchChunkSize, len(skus))] {
+			rows = append(rows, []interface{}{sku})
+		}
+		values, args := valuesList("(?::text)", rows)
+
+		var found []string
+		err := dbFrom(ctx, p.db).Model(&models.ProductModel{}).
+			Where(fmt.Sprintf("%s IN (SELECT %s FROM (VALUES %s) AS v(sku))", normalized("products.sku"), normalized("v.sku"), values), args...).
+			Pluck("id", &found).Error
+		if err != nil {
+			return nil, apperr.ErrInternal.Wrap(err)
+		}
+		ids = append(ids, found...)
+	}
+	return ids, nil
 }
 
-func (p productRepository) Delete(ctx context.Context, id string) error {
-	err := p.db.WithContext(ctx).Delete(&models.CategoryModel{}, "id = ?", id).Error
+// Delete soft-deletes the product, failing with NOT_FOUND when there is no such product. A non-zero
+// version makes the delete conditional on the product still being at that version.
+func (p productRepository) Delete(ctx context.Context, id string, version int) error {
+	return recorded(ctx, p.db, entity.AuditEntityProduct, []string{id}, func(ctx context.Context) ([]string, error) {
+		query := dbFrom(ctx, p.db)
+		if version > 0 {
+			query = query.Where("version = ?", version)
+		}
+
+		result := query.Delete(&models.ProductModel{}, "id = ?", id)
+		if result.Error != nil {
+			return nil, apperr.ErrInternal.Wrap(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil, notWritten(ctx, p.db, &models.ProductModel{}, id, version)
+		}
+		return nil, nil
+	})
+}
+
+// DeleteMany soft-deletes the products with multi-row UPDATEs. It returns one error per ref, nil
+// for the products that were deleted.
+func (p productRepository) DeleteMany(ctx context.Context, refs []entity.VersionedID) ([]error, error) {
+	var errs []error
+	err := recorded(ctx, p.db, entity.AuditEntityProduct, refIDs(refs), func(ctx context.Context) ([]string, error) {
+		var err error
+		errs, err = softDeleteMany(ctx, p.db, &models.ProductModel{}, models.ProductModel{}.TableName(), refs)
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+// FindByIDs loads the products with their category in a single joined query. Products come back in
+// no particular order and missing IDs are skipped.
+func (p productRepository) FindByIDs(ctx context.Context, ids []string) ([]entity.Product, error) {
+	if len(ids) == 0 {
+		return []entity.Product{}, nil
+	}
+
+	var products []models.ProductModel
+	err := dbFrom(ctx, p.db).Joins("Category").Where("products.id IN ?", ids).Find(&products).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToProductsEntity(products), nil
+}
+
+// FindDeleted lists soft-deleted products, most recently deleted first, with their category even
+// if it is deleted too.
+func (p productRepository) FindDeleted(ctx context.Context, pagination entity.Pagination) ([]entity.Product, error) {
+	var products []models.ProductModel
+	err := findDeleted(dbFrom(ctx, p.db).Preload("Category", unscoped), &products, pagination.Limit, pagination.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return models.ToProductsEntity(products), nil
+}
+
+// FindDeletedByID loads a soft-deleted product with its category even if it is deleted too.
+func (p productRepository) FindDeletedByID(ctx context.Context, id string) (*entity.Product, error) {
+	var product models.ProductModel
+	err := firstDeleted(dbFrom(ctx, p.db).Preload("Category", unscoped), &product, id)
+	if err != nil {
+		return nil, err
+	}
+	return models.ToProductEntity(&product), nil
+}
+
+// Restore brings a soft-deleted product back in categoryID.
+func (p productRepository) Restore(ctx context.Context, id, categoryID string) error {
+	return recorded(ctx, p.db, entity.AuditEntityProduct, []string{id}, func(ctx context.Context) ([]string, error) {
+		return nil, restore(ctx, p.db, &models.ProductModel{}, id, map[string]interface{}{"category_id": categoryID}, duplicateSKU)
+	})
+}
+
+// Purge removes a soft-deleted product for good.
+func (p productRepository) Purge(ctx context.Context, id string) error {
+	return recorded(ctx, p.db, entity.AuditEntityProduct, []string{id}, func(ctx context.Context) ([]string, error) {
+		return nil, purge(ctx, p.db, &models.ProductModel{}, id, productReferenced)
+	})
+}
+
+func (p productRepository) FindExpiredIDs(ctx context.Context, deletedBefore time.Time, afterID string, limit int) ([]string, error) {
+	return findExpiredIDs(ctx, p.db, &models.ProductModel{}, deletedBefore, afterID, limit)
+}
+
+func (p productRepository) PurgeMany(ctx context.Context, ids []string) error {
+	return recorded(ctx, p.db, entity.AuditEntityProduct, ids, func(ctx context.Context) ([]string, error) {
+		return nil, purgeMany(ctx, p.db, &models.ProductModel{}, ids, productReferenced)
+	})
+}
+
+func (p productRepository) FindRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error) {
+	return findRevisions(ctx, p.db, entity.AuditEntityProduct, id, pagination)
+}
+
+func (p productRepository) FindRevision(ctx context.Context, id string, number int) (*entity.ProductRevision, error) {
+	var revision models.ProductRevisionModel
+	if err := findRevision(ctx, p.db, entity.AuditEntityProduct, id, number, &revision); err != nil {
+		return nil, err
+	}
+
+	// The category is loaded as it is now, deleted or not, and left out once purged.
+	var category models.CategoryModel
+	result := dbFrom(ctx, p.db).Unscoped().Limit(1).Find(&category, "id = ?", revision.CategoryID)
+	if result.Error != nil {
+		return nil, apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected > 0 {
+		revision.Category = &category
+	}
+	return models.ToProductRevisionEntity(&revision), nil
+}
+
+// unscoped lets a preload load soft-deleted rows.
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// AdjustStock is the single path for changing stock levels. All adjustments are applied in one
+// transaction; if any product is missing or would go negative nothing is applied and every
+// failing adjustment is reported in the error details.
+func (p productRepository) AdjustStock(ctx context.Context, adjustments []entity.StockAdjustment) error {
+	var shortages []entity.StockShortage
+
+	ids := make([]string, 0, len(adjustments))
+	for _, adjustment := range adjustments {
+		ids = append(ids, adjustment.ProductID)
+	}
+
+	err := dbFrom(ctx, p.db).Transaction(func(tx *gorm.DB) error {
+		err := recordChanges(tx, entity.AuditEntityProduct, ids, func(tx *gorm.DB) ([]string, error) {
+			now := time.Now()
+			for i, adjustment := range adjustments {
+				shortage, err := adjustProductStock(tx, adjustment, now)
+				if err != nil {
+					return nil, err
+				}
+				if shortage != nil {
+					shortage.Index = i
+					shortages = append(shortages, *shortage)
+				}
+			}
+			return nil, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(shortages) > 0 {
+			return apperr.ErrInsufficientStock.
+				WithMessage("one or more stock adjustments could not be applied").
+				WithDetails(shortages)
+		}
+		return nil
+	})
 	if err != nil {
+		var appErr *apperr.AppError
+		if errors.As(err, &appErr) {
+			return err
+		}
 		return apperr.ErrInternal.Wrap(err)
 	}
+
 	return nil
 }
+
+// adjustProductStock applies one adjustment inside tx. Lot-tracked products are adjusted through
+// their lots (FEFO on the way out, latest-expiring lot on the way back in) and re-synced.
+func adjustProductStock(tx *gorm.DB, adjustment entity.StockAdjustment, asOf time.Time) (*entity.StockShortage, error) {
+	shortage := &entity.StockShortage{
+		ProductID: adjustment.ProductID,
+		Requested: max(adjustment.Delta, -adjustment.Delta),
+	}
+
+	var product models.ProductModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "stock").
+		First(&product, "id = ?", adjustment.ProductID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			shortage.Reason = entity.StockShortageNotFound
+			return shortage, nil
+		}
+		return nil, err
+	}
+	shortage.Available = product.Stock
+
+	tracked, err := lotTracked(tx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !tracked {
+		if product.Stock+adjustment.Delta < 0 {
+			shortage.Reason = entity.StockShortageInsufficient
+			return shortage, nil
+		}
+		err = tx.Model(&models.ProductModel{}).Where("id = ?", product.ID).
+			Updates(map[string]interface{}{
+				"stock":   gorm.Expr("stock + ?", adjustment.Delta),
+				"version": gorm.Expr("version + 1"),
+			}).Error
+		return nil, err
+	}
+
+	switch {
+	case adjustment.Delta < 0:
+		_, err = consumeLots(tx, product.ID, -adjustment.Delta, asOf)
+		if apperr.GetCode(err) == apperr.ErrInsufficientStock.Code {
+			shortage.Reason = entity.StockShortageInsufficient
+			return shortage, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	case adjustment.Delta > 0:
+		var lot models.LotModel
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ? AND status = ? AND expires_at > ?", product.ID, entity.LotStatusActive, asOf).
+			Order("expires_at DESC").
+			First(&lot).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			shortage.Reason = entity.StockShortageNoActiveLot
+			return shortage, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = tx.Model(&models.LotModel{}).Where("id = ?", lot.ID).
+			Update("quantity", gorm.Expr("quantity + ?", adjustment.Delta)).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, recomputeProductStock(tx, []string{product.ID}, asOf)
+}
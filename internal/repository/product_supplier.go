@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type productSupplierRepository struct {
+	db *gorm.DB
+}
+
+func NewProductSupplierRepository(db *gorm.DB) repository.ProductSupplierRepository {
+	return &productSupplierRepository{db: db}
+}
+
+// Upsert creates or replaces the link. Marking a link preferred clears the flag on the product's other links.
+func (p productSupplierRepository) Upsert(ctx context.Context, link *entity.ProductSupplier) error {
+	if link == nil {
+		return apperr.ErrInvalidArgument.WithMessage("product supplier cannot be nil")
+	}
+
+	value := models.ToProductSupplierModel(link)
+	err := dbFrom(ctx, p.db).Transaction(func(tx *gorm.DB) error {
+		if value.Preferred {
+			err := tx.Model(&models.ProductSupplierModel{}).
+				Where("product_id = ? AND supplier_id <> ?", value.ProductID, value.SupplierID).
+				Update("preferred", false).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "product_id"}, {Name: "supplier_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"supplier_sku", "cost_price", "min_order_quantity", "preferred", "updated_at",
+			}),
+		}).Create(&value).Error
+	})
+	if err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+
+	return nil
+}
+
+func (p productSupplierRepository) Delete(ctx context.Context, productID, supplierID string) error {
+	result := dbFrom(ctx, p.db).
+		Delete(&models.ProductSupplierModel{}, "product_id = ? AND supplier_id = ?", productID, supplierID)
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrNotFound.WithMessage("product supplier link not found")
+	}
+	return nil
+}
+
+func (p productSupplierRepository) FindByProductID(ctx context.Context, productID string) ([]entity.ProductSupplier, error) {
+	var links []models.ProductSupplierModel
+	err := dbFrom(ctx, p.db).
+		InnerJoins("Supplier").
+		Where("product_suppliers.product_id = ?", productID).
+		Order("product_suppliers.preferred DESC, product_suppliers.cost_price ASC").
+		Find(&links).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToProductSuppliersEntity(links), nil
+}
+
+func (p productSupplierRepository) FindBySupplierID(ctx context.Context, supplierID string) ([]entity.ProductSupplier, error) {
+	var links []models.ProductSupplierModel
+	err := dbFrom(ctx, p.db).
+		InnerJoins("Product").
+		Where("product_suppliers.supplier_id = ?", supplierID).
+		Order("\"Product\".name ASC").
+		Find(&links).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToProductSuppliersEntity(links), nil
+}
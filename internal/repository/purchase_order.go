@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type purchaseOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewPurchaseOrderRepository(db *gorm.DB) repository.PurchaseOrderRepository {
+	return &purchaseOrderRepository{db: db}
+}
+
+func (p purchaseOrderRepository) Create(ctx context.Context, order *entity.PurchaseOrder) (string, error) {
+	if order == nil {
+		return "", apperr.ErrInvalidArgument.WithMessage("purchase order cannot be nil")
+	}
+
+	value := models.ToPurchaseOrderModel(order)
+	err := dbFrom(ctx, p.db).Create(&value).Error
+	if err != nil {
+		return "", apperr.ErrInternal.Wrap(err)
+	}
+	return value.ID, nil
+}
+
+func (p purchaseOrderRepository) FindByID(ctx context.Context, id string) (*entity.PurchaseOrder, error) {
+	var order models.PurchaseOrderModel
+	err := p.preload(dbFrom(ctx, p.db)).First(&order, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.Wrap(err)
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToPurchaseOrderEntity(&order), nil
+}
+
+func (p purchaseOrderRepository) FindAll(ctx context.Context, filter entity.PurchaseOrdersFilter) ([]entity.PurchaseOrder, error) {
+	query := dbFrom(ctx, p.db).Model(&models.PurchaseOrderModel{})
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *filter.SupplierID)
+	}
+	query = query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset)
+
+	var orders []models.PurchaseOrderModel
+	err := p.preload(query).Find(&orders).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToPurchaseOrdersEntity(orders), nil
+}
+
+func (p purchaseOrderRepository) UpdateStatus(ctx context.Context, id string, from, to entity.PurchaseOrderStatus) error {
+	updates := map[string]interface{}{"status": to}
+	if to == entity.PurchaseOrderStatusSubmitted {
+		updates["submitted_at"] = time.Now()
+	}
+
+	result := dbFrom(ctx, p.db).Model(&models.PurchaseOrderModel{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrConflict.WithMessage("purchase order status was changed by another request")
+	}
+
+	return nil
+}
+
+// Receive records received quantities and adds them to product stock in one transaction.
+// Receipts carrying a lot number are booked as a new lot instead of raw stock, and a lot-tracked
+// product can only be received that way: raw stock would be dropped when its stock is next
+// re-synced from its lots.
+func (p purchaseOrderRepository) Receive(ctx context.Context, id string, receipts []entity.PurchaseOrderReceipt) (*entity.PurchaseOrder, error) {
+	err := dbFrom(ctx, p.db).Transaction(func(tx *gorm.DB) error {
+		var model models.PurchaseOrderModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&model, "id = ?", id).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperr.ErrNotFound.Wrap(err)
+			}
+			return apperr.ErrInternal.Wrap(err)
+		}
+		err = tx.Where("purchase_order_id = ?", id).Find(&model.Lines).Error
+		if err != nil {
+			return apperr.ErrInternal.Wrap(err)
+		}
+
+		order := models.ToPurchaseOrderEntity(&model)
+		if !order.Status.CanReceive() {
+			return apperr.ErrConflict.WithMessage("purchase order in status " + string(order.Status) + " cannot be received")
+		}
+		if err = order.ApplyReceipts(receipts); err != nil {
+			return apperr.ErrInvalidArgument.WithMessage(err.Error())
+		}
+
+		productIDs := make(map[string]string, len(order.Lines))
+		for _, line := range order.Lines {
+			productIDs[line.ID] = line.ProductID
+		}
+
+		now := time.Now()
+		var lotted []string
+		for _, receipt := range receipts {
+			productID := productIDs[receipt.LineID]
+
+			err = tx.Model(&models.PurchaseOrderLineModel{}).Where("id = ?", receipt.LineID).
+				Update("received_quantity", gorm.Expr("received_quantity + ?", receipt.Quantity)).Error
+			if err != nil {
+				return apperr.ErrInternal.Wrap(err)
+			}
+
+			if receipt.LotNumber != "" {
+				if err = lockForLot(tx, productID); err != nil {
+					var appErr *apperr.AppError
+					if errors.As(err, &appErr) {
+						return err
+					}
+					return apperr.ErrInternal.Wrap(err)
+				}
+				lot := models.LotModel{
+					ProductID:  productID,
+					LotNumber:  receipt.LotNumber,
+					Quantity:   receipt.Quantity,
+					ReceivedAt: now,
+					ExpiresAt:  *receipt.ExpiresAt,
+					Status:     string(entity.LotStatusActive),
+				}
+				if err = tx.Create(&lot).Error; err != nil {
+					return apperr.ErrInternal.Wrap(err)
+				}
+				lotted = append(lotted, productID)
+				continue
+			}
+
+			tracked, err := lotTracked(tx, productID)
+			if err != nil {
+				return apperr.ErrInternal.Wrap(err)
+			}
+			if tracked {
+				msg := fmt.Sprintf("line %s: product %s is tracked in lots, its receipt needs a lot number and expiry date", receipt.LineID, productID)
+				return apperr.ErrInvalidArgument.WithMessage(msg)
+			}
+
+			err = recordChanges(tx, entity.AuditEntityProduct, []string{productID}, func(tx *gorm.DB) ([]string, error) {
+				return nil, tx.Model(&models.ProductModel{}).Where("id = ?", productID).
+					Updates(map[string]interface{}{
+						"stock":   gorm.Expr("stock + ?", receipt.Quantity),
+						"version": gorm.Expr("version + 1"),
+					}).Error
+			})
+			if err != nil {
+				return apperr.ErrInternal.Wrap(err)
+			}
+		}
+		if err = syncProductStock(tx, lotted, now); err != nil {
+			return apperr.ErrInternal.Wrap(err)
+		}
+
+		err = tx.Model(&models.PurchaseOrderModel{}).Where("id = ?", id).
+			Update("status", order.Status).Error
+		if err != nil {
+			return apperr.ErrInternal.Wrap(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.FindByID(ctx, id)
+}
+
+func (p purchaseOrderRepository) preload(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Lines.Product").
+		Preload("Supplier")
+}
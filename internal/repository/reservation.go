@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type reservationRepository struct {
+	db *gorm.DB
+}
+
+func NewReservationRepository(db *gorm.DB) repository.ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+func (r reservationRepository) Create(ctx context.Context, reservation *entity.Reservation) (string, error) {
+	if reservation == nil {
+		return "", apperr.ErrInvalidArgument.WithMessage("reservation cannot be nil")
+	}
+
+	value := models.ToReservationModel(reservation)
+	err := dbFrom(ctx, r.db).Create(value).Error
+	if err != nil {
+		return "", apperr.ErrInternal.Wrap(err)
+	}
+	return value.ID, nil
+}
+
+func (r reservationRepository) FindByID(ctx context.Context, id string) (*entity.Reservation, error) {
+	var reservation models.ReservationModel
+	err := dbFrom(ctx, r.db).First(&reservation, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.Wrap(err)
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToReservationEntity(&reservation), nil
+}
+
+func (r reservationRepository) FindExpired(ctx context.Context, asOf time.Time, limit int) ([]entity.Reservation, error) {
+	var reservations []models.ReservationModel
+	err := dbFrom(ctx, r.db).
+		Where("status = ? AND expires_at < ?", entity.ReservationStatusHeld, asOf).
+		Order("expires_at, id").
+		Limit(limit).
+		Find(&reservations).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToReservationsEntity(reservations), nil
+}
+
+func (r reservationRepository) UpdateStatus(ctx context.Context, id string, from, to entity.ReservationStatus) error {
+	result := dbFrom(ctx, r.db).Model(&models.ReservationModel{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{"status": to, "released_at": time.Now()})
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrConflict.WithMessage("reservation status was changed by another request")
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type retentionRunRepository struct {
+	db *gorm.DB
+}
+
+func NewRetentionRunRepository(db *gorm.DB) repository.RetentionRunRepository {
+	return &retentionRunRepository{db: db}
+}
+
+func (r retentionRunRepository) Create(ctx context.Context, run *entity.RetentionRun) error {
+	if run == nil {
+		return apperr.ErrInvalidArgument.WithMessage("retention run cannot be nil")
+	}
+
+	err := dbFrom(ctx, r.db).Create(models.ToRetentionRunModel(run)).Error
+	if err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
+
+func (r retentionRunRepository) FindLatest(ctx context.Context) (*entity.RetentionRun, error) {
+	var run models.RetentionRunModel
+	err := dbFrom(ctx, r.db).Order("started_at DESC").First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.Wrap(err)
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToRetentionRunEntity(&run), nil
+}
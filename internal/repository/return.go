@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type returnRepository struct {
+	db *gorm.DB
+}
+
+func NewReturnRepository(db *gorm.DB) repository.ReturnRepository {
+	return &returnRepository{db: db}
+}
+
+func (r returnRepository) Create(ctx context.Context, rma *entity.ReturnAuthorization) (string, error) {
+	if rma == nil {
+		return "", apperr.ErrInvalidArgument.WithMessage("return authorization cannot be nil")
+	}
+
+	value := models.ToReturnModel(rma)
+	err := dbFrom(ctx, r.db).Create(&value).Error
+	if err != nil {
+		return "", apperr.ErrInternal.Wrap(err)
+	}
+	return value.ID, nil
+}
+
+func (r returnRepository) FindByID(ctx context.Context, id string) (*entity.ReturnAuthorization, error) {
+	var rma models.ReturnAuthorizationModel
+	err := dbFrom(ctx, r.db).Preload("Lines").First(&rma, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.Wrap(err)
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToReturnEntity(&rma), nil
+}
+
+func (r returnRepository) FindAll(ctx context.Context, filter entity.ReturnsFilter) ([]entity.ReturnAuthorization, error) {
+	query := dbFrom(ctx, r.db).Model(&models.ReturnAuthorizationModel{})
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.OrderID != nil {
+		query = query.Where("order_id = ?", *filter.OrderID)
+	}
+	if filter.ProductID != nil {
+		query = query.Where("id IN (?)", r.db.Model(&models.ReturnLineModel{}).Select("return_id").Where("product_id = ?", *filter.ProductID))
+	}
+	query = query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset)
+
+	var rmas []models.ReturnAuthorizationModel
+	err := query.Preload("Lines").Find(&rmas).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToReturnsEntity(rmas), nil
+}
+
+func (r returnRepository) UpdateStatus(ctx context.Context, id string, from, to entity.ReturnStatus) error {
+	updates := map[string]interface{}{"status": to}
+	if to == entity.ReturnStatusReceived {
+		updates["received_at"] = time.Now()
+	}
+
+	result := dbFrom(ctx, r.db).Model(&models.ReturnAuthorizationModel{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrConflict.WithMessage("return status was changed by another request")
+	}
+
+	return nil
+}
+
+// ReturnedQuantities sums, per product, the units on the order's returns that are authorized or
+// received. Cancelled returns give their units back.
+func (r returnRepository) ReturnedQuantities(ctx context.Context, orderID string) (map[string]int, error) {
+	var rows []struct {
+		ProductID string
+		Quantity  int
+	}
+	err := dbFrom(ctx, r.db).Table("return_lines rl").
+		Select("rl.product_id, SUM(rl.quantity) AS quantity").
+		Joins("JOIN return_authorizations ra ON ra.id = rl.return_id AND ra.deleted_at IS NULL").
+		Where("ra.order_id = ? AND ra.status <> ?", orderID, entity.ReturnStatusCancelled).
+		Group("rl.product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	returned := make(map[string]int, len(rows))
+	for _, row := range rows {
+		returned[row.ProductID] = row.Quantity
+	}
+	return returned, nil
+}
+
+// ReturnRates compares units on received returns with units on paid or shipped orders, grouped
+// by product or by the product's category. Only live products with sales or returns are included.
+func (r returnRepository) ReturnRates(ctx context.Context, filter entity.ReturnRateFilter) ([]entity.ReturnRate, error) {
+	var rows []models.ReturnRateRow
+	err := returnRatesQuery(dbFrom(ctx, r.db), filter).Scan(&rows).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToReturnRatesEntity(rows), nil
+}
+
+// returnRatesQuery builds the report query. The tables are joined by hand, so soft-deleted
+// products and categories have to be left out explicitly.
+func returnRatesQuery(db *gorm.DB, filter entity.ReturnRateFilter) *gorm.DB {
+	sold := db.Table("order_lines ol").
+		Select("ol.product_id, SUM(ol.quantity) AS quantity").
+		Joins("JOIN orders o ON o.id = ol.order_id AND o.deleted_at IS NULL").
+		Where("o.status IN ?", []entity.OrderStatus{entity.OrderStatusPaid, entity.OrderStatusShipped})
+	if filter.From != nil {
+		sold = sold.Where("o.created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		sold = sold.Where("o.created_at < ?", *filter.To)
+	}
+	sold = sold.Group("ol.product_id")
+
+	returned := db.Table("return_lines rl").
+		Select("rl.product_id, SUM(rl.quantity) AS quantity").
+		Joins("JOIN return_authorizations ra ON ra.id = rl.return_id AND ra.deleted_at IS NULL").
+		Where("ra.status = ?", entity.ReturnStatusReceived)
+	if filter.From != nil {
+		returned = returned.Where("ra.received_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		returned = returned.Where("ra.received_at < ?", *filter.To)
+	}
+	returned = returned.Group("rl.product_id")
+
+	var query *gorm.DB
+	switch filter.GroupBy {
+	case entity.ReturnRateByCategory:
+		query = db.Table("categories c").
+			Select("c.id AS id, c.name AS name, COALESCE(SUM(s.quantity), 0) AS sold_quantity, COALESCE(SUM(rt.quantity), 0) AS returned_quantity").
+			Joins("JOIN products p ON p.category_id = c.id AND p.deleted_at IS NULL").
+			Where("c.deleted_at IS NULL").
+			Group("c.id, c.name")
+	default:
+		query = db.Table("products p").
+			Select("p.id AS id, p.name AS name, COALESCE(SUM(s.quantity), 0) AS sold_quantity, COALESCE(SUM(rt.quantity), 0) AS returned_quantity").
+			Where("p.deleted_at IS NULL").
+			Group("p.id, p.name")
+	}
+
+	return query.
+		Joins("LEFT JOIN (?) AS s ON s.product_id = p.id", sold).
+		Joins("LEFT JOIN (?) AS rt ON rt.product_id = p.id", returned).
+		Where("s.quantity IS NOT NULL OR rt.quantity IS NOT NULL").
+		Order("returned_quantity DESC, name ASC")
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type ReturnRatesQueryTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func (suite *ReturnRatesQueryTestSuite) SetupTest() {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	suite.Require().NoError(err)
+	suite.db = db
+}
+
+func (suite *ReturnRatesQueryTestSuite) toSQL(filter entity.ReturnRateFilter) string {
+	var rows []models.ReturnRateRow
+	stmt := returnRatesQuery(suite.db, filter).Scan(&rows).Statement
+	return suite.db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)
+}
+
+func (suite *ReturnRatesQueryTestSuite) TestByProduct_SkipsDeletedProducts() {
+	sql := suite.toSQL(entity.ReturnRateFilter{GroupBy: entity.ReturnRateByProduct})
+
+	suite.Contains(sql, "FROM products p")
+	suite.Contains(sql, "WHERE p.deleted_at IS NULL")
+}
+
+func (suite *ReturnRatesQueryTestSuite) TestByCategory_SkipsDeletedProductsAndCategories() {
+	sql := suite.toSQL(entity.ReturnRateFilter{GroupBy: entity.ReturnRateByCategory})
+
+	suite.Contains(sql, "FROM categories c")
+	suite.Contains(sql, "JOIN products p ON p.category_id = c.id AND p.deleted_at IS NULL")
+	suite.Contains(sql, "c.deleted_at IS NULL")
+}
+
+func TestReturnRatesQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(ReturnRatesQueryTestSuite))
+}
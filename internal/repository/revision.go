@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+// insertRevisionsSQL stores the current state of the rows of a table named in a VALUES list of
+// (id, action) as their next revision. The rows are locked by the write being recorded, so no
+// other transaction can take the same number.
+const insertRevisionsSQL = `INSERT INTO revisions (entity_type, entity_id, revision, action, actor, request_id, created_at, data)
+SELECT ?, t.id,
+	COALESCE((SELECT MAX(r.revision) FROM revisions AS r WHERE r.entity_type = ? AND r.entity_id = t.id), 0) + 1,
+	v.action, ?, ?, ?, to_jsonb(t)
+FROM %s AS t JOIN (VALUES %%s) AS v(id, action) ON t.id = v.id
+RETURNING entity_id::text, revision`
+
+// selectRevisionsSQL reads revisions of one entity type together with the rows stored in them,
+// laid out as the columns of the table the rows belong to.
+const selectRevisionsSQL = `SELECT r.entity_type, r.entity_id::text AS entity_id, r.revision, r.action, r.actor, r.request_id,
+	r.created_at AS revised_at, t.*
+FROM revisions AS r CROSS JOIN LATERAL jsonb_populate_record(NULL::%s, r.data) AS t
+WHERE r.entity_type = ?`
+
+// recordRevisions stores the state the rows of entityType's table with ids were left in by a
+// write as their next revisions, actions[i] telling what the write did to ids[i]. It returns the
+// number each row's revision got.
+func recordRevisions(tx *gorm.DB, entityType entity.AuditEntityType, ids []string, actions []entity.AuditAction, actor entity.Actor, now time.Time) (map[string]int, error) {
+	rows := make([][]interface{}, 0, len(ids))
+	for i, id := range ids {
+		rows = append(rows, []interface{}{id, string(actions[i])})
+	}
+
+	numbers := make(map[string]int, len(ids))
+	query := fmt.Sprintf(insertRevisionsSQL, auditedTables[entityType])
+	for start := 0; start < len(rows); start += batchChunkSize {
+		values, args := valuesList("(?::uuid, ?::text)", rows[start:min(start+batchChunkSize, len(rows))])
+		args = append([]interface{}{string(entityType), string(entityType), actor.Name, actor.RequestID, now}, args...)
+
+		var stored []struct {
+			EntityID string
+			Revision int
+		}
+		if err := tx.Raw(fmt.Sprintf(query, values), args...).Scan(&stored).Error; err != nil {
+			return nil, apperr.ErrInternal.Wrap(err)
+		}
+		for _, revision := range stored {
+			numbers[revision.EntityID] = revision.Revision
+		}
+	}
+	return numbers, nil
+}
+
+// forgetRevisions removes the revisions of purged rows, whose history goes with them.
+func forgetRevisions(tx *gorm.DB, entityType entity.AuditEntityType, ids []string) error {
+	for start := 0; start < len(ids); start += batchChunkSize {
+		err := tx.Where("entity_type = ? AND entity_id IN ?", entityType, ids[start:min(start+batchChunkSize, len(ids))]).
+			Delete(&models.RevisionModel{}).Error
+		if err != nil {
+			return apperr.ErrInternal.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// findRevisions lists a page of the revisions of the entityType record with id, newest first.
+func findRevisions(ctx context.Context, db *gorm.DB, entityType entity.AuditEntityType, id string, pagination entity.Pagination) ([]entity.Revision, error) {
+	var revisions []models.RevisionHeader
+	err := dbFrom(ctx, db).Model(&models.RevisionModel{}).
+		Select("entity_type, entity_id::text AS entity_id, revision, action, actor, request_id, created_at AS revised_at").
+		Where("entity_type = ? AND entity_id = ?", entityType, id).
+		Order("revision DESC").Limit(pagination.Limit).Offset(pagination.Offset).
+		Scan(&revisions).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToRevisionsEntity(revisions), nil
+}
+
+// findRevision loads revision number of the entityType record with id into dest, a model
+// embedding models.RevisionHeader and the model of the record. It fails with NOT_FOUND when
+// there is no such revision.
+func findRevision(ctx context.Context, db *gorm.DB, entityType entity.AuditEntityType, id string, number int, dest interface{}) error {
+	query := fmt.Sprintf(selectRevisionsSQL, auditedTables[entityType]) + " AND r.entity_id = ? AND r.revision = ?"
+	result := dbFrom(ctx, db).Raw(query, entityType, id, number).Scan(dest)
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrNotFound.WithMessage(fmt.Sprintf("revision %d not found", number))
+	}
+	return nil
+}
+
+// findRevisionsByKeys loads the revisions of entityType records named by keys, each an
+// (id, revision number) pair, into dest, a slice of the models findRevision takes. Revisions
+// that no longer exist are left out.
+func findRevisionsByKeys(ctx context.Context, db *gorm.DB, entityType entity.AuditEntityType, keys [][]interface{}, dest interface{}) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values, args := valuesList("(?::uuid, ?::int)", keys)
+	query := fmt.Sprintf(selectRevisionsSQL, auditedTables[entityType]) + fmt.Sprintf(" AND (r.entity_id, r.revision) IN (VALUES %s)", values)
+	err := dbFrom(ctx, db).Raw(query, append([]interface{}{entityType}, args...)...).Scan(dest).Error
+	if err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
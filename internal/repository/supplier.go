@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type supplierRepository struct {
+	db *gorm.DB
+}
+
+func NewSupplierRepository(db *gorm.DB) repository.SupplierRepository {
+	return &supplierRepository{db: db}
+}
+
+func (s supplierRepository) Create(ctx context.Context, supplier *entity.Supplier) (string, error) {
+	if supplier == nil {
+		return "", apperr.ErrInvalidArgument.WithMessage("supplier cannot be nil")
+	}
+
+	value := models.ToSupplierModel(supplier)
+	err := dbFrom(ctx, s.db).Create(&value).Error
+	if err != nil {
+		return "", apperr.ErrInternal.Wrap(err)
+	}
+	return value.ID, nil
+}
+
+func (s supplierRepository) FindByID(ctx context.Context, id string) (*entity.Supplier, error) {
+	var supplier models.SupplierModel
+	err := dbFrom(ctx, s.db).First(&supplier, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.Wrap(err)
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToSupplierEntity(&supplier), nil
+}
+
+func (s supplierRepository) FindAll(ctx context.Context, filter entity.SuppliersFilter) ([]entity.Supplier, error) {
+	query := dbFrom(ctx, s.db).Model(&models.SupplierModel{})
+	if filter.Name != nil {
+		query = query.Where("name ILIKE ?", "%"+*filter.Name+"%")
+	}
+	query = query.Limit(filter.Limit).Offset(filter.Offset)
+
+	var suppliers []models.SupplierModel
+	err := query.Find(&suppliers).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	return models.ToSuppliersEntity(suppliers), nil
+}
+
+func (s supplierRepository) Update(ctx context.Context, supplier *entity.Supplier) error {
+	if supplier == nil {
+		return apperr.ErrInvalidArgument.WithMessage("supplier cannot be nil")
+	}
+
+	result := dbFrom(ctx, s.db).Model(&models.SupplierModel{}).
+		Where("id = ?", supplier.ID).
+		Updates(map[string]interface{}{
+			"name":           supplier.Name,
+			"contact_name":   supplier.ContactName,
+			"contact_email":  supplier.ContactEmail,
+			"contact_phone":  supplier.ContactPhone,
+			"lead_time_days": supplier.LeadTimeDays,
+			"currency":       supplier.Currency,
+		})
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrNotFound.WithMessage("supplier not found")
+	}
+
+	return nil
+}
+
+func (s supplierRepository) Delete(ctx context.Context, id string) error {
+	return dbFrom(ctx, s.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.SupplierModel{}, "id = ?", id)
+		if result.Error != nil {
+			return apperr.ErrInternal.Wrap(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperr.ErrNotFound.WithMessage("supplier not found")
+		}
+
+		err := tx.Delete(&models.ProductSupplierModel{}, "supplier_id = ?", id).Error
+		if err != nil {
+			return apperr.ErrInternal.Wrap(err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"gorm.io/gorm"
+)
+
+// findDeleted loads a page of the soft-deleted rows of query's model into dest, most recently
+// deleted first.
+func findDeleted(query *gorm.DB, dest interface{}, limit, offset int) error {
+	err := query.Unscoped().Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").Limit(limit).Offset(offset).
+		Find(dest).Error
+	if err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
+
+// firstDeleted loads the soft-deleted row of query's model with id into dest, failing with
+// NOT_FOUND when the row is live or gone.
+func firstDeleted(query *gorm.DB, dest interface{}, id string) error {
+	err := query.Unscoped().Where("deleted_at IS NOT NULL").First(dest, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperr.ErrNotFound.Wrap(err)
+		}
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
+
+// restore brings the soft-deleted row of model's table with id back, writing values and bumping
+// its version. It fails with NOT_FOUND when there is no such deleted row and with CONFLICT, saying
+// duplicate, when a live row has taken one of its unique values.
+func restore(ctx context.Context, db *gorm.DB, model interface{}, id string, values map[string]interface{}, duplicate string) error {
+	values["deleted_at"] = nil
+	values["version"] = gorm.Expr("version + 1")
+
+	result := dbFrom(ctx, db).Unscoped().Model(model).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(values)
+	if result.Error != nil {
+		return writeFailed(result.Error, duplicate)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
+
+// purge removes the soft-deleted row of model's table with id for good. It fails with NOT_FOUND
+// when there is no such deleted row and with CONFLICT, saying referenced, when other rows still
+// point at it.
+func purge(ctx context.Context, db *gorm.DB, model interface{}, id, referenced string) error {
+	result := dbFrom(ctx, db).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Delete(model, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
+		return apperr.ErrConflict.WithMessage(referenced)
+	}
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
+
+// findExpiredIDs lists, in id order, up to limit ids of the rows of model's table soft-deleted
+// before deletedBefore whose id sorts after afterID. Paging by id rather than by offset keeps the
+// rows a purge had to skip from being listed again.
+func findExpiredIDs(ctx context.Context, db *gorm.DB, model interface{}, deletedBefore time.Time, afterID string, limit int) ([]string, error) {
+	query := dbFrom(ctx, db).Unscoped().Model(model).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore)
+	if afterID != "" {
+		query = query.Where("id > ?", afterID)
+	}
+
+	var ids []string
+	err := query.Order("id").Limit(limit).Pluck("id", &ids).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return ids, nil
+}
+
+// purgeMany removes the soft-deleted rows of model's table with ids for good, in one statement.
+// It fails with CONFLICT, saying referenced, when other rows still point at any of them, in
+// which case none is removed.
+func purgeMany(ctx context.Context, db *gorm.DB, model interface{}, ids []string, referenced string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := dbFrom(ctx, db).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Delete(model, "id IN ?", ids).Error
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return apperr.ErrConflict.WithMessage(referenced)
+	}
+	if err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) repository.TxManager {
+	return &txManager{db: db}
+}
+
+func (t txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTx(ctx) {
+		return fn(ctx)
+	}
+
+	err := dbFrom(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+	if err != nil {
+		var appErr *apperr.AppError
+		if errors.As(err, &appErr) {
+			return err
+		}
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
+
+// dbFrom returns the transaction carried by ctx, if WithinTx started one, and db otherwise.
+func dbFrom(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// inTx reports whether ctx carries a transaction started by WithinTx.
+func inTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return ok
+}
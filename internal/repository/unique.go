@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"gorm.io/gorm"
+)
+
+// normalized returns the SQL form column is compared in for uniqueness: lower-cased, trimmed and
+// with runs of whitespace collapsed to one space. The partial unique indexes on category names and
+// product SKUs are built on this very expression, so it must not drift from
+// scripts/migrations/008_soft_delete_unique_indexes.sql.
+func normalized(column string) string {
+	return fmt.Sprintf(`lower(btrim(regexp_replace(%s, '\s+', ' ', 'g')))`, column)
+}
+
+// writeFailed maps the error of a failed write to CONFLICT, saying duplicate, when the write broke a
+// unique index, and to INTERNAL_ERROR otherwise.
+func writeFailed(err error, duplicate string) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return apperr.ErrConflict.WithMessage(duplicate)
+	}
+	return apperr.ErrInternal.Wrap(err)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"gorm.io/gorm"
+)
+
+// notWritten explains why a write on model touched no rows. Without an expected version the
+// only explanation is that the row does not exist.
+func notWritten(ctx context.Context, db *gorm.DB, model interface{}, id string, version int) error {
+	if version == 0 {
+		return apperr.ErrNotFound
+	}
+	return versionConflict(ctx, db, model, id)
+}
+
+// versionConflict explains why a versioned write on model touched no rows: the row is gone, or
+// it has moved on to another version.
+func versionConflict(ctx context.Context, db *gorm.DB, model interface{}, id string) error {
+	var current struct {
+		Version int
+	}
+	result := dbFrom(ctx, db).Model(model).Select("version").Where("id = ?", id).Limit(1).Scan(&current)
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrNotFound
+	}
+
+	return apperr.ErrPreconditionFailed.
+		WithMessage("resource has been modified since it was read").
+		WithDetails(entity.VersionConflict{CurrentVersion: current.Version})
+}
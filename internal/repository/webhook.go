@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// claimWebhookDeliveriesSQL leases the oldest due deliveries of enabled webhooks by pushing their
+// next attempt past the lease, the way claimOutboxSQL does for events.
+const claimWebhookDeliveriesSQL = `UPDATE webhook_deliveries SET next_attempt_at = ?
+WHERE id IN (
+	SELECT d.id FROM webhook_deliveries d
+	JOIN webhooks w ON w.id = d.webhook_id
+	WHERE d.status = 'pending' AND w.enabled AND d.next_attempt_at <= ?
+	ORDER BY d.id LIMIT ?
+	FOR UPDATE OF d SKIP LOCKED
+)
+RETURNING *`
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) repository.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (w webhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (string, error) {
+	if webhook == nil {
+		return "", apperr.ErrInvalidArgument.WithMessage("webhook cannot be nil")
+	}
+
+	value := models.ToWebhookModel(webhook)
+	err := dbFrom(ctx, w.db).Create(value).Error
+	if err != nil {
+		return "", apperr.ErrInternal.Wrap(err)
+	}
+	return value.ID, nil
+}
+
+func (w webhookRepository) FindByID(ctx context.Context, id string) (*entity.Webhook, error) {
+	var webhook models.WebhookModel
+	err := dbFrom(ctx, w.db).First(&webhook, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.WithMessage("webhook not found")
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToWebhookEntity(&webhook), nil
+}
+
+func (w webhookRepository) FindAll(ctx context.Context, filter entity.WebhooksFilter) ([]entity.Webhook, error) {
+	var webhooks []models.WebhookModel
+	err := dbFrom(ctx, w.db).Order("created_at, id").Limit(filter.Limit).Offset(filter.Offset).Find(&webhooks).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToWebhooksEntity(webhooks), nil
+}
+
+func (w webhookRepository) FindEnabled(ctx context.Context) ([]entity.Webhook, error) {
+	var webhooks []models.WebhookModel
+	err := dbFrom(ctx, w.db).Where("enabled").Order("created_at, id").Find(&webhooks).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToWebhooksEntity(webhooks), nil
+}
+
+func (w webhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	if webhook == nil {
+		return apperr.ErrInvalidArgument.WithMessage("webhook cannot be nil")
+	}
+
+	value := models.ToWebhookModel(webhook)
+	columns := []string{"url", "event_types", "filter", "secret", "enabled"}
+	if value.Enabled {
+		value.ConsecutiveFailures = 0
+		value.DisabledReason = ""
+		columns = append(columns, "consecutive_failures", "disabled_reason")
+	}
+
+	// Updating from the model, not a map, so event types and filter go through their serializer.
+	result := dbFrom(ctx, w.db).Model(&models.WebhookModel{}).Where("id = ?", webhook.ID).Select(columns).Updates(value)
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrNotFound.WithMessage("webhook not found")
+	}
+	return nil
+}
+
+func (w webhookRepository) Delete(ctx context.Context, id string) error {
+	result := dbFrom(ctx, w.db).Delete(&models.WebhookModel{}, "id = ?", id)
+	if result.Error != nil {
+		return apperr.ErrInternal.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.ErrNotFound.WithMessage("webhook not found")
+	}
+	return nil
+}
+
+func (w webhookRepository) CreateDeliveries(ctx context.Context, deliveries []entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	values := make([]*models.WebhookDeliveryModel, 0, len(deliveries))
+	for i := range deliveries {
+		values = append(values, models.ToWebhookDeliveryModel(&deliveries[i]))
+	}
+	err := dbFrom(ctx, w.db).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(values, batchChunkSize).Error
+	if err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
+
+func (w webhookRepository) CreateRedelivery(ctx context.Context, delivery *entity.WebhookDelivery) (int64, error) {
+	if delivery == nil {
+		return 0, apperr.ErrInvalidArgument.WithMessage("delivery cannot be nil")
+	}
+
+	value := models.ToWebhookDeliveryModel(delivery)
+	err := dbFrom(ctx, w.db).Create(value).Error
+	if err != nil {
+		return 0, apperr.ErrInternal.Wrap(err)
+	}
+	return value.ID, nil
+}
+
+func (w webhookRepository) FindDeliveries(ctx context.Context, webhookID string, filter entity.WebhookDeliveriesFilter) ([]entity.WebhookDelivery, error) {
+	query := dbFrom(ctx, w.db).Where("webhook_id = ?", webhookID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var deliveries []models.WebhookDeliveryModel
+	err := query.Order("id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&deliveries).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToWebhookDeliveriesEntity(deliveries), nil
+}
+
+func (w webhookRepository) FindDelivery(ctx context.Context, webhookID string, id int64) (*entity.WebhookDelivery, error) {
+	var delivery models.WebhookDeliveryModel
+	err := dbFrom(ctx, w.db).First(&delivery, "webhook_id = ? AND id = ?", webhookID, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound.WithMessage(fmt.Sprintf("delivery %d not found", id))
+		}
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return models.ToWebhookDeliveryEntity(&delivery), nil
+}
+
+func (w webhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	now := time.Now()
+	var deliveries []models.WebhookDeliveryModel
+	err := dbFrom(ctx, w.db).Raw(claimWebhookDeliveriesSQL, now.Add(lease), now, limit).Scan(&deliveries).Error
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+
+	// RETURNING does not keep the order of the subquery.
+	slices.SortFunc(deliveries, func(a, b models.WebhookDeliveryModel) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return models.ToWebhookDeliveriesEntity(deliveries), nil
+}
+
+func (w webhookRepository) RecordAttempt(ctx context.Context, delivery *entity.WebhookDelivery, disableAfter int) (bool, error) {
+	if delivery == nil {
+		return false, apperr.ErrInvalidArgument.WithMessage("delivery cannot be nil")
+	}
+
+	disabled := false
+	err := dbFrom(ctx, w.db).Transaction(func(tx *gorm.DB) error {
+		value := models.ToWebhookDeliveryModel(delivery)
+		result := tx.Model(&models.WebhookDeliveryModel{}).Where("id = ?", value.ID).Updates(map[string]interface{}{
+			"status":          value.Status,
+			"attempts":        value.Attempts,
+			"response_status": value.ResponseStatus,
+			"response_body":   value.ResponseBody,
+			"last_error":      value.LastError,
+			"next_attempt_at": value.NextAttemptAt,
+			"delivered_at":    value.DeliveredAt,
+		})
+		if result.Error != nil {
+			return apperr.ErrInternal.Wrap(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperr.ErrNotFound.WithMessage(fmt.Sprintf("delivery %d not found", value.ID))
+		}
+
+		if delivery.Status == entity.WebhookDeliverySucceeded {
+			err := tx.Model(&models.WebhookModel{}).Where("id = ?", delivery.WebhookID).
+				Update("consecutive_failures", 0).Error
+			if err != nil {
+				return apperr.ErrInternal.Wrap(err)
+			}
+			return nil
+		}
+
+		var webhook models.WebhookModel
+		err := tx.Model(&webhook).Clauses(clause.Returning{}).Where("id = ?", delivery.WebhookID).
+			Update("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error
+		if err != nil {
+			return apperr.ErrInternal.Wrap(err)
+		}
+		if !webhook.Enabled || webhook.ConsecutiveFailures < disableAfter {
+			return nil
+		}
+
+		err = tx.Model(&models.WebhookModel{}).Where("id = ?", delivery.WebhookID).Updates(map[string]interface{}{
+			"enabled":         false,
+			"disabled_reason": fmt.Sprintf("disabled after %d failed deliveries in a row", webhook.ConsecutiveFailures),
+		}).Error
+		if err != nil {
+			return apperr.ErrInternal.Wrap(err)
+		}
+		disabled = true
+		return nil
+	})
+	return disabled, err
+}
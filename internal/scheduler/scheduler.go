@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+type Task struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type Scheduler struct {
+	tasks  []Task
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(tasks ...Task) *Scheduler {
+	return &Scheduler{tasks: tasks}
+}
+
+// Start runs every task once immediately and then on its interval until Stop is called.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, task := range s.tasks {
+		if task.Interval <= 0 {
+			log.Printf("scheduler: task %s disabled", task.Name)
+			continue
+		}
+
+		s.wg.Add(1)
+		go func(task Task) {
+			defer s.wg.Done()
+			s.loop(ctx, task)
+		}(task)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, task Task) {
+	ticker := time.NewTicker(task.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := task.Run(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("scheduler: task %s failed: %v", task.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop cancels running tasks and waits for them to return or for ctx to expire.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type auditService struct {
+	auditRepo repository.AuditRepository
+}
+
+//go:generate mockgen -source=audit.go -destination=mocks/mock_audit.go -package=mocks
+type AuditService interface {
+	// List returns the audit entries matching filter, most recent first.
+	List(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error)
+}
+
+func NewAuditService(auditRepo repository.AuditRepository) AuditService {
+	return &auditService{
+		auditRepo: auditRepo,
+	}
+}
+
+func (a auditService) List(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	if filter.EntityType != "" && !filter.EntityType.IsValid() {
+		return nil, apperr.ErrInvalidArgument.WithMessage("entityType must be product or category")
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, apperr.ErrInvalidArgument.WithMessage("from must be before to")
+	}
+
+	return a.auditRepo.FindAll(ctx, filter)
+}
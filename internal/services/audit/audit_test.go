@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AuditServiceTestSuite struct {
+	suite.Suite
+	mockCtrl *gomock.Controller
+	mockRepo *mocks.MockAuditRepository
+	service  AuditService
+	ctx      context.Context
+}
+
+func (suite *AuditServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockRepo = mocks.NewMockAuditRepository(suite.mockCtrl)
+	suite.service = NewAuditService(suite.mockRepo)
+	suite.ctx = context.Background()
+}
+
+func (suite *AuditServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *AuditServiceTestSuite) TestList_Success() {
+	from := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	filter := entity.AuditFilter{
+		EntityType: entity.AuditEntityProduct,
+		EntityID:   "product-1",
+		Actor:      "alice",
+		From:       &from,
+		To:         &to,
+	}
+
+	expected := filter
+	expected.Limit = 10
+	entries := []entity.AuditEntry{{
+		ID:         1,
+		Actor:      "alice",
+		EntityType: entity.AuditEntityProduct,
+		EntityID:   "product-1",
+		Action:     entity.AuditActionUpdate,
+		Changes:    map[string]entity.FieldChange{"price": {Old: 999.99, New: 899.99}},
+	}}
+	suite.mockRepo.EXPECT().FindAll(suite.ctx, expected).Return(entries, nil).Times(1)
+
+	result, err := suite.service.List(suite.ctx, filter)
+
+	suite.NoError(err)
+	suite.Equal(entries, result)
+}
+
+func (suite *AuditServiceTestSuite) TestList_CapsLimit() {
+	suite.mockRepo.EXPECT().
+		FindAll(suite.ctx, entity.AuditFilter{Pagination: entity.Pagination{Limit: 100, Offset: 20}}).
+		Return([]entity.AuditEntry{}, nil).
+		Times(1)
+
+	_, err := suite.service.List(suite.ctx, entity.AuditFilter{Pagination: entity.Pagination{Limit: 500, Offset: 20}})
+
+	suite.NoError(err)
+}
+
+func (suite *AuditServiceTestSuite) TestList_InvalidEntityType() {
+	result, err := suite.service.List(suite.ctx, entity.AuditFilter{EntityType: "supplier"})
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *AuditServiceTestSuite) TestList_FromNotBeforeTo() {
+	from := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	result, err := suite.service.List(suite.ctx, entity.AuditFilter{From: &from, To: &from})
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func TestAuditServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditServiceTestSuite))
+}
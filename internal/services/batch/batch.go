@@ -0,0 +1,84 @@
+// Package batch holds the bookkeeping shared by the batch writes of the product and category
+// services.
+package batch
+
+import (
+	"fmt"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+// Check rejects a batch that is empty, holds more than limit items or asks for an unknown mode.
+func Check(count, limit int, mode entity.BatchMode) error {
+	if mode != entity.BatchAtomic && mode != entity.BatchBestEffort {
+		return apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("unknown batch mode %q", mode))
+	}
+	if count == 0 {
+		return apperr.ErrInvalidArgument.WithMessage("batch has no items")
+	}
+	if count > limit {
+		return apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("batch has %d items, the limit is %d", count, limit))
+	}
+	return nil
+}
+
+// NewResults returns one result per item, indexed in request order.
+func NewResults(count int) []entity.BatchResult {
+	results := make([]entity.BatchResult, count)
+	for i := range results {
+		results[i].Index = i
+	}
+	return results
+}
+
+// CheckID rejects an item without an ID or with an ID seen earlier in the batch, and records id
+// in seen.
+func CheckID(id string, seen map[string]bool) error {
+	if id == "" {
+		return apperr.ErrInvalidArgument.WithMessage("id is required")
+	}
+	if seen[id] {
+		return apperr.ErrInvalidArgument.WithMessage("id appears more than once in the batch")
+	}
+	seen[id] = true
+	return nil
+}
+
+// Pending returns the indexes of the items that have not failed yet.
+func Pending(results []entity.BatchResult) []int {
+	pending := make([]int, 0, len(results))
+	for i, result := range results {
+		if result.Err == nil {
+			pending = append(pending, i)
+		}
+	}
+	return pending
+}
+
+// Failure aborts an atomic batch if any item failed. The error carries the code of the first
+// failing item and lists every failing item in its details.
+func Failure(results []entity.BatchResult) error {
+	var failed []entity.BatchItemError
+	var code string
+	for _, result := range results {
+		if result.Err == nil {
+			continue
+		}
+		if code == "" {
+			code = apperr.GetCode(result.Err)
+		}
+		failed = append(failed, entity.BatchItemError{
+			Index:     result.Index,
+			ID:        result.ID,
+			ErrorCode: apperr.GetCode(result.Err),
+			Message:   result.Err.Error(),
+		})
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("batch not applied: %d of %d items failed", len(failed), len(results))
+	return apperr.New(code, message).WithDetails(failed)
+}
@@ -0,0 +1,46 @@
+package batch
+
+import (
+	"testing"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	assert.NoError(t, Check(2, 2, entity.BatchAtomic))
+	assert.NoError(t, Check(1, 2, entity.BatchBestEffort))
+
+	assert.Equal(t, apperr.ErrInvalidArgument.Code, apperr.GetCode(Check(0, 2, entity.BatchAtomic)))
+	assert.Equal(t, apperr.ErrInvalidArgument.Code, apperr.GetCode(Check(3, 2, entity.BatchAtomic)))
+	assert.Equal(t, apperr.ErrInvalidArgument.Code, apperr.GetCode(Check(1, 2, entity.BatchMode(""))))
+}
+
+func TestCheckID(t *testing.T) {
+	seen := make(map[string]bool)
+
+	assert.NoError(t, CheckID("a", seen))
+	assert.NoError(t, CheckID("b", seen))
+	assert.Error(t, CheckID("a", seen))
+	assert.Error(t, CheckID("", seen))
+}
+
+func TestPendingAndFailure(t *testing.T) {
+	results := NewResults(3)
+	assert.Equal(t, []int{0, 1, 2}, Pending(results))
+	assert.NoError(t, Failure(results))
+
+	results[1].ID = "b"
+	results[1].Err = apperr.ErrNotFound
+	results[2].Err = apperr.ErrInvalidArgument.WithMessage("name is required")
+
+	assert.Equal(t, []int{0}, Pending(results))
+
+	err := Failure(results)
+	assert.Equal(t, apperr.ErrNotFound.Code, apperr.GetCode(err))
+	assert.Equal(t, []entity.BatchItemError{
+		{Index: 1, ID: "b", ErrorCode: "NOT_FOUND", Message: "[NOT_FOUND] data not found"},
+		{Index: 2, ErrorCode: "INVALID_ARGUMENT", Message: "[INVALID_ARGUMENT] name is required"},
+	}, apperr.GetDetails(err))
+}
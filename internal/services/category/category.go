@@ -2,43 +2,119 @@ package category
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/sirawong/crud-arise/internal/domain/entity"
 	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/services/batch"
 )
 
 type categoryService struct {
-	categoryRepo repository.CategoryRepository
+	txManager     repository.TxManager
+	categoryRepo  repository.CategoryRepository
+	maxBatchItems int
 }
 
 //go:generate mockgen -source=category.go -destination=mocks/mock_category.go -package=mocks
 type CategoryService interface {
-	Create(ctx context.Context, category entity.Category) (string, error)
-	Update(ctx context.Context, id string, category entity.Category) error
+	Create(ctx context.Context, category entity.Category) (*entity.Category, error)
+	Update(ctx context.Context, id string, category entity.Category) (*entity.Category, error)
+	Patch(ctx context.Context, id string, patch entity.CategoryPatch) (*entity.Category, error)
 	GetByID(ctx context.Context, id string) (*entity.Category, error)
 	GetAll(ctx context.Context, filter entity.CategoriesFilter) ([]entity.Category, error)
-	Delete(ctx context.Context, id string) error
+	GetListStats(ctx context.Context, filter entity.CategoriesFilter) (*entity.ListStats, error)
+	Delete(ctx context.Context, id string, version int) error
+	BatchCreate(ctx context.Context, categories []entity.Category, mode entity.BatchMode) ([]entity.BatchResult, error)
+	BatchUpdate(ctx context.Context, categories []entity.Category, mode entity.BatchMode) ([]entity.BatchResult, error)
+	BatchDelete(ctx context.Context, refs []entity.VersionedID, mode entity.BatchMode) ([]entity.BatchResult, error)
+	ListTrash(ctx context.Context, pagination entity.Pagination) ([]entity.Category, error)
+	Restore(ctx context.Context, id string) (*entity.Category, error)
+	Purge(ctx context.Context, id string) error
+	ListRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error)
+	GetRevision(ctx context.Context, id string, number int) (*entity.CategoryRevision, error)
+	Revert(ctx context.Context, id string, number, version int) (*entity.Category, error)
 }
 
-func NewCategoryService(categoryRepo repository.CategoryRepository) CategoryService {
+func NewCategoryService(txManager repository.TxManager, categoryRepo repository.CategoryRepository, maxBatchItems int) CategoryService {
 	return &categoryService{
-		categoryRepo: categoryRepo,
+		txManager:     txManager,
+		categoryRepo:  categoryRepo,
+		maxBatchItems: maxBatchItems,
 	}
 }
 
-func (p categoryService) Create(ctx context.Context, category entity.Category) (string, error) {
-	id, err := p.categoryRepo.Create(ctx, &category)
+// Create stores the category and returns it as stored.
+func (p categoryService) Create(ctx context.Context, category entity.Category) (*entity.Category, error) {
+	var created *entity.Category
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		id, err := p.categoryRepo.Create(ctx, &category)
+		if err != nil {
+			return err
+		}
+
+		created, err = p.categoryRepo.FindByID(ctx, id)
+		return err
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	return id, nil
+	return created, nil
 }
 
-func (p categoryService) Update(ctx context.Context, id string, category entity.Category) error {
+// Update replaces the category and returns it as stored.
+func (p categoryService) Update(ctx context.Context, id string, category entity.Category) (*entity.Category, error) {
 	category.ID = id
 
-	return p.categoryRepo.Update(ctx, &category)
+	var updated *entity.Category
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := p.categoryRepo.Update(ctx, &category)
+		if err != nil {
+			return err
+		}
+
+		updated, err = p.categoryRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Patch changes only the fields set in patch and returns the category as stored.
+func (p categoryService) Patch(ctx context.Context, id string, patch entity.CategoryPatch) (*entity.Category, error) {
+	if patch.Name != nil && *patch.Name == "" {
+		return nil, apperr.ErrInvalidArgument.WithMessage("name cannot be empty")
+	}
+
+	if patch.IsEmpty() {
+		category, err := p.categoryRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if patch.Version > 0 && patch.Version != category.Version {
+			return nil, apperr.ErrPreconditionFailed.
+				WithMessage("resource has been modified since it was read").
+				WithDetails(entity.VersionConflict{CurrentVersion: category.Version})
+		}
+		return category, nil
+	}
+
+	var patched *entity.Category
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := p.categoryRepo.Patch(ctx, id, patch)
+		if err != nil {
+			return err
+		}
+
+		patched, err = p.categoryRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return patched, nil
 }
 
 func (p categoryService) GetByID(ctx context.Context, id string) (*entity.Category, error) {
@@ -56,6 +132,316 @@ func (p categoryService) GetAll(ctx context.Context, filter entity.CategoriesFil
 	return p.categoryRepo.FindAll(ctx, filter)
 }
 
-func (p categoryService) Delete(ctx context.Context, id string) error {
-	return p.categoryRepo.Delete(ctx, id)
+// GetListStats summarizes the categories matching filter so callers can tell whether a list has
+// changed without loading it.
+func (p categoryService) GetListStats(ctx context.Context, filter entity.CategoriesFilter) (*entity.ListStats, error) {
+	return p.categoryRepo.Stats(ctx, filter)
+}
+
+func (p categoryService) Delete(ctx context.Context, id string, version int) error {
+	return p.categoryRepo.Delete(ctx, id, version)
+}
+
+// ListTrash lists deleted categories, most recently deleted first.
+func (p categoryService) ListTrash(ctx context.Context, pagination entity.Pagination) ([]entity.Category, error) {
+	if pagination.Limit <= 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Limit > 100 {
+		pagination.Limit = 100
+	}
+
+	return p.categoryRepo.FindDeleted(ctx, pagination)
+}
+
+// Restore brings a deleted category back and returns it as stored. It fails with CONFLICT when a
+// live category has taken its name.
+func (p categoryService) Restore(ctx context.Context, id string) (*entity.Category, error) {
+	var restored *entity.Category
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		category, err := p.categoryRepo.FindDeletedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		taken, err := p.categoryRepo.FindByName(ctx, category.Name)
+		if err == nil {
+			return apperr.ErrConflict.WithMessage(fmt.Sprintf("name %q is taken by category %s", category.Name, taken.ID))
+		}
+		if apperr.GetCode(err) != apperr.ErrNotFound.Code {
+			return err
+		}
+
+		err = p.categoryRepo.Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		restored, err = p.categoryRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// Purge removes a deleted category for good. Products still in it, deleted or not, must be purged or
+// moved first.
+func (p categoryService) Purge(ctx context.Context, id string) error {
+	return p.categoryRepo.Purge(ctx, id)
+}
+
+// ListRevisions lists the revisions of a category, newest first. It fails with NOT_FOUND when the
+// category has none, which only happens once it is purged or if it never existed.
+func (p categoryService) ListRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error) {
+	if pagination.Limit <= 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Limit > 100 {
+		pagination.Limit = 100
+	}
+
+	revisions, err := p.categoryRepo.FindRevisions(ctx, id, pagination)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 && pagination.Offset == 0 {
+		return nil, apperr.ErrNotFound.WithMessage(fmt.Sprintf("category %s has no revisions", id))
+	}
+	return revisions, nil
+}
+
+// GetRevision returns the category as it was stored in one of its revisions.
+func (p categoryService) GetRevision(ctx context.Context, id string, number int) (*entity.CategoryRevision, error) {
+	return p.categoryRepo.FindRevision(ctx, id, number)
+}
+
+// Revert brings a live category's name back to what it was in a revision, as a write of its own
+// that is stored as the next revision. A non-zero version makes the revert conditional; a revert
+// that would change nothing leaves the category untouched.
+func (p categoryService) Revert(ctx context.Context, id string, number, version int) (*entity.Category, error) {
+	var reverted *entity.Category
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		revision, err := p.categoryRepo.FindRevision(ctx, id, number)
+		if err != nil {
+			return err
+		}
+
+		current, err := p.categoryRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if version > 0 && version != current.Version {
+			return apperr.ErrPreconditionFailed.
+				WithMessage("resource has been modified since it was read").
+				WithDetails(entity.VersionConflict{CurrentVersion: current.Version})
+		}
+		if revision.Category.Name == current.Name {
+			reverted = current
+			return nil
+		}
+
+		err = p.categoryRepo.Update(ctx, &entity.Category{ID: id, Name: revision.Category.Name, Version: current.Version})
+		if err != nil {
+			return err
+		}
+
+		reverted, err = p.categoryRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reverted, nil
+}
+
+// BatchCreate stores categories with multi-row inserts. In atomic mode nothing is stored unless
+// every category can be; in best-effort mode the failing categories are reported and the rest
+// are stored.
+func (p categoryService) BatchCreate(ctx context.Context, categories []entity.Category, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	err := batch.Check(len(categories), p.maxBatchItems, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	results := batch.NewResults(len(categories))
+	err = p.withinBatch(ctx, mode, func(ctx context.Context) error {
+		for i, category := range categories {
+			results[i].Err = validateName(category.Name)
+		}
+		if mode == entity.BatchAtomic {
+			if err := batch.Failure(results); err != nil {
+				return err
+			}
+		}
+
+		pending := batch.Pending(results)
+		if len(pending) == 0 {
+			return nil
+		}
+		items := make([]entity.Category, 0, len(pending))
+		for _, i := range pending {
+			items = append(items, categories[i])
+		}
+
+		var ids []string
+		err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			ids, err = p.categoryRepo.CreateMany(ctx, items)
+			return err
+		})
+		if err == nil {
+			for n, i := range pending {
+				results[i].ID = ids[n]
+			}
+			return nil
+		}
+		if mode == entity.BatchAtomic {
+			return err
+		}
+
+		// The multi-row insert was rolled back as a whole; find the offending categories one by one.
+		for _, i := range pending {
+			results[i].ID, results[i].Err = p.categoryRepo.Create(ctx, &categories[i])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// BatchUpdate replaces categories with multi-row updates. Each category carries its ID and, to
+// make its update conditional, the version it was read at.
+func (p categoryService) BatchUpdate(ctx context.Context, categories []entity.Category, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	err := batch.Check(len(categories), p.maxBatchItems, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	results := batch.NewResults(len(categories))
+	err = p.withinBatch(ctx, mode, func(ctx context.Context) error {
+		seen := make(map[string]bool)
+		for i, category := range categories {
+			results[i].ID = category.ID
+			results[i].Err = batch.CheckID(category.ID, seen)
+			if results[i].Err == nil {
+				results[i].Err = validateName(category.Name)
+			}
+		}
+		if mode == entity.BatchAtomic {
+			if err := batch.Failure(results); err != nil {
+				return err
+			}
+		}
+
+		pending := batch.Pending(results)
+		if len(pending) == 0 {
+			return nil
+		}
+		items := make([]entity.Category, 0, len(pending))
+		for _, i := range pending {
+			items = append(items, categories[i])
+		}
+
+		var errs []error
+		err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			errs, err = p.categoryRepo.UpdateMany(ctx, items)
+			return err
+		})
+		if err != nil && mode == entity.BatchAtomic {
+			return err
+		}
+		if err != nil {
+			// The multi-row update was rolled back as a whole; find the offending categories one by one.
+			for _, i := range pending {
+				results[i].Err = p.categoryRepo.Update(ctx, &categories[i])
+			}
+			return nil
+		}
+
+		for n, i := range pending {
+			results[i].Err = errs[n]
+		}
+		if mode == entity.BatchAtomic {
+			return batch.Failure(results)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// BatchDelete soft-deletes categories with multi-row updates. A non-zero version makes the delete
+// of that category conditional.
+func (p categoryService) BatchDelete(ctx context.Context, refs []entity.VersionedID, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	err := batch.Check(len(refs), p.maxBatchItems, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	results := batch.NewResults(len(refs))
+	err = p.withinBatch(ctx, mode, func(ctx context.Context) error {
+		seen := make(map[string]bool)
+		for i, ref := range refs {
+			results[i].ID = ref.ID
+			results[i].Err = batch.CheckID(ref.ID, seen)
+		}
+		if mode == entity.BatchAtomic {
+			if err := batch.Failure(results); err != nil {
+				return err
+			}
+		}
+
+		pending := batch.Pending(results)
+		if len(pending) == 0 {
+			return nil
+		}
+		items := make([]entity.VersionedID, 0, len(pending))
+		for _, i := range pending {
+			items = append(items, refs[i])
+		}
+
+		var errs []error
+		err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			errs, err = p.categoryRepo.DeleteMany(ctx, items)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for n, i := range pending {
+			results[i].Err = errs[n]
+		}
+		if mode == entity.BatchAtomic {
+			return batch.Failure(results)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// withinBatch runs fn in one transaction for an atomic batch. A best-effort batch runs without
+// one, so a failing item cannot undo the others.
+func (p categoryService) withinBatch(ctx context.Context, mode entity.BatchMode, fn func(ctx context.Context) error) error {
+	if mode == entity.BatchAtomic {
+		return p.txManager.WithinTx(ctx, fn)
+	}
+	return fn(ctx)
+}
+
+func validateName(name string) error {
+	if name == "" {
+		return apperr.ErrInvalidArgument.WithMessage("name is required")
+	}
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/sirawong/crud-arise/internal/domain/entity"
 	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
 	"github.com/sirawong/crud-arise/pkg/utils"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -15,17 +16,26 @@ import (
 
 type CategoryServiceTestSuite struct {
 	suite.Suite
-	mockCtrl *gomock.Controller
-	mockRepo *mocks.MockCategoryRepository
-	service  CategoryService
-	ctx      context.Context
+	mockCtrl      *gomock.Controller
+	mockTxManager *mocks.MockTxManager
+	mockRepo      *mocks.MockCategoryRepository
+	service       CategoryService
+	ctx           context.Context
 }
 
 func (suite *CategoryServiceTestSuite) SetupTest() {
 	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
 	suite.mockRepo = mocks.NewMockCategoryRepository(suite.mockCtrl)
-	suite.service = NewCategoryService(suite.mockRepo)
+	suite.service = NewCategoryService(suite.mockTxManager, suite.mockRepo, 3)
 	suite.ctx = context.Background()
+
+	suite.mockTxManager.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
 }
 
 func (suite *CategoryServiceTestSuite) TearDownTest() {
@@ -46,10 +56,16 @@ func (suite *CategoryServiceTestSuite) TestCreate_Success() {
 		Return(expectedID, nil).
 		Times(1)
 
-	id, err := suite.service.Create(suite.ctx, category)
+	stored := &entity.Category{ID: expectedID, Name: category.Name, Version: 1}
+	suite.mockRepo.EXPECT().
+		FindByID(suite.ctx, expectedID).
+		Return(stored, nil).
+		Times(1)
+
+	created, err := suite.service.Create(suite.ctx, category)
 
 	suite.NoError(err)
-	suite.Equal(expectedID, id)
+	suite.Equal(stored, created)
 }
 
 func (suite *CategoryServiceTestSuite) TestCreate_RepositoryError() {
@@ -66,10 +82,10 @@ func (suite *CategoryServiceTestSuite) TestCreate_RepositoryError() {
 		Return("", expectedErr).
 		Times(1)
 
-	id, err := suite.service.Create(suite.ctx, category)
+	created, err := suite.service.Create(suite.ctx, category)
 
 	suite.Error(err)
-	suite.Equal("", id)
+	suite.Nil(created)
 	suite.Equal(expectedErr, err)
 }
 
@@ -89,9 +105,16 @@ func (suite *CategoryServiceTestSuite) TestUpdate_Success() {
 		Return(nil).
 		Times(1)
 
-	err := suite.service.Update(suite.ctx, categoryID, category)
+	stored := &entity.Category{ID: categoryID, Name: category.Name, Version: 2}
+	suite.mockRepo.EXPECT().
+		FindByID(suite.ctx, categoryID).
+		Return(stored, nil).
+		Times(1)
+
+	updated, err := suite.service.Update(suite.ctx, categoryID, category)
 
 	suite.NoError(err)
+	suite.Equal(stored, updated)
 }
 
 func (suite *CategoryServiceTestSuite) TestUpdate_RepositoryError() {
@@ -111,12 +134,54 @@ func (suite *CategoryServiceTestSuite) TestUpdate_RepositoryError() {
 		Return(expectedErr).
 		Times(1)
 
-	err := suite.service.Update(suite.ctx, categoryID, category)
+	_, err := suite.service.Update(suite.ctx, categoryID, category)
 
 	suite.Error(err)
 	suite.Equal(expectedErr, err)
 }
 
+func (suite *CategoryServiceTestSuite) TestPatch_Success() {
+
+	name := "Renamed"
+	patch := entity.CategoryPatch{Name: &name, Version: 2}
+
+	suite.mockRepo.EXPECT().
+		Patch(suite.ctx, "test-id-123", patch).
+		Return(nil).
+		Times(1)
+
+	suite.mockRepo.EXPECT().
+		FindByID(suite.ctx, "test-id-123").
+		Return(&entity.Category{ID: "test-id-123", Name: name, Version: 3}, nil).
+		Times(1)
+
+	patched, err := suite.service.Patch(suite.ctx, "test-id-123", patch)
+
+	suite.NoError(err)
+	suite.Equal("Renamed", patched.Name)
+}
+
+func (suite *CategoryServiceTestSuite) TestPatch_EmptyName() {
+
+	name := ""
+
+	_, err := suite.service.Patch(suite.ctx, "test-id-123", entity.CategoryPatch{Name: &name})
+
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *CategoryServiceTestSuite) TestPatch_EmptyPatchChecksVersion() {
+
+	suite.mockRepo.EXPECT().
+		FindByID(suite.ctx, "test-id-123").
+		Return(&entity.Category{ID: "test-id-123", Version: 3}, nil).
+		Times(1)
+
+	_, err := suite.service.Patch(suite.ctx, "test-id-123", entity.CategoryPatch{Version: 2})
+
+	suite.Equal(apperr.ErrPreconditionFailed.Code, apperr.GetCode(err))
+}
+
 func (suite *CategoryServiceTestSuite) TestGetByID_Success() {
 
 	categoryID := "test-id-123"
@@ -260,16 +325,32 @@ func (suite *CategoryServiceTestSuite) TestGetAll_RepositoryError() {
 	suite.Equal(expectedErr, err)
 }
 
+func (suite *CategoryServiceTestSuite) TestGetListStats_Success() {
+
+	filter := entity.CategoriesFilter{Pagination: entity.Pagination{Limit: 10}}
+	expected := &entity.ListStats{Count: 1, LastModified: time.Now()}
+
+	suite.mockRepo.EXPECT().
+		Stats(suite.ctx, filter).
+		Return(expected, nil).
+		Times(1)
+
+	result, err := suite.service.GetListStats(suite.ctx, filter)
+
+	suite.NoError(err)
+	suite.Equal(expected, result)
+}
+
 func (suite *CategoryServiceTestSuite) TestDelete_Success() {
 
 	categoryID := "test-id-123"
 
 	suite.mockRepo.EXPECT().
-		Delete(suite.ctx, categoryID).
+		Delete(suite.ctx, categoryID, 0).
 		Return(nil).
 		Times(1)
 
-	err := suite.service.Delete(suite.ctx, categoryID)
+	err := suite.service.Delete(suite.ctx, categoryID, 0)
 
 	suite.NoError(err)
 }
@@ -280,16 +361,198 @@ func (suite *CategoryServiceTestSuite) TestDelete_RepositoryError() {
 	expectedErr := errors.New("repository error")
 
 	suite.mockRepo.EXPECT().
-		Delete(suite.ctx, categoryID).
+		Delete(suite.ctx, categoryID, 0).
 		Return(expectedErr).
 		Times(1)
 
-	err := suite.service.Delete(suite.ctx, categoryID)
+	err := suite.service.Delete(suite.ctx, categoryID, 0)
 
 	suite.Error(err)
 	suite.Equal(expectedErr, err)
 }
 
+func (suite *CategoryServiceTestSuite) TestBatchCreate_Atomic() {
+
+	categories := []entity.Category{{Name: "Books"}, {Name: "Games"}}
+
+	suite.mockRepo.EXPECT().
+		CreateMany(suite.ctx, categories).
+		Return([]string{"category-1", "category-2"}, nil).
+		Times(1)
+
+	results, err := suite.service.BatchCreate(suite.ctx, categories, entity.BatchAtomic)
+
+	suite.NoError(err)
+	suite.Equal([]entity.BatchResult{{Index: 0, ID: "category-1"}, {Index: 1, ID: "category-2"}}, results)
+}
+
+func (suite *CategoryServiceTestSuite) TestBatchCreate_AtomicAbortsOnInvalidItem() {
+
+	categories := []entity.Category{{Name: "Books"}, {Name: ""}}
+
+	results, err := suite.service.BatchCreate(suite.ctx, categories, entity.BatchAtomic)
+
+	suite.Nil(results)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *CategoryServiceTestSuite) TestBatchUpdate_BestEffortFallsBackToSingleUpdates() {
+
+	categories := []entity.Category{{ID: "category-1", Name: "Books"}, {ID: "category-2", Name: "Games"}}
+
+	suite.mockRepo.EXPECT().
+		UpdateMany(suite.ctx, categories).
+		Return(nil, apperr.ErrInternal).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		Update(suite.ctx, &categories[0]).
+		Return(nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		Update(suite.ctx, &categories[1]).
+		Return(apperr.ErrInternal).
+		Times(1)
+
+	results, err := suite.service.BatchUpdate(suite.ctx, categories, entity.BatchBestEffort)
+
+	suite.NoError(err)
+	suite.Equal([]entity.BatchResult{
+		{Index: 0, ID: "category-1"},
+		{Index: 1, ID: "category-2", Err: apperr.ErrInternal},
+	}, results)
+}
+
+func (suite *CategoryServiceTestSuite) TestBatchDelete_AtomicAbortsOnMissingCategory() {
+
+	refs := []entity.VersionedID{{ID: "category-1"}, {ID: "category-2"}}
+
+	suite.mockRepo.EXPECT().
+		DeleteMany(suite.ctx, refs).
+		Return([]error{nil, apperr.ErrNotFound}, nil).
+		Times(1)
+
+	results, err := suite.service.BatchDelete(suite.ctx, refs, entity.BatchAtomic)
+
+	suite.Nil(results)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+}
+
+func (suite *CategoryServiceTestSuite) TestBatchDelete_TooManyItems() {
+
+	refs := []entity.VersionedID{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}}
+
+	_, err := suite.service.BatchDelete(suite.ctx, refs, entity.BatchBestEffort)
+
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *CategoryServiceTestSuite) TestRestore_Success() {
+
+	restored := &entity.Category{ID: "category-1", Name: "Phones", Version: 2}
+
+	suite.mockRepo.EXPECT().
+		FindDeletedByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1", Name: "Phones"}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		FindByName(suite.ctx, "Phones").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		Restore(suite.ctx, "category-1").
+		Return(nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(restored, nil).
+		Times(1)
+
+	category, err := suite.service.Restore(suite.ctx, "category-1")
+
+	suite.NoError(err)
+	suite.Equal(restored, category)
+}
+
+func (suite *CategoryServiceTestSuite) TestRestore_NameTaken() {
+
+	suite.mockRepo.EXPECT().
+		FindDeletedByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1", Name: "Phones"}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		FindByName(suite.ctx, "Phones").
+		Return(&entity.Category{ID: "category-2", Name: "Phones"}, nil).
+		Times(1)
+
+	category, err := suite.service.Restore(suite.ctx, "category-1")
+
+	suite.Nil(category)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func (suite *CategoryServiceTestSuite) TestPurge_StillHasProducts() {
+
+	conflict := apperr.ErrConflict.WithMessage("category still has products, deleted ones included")
+
+	suite.mockRepo.EXPECT().
+		Purge(suite.ctx, "category-1").
+		Return(conflict).
+		Times(1)
+
+	suite.Equal(conflict, suite.service.Purge(suite.ctx, "category-1"))
+}
+
+func (suite *CategoryServiceTestSuite) TestListRevisions_NoRevisions() {
+
+	suite.mockRepo.EXPECT().
+		FindRevisions(suite.ctx, "category-1", entity.Pagination{Limit: 10}).
+		Return(nil, nil).
+		Times(1)
+
+	result, err := suite.service.ListRevisions(suite.ctx, "category-1", entity.Pagination{})
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+}
+
+func (suite *CategoryServiceTestSuite) TestRevert_Success() {
+
+	reverted := &entity.Category{ID: "category-1", Name: "Old", Version: 3}
+	suite.mockRepo.EXPECT().
+		FindRevision(suite.ctx, "category-1", 1).
+		Return(&entity.CategoryRevision{Category: entity.Category{ID: "category-1", Name: "Old", Version: 1}}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1", Name: "New", Version: 2}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		Update(suite.ctx, &entity.Category{ID: "category-1", Name: "Old", Version: 2}).
+		Return(nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(reverted, nil).
+		Times(1)
+
+	category, err := suite.service.Revert(suite.ctx, "category-1", 1, 2)
+
+	suite.NoError(err)
+	suite.Equal(reverted, category)
+}
+
+func (suite *CategoryServiceTestSuite) TestRevert_RevisionNotFound() {
+
+	suite.mockRepo.EXPECT().
+		FindRevision(suite.ctx, "category-1", 7).
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	_, err := suite.service.Revert(suite.ctx, "category-1", 7, 0)
+
+	suite.Equal(apperr.ErrNotFound, err)
+}
+
 func TestCategoryServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(CategoryServiceTestSuite))
 }
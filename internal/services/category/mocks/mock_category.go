@@ -41,11 +41,56 @@ func (m *MockCategoryService) EXPECT() *MockCategoryServiceMockRecorder {
 	return m.recorder
 }
 
+// BatchCreate mocks base method.
+func (m *MockCategoryService) BatchCreate(ctx context.Context, categories []entity.Category, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", ctx, categories, mode)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockCategoryServiceMockRecorder) BatchCreate(ctx, categories, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockCategoryService)(nil).BatchCreate), ctx, categories, mode)
+}
+
+// BatchDelete mocks base method.
+func (m *MockCategoryService) BatchDelete(ctx context.Context, refs []entity.VersionedID, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDelete", ctx, refs, mode)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDelete indicates an expected call of BatchDelete.
+func (mr *MockCategoryServiceMockRecorder) BatchDelete(ctx, refs, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockCategoryService)(nil).BatchDelete), ctx, refs, mode)
+}
+
+// BatchUpdate mocks base method.
+func (m *MockCategoryService) BatchUpdate(ctx context.Context, categories []entity.Category, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpdate", ctx, categories, mode)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchUpdate indicates an expected call of BatchUpdate.
+func (mr *MockCategoryServiceMockRecorder) BatchUpdate(ctx, categories, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdate", reflect.TypeOf((*MockCategoryService)(nil).BatchUpdate), ctx, categories, mode)
+}
+
 // Create mocks base method.
-func (m *MockCategoryService) Create(ctx context.Context, category entity.Category) (string, error) {
+func (m *MockCategoryService) Create(ctx context.Context, category entity.Category) (*entity.Category, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", ctx, category)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*entity.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -57,17 +102,17 @@ func (mr *MockCategoryServiceMockRecorder) Create(ctx, category any) *gomock.Cal
 }
 
 // Delete mocks base method.
-func (m *MockCategoryService) Delete(ctx context.Context, id string) error {
+func (m *MockCategoryService) Delete(ctx context.Context, id string, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCategoryServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) Delete(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryService)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryService)(nil).Delete), ctx, id, version)
 }
 
 // GetAll mocks base method.
@@ -100,14 +145,134 @@ func (mr *MockCategoryServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCategoryService)(nil).GetByID), ctx, id)
 }
 
-// Update mocks base method.
-func (m *MockCategoryService) Update(ctx context.Context, id string, category entity.Category) error {
+// GetListStats mocks base method.
+func (m *MockCategoryService) GetListStats(ctx context.Context, filter entity.CategoriesFilter) (*entity.ListStats, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, id, category)
+	ret := m.ctrl.Call(m, "GetListStats", ctx, filter)
+	ret0, _ := ret[0].(*entity.ListStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListStats indicates an expected call of GetListStats.
+func (mr *MockCategoryServiceMockRecorder) GetListStats(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListStats", reflect.TypeOf((*MockCategoryService)(nil).GetListStats), ctx, filter)
+}
+
+// GetRevision mocks base method.
+func (m *MockCategoryService) GetRevision(ctx context.Context, id string, number int) (*entity.CategoryRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, id, number)
+	ret0, _ := ret[0].(*entity.CategoryRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockCategoryServiceMockRecorder) GetRevision(ctx, id, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockCategoryService)(nil).GetRevision), ctx, id, number)
+}
+
+// ListRevisions mocks base method.
+func (m *MockCategoryService) ListRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, id, pagination)
+	ret0, _ := ret[0].([]entity.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockCategoryServiceMockRecorder) ListRevisions(ctx, id, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockCategoryService)(nil).ListRevisions), ctx, id, pagination)
+}
+
+// ListTrash mocks base method.
+func (m *MockCategoryService) ListTrash(ctx context.Context, pagination entity.Pagination) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, pagination)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockCategoryServiceMockRecorder) ListTrash(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockCategoryService)(nil).ListTrash), ctx, pagination)
+}
+
+// Patch mocks base method.
+func (m *MockCategoryService) Patch(ctx context.Context, id string, patch entity.CategoryPatch) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, patch)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockCategoryServiceMockRecorder) Patch(ctx, id, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockCategoryService)(nil).Patch), ctx, id, patch)
+}
+
+// Purge mocks base method.
+func (m *MockCategoryService) Purge(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Purge indicates an expected call of Purge.
+func (mr *MockCategoryServiceMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockCategoryService)(nil).Purge), ctx, id)
+}
+
+// Restore mocks base method.
+func (m *MockCategoryService) Restore(ctx context.Context, id string) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockCategoryServiceMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockCategoryService)(nil).Restore), ctx, id)
+}
+
+// Revert mocks base method.
+func (m *MockCategoryService) Revert(ctx context.Context, id string, number, version int) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, id, number, version)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockCategoryServiceMockRecorder) Revert(ctx, id, number, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockCategoryService)(nil).Revert), ctx, id, number, version)
+}
+
+// Update mocks base method.
+func (m *MockCategoryService) Update(ctx context.Context, id string, category entity.Category) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, category)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Update indicates an expected call of Update.
 func (mr *MockCategoryServiceMockRecorder) Update(ctx, id, category any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
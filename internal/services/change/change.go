@@ -0,0 +1,53 @@
+package change
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type changeService struct {
+	changeRepo repository.ChangeRepository
+}
+
+//go:generate mockgen -source=change.go -destination=mocks/mock_change.go -package=mocks
+type ChangeService interface {
+	// List returns the changes numbered after since, oldest first. Reading on from the page's
+	// Next misses no change, however the writes interleaved.
+	List(ctx context.Context, since int64, limit int) (*entity.ChangePage, error)
+}
+
+func NewChangeService(changeRepo repository.ChangeRepository) ChangeService {
+	return &changeService{
+		changeRepo: changeRepo,
+	}
+}
+
+func (c changeService) List(ctx context.Context, since int64, limit int) (*entity.ChangePage, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	if since < 0 {
+		return nil, apperr.ErrInvalidArgument.WithMessage("since cannot be negative")
+	}
+
+	// One change more than asked for tells whether there are more to read.
+	changes, err := c.changeRepo.FindSince(ctx, since, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &entity.ChangePage{Changes: changes, Next: since}
+	if len(changes) > limit {
+		page.Changes, page.HasMore = changes[:limit], true
+	}
+	if len(page.Changes) > 0 {
+		page.Next = page.Changes[len(page.Changes)-1].Seq
+	}
+	return page, nil
+}
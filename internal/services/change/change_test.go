@@ -0,0 +1,84 @@
+package change
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ChangeServiceTestSuite struct {
+	suite.Suite
+	mockCtrl *gomock.Controller
+	mockRepo *mocks.MockChangeRepository
+	service  ChangeService
+	ctx      context.Context
+}
+
+func (suite *ChangeServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockRepo = mocks.NewMockChangeRepository(suite.mockCtrl)
+	suite.service = NewChangeService(suite.mockRepo)
+	suite.ctx = context.Background()
+}
+
+func (suite *ChangeServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *ChangeServiceTestSuite) TestList_HasMore() {
+	suite.mockRepo.EXPECT().
+		FindSince(suite.ctx, int64(10), 3).
+		Return([]entity.Change{{Seq: 11}, {Seq: 12}, {Seq: 14}}, nil).
+		Times(1)
+
+	page, err := suite.service.List(suite.ctx, 10, 2)
+
+	suite.NoError(err)
+	suite.Equal([]entity.Change{{Seq: 11}, {Seq: 12}}, page.Changes)
+	suite.Equal(int64(12), page.Next)
+	suite.True(page.HasMore)
+}
+
+func (suite *ChangeServiceTestSuite) TestList_LastPage() {
+	suite.mockRepo.EXPECT().
+		FindSince(suite.ctx, int64(0), 101).
+		Return([]entity.Change{{Seq: 1}, {Seq: 2}}, nil).
+		Times(1)
+
+	page, err := suite.service.List(suite.ctx, 0, 0)
+
+	suite.NoError(err)
+	suite.Len(page.Changes, 2)
+	suite.Equal(int64(2), page.Next)
+	suite.False(page.HasMore)
+}
+
+func (suite *ChangeServiceTestSuite) TestList_NothingNew() {
+	suite.mockRepo.EXPECT().
+		FindSince(suite.ctx, int64(42), 1001).
+		Return(nil, nil).
+		Times(1)
+
+	page, err := suite.service.List(suite.ctx, 42, 5000)
+
+	suite.NoError(err)
+	suite.Empty(page.Changes)
+	suite.Equal(int64(42), page.Next)
+	suite.False(page.HasMore)
+}
+
+func (suite *ChangeServiceTestSuite) TestList_NegativeSince() {
+	page, err := suite.service.List(suite.ctx, -1, 10)
+
+	suite.Nil(page)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func TestChangeServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ChangeServiceTestSuite))
+}
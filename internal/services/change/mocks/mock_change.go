@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: change.go
+//
+// Generated by this command:
+//
+//	mockgen -source=change.go -destination=mocks/mock_change.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChangeService is a mock of ChangeService interface.
+type MockChangeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChangeServiceMockRecorder is the mock recorder for MockChangeService.
+type MockChangeServiceMockRecorder struct {
+	mock *MockChangeService
+}
+
+// NewMockChangeService creates a new mock instance.
+func NewMockChangeService(ctrl *gomock.Controller) *MockChangeService {
+	mock := &MockChangeService{ctrl: ctrl}
+	mock.recorder = &MockChangeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeService) EXPECT() *MockChangeServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockChangeService) List(ctx context.Context, since int64, limit int) (*entity.ChangePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, since, limit)
+	ret0, _ := ret[0].(*entity.ChangePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockChangeServiceMockRecorder) List(ctx, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockChangeService)(nil).List), ctx, since, limit)
+}
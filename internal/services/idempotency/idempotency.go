@@ -0,0 +1,101 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type idempotencyService struct {
+	idempotencyRepo repository.IdempotencyRepository
+	ttl             time.Duration
+	// inFlightTimeout bounds how long a reservation blocks retries when the request holding it
+	// never finishes, for example because the process died mid-request.
+	inFlightTimeout time.Duration
+}
+
+//go:generate mockgen -source=idempotency.go -destination=mocks/mock_idempotency.go -package=mocks
+type IdempotencyService interface {
+	// Begin reserves key for the request identified by requestHash. It returns the stored
+	// response when the same request has already completed. Otherwise the caller holds the
+	// reservation, identified by the returned token, and should process the request.
+	Begin(ctx context.Context, clientID, key, requestHash string) (*entity.IdempotentResponse, string, error)
+	Complete(ctx context.Context, clientID, key, token string, response entity.IdempotentResponse) error
+	Release(ctx context.Context, clientID, key, token string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+func NewIdempotencyService(idempotencyRepo repository.IdempotencyRepository, ttl, inFlightTimeout time.Duration) IdempotencyService {
+	return &idempotencyService{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+		inFlightTimeout: inFlightTimeout,
+	}
+}
+
+func (i idempotencyService) Begin(ctx context.Context, clientID, key, requestHash string) (*entity.IdempotentResponse, string, error) {
+	if key == "" {
+		return nil, "", apperr.ErrInvalidArgument.WithMessage("idempotency key is required")
+	}
+	if len(key) > 255 {
+		return nil, "", apperr.ErrInvalidArgument.WithMessage("idempotency key must be at most 255 characters")
+	}
+
+	now := time.Now()
+	token := uuid.New().String()
+	existing, err := i.idempotencyRepo.Reserve(ctx, &entity.IdempotencyRecord{
+		ClientID:    clientID,
+		Key:         key,
+		Token:       token,
+		RequestHash: requestHash,
+		Status:      entity.IdempotencyStatusInProgress,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(i.inFlightTimeout),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if existing == nil {
+		return nil, token, nil
+	}
+
+	if existing.RequestHash != requestHash {
+		return nil, "", apperr.ErrUnprocessable.WithMessage("idempotency key has already been used for a different request")
+	}
+	if existing.Status != entity.IdempotencyStatusCompleted {
+		return nil, "", apperr.ErrConflict.WithMessage("a request with this idempotency key is still in progress")
+	}
+
+	return &entity.IdempotentResponse{
+		Status:  existing.ResponseStatus,
+		Headers: existing.ResponseHeaders,
+		Body:    existing.ResponseBody,
+	}, "", nil
+}
+
+func (i idempotencyService) Complete(ctx context.Context, clientID, key, token string, response entity.IdempotentResponse) error {
+	return i.idempotencyRepo.Complete(ctx, &entity.IdempotencyRecord{
+		ClientID:        clientID,
+		Key:             key,
+		Token:           token,
+		Status:          entity.IdempotencyStatusCompleted,
+		ResponseStatus:  response.Status,
+		ResponseHeaders: response.Headers,
+		ResponseBody:    response.Body,
+		ExpiresAt:       time.Now().Add(i.ttl),
+	})
+}
+
+// Release drops a reservation without storing a response, so the next retry runs the request
+// again. A reservation that has since been taken over is left alone.
+func (i idempotencyService) Release(ctx context.Context, clientID, key, token string) error {
+	return i.idempotencyRepo.Delete(ctx, clientID, key, token)
+}
+
+func (i idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return i.idempotencyRepo.DeleteExpired(ctx, time.Now())
+}
@@ -0,0 +1,166 @@
+package idempotency
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type IdempotencyServiceTestSuite struct {
+	suite.Suite
+	mockCtrl *gomock.Controller
+	mockRepo *mocks.MockIdempotencyRepository
+	service  IdempotencyService
+	ctx      context.Context
+}
+
+func (suite *IdempotencyServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockRepo = mocks.NewMockIdempotencyRepository(suite.mockCtrl)
+	suite.service = NewIdempotencyService(suite.mockRepo, 24*time.Hour, time.Minute)
+	suite.ctx = context.Background()
+}
+
+func (suite *IdempotencyServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *IdempotencyServiceTestSuite) TestBegin_Reserved() {
+	var token string
+
+	suite.mockRepo.EXPECT().
+		Reserve(suite.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error) {
+			suite.Equal("client-1", record.ClientID)
+			suite.Equal("key-1", record.Key)
+			suite.Equal("hash-1", record.RequestHash)
+			suite.Equal(entity.IdempotencyStatusInProgress, record.Status)
+			suite.Equal(time.Minute, record.ExpiresAt.Sub(record.CreatedAt))
+			suite.NotEmpty(record.Token)
+			token = record.Token
+			return nil, nil
+		}).
+		Times(1)
+
+	stored, reserved, err := suite.service.Begin(suite.ctx, "client-1", "key-1", "hash-1")
+
+	suite.NoError(err)
+	suite.Nil(stored)
+	suite.Equal(token, reserved)
+}
+
+func (suite *IdempotencyServiceTestSuite) TestBegin_ReplaysCompleted() {
+
+	suite.mockRepo.EXPECT().
+		Reserve(suite.ctx, gomock.Any()).
+		Return(&entity.IdempotencyRecord{
+			RequestHash:     "hash-1",
+			Status:          entity.IdempotencyStatusCompleted,
+			ResponseStatus:  201,
+			ResponseHeaders: map[string]string{"Content-Type": "application/json"},
+			ResponseBody:    []byte(`{"id":"product-1"}`),
+		}, nil).
+		Times(1)
+
+	stored, token, err := suite.service.Begin(suite.ctx, "client-1", "key-1", "hash-1")
+
+	suite.NoError(err)
+	suite.Empty(token)
+	suite.Equal(&entity.IdempotentResponse{
+		Status:  201,
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    []byte(`{"id":"product-1"}`),
+	}, stored)
+}
+
+func (suite *IdempotencyServiceTestSuite) TestBegin_DifferentRequest() {
+
+	suite.mockRepo.EXPECT().
+		Reserve(suite.ctx, gomock.Any()).
+		Return(&entity.IdempotencyRecord{RequestHash: "hash-1", Status: entity.IdempotencyStatusCompleted}, nil).
+		Times(1)
+
+	stored, _, err := suite.service.Begin(suite.ctx, "client-1", "key-1", "hash-2")
+
+	suite.Nil(stored)
+	suite.Equal(apperr.ErrUnprocessable.Code, apperr.GetCode(err))
+}
+
+func (suite *IdempotencyServiceTestSuite) TestBegin_InProgress() {
+
+	suite.mockRepo.EXPECT().
+		Reserve(suite.ctx, gomock.Any()).
+		Return(&entity.IdempotencyRecord{RequestHash: "hash-1", Status: entity.IdempotencyStatusInProgress}, nil).
+		Times(1)
+
+	stored, _, err := suite.service.Begin(suite.ctx, "client-1", "key-1", "hash-1")
+
+	suite.Nil(stored)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func (suite *IdempotencyServiceTestSuite) TestBegin_KeyTooLong() {
+
+	stored, _, err := suite.service.Begin(suite.ctx, "client-1", strings.Repeat("k", 256), "hash-1")
+
+	suite.Nil(stored)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *IdempotencyServiceTestSuite) TestComplete_Success() {
+
+	response := entity.IdempotentResponse{Status: 201, Body: []byte(`{}`)}
+	before := time.Now()
+
+	suite.mockRepo.EXPECT().
+		Complete(suite.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, record *entity.IdempotencyRecord) error {
+			suite.Equal("token-1", record.Token)
+			suite.Equal(entity.IdempotencyStatusCompleted, record.Status)
+			suite.Equal(201, record.ResponseStatus)
+			suite.Equal([]byte(`{}`), record.ResponseBody)
+			suite.False(record.ExpiresAt.Before(before.Add(24 * time.Hour)))
+			return nil
+		}).
+		Times(1)
+
+	err := suite.service.Complete(suite.ctx, "client-1", "key-1", "token-1", response)
+
+	suite.NoError(err)
+}
+
+func (suite *IdempotencyServiceTestSuite) TestRelease_Success() {
+
+	suite.mockRepo.EXPECT().
+		Delete(suite.ctx, "client-1", "key-1", "token-1").
+		Return(nil).
+		Times(1)
+
+	err := suite.service.Release(suite.ctx, "client-1", "key-1", "token-1")
+
+	suite.NoError(err)
+}
+
+func (suite *IdempotencyServiceTestSuite) TestPurgeExpired_Success() {
+
+	suite.mockRepo.EXPECT().
+		DeleteExpired(suite.ctx, gomock.Any()).
+		Return(int64(3), nil).
+		Times(1)
+
+	count, err := suite.service.PurgeExpired(suite.ctx)
+
+	suite.NoError(err)
+	suite.Equal(int64(3), count)
+}
+
+func TestIdempotencyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyServiceTestSuite))
+}
@@ -0,0 +1,88 @@
+// Package job queues background jobs and runs them on a pool of workers.
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type jobService struct {
+	jobRepo        repository.JobRepository
+	exportPageRepo repository.ExportPageRepository
+}
+
+//go:generate mockgen -source=job.go -destination=mocks/mock_job.go -package=mocks
+type JobService interface {
+	// Enqueue queues a job of jobType with params, which are stored as JSON, on behalf of the
+	// actor of ctx.
+	Enqueue(ctx context.Context, jobType entity.JobType, params interface{}) (*entity.Job, error)
+	GetByID(ctx context.Context, id string) (*entity.Job, error)
+	// Cancel cancels a queued job and asks a running one to stop at its next heartbeat.
+	Cancel(ctx context.Context, id string) (*entity.Job, error)
+	// GetExportPage returns a page of the products a succeeded export wrote, numbered from 1.
+	GetExportPage(ctx context.Context, id string, page int) (json.RawMessage, error)
+}
+
+func NewJobService(jobRepo repository.JobRepository, exportPageRepo repository.ExportPageRepository) JobService {
+	return &jobService{
+		jobRepo:        jobRepo,
+		exportPageRepo: exportPageRepo,
+	}
+}
+
+func (j jobService) Enqueue(ctx context.Context, jobType entity.JobType, params interface{}) (*entity.Job, error) {
+	if !jobType.IsValid() {
+		return nil, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("unknown job type %q", jobType))
+	}
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, apperr.ErrInvalidArgument.Wrap(err)
+	}
+
+	actor := entity.ActorFrom(ctx)
+	id, err := j.jobRepo.Create(ctx, &entity.Job{
+		Type:      jobType,
+		Params:    raw,
+		Status:    entity.JobStatusQueued,
+		Actor:     actor.Name,
+		RequestID: actor.RequestID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return j.jobRepo.FindByID(ctx, id)
+}
+
+func (j jobService) GetByID(ctx context.Context, id string) (*entity.Job, error) {
+	return j.jobRepo.FindByID(ctx, id)
+}
+
+func (j jobService) Cancel(ctx context.Context, id string) (*entity.Job, error) {
+	return j.jobRepo.RequestCancel(ctx, id)
+}
+
+func (j jobService) GetExportPage(ctx context.Context, id string, page int) (json.RawMessage, error) {
+	if page < 1 {
+		return nil, apperr.ErrInvalidArgument.WithMessage("page must be at least 1")
+	}
+
+	job, err := j.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Type != entity.JobTypeProductExport {
+		return nil, apperr.ErrNotFound.WithMessage("job is not an export")
+	}
+	if job.Status != entity.JobStatusSucceeded {
+		return nil, apperr.ErrConflict.WithMessage("export is " + string(job.Status) + ", its pages are available once it has succeeded")
+	}
+
+	return j.exportPageRepo.Find(ctx, id, page)
+}
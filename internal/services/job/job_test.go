@@ -0,0 +1,124 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type JobServiceTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockJobRepo *mocks.MockJobRepository
+	mockPages   *mocks.MockExportPageRepository
+	service     JobService
+	ctx         context.Context
+}
+
+func (suite *JobServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockJobRepo = mocks.NewMockJobRepository(suite.mockCtrl)
+	suite.mockPages = mocks.NewMockExportPageRepository(suite.mockCtrl)
+	suite.service = NewJobService(suite.mockJobRepo, suite.mockPages)
+	suite.ctx = entity.WithActor(context.Background(), entity.Actor{Name: "importer", RequestID: "req-1"})
+}
+
+func (suite *JobServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *JobServiceTestSuite) TestEnqueue_Success() {
+	queued := &entity.Job{ID: "job-1", Type: entity.JobTypeProductPriceChange, Status: entity.JobStatusQueued}
+
+	suite.mockJobRepo.EXPECT().
+		Create(suite.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, job *entity.Job) (string, error) {
+			suite.Equal(entity.JobTypeProductPriceChange, job.Type)
+			suite.Equal(entity.JobStatusQueued, job.Status)
+			suite.JSONEq(`{"percent":5}`, string(job.Params))
+			suite.Equal("importer", job.Actor)
+			suite.Equal("req-1", job.RequestID)
+			return "job-1", nil
+		}).
+		Times(1)
+	suite.mockJobRepo.EXPECT().FindByID(suite.ctx, "job-1").Return(queued, nil).Times(1)
+
+	result, err := suite.service.Enqueue(suite.ctx, entity.JobTypeProductPriceChange, PriceChangeParams{Percent: 5})
+
+	suite.NoError(err)
+	suite.Equal(queued, result)
+}
+
+func (suite *JobServiceTestSuite) TestEnqueue_UnknownType() {
+	result, err := suite.service.Enqueue(suite.ctx, entity.JobType("product.shred"), nil)
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *JobServiceTestSuite) TestEnqueue_UnmarshallableParams() {
+	result, err := suite.service.Enqueue(suite.ctx, entity.JobTypeProductExport, json.RawMessage(`{`))
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *JobServiceTestSuite) TestGetByID_NotFound() {
+	suite.mockJobRepo.EXPECT().FindByID(suite.ctx, "job-1").Return(nil, apperr.ErrNotFound).Times(1)
+
+	result, err := suite.service.GetByID(suite.ctx, "job-1")
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+}
+
+func (suite *JobServiceTestSuite) TestCancel_Success() {
+	cancelled := &entity.Job{ID: "job-1", Status: entity.JobStatusCancelled, CancelRequested: true}
+	suite.mockJobRepo.EXPECT().RequestCancel(suite.ctx, "job-1").Return(cancelled, nil).Times(1)
+
+	result, err := suite.service.Cancel(suite.ctx, "job-1")
+
+	suite.NoError(err)
+	suite.Equal(cancelled, result)
+}
+
+func (suite *JobServiceTestSuite) TestGetExportPage_Success() {
+	succeeded := &entity.Job{ID: "job-1", Type: entity.JobTypeProductExport, Status: entity.JobStatusSucceeded}
+	suite.mockJobRepo.EXPECT().FindByID(suite.ctx, "job-1").Return(succeeded, nil).Times(1)
+	suite.mockPages.EXPECT().Find(suite.ctx, "job-1", 2).Return(json.RawMessage(`[]`), nil).Times(1)
+
+	result, err := suite.service.GetExportPage(suite.ctx, "job-1", 2)
+
+	suite.NoError(err)
+	suite.JSONEq(`[]`, string(result))
+}
+
+func (suite *JobServiceTestSuite) TestGetExportPage_NotAnExport() {
+	job := &entity.Job{ID: "job-1", Type: entity.JobTypeProductImport, Status: entity.JobStatusSucceeded}
+	suite.mockJobRepo.EXPECT().FindByID(suite.ctx, "job-1").Return(job, nil).Times(1)
+
+	result, err := suite.service.GetExportPage(suite.ctx, "job-1", 1)
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+}
+
+func (suite *JobServiceTestSuite) TestGetExportPage_StillRunning() {
+	job := &entity.Job{ID: "job-1", Type: entity.JobTypeProductExport, Status: entity.JobStatusRunning}
+	suite.mockJobRepo.EXPECT().FindByID(suite.ctx, "job-1").Return(job, nil).Times(1)
+
+	result, err := suite.service.GetExportPage(suite.ctx, "job-1", 1)
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func TestJobServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(JobServiceTestSuite))
+}
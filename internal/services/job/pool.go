@@ -0,0 +1,237 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+var (
+	// errCancelled stops a job whose cancellation was requested.
+	errCancelled = errors.New("job was cancelled")
+	// errLeaseLost stops a job that another worker took over, or that was deleted, while it ran.
+	// Its worker leaves the job to the new claim and saves nothing more.
+	errLeaseLost = errors.New("job lease was lost")
+)
+
+// Reporter saves the progress of a running job along with a checkpoint, any value that marshals
+// to JSON, from which the job can resume. A nil checkpoint clears it. Reporting with the context
+// of a transaction saves the checkpoint along with the writes it covers.
+type Reporter func(ctx context.Context, progress entity.JobProgress, checkpoint interface{}) error
+
+// Runner does the work of one type of job. Run returns the job's result, which is stored as JSON.
+// It must stop once ctx is done, returning an error. A job that was interrupted is run again with
+// the checkpoint it last reported.
+type Runner interface {
+	Run(ctx context.Context, job entity.Job, report Reporter) (interface{}, error)
+}
+
+// Pool runs queued jobs on a fixed number of workers.
+type Pool struct {
+	repo    repository.JobRepository
+	runners map[entity.JobType]Runner
+	policy  entity.JobPolicy
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewPool(repo repository.JobRepository, runners map[entity.JobType]Runner, policy entity.JobPolicy) *Pool {
+	return &Pool{
+		repo:    repo,
+		runners: runners,
+		policy:  policy,
+	}
+}
+
+// Start runs jobs until Stop is called. A worker that finished a job looks for the next one right
+// away; an idle one looks again after the poll interval.
+func (p *Pool) Start() {
+	if p.policy.Workers <= 0 {
+		log.Println("jobs: worker pool disabled")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	for i := 0; i < p.policy.Workers; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.loop(ctx)
+		}()
+	}
+}
+
+func (p *Pool) loop(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		ran, err := p.RunOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("jobs: %v", err)
+		}
+
+		if ran {
+			timer.Reset(0)
+		} else {
+			timer.Reset(p.policy.PollInterval)
+		}
+	}
+}
+
+// RunOnce claims the next job and runs it to an end. It tells whether there was a job to run.
+//
+// A job ends succeeded, failed or cancelled. When ctx is done first, as on shutdown, the job is
+// put back in the queue and resumes from its checkpoint on the next start. A job whose worker
+// died is resumed once its lease has passed, and fails once it has been started more than the
+// policy's MaxAttempts times.
+func (p *Pool) RunOnce(ctx context.Context) (bool, error) {
+	job, err := p.repo.Claim(ctx, p.policy.Lease)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	// The outcome of the job is saved even when ctx is done, which is what stopped it.
+	saveCtx := context.WithoutCancel(ctx)
+
+	switch {
+	case job.CancelRequested:
+		return true, p.repo.Finish(saveCtx, job.ID, job.Attempts, entity.JobStatusCancelled, nil, errCancelled.Error())
+	case job.Attempts > p.policy.MaxAttempts:
+		msg := fmt.Sprintf("job was interrupted %d times", job.Attempts-1)
+		return true, p.repo.Finish(saveCtx, job.ID, job.Attempts, entity.JobStatusFailed, nil, msg)
+	}
+
+	runner, ok := p.runners[job.Type]
+	if !ok {
+		msg := fmt.Sprintf("no runner for job type %q", job.Type)
+		return true, p.repo.Finish(saveCtx, job.ID, job.Attempts, entity.JobStatusFailed, nil, msg)
+	}
+
+	result, err := p.run(ctx, runner, *job)
+	switch {
+	case err == nil:
+		raw, err := json.Marshal(result)
+		if err != nil {
+			return true, p.repo.Finish(saveCtx, job.ID, job.Attempts, entity.JobStatusFailed, nil, err.Error())
+		}
+		return true, p.repo.Finish(saveCtx, job.ID, job.Attempts, entity.JobStatusSucceeded, raw, "")
+	case errors.Is(err, errCancelled):
+		return true, p.repo.Finish(saveCtx, job.ID, job.Attempts, entity.JobStatusCancelled, nil, errCancelled.Error())
+	case errors.Is(err, errLeaseLost):
+		return true, fmt.Errorf("job %s: %w", job.ID, err)
+	case ctx.Err() != nil:
+		log.Printf("jobs: job %s %s interrupted, requeued", job.ID, job.Type)
+		return true, p.repo.Requeue(saveCtx, job.ID, job.Attempts)
+	default:
+		return true, p.repo.Finish(saveCtx, job.ID, job.Attempts, entity.JobStatusFailed, nil, err.Error())
+	}
+}
+
+// run runs the job as its actor while a heartbeat keeps its lease. The job's context is cancelled
+// with errCancelled or errLeaseLost as its cause when the heartbeat, or a report for lease loss,
+// finds either, and the cause is returned in place of the runner's error.
+func (p *Pool) run(ctx context.Context, runner Runner, job entity.Job) (interface{}, error) {
+	ctx = entity.WithActor(ctx, entity.Actor{Name: job.Actor, RequestID: job.RequestID})
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.heartbeat(ctx, job, cancel)
+	}()
+
+	report := func(ctx context.Context, progress entity.JobProgress, checkpoint interface{}) error {
+		var raw json.RawMessage
+		if checkpoint != nil {
+			var err error
+			raw, err = json.Marshal(checkpoint)
+			if err != nil {
+				return apperr.ErrInternal.Wrap(err)
+			}
+		}
+		err := p.repo.SaveProgress(ctx, job.ID, job.Attempts, progress, raw)
+		if apperr.GetCode(err) == apperr.ErrConflict.Code {
+			cancel(errLeaseLost)
+		}
+		return err
+	}
+
+	result, err := runner.Run(ctx, job, report)
+	cause := context.Cause(ctx)
+	cancel(nil)
+	wg.Wait()
+
+	if err != nil && (errors.Is(cause, errCancelled) || errors.Is(cause, errLeaseLost)) {
+		return nil, cause
+	}
+	return result, err
+}
+
+// heartbeat extends the job's lease every third of it until ctx is done.
+func (p *Pool) heartbeat(ctx context.Context, job entity.Job, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(p.policy.Lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cancelRequested, err := p.repo.Heartbeat(ctx, job.ID, job.Attempts, p.policy.Lease)
+		switch {
+		case cancelRequested:
+			cancel(errCancelled)
+			return
+		case apperr.GetCode(err) == apperr.ErrConflict.Code:
+			cancel(errLeaseLost)
+			return
+		case err != nil && ctx.Err() == nil:
+			// The lease outlasts a few missed heartbeats.
+			log.Printf("jobs: heartbeat of job %s failed: %v", job.ID, err)
+		}
+	}
+}
+
+// Stop cancels the running jobs and waits for the workers to put them back in the queue, or for
+// ctx to expire.
+func (p *Pool) Stop(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,341 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type runnerFunc func(ctx context.Context, job entity.Job, report Reporter) (interface{}, error)
+
+func (f runnerFunc) Run(ctx context.Context, job entity.Job, report Reporter) (interface{}, error) {
+	return f(ctx, job, report)
+}
+
+type PoolTestSuite struct {
+	suite.Suite
+	mockCtrl *gomock.Controller
+	mockRepo *mocks.MockJobRepository
+	policy   entity.JobPolicy
+	runner   runnerFunc
+	ctx      context.Context
+}
+
+func (suite *PoolTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockRepo = mocks.NewMockJobRepository(suite.mockCtrl)
+	suite.policy = entity.JobPolicy{
+		Workers:      1,
+		PollInterval: 10 * time.Millisecond,
+		Lease:        time.Minute,
+		MaxAttempts:  3,
+		ChunkSize:    10,
+	}
+	suite.runner = func(context.Context, entity.Job, Reporter) (interface{}, error) {
+		return nil, nil
+	}
+	suite.ctx = context.Background()
+}
+
+func (suite *PoolTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *PoolTestSuite) pool() *Pool {
+	return NewPool(suite.mockRepo, map[entity.JobType]Runner{
+		entity.JobTypeProductExport: runnerFunc(func(ctx context.Context, job entity.Job, report Reporter) (interface{}, error) {
+			return suite.runner(ctx, job, report)
+		}),
+	}, suite.policy)
+}
+
+func (suite *PoolTestSuite) job() *entity.Job {
+	return &entity.Job{
+		ID:        "job-1",
+		Type:      entity.JobTypeProductExport,
+		Status:    entity.JobStatusRunning,
+		Attempts:  1,
+		Actor:     "importer",
+		RequestID: "req-1",
+	}
+}
+
+func (suite *PoolTestSuite) TestRunOnce_NoJob() {
+	suite.mockRepo.EXPECT().Claim(suite.ctx, time.Minute).Return(nil, nil).Times(1)
+
+	ran, err := suite.pool().RunOnce(suite.ctx)
+
+	suite.NoError(err)
+	suite.False(ran)
+}
+
+func (suite *PoolTestSuite) TestRunOnce_Succeeded() {
+	suite.runner = func(ctx context.Context, job entity.Job, report Reporter) (interface{}, error) {
+		suite.Equal(entity.Actor{Name: "importer", RequestID: "req-1"}, entity.ActorFrom(ctx))
+		suite.Equal("job-1", job.ID)
+		if err := report(ctx, entity.JobProgress{Done: 1, Total: 2}, map[string]int{"next": 1}); err != nil {
+			return nil, err
+		}
+		return map[string]int{"count": 2}, nil
+	}
+	gomock.InOrder(
+		suite.mockRepo.EXPECT().Claim(suite.ctx, time.Minute).Return(suite.job(), nil).Times(1),
+		suite.mockRepo.EXPECT().
+			SaveProgress(gomock.Any(), "job-1", 1, entity.JobProgress{Done: 1, Total: 2}, json.RawMessage(`{"next":1}`)).
+			Return(nil).
+			Times(1),
+		suite.mockRepo.EXPECT().
+			Finish(gomock.Any(), "job-1", 1, entity.JobStatusSucceeded, json.RawMessage(`{"count":2}`), "").
+			Return(nil).
+			Times(1),
+	)
+
+	ran, err := suite.pool().RunOnce(suite.ctx)
+
+	suite.NoError(err)
+	suite.True(ran)
+}
+
+func (suite *PoolTestSuite) TestRunOnce_Failed() {
+	suite.runner = func(context.Context, entity.Job, Reporter) (interface{}, error) {
+		return nil, apperr.ErrInvalidArgument.WithMessage("bad params")
+	}
+	suite.mockRepo.EXPECT().Claim(suite.ctx, time.Minute).Return(suite.job(), nil).Times(1)
+	suite.mockRepo.EXPECT().
+		Finish(gomock.Any(), "job-1", 1, entity.JobStatusFailed, nil, "[INVALID_ARGUMENT] bad params").
+		Return(nil).
+		Times(1)
+
+	ran, err := suite.pool().RunOnce(suite.ctx)
+
+	suite.NoError(err)
+	suite.True(ran)
+}
+
+func (suite *PoolTestSuite) TestRunOnce_CancelledByHeartbeat() {
+	suite.policy.Lease = 30 * time.Millisecond
+	suite.runner = func(ctx context.Context, _ entity.Job, _ Reporter) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	suite.mockRepo.EXPECT().Claim(suite.ctx, 30*time.Millisecond).Return(suite.job(), nil).Times(1)
+	suite.mockRepo.EXPECT().Heartbeat(gomock.Any(), "job-1", 1, 30*time.Millisecond).Return(true, nil).Times(1)
+	suite.mockRepo.EXPECT().
+		Finish(gomock.Any(), "job-1", 1, entity.JobStatusCancelled, nil, errCancelled.Error()).
+		Return(nil).
+		Times(1)
+
+	ran, err := suite.pool().RunOnce(suite.ctx)
+
+	suite.NoError(err)
+	suite.True(ran)
+}
+
+func (suite *PoolTestSuite) TestRunOnce_LeaseLost() {
+	suite.policy.Lease = 30 * time.Millisecond
+	suite.runner = func(ctx context.Context, _ entity.Job, _ Reporter) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	suite.mockRepo.EXPECT().Claim(suite.ctx, 30*time.Millisecond).Return(suite.job(), nil).Times(1)
+	suite.mockRepo.EXPECT().
+		Heartbeat(gomock.Any(), "job-1", 1, 30*time.Millisecond).
+		Return(false, apperr.ErrConflict.WithMessage("job is no longer running")).
+		Times(1)
+
+	ran, err := suite.pool().RunOnce(suite.ctx)
+
+	suite.ErrorIs(err, errLeaseLost)
+	suite.True(ran)
+}
+
+func (suite *PoolTestSuite) TestRunOnce_ReclaimedAfterLeaseExpired() {
+	stalled, reclaimed := make(chan struct{}), make(chan struct{})
+	suite.runner = func(ctx context.Context, job entity.Job, report Reporter) (interface{}, error) {
+		if job.Attempts == 1 {
+			// Stalled past its lease while another worker took the job over.
+			close(stalled)
+			<-reclaimed
+		}
+		if err := report(ctx, entity.JobProgress{Done: 1, Total: 1}, nil); err != nil {
+			return nil, err
+		}
+		return map[string]int{"attempts": job.Attempts}, nil
+	}
+	takeover := suite.job()
+	takeover.Attempts = 2
+	gomock.InOrder(
+		suite.mockRepo.EXPECT().Claim(suite.ctx, time.Minute).Return(suite.job(), nil).Times(1),
+		suite.mockRepo.EXPECT().Claim(suite.ctx, time.Minute).Return(takeover, nil).Times(1),
+	)
+	suite.mockRepo.EXPECT().
+		SaveProgress(gomock.Any(), "job-1", 2, entity.JobProgress{Done: 1, Total: 1}, nil).
+		Return(nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		Finish(gomock.Any(), "job-1", 2, entity.JobStatusSucceeded, json.RawMessage(`{"attempts":2}`), "").
+		Return(nil).
+		Times(1)
+	// The first claim is fenced off; it must not finish the job.
+	suite.mockRepo.EXPECT().
+		SaveProgress(gomock.Any(), "job-1", 1, gomock.Any(), gomock.Any()).
+		Return(apperr.ErrConflict.WithMessage("job is no longer running")).
+		Times(1)
+
+	type outcome struct {
+		ran bool
+		err error
+	}
+	first := make(chan outcome, 1)
+	go func() {
+		ran, err := suite.pool().RunOnce(suite.ctx)
+		first <- outcome{ran, err}
+	}()
+	<-stalled
+
+	ran, err := suite.pool().RunOnce(suite.ctx)
+	suite.NoError(err)
+	suite.True(ran)
+
+	close(reclaimed)
+	stale := <-first
+	suite.ErrorIs(stale.err, errLeaseLost)
+	suite.True(stale.ran)
+}
+
+func (suite *PoolTestSuite) TestRunOnce_InterruptedIsRequeued() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	suite.runner = func(ctx context.Context, _ entity.Job, _ Reporter) (interface{}, error) {
+		cancel()
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	suite.mockRepo.EXPECT().Claim(ctx, time.Minute).Return(suite.job(), nil).Times(1)
+	suite.mockRepo.EXPECT().
+		Requeue(gomock.Any(), "job-1", 1).
+		DoAndReturn(func(ctx context.Context, _ string, _ int) error {
+			suite.NoError(ctx.Err())
+			return nil
+		}).
+		Times(1)
+
+	ran, err := suite.pool().RunOnce(ctx)
+
+	suite.NoError(err)
+	suite.True(ran)
+}
+
+func (suite *PoolTestSuite) TestRunOnce_CancelRequestedBeforeResume() {
+	job := suite.job()
+	job.CancelRequested = true
+	suite.runner = func(context.Context, entity.Job, Reporter) (interface{}, error) {
+		suite.Fail("a cancelled job must not run")
+		return nil, nil
+	}
+	suite.mockRepo.EXPECT().Claim(suite.ctx, time.Minute).Return(job, nil).Times(1)
+	suite.mockRepo.EXPECT().
+		Finish(gomock.Any(), "job-1", 1, entity.JobStatusCancelled, nil, errCancelled.Error()).
+		Return(nil).
+		Times(1)
+
+	ran, err := suite.pool().RunOnce(suite.ctx)
+
+	suite.NoError(err)
+	suite.True(ran)
+}
+
+func (suite *PoolTestSuite) TestRunOnce_TooManyAttempts() {
+	job := suite.job()
+	job.Attempts = 4
+	suite.runner = func(context.Context, entity.Job, Reporter) (interface{}, error) {
+		suite.Fail("a job out of attempts must not run")
+		return nil, nil
+	}
+	suite.mockRepo.EXPECT().Claim(suite.ctx, time.Minute).Return(job, nil).Times(1)
+	suite.mockRepo.EXPECT().
+		Finish(gomock.Any(), "job-1", 4, entity.JobStatusFailed, nil, "job was interrupted 3 times").
+		Return(nil).
+		Times(1)
+
+	ran, err := suite.pool().RunOnce(suite.ctx)
+
+	suite.NoError(err)
+	suite.True(ran)
+}
+
+func (suite *PoolTestSuite) TestRunOnce_UnknownType() {
+	job := suite.job()
+	job.Type = entity.JobTypeProductImport
+	suite.mockRepo.EXPECT().Claim(suite.ctx, time.Minute).Return(job, nil).Times(1)
+	suite.mockRepo.EXPECT().
+		Finish(gomock.Any(), "job-1", 1, entity.JobStatusFailed, nil, `no runner for job type "product.import"`).
+		Return(nil).
+		Times(1)
+
+	ran, err := suite.pool().RunOnce(suite.ctx)
+
+	suite.NoError(err)
+	suite.True(ran)
+}
+
+func (suite *PoolTestSuite) TestRunOnce_ClaimFails() {
+	suite.mockRepo.EXPECT().Claim(suite.ctx, time.Minute).Return(nil, errors.New("connection refused")).Times(1)
+
+	ran, err := suite.pool().RunOnce(suite.ctx)
+
+	suite.Error(err)
+	suite.False(ran)
+}
+
+func (suite *PoolTestSuite) TestStop_RequeuesRunningJob() {
+	started := make(chan struct{})
+	suite.runner = func(ctx context.Context, _ entity.Job, _ Reporter) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	requeued := make(chan struct{})
+	gomock.InOrder(
+		suite.mockRepo.EXPECT().Claim(gomock.Any(), time.Minute).Return(suite.job(), nil).Times(1),
+		suite.mockRepo.EXPECT().Requeue(gomock.Any(), "job-1", 1).DoAndReturn(func(context.Context, string, int) error {
+			close(requeued)
+			return nil
+		}).Times(1),
+	)
+	suite.mockRepo.EXPECT().Claim(gomock.Any(), time.Minute).Return(nil, nil).AnyTimes()
+
+	pool := suite.pool()
+	pool.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(suite.ctx, time.Second)
+	defer cancel()
+	suite.NoError(pool.Stop(ctx))
+
+	select {
+	case <-requeued:
+	default:
+		suite.Fail("the running job was not requeued")
+	}
+}
+
+func (suite *PoolTestSuite) TestStart_Disabled() {
+	suite.policy.Workers = 0
+	pool := suite.pool()
+
+	pool.Start()
+
+	suite.NoError(pool.Stop(suite.ctx))
+}
+
+func TestPoolTestSuite(t *testing.T) {
+	suite.Run(t, new(PoolTestSuite))
+}
@@ -0,0 +1,388 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/services/product"
+	"github.com/sirawong/crud-arise/pkg/utils"
+)
+
+// maxReportedErrors caps the item errors kept in a job's result; the rest are only counted.
+const maxReportedErrors = 100
+
+// ImportItem is a product to create or replace by its SKU.
+type ImportItem struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	SKU         string   `json:"sku"`
+	Price       *float64 `json:"price"`
+	Stock       *int     `json:"stock"`
+	ImageURL    string   `json:"imageUrl"`
+	CategoryID  string   `json:"categoryId"`
+}
+
+func (i ImportItem) toProduct() entity.Product {
+	return entity.Product{
+		Name:        i.Name,
+		Description: i.Description,
+		SKU:         i.SKU,
+		Price:       i.Price,
+		Stock:       i.Stock,
+		ImageURL:    &i.ImageURL,
+		CategoryID:  i.CategoryID,
+	}
+}
+
+type ImportParams struct {
+	Items []ImportItem `json:"items"`
+}
+
+// ImportResult counts the imported items. Errors lists the first failing items, indexed in
+// import order.
+type ImportResult struct {
+	Created int                     `json:"created"`
+	Updated int                     `json:"updated"`
+	Failed  int                     `json:"failed"`
+	Errors  []entity.BatchItemError `json:"errors,omitempty"`
+}
+
+type importCheckpoint struct {
+	Next int `json:"next"`
+	ImportResult
+}
+
+type importRunner struct {
+	productService product.ProductService
+	chunkSize      int
+}
+
+// NewImportRunner returns the runner of product imports, which upserts the items by SKU a chunk at
+// a time on a best-effort basis. An interrupted import resumes with the chunk it was writing; the
+// upsert makes writing it again harmless.
+func NewImportRunner(productService product.ProductService, chunkSize int) Runner {
+	return &importRunner{
+		productService: productService,
+		chunkSize:      chunkSize,
+	}
+}
+
+func (r importRunner) Run(ctx context.Context, job entity.Job, report Reporter) (interface{}, error) {
+	var params ImportParams
+	if err := json.Unmarshal(job.Params, &params); err != nil {
+		return nil, apperr.ErrInvalidArgument.Wrap(err)
+	}
+	var state importCheckpoint
+	if err := unmarshalCheckpoint(job.Checkpoint, &state); err != nil {
+		return nil, err
+	}
+
+	total := len(params.Items)
+	for state.Next < total {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		end := min(state.Next+r.chunkSize, total)
+		products := make([]entity.Product, 0, end-state.Next)
+		for _, item := range params.Items[state.Next:end] {
+			products = append(products, item.toProduct())
+		}
+
+		results, err := r.productService.BatchUpsert(ctx, products, entity.BatchBestEffort)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			switch {
+			case result.Err != nil:
+				state.Failed++
+				state.Errors = appendItemError(state.Errors, state.Next+result.Index, result.ID, result.Err)
+			case result.Created:
+				state.Created++
+			default:
+				state.Updated++
+			}
+		}
+
+		state.Next = end
+		if err := report(ctx, entity.JobProgress{Done: state.Next, Total: total}, state); err != nil {
+			return nil, err
+		}
+	}
+	return state.ImportResult, nil
+}
+
+// ProductQuery selects the products an export or a price change works on.
+type ProductQuery struct {
+	Name       *string  `json:"name,omitempty"`
+	CategoryID *string  `json:"categoryId,omitempty"`
+	MinPrice   *float64 `json:"minPrice,omitempty"`
+	MaxPrice   *float64 `json:"maxPrice,omitempty"`
+}
+
+func (q ProductQuery) filter() entity.ProductFilter {
+	return entity.ProductFilter{
+		Name:       q.Name,
+		CategoryID: q.CategoryID,
+		MinPrice:   q.MinPrice,
+		MaxPrice:   q.MaxPrice,
+	}
+}
+
+type ExportParams struct {
+	ProductQuery
+}
+
+// ExportedProduct is a product as written to an export.
+type ExportedProduct struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	SKU         string    `json:"sku"`
+	Price       float64   `json:"price"`
+	Stock       int       `json:"stock"`
+	ImageURL    string    `json:"imageUrl"`
+	CategoryID  string    `json:"categoryId"`
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ExportResult counts the exported products and the pages they were written to, which are read
+// one at a time from the job's export pages.
+type ExportResult struct {
+	Count int `json:"count"`
+	Pages int `json:"pages"`
+}
+
+type exportCheckpoint struct {
+	AfterID string `json:"afterId"`
+	ExportResult
+}
+
+type exportRunner struct {
+	txManager      repository.TxManager
+	productRepo    repository.ProductRepository
+	exportPageRepo repository.ExportPageRepository
+	chunkSize      int
+}
+
+// NewExportRunner returns the runner of product exports, which reads the matching products in id
+// order a chunk at a time and writes each chunk as a page of the export. A page is saved in one
+// transaction with the checkpoint past it, so an interrupted export resumes with the next page.
+func NewExportRunner(
+	txManager repository.TxManager,
+	productRepo repository.ProductRepository,
+	exportPageRepo repository.ExportPageRepository,
+	chunkSize int,
+) Runner {
+	return &exportRunner{
+		txManager:      txManager,
+		productRepo:    productRepo,
+		exportPageRepo: exportPageRepo,
+		chunkSize:      chunkSize,
+	}
+}
+
+func (r exportRunner) Run(ctx context.Context, job entity.Job, report Reporter) (interface{}, error) {
+	var params ExportParams
+	if err := json.Unmarshal(job.Params, &params); err != nil {
+		return nil, apperr.ErrInvalidArgument.Wrap(err)
+	}
+
+	var state exportCheckpoint
+	if err := unmarshalCheckpoint(job.Checkpoint, &state); err != nil {
+		return nil, err
+	}
+
+	filter := params.filter()
+	stats, err := r.productRepo.Stats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		products, err := r.productRepo.FindAfter(ctx, filter, state.AfterID, r.chunkSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(products) == 0 {
+			return state.ExportResult, nil
+		}
+
+		page := make([]ExportedProduct, 0, len(products))
+		for _, p := range products {
+			page = append(page, ExportedProduct{
+				ID:          p.ID,
+				Name:        p.Name,
+				Description: p.Description,
+				SKU:         p.SKU,
+				Price:       utils.GetValue(p.Price),
+				Stock:       utils.GetValue(p.Stock),
+				ImageURL:    utils.GetValue(p.ImageURL),
+				CategoryID:  p.CategoryID,
+				Version:     p.Version,
+				CreatedAt:   p.CreatedAt,
+				UpdatedAt:   p.UpdatedAt,
+			})
+		}
+		raw, err := json.Marshal(page)
+		if err != nil {
+			return nil, apperr.ErrInternal.Wrap(err)
+		}
+
+		next := state
+		next.AfterID = products[len(products)-1].ID
+		next.Count += len(products)
+		next.Pages++
+		// Products added while the export runs can take it past the count it started with.
+		progress := entity.JobProgress{Done: next.Count, Total: max(int(stats.Count), next.Count)}
+		err = r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := r.exportPageRepo.Save(ctx, job.ID, next.Pages, raw); err != nil {
+				return err
+			}
+			return report(ctx, progress, next)
+		})
+		if err != nil {
+			return nil, err
+		}
+		state = next
+
+		if len(products) < r.chunkSize {
+			return state.ExportResult, nil
+		}
+	}
+}
+
+type PriceChangeParams struct {
+	ProductQuery
+	// Percent raises, or when negative lowers, each price by that percentage.
+	Percent float64 `json:"percent"`
+}
+
+// PriceChangeResult counts the repriced products. Errors lists the first products that could not
+// be repriced, indexed in the order they were found.
+type PriceChangeResult struct {
+	Changed int                     `json:"changed"`
+	Failed  int                     `json:"failed"`
+	Errors  []entity.BatchItemError `json:"errors,omitempty"`
+}
+
+type priceChangeCheckpoint struct {
+	AfterID string `json:"afterId"`
+	Done    int    `json:"done"`
+	PriceChangeResult
+}
+
+type priceChangeRunner struct {
+	txManager      repository.TxManager
+	productRepo    repository.ProductRepository
+	productService product.ProductService
+	chunkSize      int
+}
+
+// NewPriceChangeRunner returns the runner of price changes, which reprices the matching products
+// one at a time in id order. Each new price is saved in one transaction with the checkpoint past
+// it, so an interrupted price change resumes without repricing a product twice.
+func NewPriceChangeRunner(
+	txManager repository.TxManager,
+	productRepo repository.ProductRepository,
+	productService product.ProductService,
+	chunkSize int,
+) Runner {
+	return &priceChangeRunner{
+		txManager:      txManager,
+		productRepo:    productRepo,
+		productService: productService,
+		chunkSize:      chunkSize,
+	}
+}
+
+func (r priceChangeRunner) Run(ctx context.Context, job entity.Job, report Reporter) (interface{}, error) {
+	var params PriceChangeParams
+	if err := json.Unmarshal(job.Params, &params); err != nil {
+		return nil, apperr.ErrInvalidArgument.Wrap(err)
+	}
+	if params.Percent <= -100 {
+		return nil, apperr.ErrInvalidArgument.WithMessage("percent must be greater than -100")
+	}
+	var state priceChangeCheckpoint
+	if err := unmarshalCheckpoint(job.Checkpoint, &state); err != nil {
+		return nil, err
+	}
+
+	filter := params.filter()
+	stats, err := r.productRepo.Stats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		products, err := r.productRepo.FindAfter(ctx, filter, state.AfterID, r.chunkSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range products {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			next := state
+			next.AfterID = p.ID
+			next.Done++
+			progress := entity.JobProgress{Done: next.Done, Total: max(int(stats.Count), next.Done)}
+			price := entity.RoundMoney(utils.GetValue(p.Price) * (1 + params.Percent/100))
+
+			err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+				_, err := r.productService.Patch(ctx, p.ID, entity.ProductPatch{Price: &price, Version: p.Version})
+				if err != nil {
+					return err
+				}
+				next.Changed++
+				return report(ctx, progress, next)
+			})
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, err
+				}
+				// The failure is saved with the next product, or with the result.
+				next = state
+				next.AfterID = p.ID
+				next.Done++
+				next.Failed++
+				next.Errors = appendItemError(next.Errors, next.Done-1, p.ID, err)
+			}
+			state = next
+		}
+		if len(products) < r.chunkSize {
+			return state.PriceChangeResult, nil
+		}
+	}
+}
+
+func unmarshalCheckpoint(checkpoint json.RawMessage, state interface{}) error {
+	if len(checkpoint) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(checkpoint, state); err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+	return nil
+}
+
+func appendItemError(errs []entity.BatchItemError, index int, id string, err error) []entity.BatchItemError {
+	if len(errs) >= maxReportedErrors {
+		return errs
+	}
+	return append(errs, entity.BatchItemError{
+		Index:     index,
+		ID:        id,
+		ErrorCode: apperr.GetCode(err),
+		Message:   err.Error(),
+	})
+}
@@ -0,0 +1,284 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	productMocks "github.com/sirawong/crud-arise/internal/services/product/mocks"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type report struct {
+	progress   entity.JobProgress
+	checkpoint string
+}
+
+type ProductRunnersTestSuite struct {
+	suite.Suite
+	mockCtrl           *gomock.Controller
+	mockTxManager      *mocks.MockTxManager
+	mockProductRepo    *mocks.MockProductRepository
+	mockExportPageRepo *mocks.MockExportPageRepository
+	mockProductService *productMocks.MockProductService
+	reports            []report
+	ctx                context.Context
+}
+
+func (suite *ProductRunnersTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+	suite.mockProductRepo = mocks.NewMockProductRepository(suite.mockCtrl)
+	suite.mockExportPageRepo = mocks.NewMockExportPageRepository(suite.mockCtrl)
+	suite.mockProductService = productMocks.NewMockProductService(suite.mockCtrl)
+	suite.reports = nil
+
+	suite.mockTxManager.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	suite.ctx = context.Background()
+}
+
+func (suite *ProductRunnersTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *ProductRunnersTestSuite) report(_ context.Context, progress entity.JobProgress, checkpoint interface{}) error {
+	raw := ""
+	if checkpoint != nil {
+		b, err := json.Marshal(checkpoint)
+		suite.Require().NoError(err)
+		raw = string(b)
+	}
+	suite.reports = append(suite.reports, report{progress, raw})
+	return nil
+}
+
+func (suite *ProductRunnersTestSuite) job(params interface{}, checkpoint string) entity.Job {
+	raw, err := json.Marshal(params)
+	suite.Require().NoError(err)
+	job := entity.Job{ID: "job-1", Params: raw}
+	if checkpoint != "" {
+		job.Checkpoint = json.RawMessage(checkpoint)
+	}
+	return job
+}
+
+func (suite *ProductRunnersTestSuite) importParams(count int) ImportParams {
+	params := ImportParams{}
+	for i := 0; i < count; i++ {
+		params.Items = append(params.Items, ImportItem{
+			Name:       "Product",
+			SKU:        string(rune('A' + i)),
+			Price:      utils.SetPtr(10.0),
+			Stock:      utils.SetPtr(1),
+			CategoryID: "category-1",
+		})
+	}
+	return params
+}
+
+func (suite *ProductRunnersTestSuite) TestImport_UpsertsInChunks() {
+	gomock.InOrder(
+		suite.mockProductService.EXPECT().
+			BatchUpsert(suite.ctx, gomock.Len(2), entity.BatchBestEffort).
+			DoAndReturn(func(_ context.Context, products []entity.Product, _ entity.BatchMode) ([]entity.BatchResult, error) {
+				suite.Equal("A", products[0].SKU)
+				suite.Equal("B", products[1].SKU)
+				return []entity.BatchResult{
+					{Index: 0, ID: "product-a", Created: true},
+					{Index: 1, Err: apperr.ErrNotFound.WithMessage("category not found")},
+				}, nil
+			}).
+			Times(1),
+		suite.mockProductService.EXPECT().
+			BatchUpsert(suite.ctx, gomock.Len(1), entity.BatchBestEffort).
+			Return([]entity.BatchResult{{Index: 0, ID: "product-c"}}, nil).
+			Times(1),
+	)
+
+	result, err := NewImportRunner(suite.mockProductService, 2).Run(suite.ctx, suite.job(suite.importParams(3), ""), suite.report)
+
+	suite.NoError(err)
+	suite.Equal(ImportResult{
+		Created: 1,
+		Updated: 1,
+		Failed:  1,
+		Errors:  []entity.BatchItemError{{Index: 1, ErrorCode: "NOT_FOUND", Message: "[NOT_FOUND] category not found"}},
+	}, result)
+	suite.Require().Len(suite.reports, 2)
+	suite.Equal(entity.JobProgress{Done: 2, Total: 3}, suite.reports[0].progress)
+	suite.Equal(entity.JobProgress{Done: 3, Total: 3}, suite.reports[1].progress)
+}
+
+func (suite *ProductRunnersTestSuite) TestImport_ResumesFromCheckpoint() {
+	suite.mockProductService.EXPECT().
+		BatchUpsert(suite.ctx, gomock.Len(1), entity.BatchBestEffort).
+		DoAndReturn(func(_ context.Context, products []entity.Product, _ entity.BatchMode) ([]entity.BatchResult, error) {
+			suite.Equal("C", products[0].SKU)
+			return []entity.BatchResult{{Index: 0, ID: "product-c", Created: true}}, nil
+		}).
+		Times(1)
+
+	job := suite.job(suite.importParams(3), `{"next":2,"created":2,"updated":0,"failed":0}`)
+	result, err := NewImportRunner(suite.mockProductService, 2).Run(suite.ctx, job, suite.report)
+
+	suite.NoError(err)
+	suite.Equal(ImportResult{Created: 3}, result)
+}
+
+func (suite *ProductRunnersTestSuite) TestImport_StopsWhenCancelled() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	cancel()
+
+	result, err := NewImportRunner(suite.mockProductService, 2).Run(ctx, suite.job(suite.importParams(3), ""), suite.report)
+
+	suite.Nil(result)
+	suite.ErrorIs(err, context.Canceled)
+}
+
+// expectPage expects page of job-1's export to be saved with the products of ids, in order.
+func (suite *ProductRunnersTestSuite) expectPage(page int, ids ...string) *gomock.Call {
+	return suite.mockExportPageRepo.EXPECT().
+		Save(suite.ctx, "job-1", page, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ int, raw json.RawMessage) error {
+			var products []ExportedProduct
+			suite.Require().NoError(json.Unmarshal(raw, &products))
+			saved := make([]string, 0, len(products))
+			for _, p := range products {
+				saved = append(saved, p.ID)
+			}
+			suite.Equal(ids, saved)
+			return nil
+		}).
+		Times(1)
+}
+
+func (suite *ProductRunnersTestSuite) TestExport_WritesPages() {
+	categoryID := "category-1"
+	params := ExportParams{ProductQuery{CategoryID: &categoryID}}
+	filter := entity.ProductFilter{CategoryID: &categoryID}
+	gomock.InOrder(
+		suite.mockProductRepo.EXPECT().Stats(suite.ctx, filter).Return(&entity.ListStats{Count: 3}, nil).Times(1),
+		suite.mockProductRepo.EXPECT().FindAfter(suite.ctx, filter, "", 2).Return([]entity.Product{
+			{ID: "p1", SKU: "A", Price: utils.SetPtr(1.5)},
+			{ID: "p2", SKU: "B"},
+		}, nil).Times(1),
+		suite.expectPage(1, "p1", "p2"),
+		suite.mockProductRepo.EXPECT().FindAfter(suite.ctx, filter, "p2", 2).Return([]entity.Product{
+			{ID: "p3", SKU: "C"},
+		}, nil).Times(1),
+		suite.expectPage(2, "p3"),
+	)
+
+	result, err := NewExportRunner(suite.mockTxManager, suite.mockProductRepo, suite.mockExportPageRepo, 2).
+		Run(suite.ctx, suite.job(params, ""), suite.report)
+
+	suite.NoError(err)
+	suite.Equal(ExportResult{Count: 3, Pages: 2}, result)
+	suite.Equal([]report{
+		{entity.JobProgress{Done: 2, Total: 3}, `{"afterId":"p2","count":2,"pages":1}`},
+		{entity.JobProgress{Done: 3, Total: 3}, `{"afterId":"p3","count":3,"pages":2}`},
+	}, suite.reports)
+}
+
+func (suite *ProductRunnersTestSuite) TestExport_ResumesAfterCheckpoint() {
+	gomock.InOrder(
+		suite.mockProductRepo.EXPECT().Stats(suite.ctx, entity.ProductFilter{}).Return(&entity.ListStats{Count: 4}, nil).Times(1),
+		suite.mockProductRepo.EXPECT().FindAfter(suite.ctx, entity.ProductFilter{}, "p2", 2).Return([]entity.Product{
+			{ID: "p3"},
+			{ID: "p4"},
+		}, nil).Times(1),
+		suite.expectPage(2, "p3", "p4"),
+		suite.mockProductRepo.EXPECT().FindAfter(suite.ctx, entity.ProductFilter{}, "p4", 2).Return(nil, nil).Times(1),
+	)
+
+	job := suite.job(ExportParams{}, `{"afterId":"p2","count":2,"pages":1}`)
+	result, err := NewExportRunner(suite.mockTxManager, suite.mockProductRepo, suite.mockExportPageRepo, 2).
+		Run(suite.ctx, job, suite.report)
+
+	suite.NoError(err)
+	suite.Equal(ExportResult{Count: 4, Pages: 2}, result)
+}
+
+func (suite *ProductRunnersTestSuite) TestPriceChange_RepricesEachProduct() {
+	params := PriceChangeParams{Percent: 10}
+	gomock.InOrder(
+		suite.mockProductRepo.EXPECT().Stats(suite.ctx, entity.ProductFilter{}).Return(&entity.ListStats{Count: 2}, nil).Times(1),
+		suite.mockProductRepo.EXPECT().FindAfter(suite.ctx, entity.ProductFilter{}, "", 2).Return([]entity.Product{
+			{ID: "p1", Price: utils.SetPtr(9.99), Version: 3},
+			{ID: "p2", Price: utils.SetPtr(20.0), Version: 1},
+		}, nil).Times(1),
+		suite.mockProductService.EXPECT().
+			Patch(suite.ctx, "p1", entity.ProductPatch{Price: utils.SetPtr(10.99), Version: 3}).
+			Return(&entity.Product{ID: "p1"}, nil).
+			Times(1),
+		suite.mockProductService.EXPECT().
+			Patch(suite.ctx, "p2", entity.ProductPatch{Price: utils.SetPtr(22.0), Version: 1}).
+			Return(nil, apperr.ErrPreconditionFailed).
+			Times(1),
+		suite.mockProductRepo.EXPECT().FindAfter(suite.ctx, entity.ProductFilter{}, "p2", 2).Return(nil, nil).Times(1),
+	)
+
+	result, err := NewPriceChangeRunner(suite.mockTxManager, suite.mockProductRepo, suite.mockProductService, 2).
+		Run(suite.ctx, suite.job(params, ""), suite.report)
+
+	suite.NoError(err)
+	suite.Equal(PriceChangeResult{
+		Changed: 1,
+		Failed:  1,
+		Errors: []entity.BatchItemError{{
+			Index:     1,
+			ID:        "p2",
+			ErrorCode: apperr.ErrPreconditionFailed.Code,
+			Message:   apperr.ErrPreconditionFailed.Error(),
+		}},
+	}, result)
+	suite.Equal([]report{
+		{entity.JobProgress{Done: 1, Total: 2}, `{"afterId":"p1","done":1,"changed":1,"failed":0}`},
+	}, suite.reports)
+}
+
+func (suite *ProductRunnersTestSuite) TestPriceChange_ResumesAfterCheckpoint() {
+	params := PriceChangeParams{Percent: -50}
+	gomock.InOrder(
+		suite.mockProductRepo.EXPECT().Stats(suite.ctx, entity.ProductFilter{}).Return(&entity.ListStats{Count: 2}, nil).Times(1),
+		suite.mockProductRepo.EXPECT().FindAfter(suite.ctx, entity.ProductFilter{}, "p1", 2).Return([]entity.Product{
+			{ID: "p2", Price: utils.SetPtr(20.0), Version: 1},
+		}, nil).Times(1),
+		suite.mockProductService.EXPECT().
+			Patch(suite.ctx, "p2", entity.ProductPatch{Price: utils.SetPtr(10.0), Version: 1}).
+			Return(&entity.Product{ID: "p2"}, nil).
+			Times(1),
+	)
+
+	job := suite.job(params, `{"afterId":"p1","done":1,"changed":1,"failed":0}`)
+	result, err := NewPriceChangeRunner(suite.mockTxManager, suite.mockProductRepo, suite.mockProductService, 2).
+		Run(suite.ctx, job, suite.report)
+
+	suite.NoError(err)
+	suite.Equal(PriceChangeResult{Changed: 2}, result)
+	suite.Equal([]report{
+		{entity.JobProgress{Done: 2, Total: 2}, `{"afterId":"p2","done":2,"changed":2,"failed":0}`},
+	}, suite.reports)
+}
+
+func (suite *ProductRunnersTestSuite) TestPriceChange_InvalidPercent() {
+	result, err := NewPriceChangeRunner(suite.mockTxManager, suite.mockProductRepo, suite.mockProductService, 2).
+		Run(suite.ctx, suite.job(PriceChangeParams{Percent: -100}, ""), suite.report)
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func TestProductRunnersTestSuite(t *testing.T) {
+	suite.Run(t, new(ProductRunnersTestSuite))
+}
@@ -0,0 +1,93 @@
+package lot
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type lotService struct {
+	lotRepo     repository.LotRepository
+	productRepo repository.ProductRepository
+}
+
+//go:generate mockgen -source=lot.go -destination=mocks/mock_lot.go -package=mocks
+type LotService interface {
+	Receive(ctx context.Context, productID string, lot entity.Lot) (string, error)
+	GetByProductID(ctx context.Context, productID string) ([]entity.Lot, error)
+	GetExpiring(ctx context.Context, within time.Duration, pagination entity.Pagination) ([]entity.Lot, error)
+	Consume(ctx context.Context, productID string, quantity int) ([]entity.LotConsumption, error)
+	QuarantineExpired(ctx context.Context) (int64, error)
+}
+
+func NewLotService(lotRepo repository.LotRepository, productRepo repository.ProductRepository) LotService {
+	return &lotService{
+		lotRepo:     lotRepo,
+		productRepo: productRepo,
+	}
+}
+
+func (l lotService) Receive(ctx context.Context, productID string, lot entity.Lot) (string, error) {
+	if lot.LotNumber == "" {
+		return "", apperr.ErrInvalidArgument.WithMessage("lot number is required")
+	}
+	if lot.Quantity <= 0 {
+		return "", apperr.ErrInvalidArgument.WithMessage("quantity must be greater than zero")
+	}
+	if lot.ReceivedAt.IsZero() {
+		lot.ReceivedAt = time.Now()
+	}
+	if !lot.ExpiresAt.After(lot.ReceivedAt) {
+		return "", apperr.ErrInvalidArgument.WithMessage("expiry date must be after received date")
+	}
+
+	_, err := l.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return "", err
+	}
+
+	lot.ProductID = productID
+	lot.Status = entity.LotStatusActive
+	return l.lotRepo.Create(ctx, &lot)
+}
+
+func (l lotService) GetByProductID(ctx context.Context, productID string) ([]entity.Lot, error) {
+	_, err := l.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.lotRepo.FindByProductID(ctx, productID)
+}
+
+func (l lotService) GetExpiring(ctx context.Context, within time.Duration, pagination entity.Pagination) ([]entity.Lot, error) {
+	if within < 0 {
+		return nil, apperr.ErrInvalidArgument.WithMessage("within cannot be negative")
+	}
+	if pagination.Limit <= 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Limit > 100 {
+		pagination.Limit = 100
+	}
+
+	return l.lotRepo.FindExpiring(ctx, entity.ExpiringLotsFilter{
+		Before:     time.Now().Add(within),
+		Pagination: pagination,
+	})
+}
+
+func (l lotService) Consume(ctx context.Context, productID string, quantity int) ([]entity.LotConsumption, error) {
+	if quantity <= 0 {
+		return nil, apperr.ErrInvalidArgument.WithMessage("quantity must be greater than zero")
+	}
+
+	return l.lotRepo.Consume(ctx, productID, quantity, time.Now())
+}
+
+func (l lotService) QuarantineExpired(ctx context.Context) (int64, error) {
+	return l.lotRepo.QuarantineExpired(ctx, time.Now())
+}
@@ -0,0 +1,179 @@
+package lot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type LotServiceTestSuite struct {
+	suite.Suite
+	mockCtrl        *gomock.Controller
+	mockLotRepo     *mocks.MockLotRepository
+	mockProductRepo *mocks.MockProductRepository
+	service         LotService
+	ctx             context.Context
+}
+
+func (suite *LotServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockLotRepo = mocks.NewMockLotRepository(suite.mockCtrl)
+	suite.mockProductRepo = mocks.NewMockProductRepository(suite.mockCtrl)
+	suite.service = NewLotService(suite.mockLotRepo, suite.mockProductRepo)
+	suite.ctx = context.Background()
+}
+
+func (suite *LotServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *LotServiceTestSuite) TestReceive_Success() {
+
+	productID := "product-123"
+	receivedAt := time.Now()
+	lot := entity.Lot{
+		LotNumber:  "LOT-001",
+		Quantity:   50,
+		ReceivedAt: receivedAt,
+		ExpiresAt:  receivedAt.Add(90 * 24 * time.Hour),
+	}
+	expectedLot := lot
+	expectedLot.ProductID = productID
+	expectedLot.Status = entity.LotStatusActive
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, productID).
+		Return(&entity.Product{ID: productID}, nil).
+		Times(1)
+
+	suite.mockLotRepo.EXPECT().
+		Create(suite.ctx, &expectedLot).
+		Return("lot-123", nil).
+		Times(1)
+
+	id, err := suite.service.Receive(suite.ctx, productID, lot)
+
+	suite.NoError(err)
+	suite.Equal("lot-123", id)
+}
+
+func (suite *LotServiceTestSuite) TestReceive_ExpiryBeforeReceived() {
+
+	receivedAt := time.Now()
+	lot := entity.Lot{
+		LotNumber:  "LOT-001",
+		Quantity:   50,
+		ReceivedAt: receivedAt,
+		ExpiresAt:  receivedAt.Add(-time.Hour),
+	}
+
+	id, err := suite.service.Receive(suite.ctx, "product-123", lot)
+
+	suite.Error(err)
+	suite.Equal("", id)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *LotServiceTestSuite) TestReceive_ProductNotFound() {
+
+	productID := "non-existent"
+	lot := entity.Lot{
+		LotNumber: "LOT-001",
+		Quantity:  50,
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	}
+	expectedErr := apperr.ErrNotFound.WithMessage("product not found")
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, productID).
+		Return(nil, expectedErr).
+		Times(1)
+
+	id, err := suite.service.Receive(suite.ctx, productID, lot)
+
+	suite.Error(err)
+	suite.Equal("", id)
+	suite.Equal(expectedErr, err)
+}
+
+func (suite *LotServiceTestSuite) TestGetExpiring_DefaultLimit() {
+
+	within := 30 * 24 * time.Hour
+	before := time.Now().Add(within)
+
+	suite.mockLotRepo.EXPECT().
+		FindExpiring(suite.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, filter entity.ExpiringLotsFilter) ([]entity.Lot, error) {
+			suite.Equal(10, filter.Limit)
+			suite.WithinDuration(before, filter.Before, time.Second)
+			return []entity.Lot{}, nil
+		}).
+		Times(1)
+
+	lots, err := suite.service.GetExpiring(suite.ctx, within, entity.Pagination{})
+
+	suite.NoError(err)
+	suite.Empty(lots)
+}
+
+func (suite *LotServiceTestSuite) TestGetExpiring_NegativeWindow() {
+
+	lots, err := suite.service.GetExpiring(suite.ctx, -time.Hour, entity.Pagination{})
+
+	suite.Error(err)
+	suite.Nil(lots)
+}
+
+func (suite *LotServiceTestSuite) TestConsume_Success() {
+
+	productID := "product-123"
+	expected := []entity.LotConsumption{
+		{LotID: "lot-1", LotNumber: "LOT-001", Quantity: 5},
+		{LotID: "lot-2", LotNumber: "LOT-002", Quantity: 3},
+	}
+
+	suite.mockLotRepo.EXPECT().
+		Consume(suite.ctx, productID, 8, gomock.Any()).
+		Return(expected, nil).
+		Times(1)
+
+	consumptions, err := suite.service.Consume(suite.ctx, productID, 8)
+
+	suite.NoError(err)
+	suite.Equal(expected, consumptions)
+}
+
+func (suite *LotServiceTestSuite) TestConsume_InvalidQuantity() {
+
+	consumptions, err := suite.service.Consume(suite.ctx, "product-123", 0)
+
+	suite.Error(err)
+	suite.Nil(consumptions)
+}
+
+func (suite *LotServiceTestSuite) TestQuarantineExpired_RepositoryError() {
+
+	expectedErr := errors.New("repository error")
+
+	suite.mockLotRepo.EXPECT().
+		QuarantineExpired(suite.ctx, gomock.Any()).
+		Return(int64(0), expectedErr).
+		Times(1)
+
+	count, err := suite.service.QuarantineExpired(suite.ctx)
+
+	suite.Error(err)
+	suite.Equal(int64(0), count)
+	suite.Equal(expectedErr, err)
+}
+
+func TestLotServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(LotServiceTestSuite))
+}
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lot.go
+//
+// Generated by this command:
+//
+//	mockgen -source=lot.go -destination=mocks/mock_lot.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLotService is a mock of LotService interface.
+type MockLotService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLotServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockLotServiceMockRecorder is the mock recorder for MockLotService.
+type MockLotServiceMockRecorder struct {
+	mock *MockLotService
+}
+
+// NewMockLotService creates a new mock instance.
+func NewMockLotService(ctrl *gomock.Controller) *MockLotService {
+	mock := &MockLotService{ctrl: ctrl}
+	mock.recorder = &MockLotServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLotService) EXPECT() *MockLotServiceMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockLotService) Consume(ctx context.Context, productID string, quantity int) ([]entity.LotConsumption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, productID, quantity)
+	ret0, _ := ret[0].([]entity.LotConsumption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockLotServiceMockRecorder) Consume(ctx, productID, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockLotService)(nil).Consume), ctx, productID, quantity)
+}
+
+// GetByProductID mocks base method.
+func (m *MockLotService) GetByProductID(ctx context.Context, productID string) ([]entity.Lot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID)
+	ret0, _ := ret[0].([]entity.Lot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockLotServiceMockRecorder) GetByProductID(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockLotService)(nil).GetByProductID), ctx, productID)
+}
+
+// GetExpiring mocks base method.
+func (m *MockLotService) GetExpiring(ctx context.Context, within time.Duration, pagination entity.Pagination) ([]entity.Lot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiring", ctx, within, pagination)
+	ret0, _ := ret[0].([]entity.Lot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiring indicates an expected call of GetExpiring.
+func (mr *MockLotServiceMockRecorder) GetExpiring(ctx, within, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiring", reflect.TypeOf((*MockLotService)(nil).GetExpiring), ctx, within, pagination)
+}
+
+// QuarantineExpired mocks base method.
+func (m *MockLotService) QuarantineExpired(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantineExpired", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuarantineExpired indicates an expected call of QuarantineExpired.
+func (mr *MockLotServiceMockRecorder) QuarantineExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantineExpired", reflect.TypeOf((*MockLotService)(nil).QuarantineExpired), ctx)
+}
+
+// Receive mocks base method.
+func (m *MockLotService) Receive(ctx context.Context, productID string, lot entity.Lot) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, productID, lot)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockLotServiceMockRecorder) Receive(ctx, productID, lot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockLotService)(nil).Receive), ctx, productID, lot)
+}
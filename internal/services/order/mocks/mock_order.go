@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: order.go
+//
+// Generated by this command:
+//
+//	mockgen -source=order.go -destination=mocks/mock_order.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderService is a mock of OrderService interface.
+type MockOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderServiceMockRecorder is the mock recorder for MockOrderService.
+type MockOrderServiceMockRecorder struct {
+	mock *MockOrderService
+}
+
+// NewMockOrderService creates a new mock instance.
+func NewMockOrderService(ctrl *gomock.Controller) *MockOrderService {
+	mock := &MockOrderService{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockOrderService) Cancel(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockOrderServiceMockRecorder) Cancel(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockOrderService)(nil).Cancel), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockOrderService) GetAll(ctx context.Context, filter entity.OrdersFilter) ([]entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
+	ret0, _ := ret[0].([]entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockOrderServiceMockRecorder) GetAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockOrderService)(nil).GetAll), ctx, filter)
+}
+
+// GetByID mocks base method.
+func (m *MockOrderService) GetByID(ctx context.Context, id string) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOrderServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrderService)(nil).GetByID), ctx, id)
+}
+
+// Pay mocks base method.
+func (m *MockOrderService) Pay(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockOrderServiceMockRecorder) Pay(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockOrderService)(nil).Pay), ctx, id)
+}
+
+// Place mocks base method.
+func (m *MockOrderService) Place(ctx context.Context, order entity.Order) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Place", ctx, order)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Place indicates an expected call of Place.
+func (mr *MockOrderServiceMockRecorder) Place(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Place", reflect.TypeOf((*MockOrderService)(nil).Place), ctx, order)
+}
+
+// Ship mocks base method.
+func (m *MockOrderService) Ship(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ship", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ship indicates an expected call of Ship.
+func (mr *MockOrderServiceMockRecorder) Ship(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ship", reflect.TypeOf((*MockOrderService)(nil).Ship), ctx, id)
+}
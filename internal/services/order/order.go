@@ -0,0 +1,169 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/pkg/utils"
+)
+
+type orderService struct {
+	txManager   repository.TxManager
+	orderRepo   repository.OrderRepository
+	productRepo repository.ProductRepository
+}
+
+//go:generate mockgen -source=order.go -destination=mocks/mock_order.go -package=mocks
+type OrderService interface {
+	Place(ctx context.Context, order entity.Order) (*entity.Order, error)
+	GetByID(ctx context.Context, id string) (*entity.Order, error)
+	GetAll(ctx context.Context, filter entity.OrdersFilter) ([]entity.Order, error)
+	Pay(ctx context.Context, id string) error
+	Ship(ctx context.Context, id string) error
+	Cancel(ctx context.Context, id string) error
+}
+
+func NewOrderService(txManager repository.TxManager, orderRepo repository.OrderRepository, productRepo repository.ProductRepository) OrderService {
+	return &orderService{
+		txManager:   txManager,
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+	}
+}
+
+// Place snapshots the ordered products, takes their stock and stores the order. The stock and
+// the order are written in one transaction, so an order that cannot be stored takes no stock.
+func (o orderService) Place(ctx context.Context, order entity.Order) (*entity.Order, error) {
+	if len(order.Lines) == 0 {
+		return nil, apperr.ErrInvalidArgument.WithMessage("order must have at least one line")
+	}
+
+	productIDs := make([]string, 0, len(order.Lines))
+	for i, line := range order.Lines {
+		if line.Quantity <= 0 {
+			return nil, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("line %d: quantity must be greater than zero", i))
+		}
+		productIDs = append(productIDs, line.ProductID)
+	}
+
+	products, err := o.productRepo.FindByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]entity.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	var missing []entity.StockShortage
+	adjustments := make([]entity.StockAdjustment, 0, len(order.Lines))
+	for i, line := range order.Lines {
+		product, ok := byID[line.ProductID]
+		if !ok {
+			missing = append(missing, entity.StockShortage{
+				Index:     i,
+				ProductID: line.ProductID,
+				Requested: line.Quantity,
+				Reason:    entity.StockShortageNotFound,
+			})
+			continue
+		}
+
+		order.Lines[i].ProductName = product.Name
+		order.Lines[i].SKU = product.SKU
+		order.Lines[i].UnitPrice = utils.GetValue(product.Price)
+		adjustments = append(adjustments, entity.StockAdjustment{ProductID: line.ProductID, Delta: -line.Quantity})
+	}
+	if len(missing) > 0 {
+		return nil, apperr.ErrNotFound.WithMessage("one or more ordered products do not exist").WithDetails(missing)
+	}
+
+	order.Status = entity.OrderStatusPending
+	order.CalculateTotals()
+
+	var placed *entity.Order
+	err = o.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := o.productRepo.AdjustStock(ctx, adjustments)
+		if err != nil {
+			return err
+		}
+
+		id, err := o.orderRepo.Create(ctx, &order)
+		if err != nil {
+			return err
+		}
+
+		placed, err = o.orderRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return placed, nil
+}
+
+func (o orderService) GetByID(ctx context.Context, id string) (*entity.Order, error) {
+	return o.orderRepo.FindByID(ctx, id)
+}
+
+func (o orderService) GetAll(ctx context.Context, filter entity.OrdersFilter) ([]entity.Order, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	return o.orderRepo.FindAll(ctx, filter)
+}
+
+func (o orderService) Pay(ctx context.Context, id string) error {
+	_, err := o.transition(ctx, id, entity.OrderStatusPaid)
+	return err
+}
+
+func (o orderService) Ship(ctx context.Context, id string) error {
+	_, err := o.transition(ctx, id, entity.OrderStatusShipped)
+	return err
+}
+
+// Cancel moves the order to cancelled and returns its stock in one transaction. The status change
+// is made first so that two concurrent cancels cannot both restock.
+func (o orderService) Cancel(ctx context.Context, id string) error {
+	return o.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		order, err := o.transition(ctx, id, entity.OrderStatusCancelled)
+		if err != nil {
+			return err
+		}
+
+		return o.productRepo.AdjustStock(ctx, restockAdjustments(order.Lines))
+	})
+}
+
+func (o orderService) transition(ctx context.Context, id string, to entity.OrderStatus) (*entity.Order, error) {
+	order, err := o.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.Status.CanTransitionTo(to) {
+		return nil, apperr.ErrConflict.WithMessage(fmt.Sprintf("cannot move order from %s to %s", order.Status, to))
+	}
+
+	err = o.orderRepo.UpdateStatus(ctx, id, order.Status, to)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func restockAdjustments(lines []entity.OrderLine) []entity.StockAdjustment {
+	adjustments := make([]entity.StockAdjustment, 0, len(lines))
+	for _, line := range lines {
+		adjustments = append(adjustments, entity.StockAdjustment{ProductID: line.ProductID, Delta: line.Quantity})
+	}
+	return adjustments
+}
@@ -0,0 +1,277 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type OrderServiceTestSuite struct {
+	suite.Suite
+	mockCtrl        *gomock.Controller
+	mockTxManager   *mocks.MockTxManager
+	mockOrderRepo   *mocks.MockOrderRepository
+	mockProductRepo *mocks.MockProductRepository
+	service         OrderService
+	ctx             context.Context
+}
+
+func (suite *OrderServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+	suite.mockOrderRepo = mocks.NewMockOrderRepository(suite.mockCtrl)
+	suite.mockProductRepo = mocks.NewMockProductRepository(suite.mockCtrl)
+	suite.service = NewOrderService(suite.mockTxManager, suite.mockOrderRepo, suite.mockProductRepo)
+
+	suite.mockTxManager.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	suite.ctx = context.Background()
+}
+
+func (suite *OrderServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *OrderServiceTestSuite) TestPlace_Success() {
+
+	order := entity.Order{
+		CustomerRef: "customer-1",
+		Lines: []entity.OrderLine{
+			{ProductID: "product-1", Quantity: 2},
+			{ProductID: "product-2", Quantity: 1},
+		},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{"product-1", "product-2"}).
+		Return([]entity.Product{
+			{ID: "product-1", Name: "Widget", SKU: "W-1", Price: utils.SetPtr(2.5)},
+			{ID: "product-2", Name: "Gadget", SKU: "G-1", Price: utils.SetPtr(10.0)},
+		}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		AdjustStock(suite.ctx, []entity.StockAdjustment{
+			{ProductID: "product-1", Delta: -2},
+			{ProductID: "product-2", Delta: -1},
+		}).
+		Return(nil).
+		Times(1)
+
+	suite.mockOrderRepo.EXPECT().
+		Create(suite.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, order *entity.Order) (string, error) {
+			suite.Equal(entity.OrderStatusPending, order.Status)
+			suite.Equal("Widget", order.Lines[0].ProductName)
+			suite.Equal("W-1", order.Lines[0].SKU)
+			suite.Equal(2.5, order.Lines[0].UnitPrice)
+			suite.Equal(5.0, order.Lines[0].LineTotal)
+			suite.Equal(3, order.TotalQuantity)
+			suite.Equal(15.0, order.TotalAmount)
+			return "order-123", nil
+		}).
+		Times(1)
+
+	suite.mockOrderRepo.EXPECT().
+		FindByID(suite.ctx, "order-123").
+		Return(&entity.Order{ID: "order-123", Status: entity.OrderStatusPending}, nil).
+		Times(1)
+
+	result, err := suite.service.Place(suite.ctx, order)
+
+	suite.NoError(err)
+	suite.Equal("order-123", result.ID)
+}
+
+func (suite *OrderServiceTestSuite) TestPlace_NoLines() {
+
+	result, err := suite.service.Place(suite.ctx, entity.Order{})
+
+	suite.Error(err)
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *OrderServiceTestSuite) TestPlace_ProductNotFound() {
+
+	order := entity.Order{
+		Lines: []entity.OrderLine{
+			{ProductID: "product-1", Quantity: 1},
+			{ProductID: "missing", Quantity: 3},
+		},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{"product-1", "missing"}).
+		Return([]entity.Product{{ID: "product-1", Price: utils.SetPtr(1.0)}}, nil).
+		Times(1)
+
+	result, err := suite.service.Place(suite.ctx, order)
+
+	suite.Error(err)
+	suite.Nil(result)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+
+	details, ok := apperr.GetDetails(err).([]entity.StockShortage)
+	suite.True(ok)
+	suite.Len(details, 1)
+	suite.Equal(1, details[0].Index)
+	suite.Equal(entity.StockShortageNotFound, details[0].Reason)
+}
+
+func (suite *OrderServiceTestSuite) TestPlace_InsufficientStock() {
+
+	order := entity.Order{
+		Lines: []entity.OrderLine{{ProductID: "product-1", Quantity: 5}},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{"product-1"}).
+		Return([]entity.Product{{ID: "product-1", Price: utils.SetPtr(1.0)}}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		AdjustStock(suite.ctx, gomock.Any()).
+		Return(apperr.ErrInsufficientStock).
+		Times(1)
+
+	result, err := suite.service.Place(suite.ctx, order)
+
+	suite.Error(err)
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInsufficientStock.Code, apperr.GetCode(err))
+}
+
+func (suite *OrderServiceTestSuite) TestPlace_CreateFails() {
+
+	order := entity.Order{
+		Lines: []entity.OrderLine{{ProductID: "product-1", Quantity: 5}},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{"product-1"}).
+		Return([]entity.Product{{ID: "product-1", Price: utils.SetPtr(1.0)}}, nil).
+		Times(1)
+
+	gomock.InOrder(
+		suite.mockProductRepo.EXPECT().
+			AdjustStock(suite.ctx, []entity.StockAdjustment{{ProductID: "product-1", Delta: -5}}).
+			Return(nil),
+		suite.mockOrderRepo.EXPECT().
+			Create(suite.ctx, gomock.Any()).
+			Return("", apperr.ErrInternal),
+	)
+
+	result, err := suite.service.Place(suite.ctx, order)
+
+	suite.Error(err)
+	suite.Nil(result)
+}
+
+func (suite *OrderServiceTestSuite) TestPay_Success() {
+
+	suite.mockOrderRepo.EXPECT().
+		FindByID(suite.ctx, "order-123").
+		Return(&entity.Order{ID: "order-123", Status: entity.OrderStatusPending}, nil).
+		Times(1)
+
+	suite.mockOrderRepo.EXPECT().
+		UpdateStatus(suite.ctx, "order-123", entity.OrderStatusPending, entity.OrderStatusPaid).
+		Return(nil).
+		Times(1)
+
+	err := suite.service.Pay(suite.ctx, "order-123")
+
+	suite.NoError(err)
+}
+
+func (suite *OrderServiceTestSuite) TestShip_NotPaid() {
+
+	suite.mockOrderRepo.EXPECT().
+		FindByID(suite.ctx, "order-123").
+		Return(&entity.Order{ID: "order-123", Status: entity.OrderStatusPending}, nil).
+		Times(1)
+
+	err := suite.service.Ship(suite.ctx, "order-123")
+
+	suite.Error(err)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func (suite *OrderServiceTestSuite) TestCancel_Restocks() {
+
+	suite.mockOrderRepo.EXPECT().
+		FindByID(suite.ctx, "order-123").
+		Return(&entity.Order{
+			ID:     "order-123",
+			Status: entity.OrderStatusPaid,
+			Lines:  []entity.OrderLine{{ProductID: "product-1", Quantity: 4}},
+		}, nil).
+		Times(1)
+
+	suite.mockOrderRepo.EXPECT().
+		UpdateStatus(suite.ctx, "order-123", entity.OrderStatusPaid, entity.OrderStatusCancelled).
+		Return(nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		AdjustStock(suite.ctx, []entity.StockAdjustment{{ProductID: "product-1", Delta: 4}}).
+		Return(nil).
+		Times(1)
+
+	err := suite.service.Cancel(suite.ctx, "order-123")
+
+	suite.NoError(err)
+}
+
+func (suite *OrderServiceTestSuite) TestCancel_Shipped() {
+
+	suite.mockOrderRepo.EXPECT().
+		FindByID(suite.ctx, "order-123").
+		Return(&entity.Order{ID: "order-123", Status: entity.OrderStatusShipped}, nil).
+		Times(1)
+
+	err := suite.service.Cancel(suite.ctx, "order-123")
+
+	suite.Error(err)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func (suite *OrderServiceTestSuite) TestCancel_RestockFails() {
+
+	suite.mockOrderRepo.EXPECT().
+		FindByID(suite.ctx, "order-123").
+		Return(&entity.Order{
+			ID:     "order-123",
+			Status: entity.OrderStatusPending,
+			Lines:  []entity.OrderLine{{ProductID: "product-1", Quantity: 4}},
+		}, nil).
+		Times(1)
+
+	gomock.InOrder(
+		suite.mockOrderRepo.EXPECT().
+			UpdateStatus(suite.ctx, "order-123", entity.OrderStatusPending, entity.OrderStatusCancelled).
+			Return(nil),
+		suite.mockProductRepo.EXPECT().
+			AdjustStock(suite.ctx, gomock.Any()).
+			Return(apperr.ErrInternal),
+	)
+
+	err := suite.service.Cancel(suite.ctx, "order-123")
+
+	suite.Error(err)
+}
+
+func TestOrderServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(OrderServiceTestSuite))
+}
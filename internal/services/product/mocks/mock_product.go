@@ -41,11 +41,71 @@ func (m *MockProductService) EXPECT() *MockProductServiceMockRecorder {
 	return m.recorder
 }
 
+// BatchCreate mocks base method.
+func (m *MockProductService) BatchCreate(ctx context.Context, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", ctx, products, mode)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockProductServiceMockRecorder) BatchCreate(ctx, products, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockProductService)(nil).BatchCreate), ctx, products, mode)
+}
+
+// BatchDelete mocks base method.
+func (m *MockProductService) BatchDelete(ctx context.Context, refs []entity.VersionedID, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDelete", ctx, refs, mode)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDelete indicates an expected call of BatchDelete.
+func (mr *MockProductServiceMockRecorder) BatchDelete(ctx, refs, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockProductService)(nil).BatchDelete), ctx, refs, mode)
+}
+
+// BatchUpdate mocks base method.
+func (m *MockProductService) BatchUpdate(ctx context.Context, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpdate", ctx, products, mode)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchUpdate indicates an expected call of BatchUpdate.
+func (mr *MockProductServiceMockRecorder) BatchUpdate(ctx, products, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdate", reflect.TypeOf((*MockProductService)(nil).BatchUpdate), ctx, products, mode)
+}
+
+// BatchUpsert mocks base method.
+func (m *MockProductService) BatchUpsert(ctx context.Context, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpsert", ctx, products, mode)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchUpsert indicates an expected call of BatchUpsert.
+func (mr *MockProductServiceMockRecorder) BatchUpsert(ctx, products, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpsert", reflect.TypeOf((*MockProductService)(nil).BatchUpsert), ctx, products, mode)
+}
+
 // Create mocks base method.
-func (m *MockProductService) Create(ctx context.Context, product entity.Product) (string, error) {
+func (m *MockProductService) Create(ctx context.Context, product entity.Product) (*entity.Product, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", ctx, product)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*entity.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -57,17 +117,17 @@ func (mr *MockProductServiceMockRecorder) Create(ctx, product any) *gomock.Call
 }
 
 // Delete mocks base method.
-func (m *MockProductService) Delete(ctx context.Context, id string) error {
+func (m *MockProductService) Delete(ctx context.Context, id string, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockProductServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) Delete(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductService)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductService)(nil).Delete), ctx, id, version)
 }
 
 // GetAll mocks base method.
@@ -100,16 +160,183 @@ func (mr *MockProductServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProductService)(nil).GetByID), ctx, id)
 }
 
-// Update mocks base method.
-func (m *MockProductService) Update(ctx context.Context, id string, product entity.Product) error {
+// GetByIDs mocks base method.
+func (m *MockProductService) GetByIDs(ctx context.Context, ids []string) ([]entity.Product, []string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, id, product)
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]entity.Product)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockProductServiceMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockProductService)(nil).GetByIDs), ctx, ids)
+}
+
+// GetBySKU mocks base method.
+func (m *MockProductService) GetBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySKU", ctx, sku)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySKU indicates an expected call of GetBySKU.
+func (mr *MockProductServiceMockRecorder) GetBySKU(ctx, sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKU", reflect.TypeOf((*MockProductService)(nil).GetBySKU), ctx, sku)
+}
+
+// GetListStats mocks base method.
+func (m *MockProductService) GetListStats(ctx context.Context, filter entity.ProductFilter) (*entity.ListStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListStats", ctx, filter)
+	ret0, _ := ret[0].(*entity.ListStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListStats indicates an expected call of GetListStats.
+func (mr *MockProductServiceMockRecorder) GetListStats(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListStats", reflect.TypeOf((*MockProductService)(nil).GetListStats), ctx, filter)
+}
+
+// GetRevision mocks base method.
+func (m *MockProductService) GetRevision(ctx context.Context, id string, number int) (*entity.ProductRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, id, number)
+	ret0, _ := ret[0].(*entity.ProductRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockProductServiceMockRecorder) GetRevision(ctx, id, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockProductService)(nil).GetRevision), ctx, id, number)
+}
+
+// ListRevisions mocks base method.
+func (m *MockProductService) ListRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, id, pagination)
+	ret0, _ := ret[0].([]entity.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockProductServiceMockRecorder) ListRevisions(ctx, id, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockProductService)(nil).ListRevisions), ctx, id, pagination)
+}
+
+// ListTrash mocks base method.
+func (m *MockProductService) ListTrash(ctx context.Context, pagination entity.Pagination) ([]entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, pagination)
+	ret0, _ := ret[0].([]entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockProductServiceMockRecorder) ListTrash(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockProductService)(nil).ListTrash), ctx, pagination)
+}
+
+// Patch mocks base method.
+func (m *MockProductService) Patch(ctx context.Context, id string, patch entity.ProductPatch) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, patch)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockProductServiceMockRecorder) Patch(ctx, id, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockProductService)(nil).Patch), ctx, id, patch)
+}
+
+// Purge mocks base method.
+func (m *MockProductService) Purge(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Purge indicates an expected call of Purge.
+func (mr *MockProductServiceMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockProductService)(nil).Purge), ctx, id)
+}
+
+// Restore mocks base method.
+func (m *MockProductService) Restore(ctx context.Context, id, categoryID string) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, categoryID)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockProductServiceMockRecorder) Restore(ctx, id, categoryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProductService)(nil).Restore), ctx, id, categoryID)
+}
+
+// Revert mocks base method.
+func (m *MockProductService) Revert(ctx context.Context, id string, number, version int) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, id, number, version)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockProductServiceMockRecorder) Revert(ctx, id, number, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockProductService)(nil).Revert), ctx, id, number, version)
+}
+
+// Update mocks base method.
+func (m *MockProductService) Update(ctx context.Context, id string, product entity.Product) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, product)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Update indicates an expected call of Update.
 func (mr *MockProductServiceMockRecorder) Update(ctx, id, product any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductService)(nil).Update), ctx, id, product)
 }
+
+// Upsert mocks base method.
+func (m *MockProductService) Upsert(ctx context.Context, product entity.Product) (*entity.Product, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, product)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockProductServiceMockRecorder) Upsert(ctx, product any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockProductService)(nil).Upsert), ctx, product)
+}
@@ -2,44 +2,82 @@ package product
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/sirawong/crud-arise/internal/domain/entity"
 	"github.com/sirawong/crud-arise/internal/domain/repository"
 	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/internal/services/batch"
+	"github.com/sirawong/crud-arise/pkg/utils"
 )
 
 type productService struct {
-	productRepo  repository.ProductRepository
-	categoryRepo repository.CategoryRepository
+	txManager     repository.TxManager
+	productRepo   repository.ProductRepository
+	categoryRepo  repository.CategoryRepository
+	maxBatchItems int
 }
 
 //go:generate mockgen -source=product.go -destination=mocks/mock_product.go -package=mocks
 type ProductService interface {
-	Create(ctx context.Context, product entity.Product) (string, error)
-	Update(ctx context.Context, id string, product entity.Product) error
+	Create(ctx context.Context, product entity.Product) (*entity.Product, error)
+	Update(ctx context.Context, id string, product entity.Product) (*entity.Product, error)
+	Patch(ctx context.Context, id string, patch entity.ProductPatch) (*entity.Product, error)
+	Upsert(ctx context.Context, product entity.Product) (*entity.Product, bool, error)
 	GetByID(ctx context.Context, id string) (*entity.Product, error)
+	GetByIDs(ctx context.Context, ids []string) ([]entity.Product, []string, error)
+	GetBySKU(ctx context.Context, sku string) (*entity.Product, error)
 	GetAll(ctx context.Context, filter entity.ProductFilter) ([]entity.Product, error)
-	Delete(ctx context.Context, id string) error
+	GetListStats(ctx context.Context, filter entity.ProductFilter) (*entity.ListStats, error)
+	Delete(ctx context.Context, id string, version int) error
+	BatchCreate(ctx context.Context, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error)
+	BatchUpdate(ctx context.Context, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error)
+	BatchDelete(ctx context.Context, refs []entity.VersionedID, mode entity.BatchMode) ([]entity.BatchResult, error)
+	BatchUpsert(ctx context.Context, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error)
+	ListTrash(ctx context.Context, pagination entity.Pagination) ([]entity.Product, error)
+	Restore(ctx context.Context, id, categoryID string) (*entity.Product, error)
+	Purge(ctx context.Context, id string) error
+	ListRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error)
+	GetRevision(ctx context.Context, id string, number int) (*entity.ProductRevision, error)
+	Revert(ctx context.Context, id string, number, version int) (*entity.Product, error)
 }
 
-func NewProductService(productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository) ProductService {
+func NewProductService(
+	txManager repository.TxManager,
+	productRepo repository.ProductRepository,
+	categoryRepo repository.CategoryRepository,
+	maxBatchItems int,
+) ProductService {
 	return &productService{
-		productRepo:  productRepo,
-		categoryRepo: categoryRepo,
+		txManager:     txManager,
+		productRepo:   productRepo,
+		categoryRepo:  categoryRepo,
+		maxBatchItems: maxBatchItems,
 	}
 }
 
-func (p productService) Create(ctx context.Context, product entity.Product) (string, error) {
-	err := p.validateCategory(ctx, product.CategoryID)
-	if err != nil {
-		return "", err
-	}
+// Create stores the product and returns it as stored.
+func (p productService) Create(ctx context.Context, product entity.Product) (*entity.Product, error) {
+	var created *entity.Product
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := p.validateCategory(ctx, product.CategoryID)
+		if err != nil {
+			return err
+		}
+
+		id, err := p.productRepo.Create(ctx, &product)
+		if err != nil {
+			return err
+		}
 
-	id, err := p.productRepo.Create(ctx, &product)
+		created, err = p.productRepo.FindByID(ctx, id)
+		return err
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return id, nil
+	return created, nil
 }
 
 func (p productService) validateCategory(ctx context.Context, id string) error {
@@ -51,16 +89,137 @@ func (p productService) validateCategory(ctx context.Context, id string) error {
 	return nil
 }
 
-func (p productService) Update(ctx context.Context, id string, product entity.Product) error {
-	if product.CategoryID != "" {
+// Update replaces the product as a whole; fields left unset are cleared. It returns the product
+// as stored.
+func (p productService) Update(ctx context.Context, id string, product entity.Product) (*entity.Product, error) {
+	if product.CategoryID == "" {
+		return nil, apperr.ErrInvalidArgument.WithMessage("category id is required")
+	}
+
+	var updated *entity.Product
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
 		err := p.validateCategory(ctx, product.CategoryID)
 		if err != nil {
 			return err
 		}
+
+		product.ID = id
+		err = p.productRepo.Update(ctx, &product)
+		if err != nil {
+			return err
+		}
+
+		updated, err = p.productRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Upsert creates the product if its SKU is unknown and replaces it as a whole otherwise. It returns
+// the product as stored and whether it was created.
+func (p productService) Upsert(ctx context.Context, product entity.Product) (*entity.Product, bool, error) {
+	if product.SKU == "" {
+		return nil, false, apperr.ErrInvalidArgument.WithMessage("sku is required")
+	}
+	if product.CategoryID == "" {
+		return nil, false, apperr.ErrInvalidArgument.WithMessage("category id is required")
+	}
+
+	var upserted *entity.Product
+	var created bool
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := p.validateCategory(ctx, product.CategoryID)
+		if err != nil {
+			return err
+		}
+
+		results, err := p.productRepo.UpsertBySKU(ctx, []entity.Product{product})
+		if err != nil {
+			return err
+		}
+		if results[0].Err != nil {
+			return results[0].Err
+		}
+
+		created = results[0].Created
+		upserted, err = p.productRepo.FindByID(ctx, results[0].ID)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return upserted, created, nil
+}
+
+// Patch changes only the fields set in patch and returns the product as stored.
+func (p productService) Patch(ctx context.Context, id string, patch entity.ProductPatch) (*entity.Product, error) {
+	err := validatePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.IsEmpty() {
+		product, err := p.productRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return product, checkVersion(patch.Version, product.Version)
+	}
+
+	var patched *entity.Product
+	err = p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if patch.CategoryID != nil {
+			err := p.validateCategory(ctx, *patch.CategoryID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := p.productRepo.Patch(ctx, id, patch)
+		if err != nil {
+			return err
+		}
+
+		patched, err = p.productRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+func validatePatch(patch entity.ProductPatch) error {
+	if patch.Name != nil && *patch.Name == "" {
+		return apperr.ErrInvalidArgument.WithMessage("name cannot be empty")
+	}
+	if patch.SKU != nil && *patch.SKU == "" {
+		return apperr.ErrInvalidArgument.WithMessage("sku cannot be empty")
+	}
+	if patch.CategoryID != nil && *patch.CategoryID == "" {
+		return apperr.ErrInvalidArgument.WithMessage("category id cannot be empty")
 	}
+	if patch.Price != nil && *patch.Price < 0 {
+		return apperr.ErrInvalidArgument.WithMessage("price cannot be negative")
+	}
+	if patch.Stock != nil && *patch.Stock < 0 {
+		return apperr.ErrInvalidArgument.WithMessage("stock cannot be negative")
+	}
+	return nil
+}
 
-	product.ID = id
-	return p.productRepo.Update(ctx, &product)
+// checkVersion applies an expected version to a patch that changes nothing, so it still fails
+// when the client's copy is out of date.
+func checkVersion(expected, current int) error {
+	if expected > 0 && expected != current {
+		return apperr.ErrPreconditionFailed.
+			WithMessage("resource has been modified since it was read").
+			WithDetails(entity.VersionConflict{CurrentVersion: current})
+	}
+	return nil
 }
 
 func (p productService) GetByID(ctx context.Context, id string) (*entity.Product, error) {
@@ -68,6 +227,59 @@ func (p productService) GetByID(ctx context.Context, id string) (*entity.Product
 	return p.productRepo.FindByID(ctx, id)
 }
 
+// GetByIDs returns the products with the given IDs in the order they were asked for, each once,
+// and the IDs that match no product.
+func (p productService) GetByIDs(ctx context.Context, ids []string) ([]entity.Product, []string, error) {
+	if len(ids) == 0 {
+		return nil, nil, apperr.ErrInvalidArgument.WithMessage("ids are required")
+	}
+	if len(ids) > p.maxBatchItems {
+		return nil, nil, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("%d ids asked for, the limit is %d", len(ids), p.maxBatchItems))
+	}
+
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	// An ID that is not a UUID cannot match and would fail the whole query, so it is only reported.
+	lookup := make([]string, 0, len(unique))
+	for _, id := range unique {
+		if uuid.Validate(id) == nil {
+			lookup = append(lookup, id)
+		}
+	}
+
+	found, err := p.productRepo.FindByIDs(ctx, lookup)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[string]entity.Product, len(found))
+	for _, product := range found {
+		byID[product.ID] = product
+	}
+
+	products := make([]entity.Product, 0, len(found))
+	missing := make([]string, 0)
+	for _, id := range unique {
+		product, ok := byID[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		products = append(products, product)
+	}
+	return products, missing, nil
+}
+
+func (p productService) GetBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	return p.productRepo.FindBySKU(ctx, sku)
+}
+
 func (p productService) GetAll(ctx context.Context, filter entity.ProductFilter) ([]entity.Product, error) {
 	if filter.Limit <= 0 {
 		filter.Limit = 10
@@ -85,7 +297,448 @@ func (p productService) GetAll(ctx context.Context, filter entity.ProductFilter)
 	return p.productRepo.FindAll(ctx, filter)
 }
 
-func (p productService) Delete(ctx context.Context, id string) error {
+// GetListStats summarizes the products matching filter so callers can tell whether a list has
+// changed without loading it.
+func (p productService) GetListStats(ctx context.Context, filter entity.ProductFilter) (*entity.ListStats, error) {
+	return p.productRepo.Stats(ctx, filter)
+}
+
+func (p productService) Delete(ctx context.Context, id string, version int) error {
+
+	return p.productRepo.Delete(ctx, id, version)
+}
+
+// ListTrash lists deleted products, most recently deleted first.
+func (p productService) ListTrash(ctx context.Context, pagination entity.Pagination) ([]entity.Product, error) {
+	if pagination.Limit <= 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Limit > 100 {
+		pagination.Limit = 100
+	}
+
+	return p.productRepo.FindDeleted(ctx, pagination)
+}
+
+// Restore brings a deleted product back and returns it as stored. It fails with CONFLICT when a
+// live product has taken its SKU, or when its category is deleted and categoryID does not name
+// another one.
+func (p productService) Restore(ctx context.Context, id, categoryID string) (*entity.Product, error) {
+	var restored *entity.Product
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		product, err := p.productRepo.FindDeletedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		taken, err := p.productRepo.FindBySKU(ctx, product.SKU)
+		if err == nil {
+			return apperr.ErrConflict.WithMessage(fmt.Sprintf("sku %q is taken by product %s", product.SKU, taken.ID))
+		}
+		if apperr.GetCode(err) != apperr.ErrNotFound.Code {
+			return err
+		}
+
+		if categoryID == "" {
+			categoryID = product.CategoryID
+			err = p.validateCategory(ctx, categoryID)
+			if apperr.GetCode(err) == apperr.ErrNotFound.Code {
+				return apperr.ErrConflict.WithMessage(fmt.Sprintf(
+					"category %s is deleted; restore it first or choose another category", categoryID))
+			}
+		} else {
+			err = p.validateCategory(ctx, categoryID)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = p.productRepo.Restore(ctx, id, categoryID)
+		if err != nil {
+			return err
+		}
+
+		restored, err = p.productRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// Purge removes a deleted product for good.
+func (p productService) Purge(ctx context.Context, id string) error {
+	return p.productRepo.Purge(ctx, id)
+}
+
+// ListRevisions lists the revisions of a product, newest first. It fails with NOT_FOUND when the
+// product has none, which only happens once it is purged or if it never existed.
+func (p productService) ListRevisions(ctx context.Context, id string, pagination entity.Pagination) ([]entity.Revision, error) {
+	if pagination.Limit <= 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Limit > 100 {
+		pagination.Limit = 100
+	}
+
+	revisions, err := p.productRepo.FindRevisions(ctx, id, pagination)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 && pagination.Offset == 0 {
+		return nil, apperr.ErrNotFound.WithMessage(fmt.Sprintf("product %s has no revisions", id))
+	}
+	return revisions, nil
+}
+
+// GetRevision returns the product as it was stored in one of its revisions.
+func (p productService) GetRevision(ctx context.Context, id string, number int) (*entity.ProductRevision, error) {
+	return p.productRepo.FindRevision(ctx, id, number)
+}
+
+// Revert brings a live product's name, description, SKU, price, image and category back to
+// what they were in a revision, as a write of its own that is stored as the next revision. Stock
+// is left as it is, since it tracks goods on hand rather than content. A non-zero version makes
+// the revert conditional; a revert that would change nothing leaves the product untouched.
+func (p productService) Revert(ctx context.Context, id string, number, version int) (*entity.Product, error) {
+	var reverted *entity.Product
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		revision, err := p.productRepo.FindRevision(ctx, id, number)
+		if err != nil {
+			return err
+		}
+
+		current, err := p.productRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = checkVersion(version, current.Version)
+		if err != nil {
+			return err
+		}
+
+		product := revision.Product
+		if sameContent(product, *current) {
+			reverted = current
+			return nil
+		}
+
+		err = p.validateCategory(ctx, product.CategoryID)
+		if apperr.GetCode(err) == apperr.ErrNotFound.Code {
+			return apperr.ErrConflict.WithMessage(fmt.Sprintf(
+				"category %s of revision %d is deleted; restore it first", product.CategoryID, number))
+		}
+		if err != nil {
+			return err
+		}
+
+		product.ID = id
+		product.Stock = current.Stock
+		product.Version = current.Version
+		err = p.productRepo.Update(ctx, &product)
+		if err != nil {
+			return err
+		}
+
+		reverted, err = p.productRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reverted, nil
+}
+
+// sameContent tells whether a revert to a would leave b as it is.
+func sameContent(a, b entity.Product) bool {
+	return a.Name == b.Name &&
+		a.Description == b.Description &&
+		a.SKU == b.SKU &&
+		utils.GetValue(a.Price) == utils.GetValue(b.Price) &&
+		utils.GetValue(a.ImageURL) == utils.GetValue(b.ImageURL) &&
+		a.CategoryID == b.CategoryID
+}
+
+// BatchCreate stores products with multi-row inserts. In atomic mode nothing is stored unless
+// every product can be; in best-effort mode the failing products are reported and the rest are
+// stored.
+func (p productService) BatchCreate(ctx context.Context, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	err := batch.Check(len(products), p.maxBatchItems, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	results := batch.NewResults(len(products))
+	err = p.withinBatch(ctx, mode, func(ctx context.Context) error {
+		categories := make(map[string]error)
+		for i, product := range products {
+			results[i].Err = p.validateBatchItem(ctx, product, categories)
+		}
+		if mode == entity.BatchAtomic {
+			if err := batch.Failure(results); err != nil {
+				return err
+			}
+		}
+
+		pending := batch.Pending(results)
+		if len(pending) == 0 {
+			return nil
+		}
+		items := make([]entity.Product, 0, len(pending))
+		for _, i := range pending {
+			items = append(items, products[i])
+		}
+
+		var ids []string
+		err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			ids, err = p.productRepo.CreateMany(ctx, items)
+			return err
+		})
+		if err == nil {
+			for n, i := range pending {
+				results[i].ID = ids[n]
+			}
+			return nil
+		}
+		if mode == entity.BatchAtomic {
+			return err
+		}
+
+		// The multi-row insert was rolled back as a whole; find the offending products one by one.
+		for _, i := range pending {
+			results[i].ID, results[i].Err = p.productRepo.Create(ctx, &products[i])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// BatchUpdate replaces products with multi-row updates. Each product carries its ID and, to make
+// its update conditional, the version it was read at.
+func (p productService) BatchUpdate(ctx context.Context, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	err := batch.Check(len(products), p.maxBatchItems, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	results := batch.NewResults(len(products))
+	err = p.withinBatch(ctx, mode, func(ctx context.Context) error {
+		categories := make(map[string]error)
+		seen := make(map[string]bool)
+		for i, product := range products {
+			results[i].ID = product.ID
+			results[i].Err = batch.CheckID(product.ID, seen)
+			if results[i].Err == nil {
+				results[i].Err = p.validateBatchItem(ctx, product, categories)
+			}
+		}
+		if mode == entity.BatchAtomic {
+			if err := batch.Failure(results); err != nil {
+				return err
+			}
+		}
+
+		pending := batch.Pending(results)
+		if len(pending) == 0 {
+			return nil
+		}
+		items := make([]entity.Product, 0, len(pending))
+		for _, i := range pending {
+			items = append(items, products[i])
+		}
+
+		var errs []error
+		err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			errs, err = p.productRepo.UpdateMany(ctx, items)
+			return err
+		})
+		if err != nil && mode == entity.BatchAtomic {
+			return err
+		}
+		if err != nil {
+			// The multi-row update was rolled back as a whole; find the offending products one by one.
+			for _, i := range pending {
+				results[i].Err = p.productRepo.Update(ctx, &products[i])
+			}
+			return nil
+		}
+
+		for n, i := range pending {
+			results[i].Err = errs[n]
+		}
+		if mode == entity.BatchAtomic {
+			return batch.Failure(results)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// BatchDelete soft-deletes products with multi-row updates. A non-zero version makes the delete of
+// that product conditional.
+func (p productService) BatchDelete(ctx context.Context, refs []entity.VersionedID, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	err := batch.Check(len(refs), p.maxBatchItems, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	results := batch.NewResults(len(refs))
+	err = p.withinBatch(ctx, mode, func(ctx context.Context) error {
+		seen := make(map[string]bool)
+		for i, ref := range refs {
+			results[i].ID = ref.ID
+			results[i].Err = batch.CheckID(ref.ID, seen)
+		}
+		if mode == entity.BatchAtomic {
+			if err := batch.Failure(results); err != nil {
+				return err
+			}
+		}
+
+		pending := batch.Pending(results)
+		if len(pending) == 0 {
+			return nil
+		}
+		items := make([]entity.VersionedID, 0, len(pending))
+		for _, i := range pending {
+			items = append(items, refs[i])
+		}
 
-	return p.productRepo.Delete(ctx, id)
+		var errs []error
+		err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			errs, err = p.productRepo.DeleteMany(ctx, items)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for n, i := range pending {
+			results[i].Err = errs[n]
+		}
+		if mode == entity.BatchAtomic {
+			return batch.Failure(results)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// BatchUpsert creates or replaces products by SKU with multi-row upserts. Modes work as for
+// BatchCreate; each result tells whether its product was created.
+func (p productService) BatchUpsert(ctx context.Context, products []entity.Product, mode entity.BatchMode) ([]entity.BatchResult, error) {
+	err := batch.Check(len(products), p.maxBatchItems, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	results := batch.NewResults(len(products))
+	err = p.withinBatch(ctx, mode, func(ctx context.Context) error {
+		categories := make(map[string]error)
+		seen := make(map[string]bool)
+		for i, product := range products {
+			results[i].Err = p.validateBatchItem(ctx, product, categories)
+			key := utils.NormalizeKey(product.SKU)
+			if results[i].Err == nil && seen[key] {
+				// A single upsert statement cannot write the same row twice.
+				results[i].Err = apperr.ErrInvalidArgument.WithMessage("sku appears more than once in the batch")
+			}
+			seen[key] = true
+		}
+		if mode == entity.BatchAtomic {
+			if err := batch.Failure(results); err != nil {
+				return err
+			}
+		}
+
+		pending := batch.Pending(results)
+		if len(pending) == 0 {
+			return nil
+		}
+		items := make([]entity.Product, 0, len(pending))
+		for _, i := range pending {
+			items = append(items, products[i])
+		}
+
+		var upserted []entity.BatchResult
+		err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			upserted, err = p.productRepo.UpsertBySKU(ctx, items)
+			return err
+		})
+		if err != nil && mode == entity.BatchAtomic {
+			return err
+		}
+		if err != nil {
+			// The multi-row upsert was rolled back as a whole; find the offending products one by one.
+			for _, i := range pending {
+				one, err := p.productRepo.UpsertBySKU(ctx, products[i:i+1])
+				if err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].ID, results[i].Created, results[i].Err = one[0].ID, one[0].Created, one[0].Err
+			}
+			return nil
+		}
+
+		for n, i := range pending {
+			results[i].ID, results[i].Created, results[i].Err = upserted[n].ID, upserted[n].Created, upserted[n].Err
+		}
+		if mode == entity.BatchAtomic {
+			return batch.Failure(results)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// withinBatch runs fn in one transaction for an atomic batch. A best-effort batch runs without
+// one, so a failing item cannot undo the others.
+func (p productService) withinBatch(ctx context.Context, mode entity.BatchMode, fn func(ctx context.Context) error) error {
+	if mode == entity.BatchAtomic {
+		return p.txManager.WithinTx(ctx, fn)
+	}
+	return fn(ctx)
+}
+
+// validateBatchItem checks a product of a batch the way the request binding checks a single one,
+// and that its category exists. Category lookups are remembered in categories.
+func (p productService) validateBatchItem(ctx context.Context, product entity.Product, categories map[string]error) error {
+	switch {
+	case product.Name == "":
+		return apperr.ErrInvalidArgument.WithMessage("name is required")
+	case product.SKU == "":
+		return apperr.ErrInvalidArgument.WithMessage("sku is required")
+	case product.CategoryID == "":
+		return apperr.ErrInvalidArgument.WithMessage("category id is required")
+	case product.Price == nil || product.Stock == nil:
+		return apperr.ErrInvalidArgument.WithMessage("price and stock are required")
+	case *product.Price < 0:
+		return apperr.ErrInvalidArgument.WithMessage("price cannot be negative")
+	case *product.Stock < 0:
+		return apperr.ErrInvalidArgument.WithMessage("stock cannot be negative")
+	}
+
+	err, ok := categories[product.CategoryID]
+	if !ok {
+		err = p.validateCategory(ctx, product.CategoryID)
+		categories[product.CategoryID] = err
+	}
+	return err
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/sirawong/crud-arise/internal/domain/entity"
 	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
 	"github.com/sirawong/crud-arise/pkg/utils"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -16,6 +17,7 @@ import (
 type ProductServiceTestSuite struct {
 	suite.Suite
 	mockCtrl         *gomock.Controller
+	mockTxManager    *mocks.MockTxManager
 	mockProductRepo  *mocks.MockProductRepository
 	mockCategoryRepo *mocks.MockCategoryRepository
 	service          ProductService
@@ -24,9 +26,17 @@ type ProductServiceTestSuite struct {
 
 func (suite *ProductServiceTestSuite) SetupTest() {
 	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
 	suite.mockProductRepo = mocks.NewMockProductRepository(suite.mockCtrl)
 	suite.mockCategoryRepo = mocks.NewMockCategoryRepository(suite.mockCtrl)
-	suite.service = NewProductService(suite.mockProductRepo, suite.mockCategoryRepo)
+	suite.service = NewProductService(suite.mockTxManager, suite.mockProductRepo, suite.mockCategoryRepo, 3)
+
+	suite.mockTxManager.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
 	suite.ctx = context.Background()
 }
 
@@ -63,10 +73,16 @@ func (suite *ProductServiceTestSuite) TestCreate_Success() {
 		Return(expectedID, nil).
 		Times(1)
 
-	id, err := suite.service.Create(suite.ctx, product)
+	stored := &entity.Product{ID: expectedID, Name: product.Name, Version: 1}
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, expectedID).
+		Return(stored, nil).
+		Times(1)
+
+	created, err := suite.service.Create(suite.ctx, product)
 
 	suite.NoError(err)
-	suite.Equal(expectedID, id)
+	suite.Equal(stored, created)
 }
 
 func (suite *ProductServiceTestSuite) TestCreate_InvalidCategory() {
@@ -83,10 +99,10 @@ func (suite *ProductServiceTestSuite) TestCreate_InvalidCategory() {
 		Return(nil, expectedErr).
 		Times(1)
 
-	id, err := suite.service.Create(suite.ctx, product)
+	created, err := suite.service.Create(suite.ctx, product)
 
 	suite.Error(err)
-	suite.Equal("", id)
+	suite.Nil(created)
 	suite.Equal(expectedErr, err)
 }
 
@@ -113,13 +129,54 @@ func (suite *ProductServiceTestSuite) TestCreate_RepositoryError() {
 		Return("", expectedErr).
 		Times(1)
 
-	id, err := suite.service.Create(suite.ctx, product)
+	created, err := suite.service.Create(suite.ctx, product)
 
 	suite.Error(err)
-	suite.Equal("", id)
+	suite.Nil(created)
 	suite.Equal(expectedErr, err)
 }
 
+func (suite *ProductServiceTestSuite) TestCreate_WithinTransaction() {
+
+	type txKey struct{}
+	txCtx := context.WithValue(suite.ctx, txKey{}, "tx")
+	txManager := mocks.NewMockTxManager(suite.mockCtrl)
+	service := NewProductService(txManager, suite.mockProductRepo, suite.mockCategoryRepo, 3)
+
+	categoryID := "category-123"
+	product := entity.Product{
+		Name:       "Test Product",
+		CategoryID: categoryID,
+	}
+
+	txManager.EXPECT().
+		WithinTx(suite.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(ctx context.Context) error) error {
+			return fn(txCtx)
+		}).
+		Times(1)
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(txCtx, categoryID).
+		Return(&entity.Category{ID: categoryID}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		Create(txCtx, &product).
+		Return("product-123", nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(txCtx, "product-123").
+		Return(&entity.Product{ID: "product-123"}, nil).
+		Times(1)
+
+	created, err := service.Create(suite.ctx, product)
+
+	suite.NoError(err)
+	suite.Equal("product-123", created.ID)
+}
+
 func (suite *ProductServiceTestSuite) TestUpdate_Success() {
 
 	productID := "product-123"
@@ -146,9 +203,16 @@ func (suite *ProductServiceTestSuite) TestUpdate_Success() {
 		Return(nil).
 		Times(1)
 
-	err := suite.service.Update(suite.ctx, productID, product)
+	stored := &entity.Product{ID: productID, Name: product.Name, Version: 2}
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, productID).
+		Return(stored, nil).
+		Times(1)
+
+	updated, err := suite.service.Update(suite.ctx, productID, product)
 
 	suite.NoError(err)
+	suite.Equal(stored, updated)
 }
 
 func (suite *ProductServiceTestSuite) TestUpdate_WithoutCategoryID() {
@@ -159,17 +223,92 @@ func (suite *ProductServiceTestSuite) TestUpdate_WithoutCategoryID() {
 		Description: "Updated Description",
 		CategoryID:  "",
 	}
-	expectedProduct := product
-	expectedProduct.ID = productID
+
+	_, err := suite.service.Update(suite.ctx, productID, product)
+
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *ProductServiceTestSuite) TestPatch_Success() {
+
+	productID := "product-123"
+	categoryID := "category-456"
+	cleared := ""
+	patch := entity.ProductPatch{
+		Description: &cleared,
+		Price:       new(float64),
+		CategoryID:  &categoryID,
+		Version:     2,
+	}
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, categoryID).
+		Return(&entity.Category{ID: categoryID}, nil).
+		Times(1)
 
 	suite.mockProductRepo.EXPECT().
-		Update(suite.ctx, &expectedProduct).
+		Patch(suite.ctx, productID, patch).
+		Return(nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, productID).
+		Return(&entity.Product{ID: productID, Version: 3}, nil).
+		Times(1)
+
+	patched, err := suite.service.Patch(suite.ctx, productID, patch)
+
+	suite.NoError(err)
+	suite.Equal(3, patched.Version)
+}
+
+func (suite *ProductServiceTestSuite) TestPatch_WithoutCategory() {
+
+	productID := "product-123"
+	stock := 0
+	patch := entity.ProductPatch{Stock: &stock}
+
+	suite.mockProductRepo.EXPECT().
+		Patch(suite.ctx, productID, patch).
 		Return(nil).
 		Times(1)
 
-	err := suite.service.Update(suite.ctx, productID, product)
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, productID).
+		Return(&entity.Product{ID: productID, Version: 3}, nil).
+		Times(1)
+
+	patched, err := suite.service.Patch(suite.ctx, productID, patch)
 
 	suite.NoError(err)
+	suite.Equal(3, patched.Version)
+}
+
+func (suite *ProductServiceTestSuite) TestPatch_Invalid() {
+
+	empty := ""
+	negative := -1.0
+
+	_, err := suite.service.Patch(suite.ctx, "product-123", entity.ProductPatch{Name: &empty})
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+
+	_, err = suite.service.Patch(suite.ctx, "product-123", entity.ProductPatch{Price: &negative})
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *ProductServiceTestSuite) TestPatch_EmptyPatch() {
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-123").
+		Return(&entity.Product{ID: "product-123", Version: 4}, nil).
+		Times(2)
+
+	product, err := suite.service.Patch(suite.ctx, "product-123", entity.ProductPatch{Version: 4})
+	suite.NoError(err)
+	suite.Equal(4, product.Version)
+
+	_, err = suite.service.Patch(suite.ctx, "product-123", entity.ProductPatch{Version: 3})
+	suite.Equal(apperr.ErrPreconditionFailed.Code, apperr.GetCode(err))
 }
 
 func (suite *ProductServiceTestSuite) TestUpdate_InvalidCategory() {
@@ -187,7 +326,7 @@ func (suite *ProductServiceTestSuite) TestUpdate_InvalidCategory() {
 		Return(nil, expectedErr).
 		Times(1)
 
-	err := suite.service.Update(suite.ctx, productID, product)
+	_, err := suite.service.Update(suite.ctx, productID, product)
 
 	suite.Error(err)
 	suite.Equal(expectedErr, err)
@@ -219,7 +358,7 @@ func (suite *ProductServiceTestSuite) TestUpdate_RepositoryError() {
 		Return(expectedErr).
 		Times(1)
 
-	err := suite.service.Update(suite.ctx, productID, product)
+	_, err := suite.service.Update(suite.ctx, productID, product)
 
 	suite.Error(err)
 	suite.Equal(expectedErr, err)
@@ -440,6 +579,23 @@ func (suite *ProductServiceTestSuite) TestGetAll_OnlyMaxPrice() {
 	suite.Equal(expectedProducts, products)
 }
 
+func (suite *ProductServiceTestSuite) TestGetListStats_Success() {
+
+	name := "phone"
+	filter := entity.ProductFilter{Name: &name}
+	expected := &entity.ListStats{Count: 4, LastModified: time.Now()}
+
+	suite.mockProductRepo.EXPECT().
+		Stats(suite.ctx, filter).
+		Return(expected, nil).
+		Times(1)
+
+	result, err := suite.service.GetListStats(suite.ctx, filter)
+
+	suite.NoError(err)
+	suite.Equal(expected, result)
+}
+
 func (suite *ProductServiceTestSuite) TestGetAll_RepositoryError() {
 
 	filter := entity.ProductFilter{
@@ -467,11 +623,11 @@ func (suite *ProductServiceTestSuite) TestDelete_Success() {
 	productID := "product-123"
 
 	suite.mockProductRepo.EXPECT().
-		Delete(suite.ctx, productID).
+		Delete(suite.ctx, productID, 0).
 		Return(nil).
 		Times(1)
 
-	err := suite.service.Delete(suite.ctx, productID)
+	err := suite.service.Delete(suite.ctx, productID, 0)
 
 	suite.NoError(err)
 }
@@ -482,16 +638,721 @@ func (suite *ProductServiceTestSuite) TestDelete_RepositoryError() {
 	expectedErr := errors.New("repository error")
 
 	suite.mockProductRepo.EXPECT().
-		Delete(suite.ctx, productID).
+		Delete(suite.ctx, productID, 0).
 		Return(expectedErr).
 		Times(1)
 
-	err := suite.service.Delete(suite.ctx, productID)
+	err := suite.service.Delete(suite.ctx, productID, 0)
 
 	suite.Error(err)
 	suite.Equal(expectedErr, err)
 }
 
+func (suite *ProductServiceTestSuite) TestGetByIDs_PreservesOrderAndReportsMissing() {
+
+	first := "5f0c7b8e-1d1a-4c57-9a43-4a7c1f0b2d01"
+	second := "5f0c7b8e-1d1a-4c57-9a43-4a7c1f0b2d02"
+	gone := "5f0c7b8e-1d1a-4c57-9a43-4a7c1f0b2d03"
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{second, gone, first}).
+		Return([]entity.Product{{ID: first, Name: "First"}, {ID: second, Name: "Second"}}, nil).
+		Times(1)
+
+	products, missing, err := suite.service.GetByIDs(suite.ctx, []string{second, gone, first})
+
+	suite.NoError(err)
+	suite.Equal([]entity.Product{{ID: second, Name: "Second"}, {ID: first, Name: "First"}}, products)
+	suite.Equal([]string{gone}, missing)
+}
+
+func (suite *ProductServiceTestSuite) TestGetByIDs_SkipsDuplicatesAndMalformedIDs() {
+
+	id := "5f0c7b8e-1d1a-4c57-9a43-4a7c1f0b2d01"
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{id}).
+		Return([]entity.Product{{ID: id}}, nil).
+		Times(1)
+
+	products, missing, err := suite.service.GetByIDs(suite.ctx, []string{"not-a-uuid", id, id})
+
+	suite.NoError(err)
+	suite.Equal([]entity.Product{{ID: id}}, products)
+	suite.Equal([]string{"not-a-uuid"}, missing)
+}
+
+func (suite *ProductServiceTestSuite) TestGetByIDs_TooMany() {
+
+	_, _, err := suite.service.GetByIDs(suite.ctx, []string{"a", "b", "c", "d"})
+
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *ProductServiceTestSuite) TestGetByIDs_RepositoryError() {
+
+	id := "5f0c7b8e-1d1a-4c57-9a43-4a7c1f0b2d01"
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{id}).
+		Return(nil, apperr.ErrInternal).
+		Times(1)
+
+	_, _, err := suite.service.GetByIDs(suite.ctx, []string{id})
+
+	suite.Equal(apperr.ErrInternal, err)
+}
+
+func (suite *ProductServiceTestSuite) TestGetBySKU() {
+
+	expected := &entity.Product{ID: "product-1", SKU: "TEST-001", Category: &entity.Category{ID: "category-1"}}
+
+	suite.mockProductRepo.EXPECT().
+		FindBySKU(suite.ctx, "TEST-001").
+		Return(expected, nil).
+		Times(1)
+
+	product, err := suite.service.GetBySKU(suite.ctx, "TEST-001")
+
+	suite.NoError(err)
+	suite.Equal(expected, product)
+}
+
+func batchProduct(name, categoryID string) entity.Product {
+	return entity.Product{
+		Name:       name,
+		SKU:        name + "-SKU",
+		Price:      utils.SetPtr(9.99),
+		Stock:      utils.SetPtr(5),
+		CategoryID: categoryID,
+	}
+}
+
+func (suite *ProductServiceTestSuite) TestBatchCreate_Atomic() {
+
+	products := []entity.Product{batchProduct("a", "category-1"), batchProduct("b", "category-1")}
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		CreateMany(suite.ctx, products).
+		Return([]string{"product-1", "product-2"}, nil).
+		Times(1)
+
+	results, err := suite.service.BatchCreate(suite.ctx, products, entity.BatchAtomic)
+
+	suite.NoError(err)
+	suite.Equal([]entity.BatchResult{{Index: 0, ID: "product-1"}, {Index: 1, ID: "product-2"}}, results)
+}
+
+func (suite *ProductServiceTestSuite) TestBatchCreate_AtomicAbortsOnInvalidItem() {
+
+	products := []entity.Product{batchProduct("a", "category-1"), batchProduct("b", "missing")}
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "missing").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	results, err := suite.service.BatchCreate(suite.ctx, products, entity.BatchAtomic)
+
+	suite.Nil(results)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+	suite.Equal([]entity.BatchItemError{{
+		Index:     1,
+		ErrorCode: apperr.ErrNotFound.Code,
+		Message:   apperr.ErrNotFound.Error(),
+	}}, apperr.GetDetails(err))
+}
+
+func (suite *ProductServiceTestSuite) TestBatchCreate_BestEffortSkipsInvalidItems() {
+
+	products := []entity.Product{batchProduct("", "category-1"), batchProduct("b", "category-1")}
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		CreateMany(suite.ctx, products[1:]).
+		Return([]string{"product-2"}, nil).
+		Times(1)
+
+	results, err := suite.service.BatchCreate(suite.ctx, products, entity.BatchBestEffort)
+
+	suite.NoError(err)
+	suite.Len(results, 2)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(results[0].Err))
+	suite.Equal(entity.BatchResult{Index: 1, ID: "product-2"}, results[1])
+}
+
+func (suite *ProductServiceTestSuite) TestBatchCreate_BestEffortFallsBackToSingleInserts() {
+
+	products := []entity.Product{batchProduct("a", "category-1"), batchProduct("b", "category-1")}
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		CreateMany(suite.ctx, products).
+		Return(nil, apperr.ErrInternal).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		Create(suite.ctx, &products[0]).
+		Return("product-1", nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		Create(suite.ctx, &products[1]).
+		Return("", apperr.ErrInternal).
+		Times(1)
+
+	results, err := suite.service.BatchCreate(suite.ctx, products, entity.BatchBestEffort)
+
+	suite.NoError(err)
+	suite.Equal(entity.BatchResult{Index: 0, ID: "product-1"}, results[0])
+	suite.Equal(apperr.ErrInternal, results[1].Err)
+}
+
+func (suite *ProductServiceTestSuite) TestBatchCreate_TooManyItems() {
+
+	products := make([]entity.Product, 4)
+
+	results, err := suite.service.BatchCreate(suite.ctx, products, entity.BatchAtomic)
+
+	suite.Nil(results)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *ProductServiceTestSuite) TestBatchCreate_UnknownMode() {
+
+	products := []entity.Product{batchProduct("a", "category-1")}
+
+	_, err := suite.service.BatchCreate(suite.ctx, products, entity.BatchMode("sometimes"))
+
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *ProductServiceTestSuite) TestBatchUpdate_AtomicAbortsOnVersionConflict() {
+
+	first := batchProduct("a", "category-1")
+	first.ID = "product-1"
+	second := batchProduct("b", "category-1")
+	second.ID = "product-2"
+	second.Version = 4
+	products := []entity.Product{first, second}
+	conflict := apperr.ErrPreconditionFailed.WithDetails(entity.VersionConflict{CurrentVersion: 5})
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		UpdateMany(suite.ctx, products).
+		Return([]error{nil, conflict}, nil).
+		Times(1)
+
+	results, err := suite.service.BatchUpdate(suite.ctx, products, entity.BatchAtomic)
+
+	suite.Nil(results)
+	suite.Equal(apperr.ErrPreconditionFailed.Code, apperr.GetCode(err))
+	details := apperr.GetDetails(err).([]entity.BatchItemError)
+	suite.Len(details, 1)
+	suite.Equal(1, details[0].Index)
+	suite.Equal("product-2", details[0].ID)
+}
+
+func (suite *ProductServiceTestSuite) TestBatchUpdate_BestEffortRejectsDuplicateIDs() {
+
+	first := batchProduct("a", "category-1")
+	first.ID = "product-1"
+	products := []entity.Product{first, first}
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		UpdateMany(suite.ctx, products[:1]).
+		Return([]error{nil}, nil).
+		Times(1)
+
+	results, err := suite.service.BatchUpdate(suite.ctx, products, entity.BatchBestEffort)
+
+	suite.NoError(err)
+	suite.Equal(entity.BatchResult{Index: 0, ID: "product-1"}, results[0])
+	suite.Equal("product-1", results[1].ID)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(results[1].Err))
+}
+
+func (suite *ProductServiceTestSuite) TestBatchDelete_BestEffort() {
+
+	refs := []entity.VersionedID{{ID: "product-1"}, {ID: "product-2", Version: 2}}
+
+	suite.mockProductRepo.EXPECT().
+		DeleteMany(suite.ctx, refs).
+		Return([]error{nil, apperr.ErrNotFound}, nil).
+		Times(1)
+
+	results, err := suite.service.BatchDelete(suite.ctx, refs, entity.BatchBestEffort)
+
+	suite.NoError(err)
+	suite.Equal([]entity.BatchResult{
+		{Index: 0, ID: "product-1"},
+		{Index: 1, ID: "product-2", Err: apperr.ErrNotFound},
+	}, results)
+}
+
+func (suite *ProductServiceTestSuite) TestUpsert_Created() {
+
+	product := batchProduct("a", "category-1")
+	stored := &entity.Product{ID: "product-1", SKU: product.SKU, Version: 1}
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		UpsertBySKU(suite.ctx, []entity.Product{product}).
+		Return([]entity.BatchResult{{ID: "product-1", Created: true}}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-1").
+		Return(stored, nil).
+		Times(1)
+
+	upserted, created, err := suite.service.Upsert(suite.ctx, product)
+
+	suite.NoError(err)
+	suite.True(created)
+	suite.Equal(stored, upserted)
+}
+
+func (suite *ProductServiceTestSuite) TestUpsert_Conflict() {
+
+	product := batchProduct("a", "category-1")
+	conflict := apperr.ErrConflict.WithMessage("sku is already taken by another product")
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		UpsertBySKU(suite.ctx, []entity.Product{product}).
+		Return([]entity.BatchResult{{Err: conflict}}, nil).
+		Times(1)
+
+	upserted, created, err := suite.service.Upsert(suite.ctx, product)
+
+	suite.Nil(upserted)
+	suite.False(created)
+	suite.Equal(conflict, err)
+}
+
+func (suite *ProductServiceTestSuite) TestUpsert_InvalidCategory() {
+
+	product := batchProduct("a", "missing")
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "missing").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	_, _, err := suite.service.Upsert(suite.ctx, product)
+
+	suite.Equal(apperr.ErrNotFound, err)
+}
+
+func (suite *ProductServiceTestSuite) TestBatchUpsert_BestEffortRejectsDuplicateSKUs() {
+
+	products := []entity.Product{batchProduct("a", "category-1"), batchProduct("a", "category-1"), batchProduct("b", "category-1")}
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		UpsertBySKU(suite.ctx, []entity.Product{products[0], products[2]}).
+		Return([]entity.BatchResult{{Index: 0, ID: "product-1", Created: true}, {Index: 1, ID: "product-2"}}, nil).
+		Times(1)
+
+	results, err := suite.service.BatchUpsert(suite.ctx, products, entity.BatchBestEffort)
+
+	suite.NoError(err)
+	suite.Equal(entity.BatchResult{Index: 0, ID: "product-1", Created: true}, results[0])
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(results[1].Err))
+	suite.Equal(entity.BatchResult{Index: 2, ID: "product-2"}, results[2])
+}
+
+func (suite *ProductServiceTestSuite) TestBatchUpsert_RejectsNormalizedDuplicateSKUs() {
+
+	first := batchProduct("a", "category-1")
+	second := batchProduct("a", "category-1")
+	second.SKU = "  A-sku "
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	results, err := suite.service.BatchUpsert(suite.ctx, []entity.Product{first, second}, entity.BatchAtomic)
+
+	suite.Nil(results)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+	suite.Equal(1, apperr.GetDetails(err).([]entity.BatchItemError)[0].Index)
+}
+
+func (suite *ProductServiceTestSuite) TestBatchUpsert_AtomicAbortsOnConflict() {
+
+	products := []entity.Product{batchProduct("a", "category-1"), batchProduct("b", "category-1")}
+	conflict := apperr.ErrConflict.WithMessage("sku is already taken by another product")
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		UpsertBySKU(suite.ctx, products).
+		Return([]entity.BatchResult{{Index: 0, ID: "product-1"}, {Index: 1, Err: conflict}}, nil).
+		Times(1)
+
+	results, err := suite.service.BatchUpsert(suite.ctx, products, entity.BatchAtomic)
+
+	suite.Nil(results)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+	suite.Equal([]entity.BatchItemError{{
+		Index:     1,
+		ErrorCode: apperr.ErrConflict.Code,
+		Message:   conflict.Error(),
+	}}, apperr.GetDetails(err))
+}
+
+func (suite *ProductServiceTestSuite) TestBatchUpsert_BestEffortFallsBackToSingleUpserts() {
+
+	products := []entity.Product{batchProduct("a", "category-1"), batchProduct("b", "category-1")}
+
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		UpsertBySKU(suite.ctx, products).
+		Return(nil, apperr.ErrInternal).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		UpsertBySKU(suite.ctx, products[:1]).
+		Return([]entity.BatchResult{{ID: "product-1"}}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		UpsertBySKU(suite.ctx, products[1:]).
+		Return(nil, apperr.ErrInternal).
+		Times(1)
+
+	results, err := suite.service.BatchUpsert(suite.ctx, products, entity.BatchBestEffort)
+
+	suite.NoError(err)
+	suite.Equal(entity.BatchResult{Index: 0, ID: "product-1"}, results[0])
+	suite.Equal(apperr.ErrInternal, results[1].Err)
+}
+
+func (suite *ProductServiceTestSuite) TestListTrash_DefaultLimit() {
+
+	suite.mockProductRepo.EXPECT().
+		FindDeleted(suite.ctx, entity.Pagination{Limit: 10}).
+		Return([]entity.Product{}, nil).
+		Times(1)
+
+	products, err := suite.service.ListTrash(suite.ctx, entity.Pagination{})
+
+	suite.NoError(err)
+	suite.Empty(products)
+}
+
+func (suite *ProductServiceTestSuite) TestRestore_Success() {
+
+	deleted := &entity.Product{ID: "product-1", SKU: "A-1", CategoryID: "category-1"}
+	restored := &entity.Product{ID: "product-1", SKU: "A-1", CategoryID: "category-1", Version: 3}
+
+	suite.mockProductRepo.EXPECT().
+		FindDeletedByID(suite.ctx, "product-1").
+		Return(deleted, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindBySKU(suite.ctx, "A-1").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		Restore(suite.ctx, "product-1", "category-1").
+		Return(nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-1").
+		Return(restored, nil).
+		Times(1)
+
+	product, err := suite.service.Restore(suite.ctx, "product-1", "")
+
+	suite.NoError(err)
+	suite.Equal(restored, product)
+}
+
+func (suite *ProductServiceTestSuite) TestRestore_SKUTaken() {
+
+	suite.mockProductRepo.EXPECT().
+		FindDeletedByID(suite.ctx, "product-1").
+		Return(&entity.Product{ID: "product-1", SKU: "A-1", CategoryID: "category-1"}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindBySKU(suite.ctx, "A-1").
+		Return(&entity.Product{ID: "product-2", SKU: "A-1"}, nil).
+		Times(1)
+
+	product, err := suite.service.Restore(suite.ctx, "product-1", "")
+
+	suite.Nil(product)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+	suite.Contains(err.Error(), "product-2")
+}
+
+func (suite *ProductServiceTestSuite) TestRestore_CategoryDeleted() {
+
+	suite.mockProductRepo.EXPECT().
+		FindDeletedByID(suite.ctx, "product-1").
+		Return(&entity.Product{ID: "product-1", SKU: "A-1", CategoryID: "category-1"}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindBySKU(suite.ctx, "A-1").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	product, err := suite.service.Restore(suite.ctx, "product-1", "")
+
+	suite.Nil(product)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func (suite *ProductServiceTestSuite) TestRestore_IntoAnotherCategory() {
+
+	suite.mockProductRepo.EXPECT().
+		FindDeletedByID(suite.ctx, "product-1").
+		Return(&entity.Product{ID: "product-1", SKU: "A-1", CategoryID: "category-1"}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindBySKU(suite.ctx, "A-1").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-2").
+		Return(&entity.Category{ID: "category-2"}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		Restore(suite.ctx, "product-1", "category-2").
+		Return(nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-1").
+		Return(&entity.Product{ID: "product-1", CategoryID: "category-2"}, nil).
+		Times(1)
+
+	product, err := suite.service.Restore(suite.ctx, "product-1", "category-2")
+
+	suite.NoError(err)
+	suite.Equal("category-2", product.CategoryID)
+}
+
+func (suite *ProductServiceTestSuite) TestRestore_NotInTrash() {
+
+	suite.mockProductRepo.EXPECT().
+		FindDeletedByID(suite.ctx, "product-1").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	_, err := suite.service.Restore(suite.ctx, "product-1", "")
+
+	suite.Equal(apperr.ErrNotFound, err)
+}
+
+func (suite *ProductServiceTestSuite) TestPurge() {
+
+	suite.mockProductRepo.EXPECT().
+		Purge(suite.ctx, "product-1").
+		Return(nil).
+		Times(1)
+
+	suite.NoError(suite.service.Purge(suite.ctx, "product-1"))
+}
+
+func (suite *ProductServiceTestSuite) TestListRevisions_DefaultLimit() {
+
+	revisions := []entity.Revision{{EntityID: "product-1", Number: 2}, {EntityID: "product-1", Number: 1}}
+	suite.mockProductRepo.EXPECT().
+		FindRevisions(suite.ctx, "product-1", entity.Pagination{Limit: 10}).
+		Return(revisions, nil).
+		Times(1)
+
+	result, err := suite.service.ListRevisions(suite.ctx, "product-1", entity.Pagination{})
+
+	suite.NoError(err)
+	suite.Equal(revisions, result)
+}
+
+func (suite *ProductServiceTestSuite) TestListRevisions_NoRevisions() {
+
+	suite.mockProductRepo.EXPECT().
+		FindRevisions(suite.ctx, "product-1", entity.Pagination{Limit: 100}).
+		Return([]entity.Revision{}, nil).
+		Times(1)
+
+	result, err := suite.service.ListRevisions(suite.ctx, "product-1", entity.Pagination{Limit: 500})
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+}
+
+func (suite *ProductServiceTestSuite) TestRevert_Success() {
+
+	revision := &entity.ProductRevision{
+		Revision: entity.Revision{EntityID: "product-1", Number: 1},
+		Product: entity.Product{ID: "product-1", Name: "Old", SKU: "A-1", Price: utils.SetPtr(5.0),
+			Stock: utils.SetPtr(1), CategoryID: "category-1", Version: 1},
+	}
+	current := &entity.Product{ID: "product-1", Name: "New", SKU: "A-1", Price: utils.SetPtr(7.0),
+		Stock: utils.SetPtr(40), CategoryID: "category-1", Version: 4}
+	reverted := &entity.Product{ID: "product-1", Name: "Old", Version: 5}
+
+	suite.mockProductRepo.EXPECT().
+		FindRevision(suite.ctx, "product-1", 1).
+		Return(revision, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-1").
+		Return(current, nil).
+		Times(1)
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-1").
+		Return(&entity.Category{ID: "category-1"}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		Update(suite.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, product *entity.Product) error {
+			suite.Equal("Old", product.Name)
+			suite.Equal(5.0, *product.Price)
+			suite.Equal(40, *product.Stock)
+			suite.Equal(4, product.Version)
+			return nil
+		}).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-1").
+		Return(reverted, nil).
+		Times(1)
+
+	product, err := suite.service.Revert(suite.ctx, "product-1", 1, 4)
+
+	suite.NoError(err)
+	suite.Equal(reverted, product)
+}
+
+func (suite *ProductServiceTestSuite) TestRevert_VersionMismatch() {
+
+	suite.mockProductRepo.EXPECT().
+		FindRevision(suite.ctx, "product-1", 1).
+		Return(&entity.ProductRevision{Product: entity.Product{ID: "product-1"}}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-1").
+		Return(&entity.Product{ID: "product-1", Version: 4}, nil).
+		Times(1)
+
+	product, err := suite.service.Revert(suite.ctx, "product-1", 1, 3)
+
+	suite.Nil(product)
+	suite.Equal(apperr.ErrPreconditionFailed.Code, apperr.GetCode(err))
+}
+
+func (suite *ProductServiceTestSuite) TestRevert_Unchanged() {
+
+	current := &entity.Product{ID: "product-1", Name: "Same", SKU: "A-1", Price: utils.SetPtr(5.0),
+		Stock: utils.SetPtr(3), CategoryID: "category-1", Version: 4}
+	suite.mockProductRepo.EXPECT().
+		FindRevision(suite.ctx, "product-1", 2).
+		Return(&entity.ProductRevision{Product: entity.Product{ID: "product-1", Name: "Same", SKU: "A-1",
+			Price: utils.SetPtr(5.0), Stock: utils.SetPtr(9), CategoryID: "category-1"}}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-1").
+		Return(current, nil).
+		Times(1)
+
+	product, err := suite.service.Revert(suite.ctx, "product-1", 2, 0)
+
+	suite.NoError(err)
+	suite.Equal(current, product)
+}
+
+func (suite *ProductServiceTestSuite) TestRevert_CategoryDeleted() {
+
+	suite.mockProductRepo.EXPECT().
+		FindRevision(suite.ctx, "product-1", 1).
+		Return(&entity.ProductRevision{Product: entity.Product{ID: "product-1", Name: "Old", CategoryID: "category-9"}}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-1").
+		Return(&entity.Product{ID: "product-1", Name: "New", CategoryID: "category-1", Version: 2}, nil).
+		Times(1)
+	suite.mockCategoryRepo.EXPECT().
+		FindByID(suite.ctx, "category-9").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	product, err := suite.service.Revert(suite.ctx, "product-1", 1, 0)
+
+	suite.Nil(product)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+	suite.Contains(err.Error(), "category-9")
+}
+
+func (suite *ProductServiceTestSuite) TestRevert_ProductDeleted() {
+
+	suite.mockProductRepo.EXPECT().
+		FindRevision(suite.ctx, "product-1", 1).
+		Return(&entity.ProductRevision{Product: entity.Product{ID: "product-1"}}, nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-1").
+		Return(nil, apperr.ErrNotFound).
+		Times(1)
+
+	_, err := suite.service.Revert(suite.ctx, "product-1", 1, 0)
+
+	suite.Equal(apperr.ErrNotFound, err)
+}
+
 func TestProductServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(ProductServiceTestSuite))
 }
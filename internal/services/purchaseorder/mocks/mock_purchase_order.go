@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: purchase_order.go
+//
+// Generated by this command:
+//
+//	mockgen -source=purchase_order.go -destination=mocks/mock_purchase_order.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPurchaseOrderService is a mock of PurchaseOrderService interface.
+type MockPurchaseOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseOrderServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPurchaseOrderServiceMockRecorder is the mock recorder for MockPurchaseOrderService.
+type MockPurchaseOrderServiceMockRecorder struct {
+	mock *MockPurchaseOrderService
+}
+
+// NewMockPurchaseOrderService creates a new mock instance.
+func NewMockPurchaseOrderService(ctrl *gomock.Controller) *MockPurchaseOrderService {
+	mock := &MockPurchaseOrderService{ctrl: ctrl}
+	mock.recorder = &MockPurchaseOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseOrderService) EXPECT() *MockPurchaseOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockPurchaseOrderService) Cancel(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockPurchaseOrderServiceMockRecorder) Cancel(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockPurchaseOrderService)(nil).Cancel), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockPurchaseOrderService) Create(ctx context.Context, order entity.PurchaseOrder) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, order)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPurchaseOrderServiceMockRecorder) Create(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPurchaseOrderService)(nil).Create), ctx, order)
+}
+
+// GetAll mocks base method.
+func (m *MockPurchaseOrderService) GetAll(ctx context.Context, filter entity.PurchaseOrdersFilter) ([]entity.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
+	ret0, _ := ret[0].([]entity.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPurchaseOrderServiceMockRecorder) GetAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPurchaseOrderService)(nil).GetAll), ctx, filter)
+}
+
+// GetByID mocks base method.
+func (m *MockPurchaseOrderService) GetByID(ctx context.Context, id string) (*entity.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPurchaseOrderServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPurchaseOrderService)(nil).GetByID), ctx, id)
+}
+
+// Receive mocks base method.
+func (m *MockPurchaseOrderService) Receive(ctx context.Context, id string, receipts []entity.PurchaseOrderReceipt) (*entity.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, id, receipts)
+	ret0, _ := ret[0].(*entity.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockPurchaseOrderServiceMockRecorder) Receive(ctx, id, receipts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockPurchaseOrderService)(nil).Receive), ctx, id, receipts)
+}
+
+// Submit mocks base method.
+func (m *MockPurchaseOrderService) Submit(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockPurchaseOrderServiceMockRecorder) Submit(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockPurchaseOrderService)(nil).Submit), ctx, id)
+}
@@ -0,0 +1,122 @@
+package purchaseorder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type purchaseOrderService struct {
+	purchaseOrderRepo repository.PurchaseOrderRepository
+	productRepo       repository.ProductRepository
+	supplierRepo      repository.SupplierRepository
+}
+
+//go:generate mockgen -source=purchase_order.go -destination=mocks/mock_purchase_order.go -package=mocks
+type PurchaseOrderService interface {
+	Create(ctx context.Context, order entity.PurchaseOrder) (string, error)
+	GetByID(ctx context.Context, id string) (*entity.PurchaseOrder, error)
+	GetAll(ctx context.Context, filter entity.PurchaseOrdersFilter) ([]entity.PurchaseOrder, error)
+	Submit(ctx context.Context, id string) error
+	Cancel(ctx context.Context, id string) error
+	Receive(ctx context.Context, id string, receipts []entity.PurchaseOrderReceipt) (*entity.PurchaseOrder, error)
+}
+
+func NewPurchaseOrderService(
+	purchaseOrderRepo repository.PurchaseOrderRepository,
+	productRepo repository.ProductRepository,
+	supplierRepo repository.SupplierRepository,
+) PurchaseOrderService {
+	return &purchaseOrderService{
+		purchaseOrderRepo: purchaseOrderRepo,
+		productRepo:       productRepo,
+		supplierRepo:      supplierRepo,
+	}
+}
+
+func (p purchaseOrderService) Create(ctx context.Context, order entity.PurchaseOrder) (string, error) {
+	if len(order.Lines) == 0 {
+		return "", apperr.ErrInvalidArgument.WithMessage("purchase order must have at least one line")
+	}
+
+	for i, line := range order.Lines {
+		if line.Quantity <= 0 {
+			return "", apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("line %d: quantity must be greater than zero", i))
+		}
+		if line.UnitCost < 0 {
+			return "", apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("line %d: unit cost cannot be negative", i))
+		}
+
+		_, err := p.productRepo.FindByID(ctx, line.ProductID)
+		if err != nil {
+			return "", err
+		}
+		order.Lines[i].ReceivedQuantity = 0
+	}
+
+	if order.SupplierID != nil {
+		_, err := p.supplierRepo.FindByID(ctx, *order.SupplierID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	order.Status = entity.PurchaseOrderStatusDraft
+	return p.purchaseOrderRepo.Create(ctx, &order)
+}
+
+func (p purchaseOrderService) GetByID(ctx context.Context, id string) (*entity.PurchaseOrder, error) {
+	return p.purchaseOrderRepo.FindByID(ctx, id)
+}
+
+func (p purchaseOrderService) GetAll(ctx context.Context, filter entity.PurchaseOrdersFilter) ([]entity.PurchaseOrder, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	return p.purchaseOrderRepo.FindAll(ctx, filter)
+}
+
+func (p purchaseOrderService) Submit(ctx context.Context, id string) error {
+	return p.transition(ctx, id, entity.PurchaseOrderStatusSubmitted)
+}
+
+func (p purchaseOrderService) Cancel(ctx context.Context, id string) error {
+	return p.transition(ctx, id, entity.PurchaseOrderStatusCancelled)
+}
+
+func (p purchaseOrderService) transition(ctx context.Context, id string, to entity.PurchaseOrderStatus) error {
+	order, err := p.purchaseOrderRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !order.Status.CanTransitionTo(to) {
+		return apperr.ErrConflict.WithMessage(fmt.Sprintf("cannot move purchase order from %s to %s", order.Status, to))
+	}
+
+	return p.purchaseOrderRepo.UpdateStatus(ctx, id, order.Status, to)
+}
+
+func (p purchaseOrderService) Receive(ctx context.Context, id string, receipts []entity.PurchaseOrderReceipt) (*entity.PurchaseOrder, error) {
+	if len(receipts) == 0 {
+		return nil, apperr.ErrInvalidArgument.WithMessage("at least one receipt line is required")
+	}
+
+	for i, receipt := range receipts {
+		if receipt.Quantity <= 0 {
+			return nil, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("receipt %d: quantity must be greater than zero", i))
+		}
+		if receipt.LotNumber != "" && receipt.ExpiresAt == nil {
+			return nil, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("receipt %d: expiry date is required when a lot number is given", i))
+		}
+	}
+
+	return p.purchaseOrderRepo.Receive(ctx, id, receipts)
+}
@@ -0,0 +1,185 @@
+package purchaseorder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type PurchaseOrderServiceTestSuite struct {
+	suite.Suite
+	mockCtrl              *gomock.Controller
+	mockPurchaseOrderRepo *mocks.MockPurchaseOrderRepository
+	mockProductRepo       *mocks.MockProductRepository
+	mockSupplierRepo      *mocks.MockSupplierRepository
+	service               PurchaseOrderService
+	ctx                   context.Context
+}
+
+func (suite *PurchaseOrderServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockPurchaseOrderRepo = mocks.NewMockPurchaseOrderRepository(suite.mockCtrl)
+	suite.mockProductRepo = mocks.NewMockProductRepository(suite.mockCtrl)
+	suite.mockSupplierRepo = mocks.NewMockSupplierRepository(suite.mockCtrl)
+	suite.service = NewPurchaseOrderService(suite.mockPurchaseOrderRepo, suite.mockProductRepo, suite.mockSupplierRepo)
+	suite.ctx = context.Background()
+}
+
+func (suite *PurchaseOrderServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *PurchaseOrderServiceTestSuite) TestCreate_Success() {
+
+	supplierID := "supplier-123"
+	order := entity.PurchaseOrder{
+		SupplierID: &supplierID,
+		Lines: []entity.PurchaseOrderLine{
+			{ProductID: "product-1", Quantity: 10, UnitCost: 2.5},
+			{ProductID: "product-2", Quantity: 5, UnitCost: 4},
+		},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-1").
+		Return(&entity.Product{ID: "product-1"}, nil).
+		Times(1)
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-2").
+		Return(&entity.Product{ID: "product-2"}, nil).
+		Times(1)
+
+	suite.mockSupplierRepo.EXPECT().
+		FindByID(suite.ctx, supplierID).
+		Return(&entity.Supplier{ID: supplierID}, nil).
+		Times(1)
+
+	suite.mockPurchaseOrderRepo.EXPECT().
+		Create(suite.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, order *entity.PurchaseOrder) (string, error) {
+			suite.Equal(entity.PurchaseOrderStatusDraft, order.Status)
+			suite.Len(order.Lines, 2)
+			return "po-123", nil
+		}).
+		Times(1)
+
+	id, err := suite.service.Create(suite.ctx, order)
+
+	suite.NoError(err)
+	suite.Equal("po-123", id)
+}
+
+func (suite *PurchaseOrderServiceTestSuite) TestCreate_NoLines() {
+
+	id, err := suite.service.Create(suite.ctx, entity.PurchaseOrder{})
+
+	suite.Error(err)
+	suite.Equal("", id)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *PurchaseOrderServiceTestSuite) TestCreate_ProductNotFound() {
+
+	order := entity.PurchaseOrder{
+		Lines: []entity.PurchaseOrderLine{{ProductID: "missing", Quantity: 1}},
+	}
+	expectedErr := apperr.ErrNotFound.WithMessage("product not found")
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "missing").
+		Return(nil, expectedErr).
+		Times(1)
+
+	id, err := suite.service.Create(suite.ctx, order)
+
+	suite.Equal("", id)
+	suite.Equal(expectedErr, err)
+}
+
+func (suite *PurchaseOrderServiceTestSuite) TestSubmit_Success() {
+
+	suite.mockPurchaseOrderRepo.EXPECT().
+		FindByID(suite.ctx, "po-123").
+		Return(&entity.PurchaseOrder{ID: "po-123", Status: entity.PurchaseOrderStatusDraft}, nil).
+		Times(1)
+
+	suite.mockPurchaseOrderRepo.EXPECT().
+		UpdateStatus(suite.ctx, "po-123", entity.PurchaseOrderStatusDraft, entity.PurchaseOrderStatusSubmitted).
+		Return(nil).
+		Times(1)
+
+	err := suite.service.Submit(suite.ctx, "po-123")
+
+	suite.NoError(err)
+}
+
+func (suite *PurchaseOrderServiceTestSuite) TestSubmit_AlreadyReceived() {
+
+	suite.mockPurchaseOrderRepo.EXPECT().
+		FindByID(suite.ctx, "po-123").
+		Return(&entity.PurchaseOrder{ID: "po-123", Status: entity.PurchaseOrderStatusReceived}, nil).
+		Times(1)
+
+	err := suite.service.Submit(suite.ctx, "po-123")
+
+	suite.Error(err)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func (suite *PurchaseOrderServiceTestSuite) TestCancel_PartiallyReceived() {
+
+	suite.mockPurchaseOrderRepo.EXPECT().
+		FindByID(suite.ctx, "po-123").
+		Return(&entity.PurchaseOrder{ID: "po-123", Status: entity.PurchaseOrderStatusPartiallyReceived}, nil).
+		Times(1)
+
+	suite.mockPurchaseOrderRepo.EXPECT().
+		UpdateStatus(suite.ctx, "po-123", entity.PurchaseOrderStatusPartiallyReceived, entity.PurchaseOrderStatusCancelled).
+		Return(nil).
+		Times(1)
+
+	err := suite.service.Cancel(suite.ctx, "po-123")
+
+	suite.NoError(err)
+}
+
+func (suite *PurchaseOrderServiceTestSuite) TestReceive_Success() {
+
+	receipts := []entity.PurchaseOrderReceipt{
+		{LineID: "line-1", Quantity: 4},
+		{LineID: "line-2", Quantity: 5, LotNumber: "LOT-9", ExpiresAt: utils.SetPtr(time.Now().Add(24 * time.Hour))},
+	}
+	expected := &entity.PurchaseOrder{ID: "po-123", Status: entity.PurchaseOrderStatusPartiallyReceived}
+
+	suite.mockPurchaseOrderRepo.EXPECT().
+		Receive(suite.ctx, "po-123", receipts).
+		Return(expected, nil).
+		Times(1)
+
+	order, err := suite.service.Receive(suite.ctx, "po-123", receipts)
+
+	suite.NoError(err)
+	suite.Equal(expected, order)
+}
+
+func (suite *PurchaseOrderServiceTestSuite) TestReceive_LotWithoutExpiry() {
+
+	receipts := []entity.PurchaseOrderReceipt{{LineID: "line-1", Quantity: 4, LotNumber: "LOT-9"}}
+
+	order, err := suite.service.Receive(suite.ctx, "po-123", receipts)
+
+	suite.Nil(order)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func TestPurchaseOrderServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PurchaseOrderServiceTestSuite))
+}
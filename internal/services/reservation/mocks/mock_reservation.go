@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reservation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reservation.go -destination=mocks/mock_reservation.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReservationService is a mock of ReservationService interface.
+type MockReservationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReservationServiceMockRecorder is the mock recorder for MockReservationService.
+type MockReservationServiceMockRecorder struct {
+	mock *MockReservationService
+}
+
+// NewMockReservationService creates a new mock instance.
+func NewMockReservationService(ctrl *gomock.Controller) *MockReservationService {
+	mock := &MockReservationService{ctrl: ctrl}
+	mock.recorder = &MockReservationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationService) EXPECT() *MockReservationServiceMockRecorder {
+	return m.recorder
+}
+
+// Release mocks base method.
+func (m *MockReservationService) Release(ctx context.Context, id string) (*entity.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id)
+	ret0, _ := ret[0].(*entity.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockReservationServiceMockRecorder) Release(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockReservationService)(nil).Release), ctx, id)
+}
+
+// ReleaseExpired mocks base method.
+func (m *MockReservationService) ReleaseExpired(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpired", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseExpired indicates an expected call of ReleaseExpired.
+func (mr *MockReservationServiceMockRecorder) ReleaseExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpired", reflect.TypeOf((*MockReservationService)(nil).ReleaseExpired), ctx)
+}
+
+// Reserve mocks base method.
+func (m *MockReservationService) Reserve(ctx context.Context, productID string, quantity int) (*entity.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, productID, quantity)
+	ret0, _ := ret[0].(*entity.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockReservationServiceMockRecorder) Reserve(ctx, productID, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockReservationService)(nil).Reserve), ctx, productID, quantity)
+}
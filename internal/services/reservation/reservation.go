@@ -0,0 +1,148 @@
+package reservation
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type reservationService struct {
+	txManager       repository.TxManager
+	reservationRepo repository.ReservationRepository
+	productRepo     repository.ProductRepository
+	policy          entity.ReservationPolicy
+}
+
+//go:generate mockgen -source=reservation.go -destination=mocks/mock_reservation.go -package=mocks
+type ReservationService interface {
+	// Reserve takes quantity of the product's stock and holds it for the policy's TTL.
+	Reserve(ctx context.Context, productID string, quantity int) (*entity.Reservation, error)
+	// Release ends a held reservation and puts its stock back. Only the principal that made the
+	// reservation can release it; to anyone else it does not exist.
+	Release(ctx context.Context, id string) (*entity.Reservation, error)
+	// ReleaseExpired ends a batch of reservations whose TTL has passed and returns how many.
+	ReleaseExpired(ctx context.Context) (int, error)
+}
+
+func NewReservationService(
+	txManager repository.TxManager,
+	reservationRepo repository.ReservationRepository,
+	productRepo repository.ProductRepository,
+	policy entity.ReservationPolicy,
+) ReservationService {
+	return &reservationService{
+		txManager:       txManager,
+		reservationRepo: reservationRepo,
+		productRepo:     productRepo,
+		policy:          policy,
+	}
+}
+
+// Reserve takes the stock and stores the reservation in one transaction, so stock is never held
+// without a reservation to release it.
+func (r reservationService) Reserve(ctx context.Context, productID string, quantity int) (*entity.Reservation, error) {
+	if quantity <= 0 {
+		return nil, apperr.ErrInvalidArgument.WithMessage("quantity must be greater than zero")
+	}
+
+	var reserved *entity.Reservation
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := r.productRepo.AdjustStock(ctx, []entity.StockAdjustment{{ProductID: productID, Delta: -quantity}})
+		if err != nil {
+			return err
+		}
+
+		id, err := r.reservationRepo.Create(ctx, &entity.Reservation{
+			ProductID: productID,
+			Quantity:  quantity,
+			Status:    entity.ReservationStatusHeld,
+			Actor:     entity.ActorFrom(ctx).Name,
+			Owner:     entity.PrincipalFrom(ctx),
+			ExpiresAt: time.Now().Add(r.policy.TTL),
+		})
+		if err != nil {
+			return err
+		}
+
+		reserved, err = r.reservationRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reserved, nil
+}
+
+func (r reservationService) Release(ctx context.Context, id string) (*entity.Reservation, error) {
+	var released *entity.Reservation
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		reservation, err := r.reservationRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if owner := entity.PrincipalFrom(ctx); owner == "" || reservation.Owner != owner {
+			return apperr.ErrNotFound.WithMessage("reservation not found")
+		}
+		if reservation.Status != entity.ReservationStatusHeld {
+			return apperr.ErrConflict.WithMessage("reservation is already " + string(reservation.Status))
+		}
+
+		err = r.end(ctx, *reservation, entity.ReservationStatusReleased)
+		if err != nil {
+			return err
+		}
+
+		released, err = r.reservationRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// ReleaseExpired expires each reservation in its own transaction, so one that cannot be ended
+// does not hold back the rest. When the stock has nowhere to go back to, because the product is
+// gone or has no active lot, the reservation is expired anyway and the loss is logged; retrying
+// would not help and would keep it at the head of every batch.
+func (r reservationService) ReleaseExpired(ctx context.Context) (int, error) {
+	reservations, err := r.reservationRepo.FindExpired(ctx, time.Now(), r.policy.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, reservation := range reservations {
+		err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			return r.end(ctx, reservation, entity.ReservationStatusExpired)
+		})
+		if apperr.GetCode(err) == apperr.ErrInsufficientStock.Code {
+			log.Printf("reservation %s: stock of product %s could not be put back: %v", reservation.ID, reservation.ProductID, err)
+			err = r.reservationRepo.UpdateStatus(ctx, reservation.ID, entity.ReservationStatusHeld, entity.ReservationStatusExpired)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return count, err
+			}
+			log.Printf("reservation %s: failed to expire: %v", reservation.ID, err)
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// end moves a held reservation to status and puts its stock back. The status change is made
+// first so that two concurrent releases cannot both restock.
+func (r reservationService) end(ctx context.Context, reservation entity.Reservation, status entity.ReservationStatus) error {
+	err := r.reservationRepo.UpdateStatus(ctx, reservation.ID, entity.ReservationStatusHeld, status)
+	if err != nil {
+		return err
+	}
+
+	return r.productRepo.AdjustStock(ctx, []entity.StockAdjustment{{ProductID: reservation.ProductID, Delta: reservation.Quantity}})
+}
@@ -0,0 +1,207 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ReservationServiceTestSuite struct {
+	suite.Suite
+	mockCtrl            *gomock.Controller
+	mockTxManager       *mocks.MockTxManager
+	mockReservationRepo *mocks.MockReservationRepository
+	mockProductRepo     *mocks.MockProductRepository
+	service             ReservationService
+	ctx                 context.Context
+}
+
+func (suite *ReservationServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+	suite.mockReservationRepo = mocks.NewMockReservationRepository(suite.mockCtrl)
+	suite.mockProductRepo = mocks.NewMockProductRepository(suite.mockCtrl)
+	suite.service = NewReservationService(suite.mockTxManager, suite.mockReservationRepo, suite.mockProductRepo, entity.ReservationPolicy{
+		TTL:       15 * time.Minute,
+		BatchSize: 10,
+	})
+
+	suite.mockTxManager.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	suite.ctx = entity.WithPrincipal(entity.WithActor(context.Background(), entity.Actor{Name: "pos-1"}), "token:1")
+}
+
+func (suite *ReservationServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *ReservationServiceTestSuite) TestReserve_Success() {
+	held := &entity.Reservation{ID: "reservation-1", ProductID: "product-1", Quantity: 2, Status: entity.ReservationStatusHeld}
+
+	suite.mockProductRepo.EXPECT().
+		AdjustStock(suite.ctx, []entity.StockAdjustment{{ProductID: "product-1", Delta: -2}}).
+		Return(nil).
+		Times(1)
+	suite.mockReservationRepo.EXPECT().
+		Create(suite.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, reservation *entity.Reservation) (string, error) {
+			suite.Equal("product-1", reservation.ProductID)
+			suite.Equal(2, reservation.Quantity)
+			suite.Equal(entity.ReservationStatusHeld, reservation.Status)
+			suite.Equal("pos-1", reservation.Actor)
+			suite.Equal("token:1", reservation.Owner)
+			suite.WithinDuration(time.Now().Add(15*time.Minute), reservation.ExpiresAt, time.Minute)
+			return "reservation-1", nil
+		}).
+		Times(1)
+	suite.mockReservationRepo.EXPECT().FindByID(suite.ctx, "reservation-1").Return(held, nil).Times(1)
+
+	result, err := suite.service.Reserve(suite.ctx, "product-1", 2)
+
+	suite.NoError(err)
+	suite.Equal(held, result)
+}
+
+func (suite *ReservationServiceTestSuite) TestReserve_InvalidQuantity() {
+	result, err := suite.service.Reserve(suite.ctx, "product-1", 0)
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *ReservationServiceTestSuite) TestReserve_InsufficientStock() {
+	suite.mockProductRepo.EXPECT().
+		AdjustStock(suite.ctx, gomock.Any()).
+		Return(apperr.ErrInsufficientStock).
+		Times(1)
+
+	result, err := suite.service.Reserve(suite.ctx, "product-1", 5)
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInsufficientStock.Code, apperr.GetCode(err))
+}
+
+func (suite *ReservationServiceTestSuite) TestRelease_Success() {
+	held := &entity.Reservation{ID: "reservation-1", ProductID: "product-1", Quantity: 2, Status: entity.ReservationStatusHeld, Actor: "pos-1", Owner: "token:1"}
+	released := &entity.Reservation{ID: "reservation-1", ProductID: "product-1", Quantity: 2, Status: entity.ReservationStatusReleased, Actor: "pos-1", Owner: "token:1"}
+
+	gomock.InOrder(
+		suite.mockReservationRepo.EXPECT().FindByID(suite.ctx, "reservation-1").Return(held, nil),
+		suite.mockReservationRepo.EXPECT().
+			UpdateStatus(suite.ctx, "reservation-1", entity.ReservationStatusHeld, entity.ReservationStatusReleased).
+			Return(nil),
+		suite.mockProductRepo.EXPECT().
+			AdjustStock(suite.ctx, []entity.StockAdjustment{{ProductID: "product-1", Delta: 2}}).
+			Return(nil),
+		suite.mockReservationRepo.EXPECT().FindByID(suite.ctx, "reservation-1").Return(released, nil),
+	)
+
+	result, err := suite.service.Release(suite.ctx, "reservation-1")
+
+	suite.NoError(err)
+	suite.Equal(released, result)
+}
+
+func (suite *ReservationServiceTestSuite) TestRelease_NotHeld() {
+	suite.mockReservationRepo.EXPECT().
+		FindByID(suite.ctx, "reservation-1").
+		Return(&entity.Reservation{ID: "reservation-1", Status: entity.ReservationStatusExpired, Actor: "pos-1", Owner: "token:1"}, nil).
+		Times(1)
+
+	result, err := suite.service.Release(suite.ctx, "reservation-1")
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func (suite *ReservationServiceTestSuite) TestRelease_OtherOwner() {
+	// The actor name is the caller's own claim, so matching it is not enough.
+	suite.mockReservationRepo.EXPECT().
+		FindByID(suite.ctx, "reservation-1").
+		Return(&entity.Reservation{ID: "reservation-1", ProductID: "product-1", Quantity: 2, Status: entity.ReservationStatusHeld, Actor: "pos-1", Owner: "token:2"}, nil).
+		Times(1)
+
+	result, err := suite.service.Release(suite.ctx, "reservation-1")
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+}
+
+func (suite *ReservationServiceTestSuite) TestRelease_Unauthenticated() {
+	ctx := entity.WithActor(context.Background(), entity.Actor{Name: "pos-1"})
+	suite.mockReservationRepo.EXPECT().
+		FindByID(ctx, "reservation-1").
+		Return(&entity.Reservation{ID: "reservation-1", ProductID: "product-1", Quantity: 2, Status: entity.ReservationStatusHeld, Actor: "pos-1"}, nil).
+		Times(1)
+
+	result, err := suite.service.Release(ctx, "reservation-1")
+
+	suite.Nil(result)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+}
+
+func (suite *ReservationServiceTestSuite) TestReleaseExpired() {
+	expired := []entity.Reservation{
+		{ID: "reservation-1", ProductID: "product-1", Quantity: 1, Status: entity.ReservationStatusHeld},
+		{ID: "reservation-2", ProductID: "product-2", Quantity: 3, Status: entity.ReservationStatusHeld},
+		{ID: "reservation-3", ProductID: "product-3", Quantity: 2, Status: entity.ReservationStatusHeld},
+	}
+	suite.mockReservationRepo.EXPECT().FindExpired(suite.ctx, gomock.Any(), 10).Return(expired, nil).Times(1)
+
+	suite.mockReservationRepo.EXPECT().
+		UpdateStatus(suite.ctx, "reservation-1", entity.ReservationStatusHeld, entity.ReservationStatusExpired).
+		Return(nil).
+		Times(1)
+	suite.mockProductRepo.EXPECT().
+		AdjustStock(suite.ctx, []entity.StockAdjustment{{ProductID: "product-1", Delta: 1}}).
+		Return(nil).
+		Times(1)
+
+	// The product is gone: the reservation is expired without its stock.
+	suite.mockReservationRepo.EXPECT().
+		UpdateStatus(suite.ctx, "reservation-2", entity.ReservationStatusHeld, entity.ReservationStatusExpired).
+		Return(nil).
+		Times(2)
+	suite.mockProductRepo.EXPECT().
+		AdjustStock(suite.ctx, []entity.StockAdjustment{{ProductID: "product-2", Delta: 3}}).
+		Return(apperr.ErrInsufficientStock).
+		Times(1)
+
+	// Released by someone else in the meantime.
+	suite.mockReservationRepo.EXPECT().
+		UpdateStatus(suite.ctx, "reservation-3", entity.ReservationStatusHeld, entity.ReservationStatusExpired).
+		Return(apperr.ErrConflict).
+		Times(1)
+
+	count, err := suite.service.ReleaseExpired(suite.ctx)
+
+	suite.NoError(err)
+	suite.Equal(2, count)
+}
+
+func (suite *ReservationServiceTestSuite) TestReleaseExpired_FindError() {
+	suite.mockReservationRepo.EXPECT().
+		FindExpired(suite.ctx, gomock.Any(), 10).
+		Return(nil, errors.New("database error")).
+		Times(1)
+
+	count, err := suite.service.ReleaseExpired(suite.ctx)
+
+	suite.Error(err)
+	suite.Zero(count)
+}
+
+func TestReservationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReservationServiceTestSuite))
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: retention.go
+//
+// Generated by this command:
+//
+//	mockgen -source=retention.go -destination=mocks/mock_retention.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mocktrash is a mock of trash interface.
+type Mocktrash struct {
+	ctrl     *gomock.Controller
+	recorder *MocktrashMockRecorder
+	isgomock struct{}
+}
+
+// MocktrashMockRecorder is the mock recorder for Mocktrash.
+type MocktrashMockRecorder struct {
+	mock *Mocktrash
+}
+
+// NewMocktrash creates a new mock instance.
+func NewMocktrash(ctrl *gomock.Controller) *Mocktrash {
+	mock := &Mocktrash{ctrl: ctrl}
+	mock.recorder = &MocktrashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktrash) EXPECT() *MocktrashMockRecorder {
+	return m.recorder
+}
+
+// FindExpiredIDs mocks base method.
+func (m *Mocktrash) FindExpiredIDs(ctx context.Context, deletedBefore time.Time, afterID string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredIDs", ctx, deletedBefore, afterID, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredIDs indicates an expected call of FindExpiredIDs.
+func (mr *MocktrashMockRecorder) FindExpiredIDs(ctx, deletedBefore, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredIDs", reflect.TypeOf((*Mocktrash)(nil).FindExpiredIDs), ctx, deletedBefore, afterID, limit)
+}
+
+// Purge mocks base method.
+func (m *Mocktrash) Purge(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MocktrashMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*Mocktrash)(nil).Purge), ctx, id)
+}
+
+// PurgeMany mocks base method.
+func (m *Mocktrash) PurgeMany(ctx context.Context, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeMany", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeMany indicates an expected call of PurgeMany.
+func (mr *MocktrashMockRecorder) PurgeMany(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeMany", reflect.TypeOf((*Mocktrash)(nil).PurgeMany), ctx, ids)
+}
+
+// MockRetentionService is a mock of RetentionService interface.
+type MockRetentionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetentionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRetentionServiceMockRecorder is the mock recorder for MockRetentionService.
+type MockRetentionServiceMockRecorder struct {
+	mock *MockRetentionService
+}
+
+// NewMockRetentionService creates a new mock instance.
+func NewMockRetentionService(ctrl *gomock.Controller) *MockRetentionService {
+	mock := &MockRetentionService{ctrl: ctrl}
+	mock.recorder = &MockRetentionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetentionService) EXPECT() *MockRetentionServiceMockRecorder {
+	return m.recorder
+}
+
+// LastRun mocks base method.
+func (m *MockRetentionService) LastRun(ctx context.Context) (*entity.RetentionRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastRun", ctx)
+	ret0, _ := ret[0].(*entity.RetentionRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastRun indicates an expected call of LastRun.
+func (mr *MockRetentionServiceMockRecorder) LastRun(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastRun", reflect.TypeOf((*MockRetentionService)(nil).LastRun), ctx)
+}
+
+// Run mocks base method.
+func (m *MockRetentionService) Run(ctx context.Context) (*entity.RetentionRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(*entity.RetentionRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockRetentionServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockRetentionService)(nil).Run), ctx)
+}
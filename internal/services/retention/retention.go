@@ -0,0 +1,148 @@
+package retention
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+// trash is what the retention policy needs of a repository holding soft-deleted rows.
+type trash interface {
+	FindExpiredIDs(ctx context.Context, deletedBefore time.Time, afterID string, limit int) ([]string, error)
+	PurgeMany(ctx context.Context, ids []string) error
+	Purge(ctx context.Context, id string) error
+}
+
+type retentionService struct {
+	productRepo  repository.ProductRepository
+	categoryRepo repository.CategoryRepository
+	runRepo      repository.RetentionRunRepository
+	policy       entity.RetentionPolicy
+}
+
+//go:generate mockgen -source=retention.go -destination=mocks/mock_retention.go -package=mocks
+type RetentionService interface {
+	// Run purges the products and then the categories soft-deleted more than the policy's days
+	// ago. Products go first so a category whose products have all expired goes in the same run.
+	// Every run is recorded, whether it completed or not.
+	Run(ctx context.Context) (*entity.RetentionRun, error)
+	// LastRun returns the outcome of the latest recorded run, failing with NOT_FOUND before the
+	// first one.
+	LastRun(ctx context.Context) (*entity.RetentionRun, error)
+}
+
+func NewRetentionService(productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository, runRepo repository.RetentionRunRepository, policy entity.RetentionPolicy) RetentionService {
+	return &retentionService{
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		runRepo:      runRepo,
+		policy:       policy,
+	}
+}
+
+func (r *retentionService) Run(ctx context.Context) (*entity.RetentionRun, error) {
+	now := time.Now()
+	run := &entity.RetentionRun{
+		StartedAt:     now,
+		DeletedBefore: now.AddDate(0, 0, -r.policy.Days),
+		DryRun:        r.policy.DryRun,
+	}
+
+	run.Products, run.Err = r.purgeExpired(ctx, "products", r.productRepo, run.DeletedBefore)
+	if run.Err == nil {
+		run.Categories, run.Err = r.purgeExpired(ctx, "categories", r.categoryRepo, run.DeletedBefore)
+	}
+	run.FinishedAt = time.Now()
+
+	if err := r.runRepo.Create(ctx, run); err != nil {
+		if run.Err == nil {
+			return run, err
+		}
+		log.Printf("retention: could not record run: %v", err)
+	}
+
+	return run, run.Err
+}
+
+func (r *retentionService) LastRun(ctx context.Context) (*entity.RetentionRun, error) {
+	run, err := r.runRepo.FindLatest(ctx)
+	if err != nil {
+		if apperr.GetCode(err) == apperr.ErrNotFound.Code {
+			return nil, apperr.ErrNotFound.WithMessage("retention has not run yet")
+		}
+		return nil, err
+	}
+	return run, nil
+}
+
+// purgeExpired purges the expired rows of one table a batch at a time and logs the ids of every
+// batch.
+func (r *retentionService) purgeExpired(ctx context.Context, table string, repo trash, deletedBefore time.Time) (entity.RetentionResult, error) {
+	var result entity.RetentionResult
+	afterID := ""
+	for {
+		ids, err := repo.FindExpiredIDs(ctx, deletedBefore, afterID, r.policy.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(ids) == 0 {
+			return result, nil
+		}
+		afterID = ids[len(ids)-1]
+
+		if r.policy.DryRun {
+			result.Purged = append(result.Purged, ids...)
+			log.Printf("retention: dry run, would purge %d %s: %s", len(ids), table, strings.Join(ids, ", "))
+		} else {
+			purged, skipped, err := purgeBatch(ctx, repo, ids)
+			result.Purged = append(result.Purged, purged...)
+			result.Skipped = append(result.Skipped, skipped...)
+			if len(purged) > 0 {
+				log.Printf("retention: purged %d %s: %s", len(purged), table, strings.Join(purged, ", "))
+			}
+			if len(skipped) > 0 {
+				log.Printf("retention: kept %d referenced %s: %s", len(skipped), table, strings.Join(skipped, ", "))
+			}
+			if err != nil {
+				return result, err
+			}
+		}
+
+		if len(ids) < r.policy.BatchSize {
+			return result, nil
+		}
+	}
+}
+
+// purgeBatch purges ids in one statement. When some of them are still referenced it falls back
+// to purging them one at a time, so the rest still go and only the referenced ones are skipped.
+func purgeBatch(ctx context.Context, repo trash, ids []string) ([]string, []string, error) {
+	err := repo.PurgeMany(ctx, ids)
+	if err == nil {
+		return ids, nil, nil
+	}
+	if apperr.GetCode(err) != apperr.ErrConflict.Code {
+		return nil, nil, err
+	}
+
+	var purged, skipped []string
+	for _, id := range ids {
+		err := repo.Purge(ctx, id)
+		switch {
+		case err == nil:
+			purged = append(purged, id)
+		case apperr.GetCode(err) == apperr.ErrConflict.Code:
+			skipped = append(skipped, id)
+		case apperr.GetCode(err) == apperr.ErrNotFound.Code:
+			// Restored since it was listed.
+		default:
+			return purged, skipped, err
+		}
+	}
+	return purged, skipped, nil
+}
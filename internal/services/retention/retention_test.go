@@ -0,0 +1,149 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type RetentionServiceTestSuite struct {
+	suite.Suite
+	mockCtrl         *gomock.Controller
+	mockProductRepo  *mocks.MockProductRepository
+	mockCategoryRepo *mocks.MockCategoryRepository
+	mockRunRepo      *mocks.MockRetentionRunRepository
+	service          RetentionService
+	ctx              context.Context
+}
+
+func (suite *RetentionServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockProductRepo = mocks.NewMockProductRepository(suite.mockCtrl)
+	suite.mockCategoryRepo = mocks.NewMockCategoryRepository(suite.mockCtrl)
+	suite.mockRunRepo = mocks.NewMockRetentionRunRepository(suite.mockCtrl)
+	suite.service = suite.newService(entity.RetentionPolicy{Days: 30, BatchSize: 2})
+	suite.ctx = context.Background()
+}
+
+func (suite *RetentionServiceTestSuite) newService(policy entity.RetentionPolicy) RetentionService {
+	return NewRetentionService(suite.mockProductRepo, suite.mockCategoryRepo, suite.mockRunRepo, policy)
+}
+
+func (suite *RetentionServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *RetentionServiceTestSuite) TestRun_PurgesInBatches() {
+	gomock.InOrder(
+		suite.mockProductRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "", 2).Return([]string{"p1", "p2"}, nil),
+		suite.mockProductRepo.EXPECT().PurgeMany(suite.ctx, []string{"p1", "p2"}).Return(nil),
+		suite.mockProductRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "p2", 2).Return([]string{"p3"}, nil),
+		suite.mockProductRepo.EXPECT().PurgeMany(suite.ctx, []string{"p3"}).Return(nil),
+		suite.mockCategoryRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "", 2).Return([]string{"c1"}, nil),
+		suite.mockCategoryRepo.EXPECT().PurgeMany(suite.ctx, []string{"c1"}).Return(nil),
+		suite.mockRunRepo.EXPECT().Create(suite.ctx, gomock.Any()).Return(nil),
+	)
+
+	run, err := suite.service.Run(suite.ctx)
+
+	suite.NoError(err)
+	suite.False(run.DryRun)
+	suite.Equal([]string{"p1", "p2", "p3"}, run.Products.Purged)
+	suite.Equal([]string{"c1"}, run.Categories.Purged)
+	suite.WithinDuration(run.StartedAt.AddDate(0, 0, -30), run.DeletedBefore, time.Second)
+	suite.False(run.FinishedAt.Before(run.StartedAt))
+}
+
+func (suite *RetentionServiceTestSuite) TestRun_SkipsReferencedRows() {
+	suite.mockProductRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "", 2).Return([]string{"p1", "p2"}, nil)
+	suite.mockProductRepo.EXPECT().PurgeMany(suite.ctx, []string{"p1", "p2"}).Return(apperr.ErrConflict)
+	suite.mockProductRepo.EXPECT().Purge(suite.ctx, "p1").Return(apperr.ErrConflict)
+	suite.mockProductRepo.EXPECT().Purge(suite.ctx, "p2").Return(nil)
+	suite.mockProductRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "p2", 2).Return(nil, nil)
+	suite.mockCategoryRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "", 2).Return([]string{"c1", "c2"}, nil)
+	suite.mockCategoryRepo.EXPECT().PurgeMany(suite.ctx, []string{"c1", "c2"}).Return(apperr.ErrConflict)
+	suite.mockCategoryRepo.EXPECT().Purge(suite.ctx, "c1").Return(apperr.ErrNotFound)
+	suite.mockCategoryRepo.EXPECT().Purge(suite.ctx, "c2").Return(apperr.ErrConflict)
+	suite.mockCategoryRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "c2", 2).Return(nil, nil)
+	suite.mockRunRepo.EXPECT().Create(suite.ctx, gomock.Any()).Return(nil)
+
+	run, err := suite.service.Run(suite.ctx)
+
+	suite.NoError(err)
+	suite.Equal([]string{"p2"}, run.Products.Purged)
+	suite.Equal([]string{"p1"}, run.Products.Skipped)
+	suite.Empty(run.Categories.Purged)
+	suite.Equal([]string{"c2"}, run.Categories.Skipped)
+}
+
+func (suite *RetentionServiceTestSuite) TestRun_DryRun() {
+	service := suite.newService(entity.RetentionPolicy{Days: 7, BatchSize: 2, DryRun: true})
+	suite.mockProductRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "", 2).Return([]string{"p1", "p2"}, nil)
+	suite.mockProductRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "p2", 2).Return(nil, nil)
+	suite.mockCategoryRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "", 2).Return([]string{"c1"}, nil)
+	suite.mockRunRepo.EXPECT().Create(suite.ctx, gomock.Any()).Return(nil)
+
+	run, err := service.Run(suite.ctx)
+
+	suite.NoError(err)
+	suite.True(run.DryRun)
+	suite.Equal([]string{"p1", "p2"}, run.Products.Purged)
+	suite.Equal([]string{"c1"}, run.Categories.Purged)
+}
+
+func (suite *RetentionServiceTestSuite) TestRun_FailureIsRecorded() {
+	suite.mockProductRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "", 2).Return([]string{"p1"}, nil)
+	suite.mockProductRepo.EXPECT().PurgeMany(suite.ctx, []string{"p1"}).Return(apperr.ErrInternal)
+	var recorded *entity.RetentionRun
+	suite.mockRunRepo.EXPECT().Create(suite.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, run *entity.RetentionRun) error {
+			recorded = run
+			return nil
+		})
+
+	run, err := suite.service.Run(suite.ctx)
+
+	suite.Equal(apperr.ErrInternal, err)
+	suite.Empty(run.Products.Purged)
+	suite.Same(run, recorded)
+	suite.Equal(apperr.ErrInternal, recorded.Err)
+}
+
+func (suite *RetentionServiceTestSuite) TestRun_RecordingFails() {
+	suite.mockProductRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "", 2).Return(nil, nil)
+	suite.mockCategoryRepo.EXPECT().FindExpiredIDs(suite.ctx, gomock.Any(), "", 2).Return(nil, nil)
+	suite.mockRunRepo.EXPECT().Create(suite.ctx, gomock.Any()).Return(apperr.ErrInternal)
+
+	_, err := suite.service.Run(suite.ctx)
+
+	suite.Equal(apperr.ErrInternal, err)
+}
+
+func (suite *RetentionServiceTestSuite) TestLastRun_ReadsLatestRecordedRun() {
+	latest := &entity.RetentionRun{StartedAt: time.Now(), Products: entity.RetentionResult{Purged: []string{"p1"}}}
+	suite.mockRunRepo.EXPECT().FindLatest(suite.ctx).Return(latest, nil)
+
+	run, err := suite.service.LastRun(suite.ctx)
+
+	suite.NoError(err)
+	suite.Same(latest, run)
+}
+
+func (suite *RetentionServiceTestSuite) TestLastRun_NotRunYet() {
+	suite.mockRunRepo.EXPECT().FindLatest(suite.ctx).Return(nil, apperr.ErrNotFound)
+
+	run, err := suite.service.LastRun(suite.ctx)
+
+	suite.Nil(run)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+}
+
+func TestRetentionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RetentionServiceTestSuite))
+}
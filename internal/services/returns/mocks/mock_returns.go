@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: returns.go
+//
+// Generated by this command:
+//
+//	mockgen -source=returns.go -destination=mocks/mock_returns.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReturnService is a mock of ReturnService interface.
+type MockReturnService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReturnServiceMockRecorder is the mock recorder for MockReturnService.
+type MockReturnServiceMockRecorder struct {
+	mock *MockReturnService
+}
+
+// NewMockReturnService creates a new mock instance.
+func NewMockReturnService(ctrl *gomock.Controller) *MockReturnService {
+	mock := &MockReturnService{ctrl: ctrl}
+	mock.recorder = &MockReturnServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnService) EXPECT() *MockReturnServiceMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockReturnService) Cancel(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockReturnServiceMockRecorder) Cancel(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockReturnService)(nil).Cancel), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockReturnService) Create(ctx context.Context, rma entity.ReturnAuthorization) (*entity.ReturnAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, rma)
+	ret0, _ := ret[0].(*entity.ReturnAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnServiceMockRecorder) Create(ctx, rma any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturnService)(nil).Create), ctx, rma)
+}
+
+// GetAll mocks base method.
+func (m *MockReturnService) GetAll(ctx context.Context, filter entity.ReturnsFilter) ([]entity.ReturnAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
+	ret0, _ := ret[0].([]entity.ReturnAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockReturnServiceMockRecorder) GetAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockReturnService)(nil).GetAll), ctx, filter)
+}
+
+// GetByID mocks base method.
+func (m *MockReturnService) GetByID(ctx context.Context, id string) (*entity.ReturnAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.ReturnAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReturnServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReturnService)(nil).GetByID), ctx, id)
+}
+
+// Receive mocks base method.
+func (m *MockReturnService) Receive(ctx context.Context, id string) (*entity.ReturnAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, id)
+	ret0, _ := ret[0].(*entity.ReturnAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockReturnServiceMockRecorder) Receive(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockReturnService)(nil).Receive), ctx, id)
+}
+
+// ReturnRates mocks base method.
+func (m *MockReturnService) ReturnRates(ctx context.Context, filter entity.ReturnRateFilter) ([]entity.ReturnRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnRates", ctx, filter)
+	ret0, _ := ret[0].([]entity.ReturnRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnRates indicates an expected call of ReturnRates.
+func (mr *MockReturnServiceMockRecorder) ReturnRates(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnRates", reflect.TypeOf((*MockReturnService)(nil).ReturnRates), ctx, filter)
+}
@@ -0,0 +1,203 @@
+package returns
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type returnService struct {
+	txManager   repository.TxManager
+	returnRepo  repository.ReturnRepository
+	orderRepo   repository.OrderRepository
+	productRepo repository.ProductRepository
+}
+
+//go:generate mockgen -source=returns.go -destination=mocks/mock_returns.go -package=mocks
+type ReturnService interface {
+	Create(ctx context.Context, rma entity.ReturnAuthorization) (*entity.ReturnAuthorization, error)
+	GetByID(ctx context.Context, id string) (*entity.ReturnAuthorization, error)
+	GetAll(ctx context.Context, filter entity.ReturnsFilter) ([]entity.ReturnAuthorization, error)
+	Receive(ctx context.Context, id string) (*entity.ReturnAuthorization, error)
+	Cancel(ctx context.Context, id string) error
+	ReturnRates(ctx context.Context, filter entity.ReturnRateFilter) ([]entity.ReturnRate, error)
+}
+
+func NewReturnService(
+	txManager repository.TxManager,
+	returnRepo repository.ReturnRepository,
+	orderRepo repository.OrderRepository,
+	productRepo repository.ProductRepository,
+) ReturnService {
+	return &returnService{
+		txManager:   txManager,
+		returnRepo:  returnRepo,
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+	}
+}
+
+// Create authorizes a return. When the return references an order, the order must have been
+// shipped and each product may not be returned in a larger quantity than was ordered, less what
+// the order's other returns already cover. The order stays locked until the return is stored, so
+// concurrent returns against it are checked one after another.
+func (r returnService) Create(ctx context.Context, rma entity.ReturnAuthorization) (*entity.ReturnAuthorization, error) {
+	if len(rma.Lines) == 0 {
+		return nil, apperr.ErrInvalidArgument.WithMessage("return must have at least one line")
+	}
+
+	requested := make(map[string]int, len(rma.Lines))
+	productIDs := make([]string, 0, len(rma.Lines))
+	for i, line := range rma.Lines {
+		if line.Quantity <= 0 {
+			return nil, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("line %d: quantity must be greater than zero", i))
+		}
+		if !line.Disposition.IsValid() {
+			return nil, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("line %d: disposition must be one of restock, refurbish, scrap", i))
+		}
+		if _, seen := requested[line.ProductID]; !seen {
+			productIDs = append(productIDs, line.ProductID)
+		}
+		requested[line.ProductID] += line.Quantity
+	}
+
+	products, err := r.productRepo.FindByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) != len(productIDs) {
+		return nil, apperr.ErrNotFound.WithMessage("one or more returned products do not exist")
+	}
+
+	rma.Status = entity.ReturnStatusAuthorized
+	rma.ReceivedAt = nil
+
+	var created *entity.ReturnAuthorization
+	err = r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if rma.OrderID != nil {
+			err := r.checkAgainstOrder(ctx, *rma.OrderID, requested)
+			if err != nil {
+				return err
+			}
+		}
+
+		id, err := r.returnRepo.Create(ctx, &rma)
+		if err != nil {
+			return err
+		}
+
+		created, err = r.returnRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r returnService) checkAgainstOrder(ctx context.Context, orderID string, requested map[string]int) error {
+	order, err := r.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if order.Status != entity.OrderStatusShipped {
+		return apperr.ErrConflict.WithMessage(fmt.Sprintf("only shipped orders can be returned, order is %s", order.Status))
+	}
+
+	ordered := make(map[string]int, len(order.Lines))
+	for _, line := range order.Lines {
+		ordered[line.ProductID] += line.Quantity
+	}
+
+	returned, err := r.returnRepo.ReturnedQuantities(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for productID, quantity := range requested {
+		remaining := ordered[productID] - returned[productID]
+		if quantity > remaining {
+			return apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("product %s: returning %d exceeds the %d of %d ordered not yet returned", productID, quantity, remaining, ordered[productID]))
+		}
+	}
+	return nil
+}
+
+func (r returnService) GetByID(ctx context.Context, id string) (*entity.ReturnAuthorization, error) {
+	return r.returnRepo.FindByID(ctx, id)
+}
+
+func (r returnService) GetAll(ctx context.Context, filter entity.ReturnsFilter) ([]entity.ReturnAuthorization, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	return r.returnRepo.FindAll(ctx, filter)
+}
+
+// Receive marks the returned goods as arrived and puts restock lines back into stock through
+// ProductRepository.AdjustStock. Refurbish and scrap lines are recorded but do not change stock.
+// The status change is made first so a return cannot be restocked twice.
+func (r returnService) Receive(ctx context.Context, id string) (*entity.ReturnAuthorization, error) {
+	rma, err := r.transition(ctx, id, entity.ReturnStatusReceived)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustments := rma.RestockAdjustments()
+	if len(adjustments) > 0 {
+		err = r.productRepo.AdjustStock(ctx, adjustments)
+		if err != nil {
+			if revertErr := r.returnRepo.UpdateStatus(ctx, id, entity.ReturnStatusReceived, rma.Status); revertErr != nil {
+				log.Printf("return %s: failed to revert receipt after restock error: %v", id, revertErr)
+			}
+			return nil, err
+		}
+	}
+
+	return r.returnRepo.FindByID(ctx, id)
+}
+
+func (r returnService) Cancel(ctx context.Context, id string) error {
+	_, err := r.transition(ctx, id, entity.ReturnStatusCancelled)
+	return err
+}
+
+func (r returnService) ReturnRates(ctx context.Context, filter entity.ReturnRateFilter) ([]entity.ReturnRate, error) {
+	switch filter.GroupBy {
+	case "":
+		filter.GroupBy = entity.ReturnRateByProduct
+	case entity.ReturnRateByProduct, entity.ReturnRateByCategory:
+	default:
+		return nil, apperr.ErrInvalidArgument.WithMessage("groupBy must be product or category")
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, apperr.ErrInvalidArgument.WithMessage("from must be before to")
+	}
+
+	return r.returnRepo.ReturnRates(ctx, filter)
+}
+
+func (r returnService) transition(ctx context.Context, id string, to entity.ReturnStatus) (*entity.ReturnAuthorization, error) {
+	rma, err := r.returnRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rma.Status.CanTransitionTo(to) {
+		return nil, apperr.ErrConflict.WithMessage(fmt.Sprintf("cannot move return from %s to %s", rma.Status, to))
+	}
+
+	err = r.returnRepo.UpdateStatus(ctx, id, rma.Status, to)
+	if err != nil {
+		return nil, err
+	}
+	return rma, nil
+}
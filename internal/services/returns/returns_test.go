@@ -0,0 +1,341 @@
+package returns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ReturnServiceTestSuite struct {
+	suite.Suite
+	mockCtrl        *gomock.Controller
+	mockTxManager   *mocks.MockTxManager
+	mockReturnRepo  *mocks.MockReturnRepository
+	mockOrderRepo   *mocks.MockOrderRepository
+	mockProductRepo *mocks.MockProductRepository
+	service         ReturnService
+	ctx             context.Context
+}
+
+func (suite *ReturnServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+	suite.mockReturnRepo = mocks.NewMockReturnRepository(suite.mockCtrl)
+	suite.mockOrderRepo = mocks.NewMockOrderRepository(suite.mockCtrl)
+	suite.mockProductRepo = mocks.NewMockProductRepository(suite.mockCtrl)
+	suite.service = NewReturnService(suite.mockTxManager, suite.mockReturnRepo, suite.mockOrderRepo, suite.mockProductRepo)
+
+	suite.mockTxManager.EXPECT().
+		WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	suite.ctx = context.Background()
+}
+
+func (suite *ReturnServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *ReturnServiceTestSuite) TestCreate_Success() {
+
+	orderID := "order-1"
+	rma := entity.ReturnAuthorization{
+		OrderID: &orderID,
+		Reason:  "damaged in transit",
+		Lines: []entity.ReturnLine{
+			{ProductID: "product-1", Quantity: 1, Disposition: entity.ReturnDispositionRestock},
+			{ProductID: "product-1", Quantity: 1, Disposition: entity.ReturnDispositionScrap},
+		},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{"product-1"}).
+		Return([]entity.Product{{ID: "product-1"}}, nil).
+		Times(1)
+
+	suite.mockOrderRepo.EXPECT().
+		FindByID(suite.ctx, orderID).
+		Return(&entity.Order{
+			ID:     orderID,
+			Status: entity.OrderStatusShipped,
+			Lines:  []entity.OrderLine{{ProductID: "product-1", Quantity: 2}},
+		}, nil).
+		Times(1)
+
+	suite.mockReturnRepo.EXPECT().
+		ReturnedQuantities(suite.ctx, orderID).
+		Return(map[string]int{}, nil).
+		Times(1)
+
+	suite.mockReturnRepo.EXPECT().
+		Create(suite.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, rma *entity.ReturnAuthorization) (string, error) {
+			suite.Equal(entity.ReturnStatusAuthorized, rma.Status)
+			suite.Len(rma.Lines, 2)
+			return "rma-123", nil
+		}).
+		Times(1)
+
+	suite.mockReturnRepo.EXPECT().
+		FindByID(suite.ctx, "rma-123").
+		Return(&entity.ReturnAuthorization{ID: "rma-123", Status: entity.ReturnStatusAuthorized}, nil).
+		Times(1)
+
+	result, err := suite.service.Create(suite.ctx, rma)
+
+	suite.NoError(err)
+	suite.Equal("rma-123", result.ID)
+}
+
+func (suite *ReturnServiceTestSuite) TestCreate_InvalidDisposition() {
+
+	rma := entity.ReturnAuthorization{
+		Lines: []entity.ReturnLine{{ProductID: "product-1", Quantity: 1, Disposition: "resell"}},
+	}
+
+	result, err := suite.service.Create(suite.ctx, rma)
+
+	suite.Error(err)
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *ReturnServiceTestSuite) TestCreate_ProductNotFound() {
+
+	rma := entity.ReturnAuthorization{
+		Lines: []entity.ReturnLine{{ProductID: "missing", Quantity: 1, Disposition: entity.ReturnDispositionScrap}},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{"missing"}).
+		Return([]entity.Product{}, nil).
+		Times(1)
+
+	result, err := suite.service.Create(suite.ctx, rma)
+
+	suite.Error(err)
+	suite.Nil(result)
+	suite.Equal(apperr.ErrNotFound.Code, apperr.GetCode(err))
+}
+
+func (suite *ReturnServiceTestSuite) TestCreate_ExceedsOrderedQuantity() {
+
+	orderID := "order-1"
+	rma := entity.ReturnAuthorization{
+		OrderID: &orderID,
+		Lines:   []entity.ReturnLine{{ProductID: "product-1", Quantity: 3, Disposition: entity.ReturnDispositionRestock}},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{"product-1"}).
+		Return([]entity.Product{{ID: "product-1"}}, nil).
+		Times(1)
+
+	suite.mockOrderRepo.EXPECT().
+		FindByID(suite.ctx, orderID).
+		Return(&entity.Order{
+			ID:     orderID,
+			Status: entity.OrderStatusShipped,
+			Lines:  []entity.OrderLine{{ProductID: "product-1", Quantity: 2}},
+		}, nil).
+		Times(1)
+
+	suite.mockReturnRepo.EXPECT().
+		ReturnedQuantities(suite.ctx, orderID).
+		Return(map[string]int{}, nil).
+		Times(1)
+
+	result, err := suite.service.Create(suite.ctx, rma)
+
+	suite.Error(err)
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *ReturnServiceTestSuite) TestCreate_ExceedsQuantityLeftAfterEarlierReturns() {
+
+	orderID := "order-1"
+	rma := entity.ReturnAuthorization{
+		OrderID: &orderID,
+		Lines:   []entity.ReturnLine{{ProductID: "product-1", Quantity: 2, Disposition: entity.ReturnDispositionRestock}},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{"product-1"}).
+		Return([]entity.Product{{ID: "product-1"}}, nil).
+		Times(1)
+
+	suite.mockOrderRepo.EXPECT().
+		FindByID(suite.ctx, orderID).
+		Return(&entity.Order{
+			ID:     orderID,
+			Status: entity.OrderStatusShipped,
+			Lines:  []entity.OrderLine{{ProductID: "product-1", Quantity: 3}},
+		}, nil).
+		Times(1)
+
+	suite.mockReturnRepo.EXPECT().
+		ReturnedQuantities(suite.ctx, orderID).
+		Return(map[string]int{"product-1": 2}, nil).
+		Times(1)
+
+	result, err := suite.service.Create(suite.ctx, rma)
+
+	suite.Error(err)
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+	suite.Contains(err.Error(), "returning 2 exceeds the 1 of 3 ordered not yet returned")
+}
+
+func (suite *ReturnServiceTestSuite) TestCreate_OrderNotShipped() {
+
+	orderID := "order-1"
+	rma := entity.ReturnAuthorization{
+		OrderID: &orderID,
+		Lines:   []entity.ReturnLine{{ProductID: "product-1", Quantity: 1, Disposition: entity.ReturnDispositionRestock}},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByIDs(suite.ctx, []string{"product-1"}).
+		Return([]entity.Product{{ID: "product-1"}}, nil).
+		Times(1)
+
+	suite.mockOrderRepo.EXPECT().
+		FindByID(suite.ctx, orderID).
+		Return(&entity.Order{ID: orderID, Status: entity.OrderStatusPaid}, nil).
+		Times(1)
+
+	result, err := suite.service.Create(suite.ctx, rma)
+
+	suite.Error(err)
+	suite.Nil(result)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func (suite *ReturnServiceTestSuite) TestReceive_RestocksOnlyRestockLines() {
+
+	rma := &entity.ReturnAuthorization{
+		ID:     "rma-123",
+		Status: entity.ReturnStatusAuthorized,
+		Lines: []entity.ReturnLine{
+			{ProductID: "product-1", Quantity: 2, Disposition: entity.ReturnDispositionRestock},
+			{ProductID: "product-1", Quantity: 1, Disposition: entity.ReturnDispositionRefurbish},
+			{ProductID: "product-2", Quantity: 1, Disposition: entity.ReturnDispositionScrap},
+		},
+	}
+
+	gomock.InOrder(
+		suite.mockReturnRepo.EXPECT().
+			FindByID(suite.ctx, "rma-123").
+			Return(rma, nil),
+		suite.mockReturnRepo.EXPECT().
+			UpdateStatus(suite.ctx, "rma-123", entity.ReturnStatusAuthorized, entity.ReturnStatusReceived).
+			Return(nil),
+		suite.mockProductRepo.EXPECT().
+			AdjustStock(suite.ctx, []entity.StockAdjustment{{ProductID: "product-1", Delta: 2}}).
+			Return(nil),
+		suite.mockReturnRepo.EXPECT().
+			FindByID(suite.ctx, "rma-123").
+			Return(&entity.ReturnAuthorization{ID: "rma-123", Status: entity.ReturnStatusReceived}, nil),
+	)
+
+	result, err := suite.service.Receive(suite.ctx, "rma-123")
+
+	suite.NoError(err)
+	suite.Equal(entity.ReturnStatusReceived, result.Status)
+}
+
+func (suite *ReturnServiceTestSuite) TestReceive_NoRestockLines() {
+
+	rma := &entity.ReturnAuthorization{
+		ID:     "rma-123",
+		Status: entity.ReturnStatusAuthorized,
+		Lines:  []entity.ReturnLine{{ProductID: "product-1", Quantity: 1, Disposition: entity.ReturnDispositionScrap}},
+	}
+
+	suite.mockReturnRepo.EXPECT().FindByID(suite.ctx, "rma-123").Return(rma, nil).Times(2)
+	suite.mockReturnRepo.EXPECT().
+		UpdateStatus(suite.ctx, "rma-123", entity.ReturnStatusAuthorized, entity.ReturnStatusReceived).
+		Return(nil).
+		Times(1)
+
+	_, err := suite.service.Receive(suite.ctx, "rma-123")
+
+	suite.NoError(err)
+}
+
+func (suite *ReturnServiceTestSuite) TestReceive_AlreadyReceived() {
+
+	suite.mockReturnRepo.EXPECT().
+		FindByID(suite.ctx, "rma-123").
+		Return(&entity.ReturnAuthorization{ID: "rma-123", Status: entity.ReturnStatusReceived}, nil).
+		Times(1)
+
+	result, err := suite.service.Receive(suite.ctx, "rma-123")
+
+	suite.Error(err)
+	suite.Nil(result)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func (suite *ReturnServiceTestSuite) TestReceive_RestockFailsRevertsStatus() {
+
+	rma := &entity.ReturnAuthorization{
+		ID:     "rma-123",
+		Status: entity.ReturnStatusAuthorized,
+		Lines:  []entity.ReturnLine{{ProductID: "product-1", Quantity: 1, Disposition: entity.ReturnDispositionRestock}},
+	}
+
+	gomock.InOrder(
+		suite.mockReturnRepo.EXPECT().
+			FindByID(suite.ctx, "rma-123").
+			Return(rma, nil),
+		suite.mockReturnRepo.EXPECT().
+			UpdateStatus(suite.ctx, "rma-123", entity.ReturnStatusAuthorized, entity.ReturnStatusReceived).
+			Return(nil),
+		suite.mockProductRepo.EXPECT().
+			AdjustStock(suite.ctx, gomock.Any()).
+			Return(apperr.ErrInsufficientStock),
+		suite.mockReturnRepo.EXPECT().
+			UpdateStatus(suite.ctx, "rma-123", entity.ReturnStatusReceived, entity.ReturnStatusAuthorized).
+			Return(nil),
+	)
+
+	result, err := suite.service.Receive(suite.ctx, "rma-123")
+
+	suite.Error(err)
+	suite.Nil(result)
+}
+
+func (suite *ReturnServiceTestSuite) TestReturnRates_DefaultsToProduct() {
+
+	suite.mockReturnRepo.EXPECT().
+		ReturnRates(suite.ctx, entity.ReturnRateFilter{GroupBy: entity.ReturnRateByProduct}).
+		Return([]entity.ReturnRate{{ID: "product-1", SoldQuantity: 10, ReturnedQuantity: 1, Rate: 0.1}}, nil).
+		Times(1)
+
+	result, err := suite.service.ReturnRates(suite.ctx, entity.ReturnRateFilter{})
+
+	suite.NoError(err)
+	suite.Len(result, 1)
+}
+
+func (suite *ReturnServiceTestSuite) TestReturnRates_InvalidGroup() {
+
+	result, err := suite.service.ReturnRates(suite.ctx, entity.ReturnRateFilter{GroupBy: "supplier"})
+
+	suite.Error(err)
+	suite.Nil(result)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func TestReturnServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReturnServiceTestSuite))
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stream.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stream.go -destination=mocks/mock_stream.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	stream "github.com/sirawong/crud-arise/internal/services/stream"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStreamService is a mock of StreamService interface.
+type MockStreamService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStreamServiceMockRecorder is the mock recorder for MockStreamService.
+type MockStreamServiceMockRecorder struct {
+	mock *MockStreamService
+}
+
+// NewMockStreamService creates a new mock instance.
+func NewMockStreamService(ctrl *gomock.Controller) *MockStreamService {
+	mock := &MockStreamService{ctrl: ctrl}
+	mock.recorder = &MockStreamServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamService) EXPECT() *MockStreamServiceMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockStreamService) Subscribe(ctx context.Context, filter entity.StreamFilter, lastSeq *int64) (stream.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, filter, lastSeq)
+	ret0, _ := ret[0].(stream.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockStreamServiceMockRecorder) Subscribe(ctx, filter, lastSeq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockStreamService)(nil).Subscribe), ctx, filter, lastSeq)
+}
+
+// MockSubscription is a mock of Subscription interface.
+type MockSubscription struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionMockRecorder is the mock recorder for MockSubscription.
+type MockSubscriptionMockRecorder struct {
+	mock *MockSubscription
+}
+
+// NewMockSubscription creates a new mock instance.
+func NewMockSubscription(ctrl *gomock.Controller) *MockSubscription {
+	mock := &MockSubscription{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscription) EXPECT() *MockSubscriptionMockRecorder {
+	return m.recorder
+}
+
+// Changes mocks base method.
+func (m *MockSubscription) Changes() <-chan entity.Change {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Changes")
+	ret0, _ := ret[0].(<-chan entity.Change)
+	return ret0
+}
+
+// Changes indicates an expected call of Changes.
+func (mr *MockSubscriptionMockRecorder) Changes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Changes", reflect.TypeOf((*MockSubscription)(nil).Changes))
+}
+
+// Close mocks base method.
+func (m *MockSubscription) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSubscriptionMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSubscription)(nil).Close))
+}
+
+// Err mocks base method.
+func (m *MockSubscription) Err() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Err")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Err indicates an expected call of Err.
+func (mr *MockSubscriptionMockRecorder) Err() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockSubscription)(nil).Err))
+}
@@ -0,0 +1,301 @@
+package stream
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+var (
+	errTooSlow  = apperr.ErrUnavailable.WithMessage("subscriber fell too far behind; reconnect to resume")
+	errStopping = apperr.ErrUnavailable.WithMessage("product stream is shutting down")
+)
+
+//go:generate mockgen -source=stream.go -destination=mocks/mock_stream.go -package=mocks
+type StreamService interface {
+	// Subscribe streams the product changes filter keeps, starting after the change numbered
+	// lastSeq, or with the next change to commit when lastSeq is nil. The subscription lasts
+	// until ctx is done or it is closed, unless the subscriber falls behind or the service stops.
+	Subscribe(ctx context.Context, filter entity.StreamFilter, lastSeq *int64) (Subscription, error)
+}
+
+type Subscription interface {
+	// Changes delivers the changes in the order they committed. It is closed when the
+	// subscription ends.
+	Changes() <-chan entity.Change
+	// Err tells why the subscription ended once Changes is closed, nil when it was closed or
+	// its context ended.
+	Err() error
+	Close()
+}
+
+// Broker is the StreamService. It follows the changes feed and hands every new change to the
+// subscribers it matches, so the feed is read once however many subscribers there are.
+// Subscribers resuming from an earlier change are replayed the changes they missed first.
+type Broker struct {
+	changeRepo repository.ChangeRepository
+	policy     entity.StreamPolicy
+
+	mu          sync.Mutex
+	subscribers map[*subscription]struct{}
+	head        int64
+	running     bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewBroker(changeRepo repository.ChangeRepository, policy entity.StreamPolicy) *Broker {
+	return &Broker{
+		changeRepo:  changeRepo,
+		policy:      policy,
+		subscribers: make(map[*subscription]struct{}),
+	}
+}
+
+// Start looks up the latest change and then follows the feed from there until Stop is called.
+func (b *Broker) Start() error {
+	if b.policy.PollInterval <= 0 {
+		log.Println("stream: product stream disabled")
+		return nil
+	}
+
+	head, err := b.changeRepo.LastSeq(context.Background())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	b.mu.Lock()
+	b.head = head
+	b.running = true
+	b.mu.Unlock()
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.loop(ctx)
+	}()
+	return nil
+}
+
+func (b *Broker) loop(ctx context.Context) {
+	timer := time.NewTimer(b.policy.PollInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		count, err := b.poll(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("stream: reading changes failed: %v", err)
+		}
+
+		if err == nil && count == b.policy.BatchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(b.policy.PollInterval)
+		}
+	}
+}
+
+// poll reads the changes committed since the last poll and hands each to the subscribers it
+// matches. A subscriber whose buffer is full is cut off rather than holding up the others.
+func (b *Broker) poll(ctx context.Context) (int, error) {
+	b.mu.Lock()
+	head := b.head
+	b.mu.Unlock()
+
+	changes, err := b.changeRepo.FindSince(ctx, head, b.policy.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, change := range changes {
+		for sub := range b.subscribers {
+			if !sub.filter.Matches(change) {
+				continue
+			}
+			select {
+			case sub.live <- change:
+			default:
+				b.drop(sub, errTooSlow)
+			}
+		}
+		b.head = change.Seq
+	}
+	return len(changes), nil
+}
+
+func (b *Broker) Subscribe(ctx context.Context, filter entity.StreamFilter, lastSeq *int64) (Subscription, error) {
+	b.mu.Lock()
+	if !b.running {
+		b.mu.Unlock()
+		return nil, apperr.ErrUnavailable.WithMessage("product stream is not running")
+	}
+	sub := &subscription{
+		filter:  filter,
+		live:    make(chan entity.Change, b.policy.BufferSize),
+		changes: make(chan entity.Change),
+		done:    make(chan struct{}),
+	}
+	b.subscribers[sub] = struct{}{}
+	head := b.head
+	b.wg.Add(1)
+	b.mu.Unlock()
+
+	cursor := head
+	if lastSeq != nil {
+		cursor = *lastSeq
+	}
+	go func() {
+		defer b.wg.Done()
+		defer b.unsubscribe(sub)
+		b.serve(ctx, sub, cursor, head)
+	}()
+	return sub, nil
+}
+
+// serve replays the changes after cursor up to head that sub missed, then passes on the ones
+// the broker hands it. Changes the replay already covered are skipped.
+func (b *Broker) serve(ctx context.Context, sub *subscription, cursor, head int64) {
+	defer close(sub.changes)
+
+	for cursor < head {
+		changes, err := b.changeRepo.FindSince(ctx, cursor, b.policy.BatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				sub.fail(err)
+			}
+			return
+		}
+		if len(changes) == 0 {
+			break
+		}
+		for _, change := range changes {
+			cursor = change.Seq
+			if sub.filter.Matches(change) && !sub.send(ctx, change) {
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sub.done:
+			return
+		case change, ok := <-sub.live:
+			if !ok {
+				return
+			}
+			if change.Seq <= cursor {
+				continue
+			}
+			cursor = change.Seq
+			if !sub.send(ctx, change) {
+				return
+			}
+		}
+	}
+}
+
+func (b *Broker) unsubscribe(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subscribers, sub)
+}
+
+// drop ends sub with err. The caller holds b.mu.
+func (b *Broker) drop(sub *subscription, err error) {
+	delete(b.subscribers, sub)
+	sub.fail(err)
+	close(sub.live)
+}
+
+// Stop ends every subscription and stops following the feed, then waits for the broker to
+// return or for ctx to expire. Subscribing fails from then on.
+func (b *Broker) Stop(ctx context.Context) error {
+	if b.cancel == nil {
+		return nil
+	}
+	b.cancel()
+
+	b.mu.Lock()
+	b.running = false
+	for sub := range b.subscribers {
+		b.drop(sub, errStopping)
+	}
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type subscription struct {
+	filter entity.StreamFilter
+	// live buffers the changes the broker hands over; changes is drained by the subscriber.
+	live    chan entity.Change
+	changes chan entity.Change
+	done    chan struct{}
+	once    sync.Once
+
+	mu  sync.Mutex
+	err error
+}
+
+func (s *subscription) Changes() <-chan entity.Change {
+	return s.changes
+}
+
+func (s *subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *subscription) Close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+func (s *subscription) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+func (s *subscription) send(ctx context.Context, change entity.Change) bool {
+	select {
+	case s.changes <- change:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-s.done:
+		return false
+	}
+}
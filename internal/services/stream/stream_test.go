@@ -0,0 +1,184 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type BrokerTestSuite struct {
+	suite.Suite
+	mockCtrl *gomock.Controller
+	mockRepo *mocks.MockChangeRepository
+	broker   *Broker
+	ctx      context.Context
+}
+
+func (suite *BrokerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockRepo = mocks.NewMockChangeRepository(suite.mockCtrl)
+	// The poll interval is long enough for the tests to drive polling themselves.
+	suite.broker = NewBroker(suite.mockRepo, entity.StreamPolicy{
+		PollInterval: time.Hour,
+		BatchSize:    10,
+		BufferSize:   1,
+	})
+	suite.ctx = context.Background()
+}
+
+func (suite *BrokerTestSuite) TearDownTest() {
+	suite.NoError(suite.broker.Stop(suite.ctx))
+	suite.mockCtrl.Finish()
+}
+
+func (suite *BrokerTestSuite) start(head int64) {
+	suite.mockRepo.EXPECT().LastSeq(gomock.Any()).Return(head, nil).Times(1)
+	suite.NoError(suite.broker.Start())
+}
+
+func product(seq int64, id, categoryID string) entity.Change {
+	return entity.Change{
+		Seq:        seq,
+		EntityType: entity.AuditEntityProduct,
+		EntityID:   id,
+		Action:     entity.AuditActionUpdate,
+		Product:    &entity.Product{ID: id, CategoryID: categoryID},
+	}
+}
+
+func (suite *BrokerTestSuite) receive(sub Subscription) []int64 {
+	var seqs []int64
+	for change := range sub.Changes() {
+		seqs = append(seqs, change.Seq)
+	}
+	return seqs
+}
+
+func (suite *BrokerTestSuite) next(sub Subscription) int64 {
+	select {
+	case change := <-sub.Changes():
+		return change.Seq
+	case <-time.After(time.Second):
+		suite.FailNow("no change received")
+		return 0
+	}
+}
+
+func (suite *BrokerTestSuite) TestSubscribe_NotRunning() {
+	_, err := suite.broker.Subscribe(suite.ctx, entity.StreamFilter{}, nil)
+
+	suite.Error(err)
+	suite.Equal(apperr.ErrUnavailable.Code, apperr.GetCode(err))
+}
+
+func (suite *BrokerTestSuite) TestPoll_HandsOnMatchingChanges() {
+	suite.start(5)
+	sub, err := suite.broker.Subscribe(suite.ctx, entity.StreamFilter{CategoryIDs: []string{"drinks"}}, nil)
+	suite.NoError(err)
+	defer sub.Close()
+
+	suite.mockRepo.EXPECT().
+		FindSince(gomock.Any(), int64(5), 10).
+		Return([]entity.Change{
+			product(6, "tea", "drinks"),
+			{Seq: 7, EntityType: entity.AuditEntityCategory, EntityID: "drinks"},
+			product(8, "chips", "snacks"),
+		}, nil).
+		Times(1)
+	count, err := suite.broker.poll(suite.ctx)
+	suite.NoError(err)
+	suite.Equal(3, count)
+	suite.Equal(int64(6), suite.next(sub))
+
+	suite.mockRepo.EXPECT().
+		FindSince(gomock.Any(), int64(8), 10).
+		Return([]entity.Change{product(9, "coffee", "drinks")}, nil).
+		Times(1)
+	_, err = suite.broker.poll(suite.ctx)
+	suite.NoError(err)
+	suite.Equal(int64(9), suite.next(sub))
+}
+
+func (suite *BrokerTestSuite) TestSubscribe_ReplaysMissedChanges() {
+	suite.start(5)
+	suite.mockRepo.EXPECT().
+		FindSince(gomock.Any(), int64(2), 10).
+		Return([]entity.Change{product(3, "tea", "drinks"), product(5, "tea", "drinks")}, nil).
+		Times(1)
+
+	lastSeq := int64(2)
+	sub, err := suite.broker.Subscribe(suite.ctx, entity.StreamFilter{}, &lastSeq)
+	suite.NoError(err)
+	defer sub.Close()
+
+	suite.Equal(int64(3), suite.next(sub))
+	suite.Equal(int64(5), suite.next(sub))
+
+	suite.mockRepo.EXPECT().
+		FindSince(gomock.Any(), int64(5), 10).
+		Return([]entity.Change{product(6, "tea", "drinks")}, nil).
+		Times(1)
+	_, err = suite.broker.poll(suite.ctx)
+	suite.NoError(err)
+	suite.Equal(int64(6), suite.next(sub))
+}
+
+func (suite *BrokerTestSuite) TestPoll_CutsOffSlowSubscriber() {
+	suite.start(5)
+	sub, err := suite.broker.Subscribe(suite.ctx, entity.StreamFilter{}, nil)
+	suite.NoError(err)
+	defer sub.Close()
+
+	suite.mockRepo.EXPECT().
+		FindSince(gomock.Any(), int64(5), 10).
+		Return([]entity.Change{product(6, "tea", "drinks")}, nil).
+		Times(1)
+	_, err = suite.broker.poll(suite.ctx)
+	suite.NoError(err)
+	// The subscriber is handed change 6 but does not read it, so later changes pile up.
+	suite.Eventually(func() bool { return len(sub.(*subscription).live) == 0 }, time.Second, time.Millisecond)
+
+	suite.mockRepo.EXPECT().
+		FindSince(gomock.Any(), int64(6), 10).
+		Return([]entity.Change{product(7, "tea", "drinks"), product(8, "tea", "drinks")}, nil).
+		Times(1)
+	_, err = suite.broker.poll(suite.ctx)
+	suite.NoError(err)
+
+	suite.Equal([]int64{6, 7}, suite.receive(sub))
+	suite.Equal(apperr.ErrUnavailable.Code, apperr.GetCode(sub.Err()))
+}
+
+func (suite *BrokerTestSuite) TestClose_EndsSubscription() {
+	suite.start(5)
+	sub, err := suite.broker.Subscribe(suite.ctx, entity.StreamFilter{}, nil)
+	suite.NoError(err)
+
+	sub.Close()
+
+	suite.Empty(suite.receive(sub))
+	suite.NoError(sub.Err())
+}
+
+func (suite *BrokerTestSuite) TestStop_EndsSubscriptions() {
+	suite.start(5)
+	sub, err := suite.broker.Subscribe(suite.ctx, entity.StreamFilter{}, nil)
+	suite.NoError(err)
+
+	suite.NoError(suite.broker.Stop(suite.ctx))
+
+	suite.Empty(suite.receive(sub))
+	suite.Equal(apperr.ErrUnavailable.Code, apperr.GetCode(sub.Err()))
+	_, err = suite.broker.Subscribe(suite.ctx, entity.StreamFilter{}, nil)
+	suite.Error(err)
+}
+
+func TestBrokerTestSuite(t *testing.T) {
+	suite.Run(t, new(BrokerTestSuite))
+}
@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: supplier.go
+//
+// Generated by this command:
+//
+//	mockgen -source=supplier.go -destination=mocks/mock_supplier.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/sirawong/crud-arise/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSupplierService is a mock of SupplierService interface.
+type MockSupplierService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupplierServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSupplierServiceMockRecorder is the mock recorder for MockSupplierService.
+type MockSupplierServiceMockRecorder struct {
+	mock *MockSupplierService
+}
+
+// NewMockSupplierService creates a new mock instance.
+func NewMockSupplierService(ctrl *gomock.Controller) *MockSupplierService {
+	mock := &MockSupplierService{ctrl: ctrl}
+	mock.recorder = &MockSupplierServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupplierService) EXPECT() *MockSupplierServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSupplierService) Create(ctx context.Context, supplier entity.Supplier) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, supplier)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSupplierServiceMockRecorder) Create(ctx, supplier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSupplierService)(nil).Create), ctx, supplier)
+}
+
+// Delete mocks base method.
+func (m *MockSupplierService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSupplierServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSupplierService)(nil).Delete), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockSupplierService) GetAll(ctx context.Context, filter entity.SuppliersFilter) ([]entity.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
+	ret0, _ := ret[0].([]entity.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockSupplierServiceMockRecorder) GetAll(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSupplierService)(nil).GetAll), ctx, filter)
+}
+
+// GetByID mocks base method.
+func (m *MockSupplierService) GetByID(ctx context.Context, id string) (*entity.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSupplierServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSupplierService)(nil).GetByID), ctx, id)
+}
+
+// GetProductSuppliers mocks base method.
+func (m *MockSupplierService) GetProductSuppliers(ctx context.Context, productID string) ([]entity.ProductSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductSuppliers", ctx, productID)
+	ret0, _ := ret[0].([]entity.ProductSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductSuppliers indicates an expected call of GetProductSuppliers.
+func (mr *MockSupplierServiceMockRecorder) GetProductSuppliers(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductSuppliers", reflect.TypeOf((*MockSupplierService)(nil).GetProductSuppliers), ctx, productID)
+}
+
+// GetSupplierProducts mocks base method.
+func (m *MockSupplierService) GetSupplierProducts(ctx context.Context, supplierID string) ([]entity.ProductSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplierProducts", ctx, supplierID)
+	ret0, _ := ret[0].([]entity.ProductSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplierProducts indicates an expected call of GetSupplierProducts.
+func (mr *MockSupplierServiceMockRecorder) GetSupplierProducts(ctx, supplierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplierProducts", reflect.TypeOf((*MockSupplierService)(nil).GetSupplierProducts), ctx, supplierID)
+}
+
+// LinkProduct mocks base method.
+func (m *MockSupplierService) LinkProduct(ctx context.Context, link entity.ProductSupplier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkProduct", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkProduct indicates an expected call of LinkProduct.
+func (mr *MockSupplierServiceMockRecorder) LinkProduct(ctx, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkProduct", reflect.TypeOf((*MockSupplierService)(nil).LinkProduct), ctx, link)
+}
+
+// UnlinkProduct mocks base method.
+func (m *MockSupplierService) UnlinkProduct(ctx context.Context, productID, supplierID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkProduct", ctx, productID, supplierID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkProduct indicates an expected call of UnlinkProduct.
+func (mr *MockSupplierServiceMockRecorder) UnlinkProduct(ctx, productID, supplierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkProduct", reflect.TypeOf((*MockSupplierService)(nil).UnlinkProduct), ctx, productID, supplierID)
+}
+
+// Update mocks base method.
+func (m *MockSupplierService) Update(ctx context.Context, id string, supplier entity.Supplier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, supplier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSupplierServiceMockRecorder) Update(ctx, id, supplier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSupplierService)(nil).Update), ctx, id, supplier)
+}
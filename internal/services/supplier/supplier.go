@@ -0,0 +1,119 @@
+package supplier
+
+import (
+	"context"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+type supplierService struct {
+	supplierRepo        repository.SupplierRepository
+	productSupplierRepo repository.ProductSupplierRepository
+	productRepo         repository.ProductRepository
+}
+
+//go:generate mockgen -source=supplier.go -destination=mocks/mock_supplier.go -package=mocks
+type SupplierService interface {
+	Create(ctx context.Context, supplier entity.Supplier) (string, error)
+	Update(ctx context.Context, id string, supplier entity.Supplier) error
+	GetByID(ctx context.Context, id string) (*entity.Supplier, error)
+	GetAll(ctx context.Context, filter entity.SuppliersFilter) ([]entity.Supplier, error)
+	Delete(ctx context.Context, id string) error
+
+	LinkProduct(ctx context.Context, link entity.ProductSupplier) error
+	UnlinkProduct(ctx context.Context, productID, supplierID string) error
+	GetProductSuppliers(ctx context.Context, productID string) ([]entity.ProductSupplier, error)
+	GetSupplierProducts(ctx context.Context, supplierID string) ([]entity.ProductSupplier, error)
+}
+
+func NewSupplierService(
+	supplierRepo repository.SupplierRepository,
+	productSupplierRepo repository.ProductSupplierRepository,
+	productRepo repository.ProductRepository,
+) SupplierService {
+	return &supplierService{
+		supplierRepo:        supplierRepo,
+		productSupplierRepo: productSupplierRepo,
+		productRepo:         productRepo,
+	}
+}
+
+func (s supplierService) Create(ctx context.Context, supplier entity.Supplier) (string, error) {
+	if supplier.LeadTimeDays < 0 {
+		return "", apperr.ErrInvalidArgument.WithMessage("lead time cannot be negative")
+	}
+
+	return s.supplierRepo.Create(ctx, &supplier)
+}
+
+func (s supplierService) Update(ctx context.Context, id string, supplier entity.Supplier) error {
+	if supplier.LeadTimeDays < 0 {
+		return apperr.ErrInvalidArgument.WithMessage("lead time cannot be negative")
+	}
+
+	supplier.ID = id
+	return s.supplierRepo.Update(ctx, &supplier)
+}
+
+func (s supplierService) GetByID(ctx context.Context, id string) (*entity.Supplier, error) {
+	return s.supplierRepo.FindByID(ctx, id)
+}
+
+func (s supplierService) GetAll(ctx context.Context, filter entity.SuppliersFilter) ([]entity.Supplier, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	return s.supplierRepo.FindAll(ctx, filter)
+}
+
+func (s supplierService) Delete(ctx context.Context, id string) error {
+	return s.supplierRepo.Delete(ctx, id)
+}
+
+func (s supplierService) LinkProduct(ctx context.Context, link entity.ProductSupplier) error {
+	if link.CostPrice < 0 {
+		return apperr.ErrInvalidArgument.WithMessage("cost price cannot be negative")
+	}
+	if link.MinOrderQuantity <= 0 {
+		link.MinOrderQuantity = 1
+	}
+
+	_, err := s.productRepo.FindByID(ctx, link.ProductID)
+	if err != nil {
+		return err
+	}
+	_, err = s.supplierRepo.FindByID(ctx, link.SupplierID)
+	if err != nil {
+		return err
+	}
+
+	return s.productSupplierRepo.Upsert(ctx, &link)
+}
+
+func (s supplierService) UnlinkProduct(ctx context.Context, productID, supplierID string) error {
+	return s.productSupplierRepo.Delete(ctx, productID, supplierID)
+}
+
+func (s supplierService) GetProductSuppliers(ctx context.Context, productID string) ([]entity.ProductSupplier, error) {
+	_, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.productSupplierRepo.FindByProductID(ctx, productID)
+}
+
+func (s supplierService) GetSupplierProducts(ctx context.Context, supplierID string) ([]entity.ProductSupplier, error) {
+	_, err := s.supplierRepo.FindByID(ctx, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.productSupplierRepo.FindBySupplierID(ctx, supplierID)
+}
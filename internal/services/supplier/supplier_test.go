@@ -0,0 +1,200 @@
+package supplier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type SupplierServiceTestSuite struct {
+	suite.Suite
+	mockCtrl                *gomock.Controller
+	mockSupplierRepo        *mocks.MockSupplierRepository
+	mockProductSupplierRepo *mocks.MockProductSupplierRepository
+	mockProductRepo         *mocks.MockProductRepository
+	service                 SupplierService
+	ctx                     context.Context
+}
+
+func (suite *SupplierServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockSupplierRepo = mocks.NewMockSupplierRepository(suite.mockCtrl)
+	suite.mockProductSupplierRepo = mocks.NewMockProductSupplierRepository(suite.mockCtrl)
+	suite.mockProductRepo = mocks.NewMockProductRepository(suite.mockCtrl)
+	suite.service = NewSupplierService(suite.mockSupplierRepo, suite.mockProductSupplierRepo, suite.mockProductRepo)
+	suite.ctx = context.Background()
+}
+
+func (suite *SupplierServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *SupplierServiceTestSuite) TestCreate_Success() {
+
+	supplier := entity.Supplier{
+		Name:         "Acme Seeds",
+		LeadTimeDays: 14,
+		Currency:     "EUR",
+	}
+
+	suite.mockSupplierRepo.EXPECT().
+		Create(suite.ctx, &supplier).
+		Return("supplier-123", nil).
+		Times(1)
+
+	id, err := suite.service.Create(suite.ctx, supplier)
+
+	suite.NoError(err)
+	suite.Equal("supplier-123", id)
+}
+
+func (suite *SupplierServiceTestSuite) TestCreate_NegativeLeadTime() {
+
+	id, err := suite.service.Create(suite.ctx, entity.Supplier{Name: "Acme", LeadTimeDays: -1})
+
+	suite.Error(err)
+	suite.Equal("", id)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *SupplierServiceTestSuite) TestUpdate_Success() {
+
+	supplier := entity.Supplier{Name: "Acme Seeds", Currency: "USD"}
+	expected := supplier
+	expected.ID = "supplier-123"
+
+	suite.mockSupplierRepo.EXPECT().
+		Update(suite.ctx, &expected).
+		Return(nil).
+		Times(1)
+
+	err := suite.service.Update(suite.ctx, "supplier-123", supplier)
+
+	suite.NoError(err)
+}
+
+func (suite *SupplierServiceTestSuite) TestGetAll_DefaultLimit() {
+
+	suite.mockSupplierRepo.EXPECT().
+		FindAll(suite.ctx, entity.SuppliersFilter{Pagination: entity.Pagination{Limit: 10}}).
+		Return([]entity.Supplier{}, nil).
+		Times(1)
+
+	suppliers, err := suite.service.GetAll(suite.ctx, entity.SuppliersFilter{})
+
+	suite.NoError(err)
+	suite.Empty(suppliers)
+}
+
+func (suite *SupplierServiceTestSuite) TestLinkProduct_Success() {
+
+	link := entity.ProductSupplier{
+		ProductID:   "product-123",
+		SupplierID:  "supplier-123",
+		SupplierSKU: "ACME-42",
+		CostPrice:   12.5,
+		Preferred:   true,
+	}
+	expected := link
+	expected.MinOrderQuantity = 1
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-123").
+		Return(&entity.Product{ID: "product-123"}, nil).
+		Times(1)
+
+	suite.mockSupplierRepo.EXPECT().
+		FindByID(suite.ctx, "supplier-123").
+		Return(&entity.Supplier{ID: "supplier-123"}, nil).
+		Times(1)
+
+	suite.mockProductSupplierRepo.EXPECT().
+		Upsert(suite.ctx, &expected).
+		Return(nil).
+		Times(1)
+
+	err := suite.service.LinkProduct(suite.ctx, link)
+
+	suite.NoError(err)
+}
+
+func (suite *SupplierServiceTestSuite) TestLinkProduct_SupplierNotFound() {
+
+	link := entity.ProductSupplier{ProductID: "product-123", SupplierID: "missing", MinOrderQuantity: 10}
+	expectedErr := apperr.ErrNotFound.WithMessage("supplier not found")
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-123").
+		Return(&entity.Product{ID: "product-123"}, nil).
+		Times(1)
+
+	suite.mockSupplierRepo.EXPECT().
+		FindByID(suite.ctx, "missing").
+		Return(nil, expectedErr).
+		Times(1)
+
+	err := suite.service.LinkProduct(suite.ctx, link)
+
+	suite.Equal(expectedErr, err)
+}
+
+func (suite *SupplierServiceTestSuite) TestLinkProduct_NegativeCost() {
+
+	err := suite.service.LinkProduct(suite.ctx, entity.ProductSupplier{CostPrice: -1})
+
+	suite.Error(err)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *SupplierServiceTestSuite) TestGetProductSuppliers_Success() {
+
+	expected := []entity.ProductSupplier{
+		{ProductID: "product-123", SupplierID: "supplier-1", Preferred: true},
+		{ProductID: "product-123", SupplierID: "supplier-2"},
+	}
+
+	suite.mockProductRepo.EXPECT().
+		FindByID(suite.ctx, "product-123").
+		Return(&entity.Product{ID: "product-123"}, nil).
+		Times(1)
+
+	suite.mockProductSupplierRepo.EXPECT().
+		FindByProductID(suite.ctx, "product-123").
+		Return(expected, nil).
+		Times(1)
+
+	links, err := suite.service.GetProductSuppliers(suite.ctx, "product-123")
+
+	suite.NoError(err)
+	suite.Equal(expected, links)
+}
+
+func (suite *SupplierServiceTestSuite) TestGetSupplierProducts_RepositoryError() {
+
+	expectedErr := errors.New("repository error")
+
+	suite.mockSupplierRepo.EXPECT().
+		FindByID(suite.ctx, "supplier-123").
+		Return(&entity.Supplier{ID: "supplier-123"}, nil).
+		Times(1)
+
+	suite.mockProductSupplierRepo.EXPECT().
+		FindBySupplierID(suite.ctx, "supplier-123").
+		Return(nil, expectedErr).
+		Times(1)
+
+	links, err := suite.service.GetSupplierProducts(suite.ctx, "supplier-123")
+
+	suite.Nil(links)
+	suite.Equal(expectedErr, err)
+}
+
+func TestSupplierServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(SupplierServiceTestSuite))
+}
@@ -0,0 +1,401 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+)
+
+const (
+	// SignatureHeader carries "t=<unix seconds>,v1=<hex HMAC-SHA256>" of every delivery; see Sign.
+	SignatureHeader = "X-Signature"
+	// responseBodyLimit caps the bytes of a receiver's answer kept in the delivery log.
+	responseBodyLimit = 1024
+)
+
+type webhookService struct {
+	webhookRepo repository.WebhookRepository
+	client      *http.Client
+	policy      entity.WebhookPolicy
+}
+
+//go:generate mockgen -source=webhook.go -destination=mocks/mock_webhook.go -package=mocks
+type WebhookService interface {
+	// Create subscribes a webhook, generating its secret when none is given.
+	Create(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error)
+	// Update replaces a webhook's settings; an empty secret keeps the current one.
+	Update(ctx context.Context, id string, webhook entity.Webhook) error
+	GetByID(ctx context.Context, id string) (*entity.Webhook, error)
+	GetAll(ctx context.Context, filter entity.WebhooksFilter) ([]entity.Webhook, error)
+	Delete(ctx context.Context, id string) error
+
+	ListDeliveries(ctx context.Context, webhookID string, filter entity.WebhookDeliveriesFilter) ([]entity.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, webhookID string, id int64) (*entity.WebhookDelivery, error)
+	// Redeliver queues the event of a past delivery to be sent again.
+	Redeliver(ctx context.Context, webhookID string, id int64) (*entity.WebhookDelivery, error)
+
+	// Publish queues a delivery of event to every enabled webhook subscribed to it, which makes
+	// the service a publisher of the outbox.
+	Publish(ctx context.Context, event entity.DomainEvent) error
+	// DeliverDue sends a batch of due deliveries and returns how many were attempted.
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepository, client *http.Client, policy entity.WebhookPolicy) WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		client:      client,
+		policy:      policy,
+	}
+}
+
+func (w webhookService) Create(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error) {
+	if err := validate(webhook); err != nil {
+		return nil, err
+	}
+	if webhook.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+
+	id, err := w.webhookRepo.Create(ctx, &webhook)
+	if err != nil {
+		return nil, err
+	}
+	return w.webhookRepo.FindByID(ctx, id)
+}
+
+func (w webhookService) Update(ctx context.Context, id string, webhook entity.Webhook) error {
+	if err := validate(webhook); err != nil {
+		return err
+	}
+	if webhook.Secret == "" {
+		current, err := w.webhookRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		webhook.Secret = current.Secret
+	}
+
+	webhook.ID = id
+	return w.webhookRepo.Update(ctx, &webhook)
+}
+
+func (w webhookService) GetByID(ctx context.Context, id string) (*entity.Webhook, error) {
+	return w.webhookRepo.FindByID(ctx, id)
+}
+
+func (w webhookService) GetAll(ctx context.Context, filter entity.WebhooksFilter) ([]entity.Webhook, error) {
+	filter.Limit = pageLimit(filter.Limit)
+	return w.webhookRepo.FindAll(ctx, filter)
+}
+
+func (w webhookService) Delete(ctx context.Context, id string) error {
+	return w.webhookRepo.Delete(ctx, id)
+}
+
+func (w webhookService) ListDeliveries(ctx context.Context, webhookID string, filter entity.WebhookDeliveriesFilter) ([]entity.WebhookDelivery, error) {
+	if filter.Status != nil && !filter.Status.IsValid() {
+		return nil, apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("unknown delivery status %q", *filter.Status))
+	}
+	_, err := w.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Limit = pageLimit(filter.Limit)
+	return w.webhookRepo.FindDeliveries(ctx, webhookID, filter)
+}
+
+func (w webhookService) GetDelivery(ctx context.Context, webhookID string, id int64) (*entity.WebhookDelivery, error) {
+	return w.webhookRepo.FindDelivery(ctx, webhookID, id)
+}
+
+func (w webhookService) Redeliver(ctx context.Context, webhookID string, id int64) (*entity.WebhookDelivery, error) {
+	webhook, err := w.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !webhook.Enabled {
+		return nil, apperr.ErrConflict.WithMessage(fmt.Sprintf("webhook %s is disabled; enable it before redelivering", webhookID))
+	}
+	original, err := w.webhookRepo.FindDelivery(ctx, webhookID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	redelivery := entity.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        entity.WebhookDeliveryPending,
+		NextAttemptAt: now,
+		RedeliveryOf:  &original.ID,
+		CreatedAt:     now,
+	}
+	redelivery.ID, err = w.webhookRepo.CreateRedelivery(ctx, &redelivery)
+	if err != nil {
+		return nil, err
+	}
+	return &redelivery, nil
+}
+
+func (w webhookService) Publish(ctx context.Context, event entity.DomainEvent) error {
+	webhooks, err := w.webhookRepo.FindEnabled(ctx)
+	if err != nil {
+		return err
+	}
+
+	var subject eventSubject
+	if err := json.Unmarshal(event.Payload, &subject); err != nil {
+		return apperr.ErrInternal.Wrap(err)
+	}
+
+	var payload []byte
+	now := time.Now()
+	deliveries := make([]entity.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.Type) || !subject.matches(event, webhook.Filter) {
+			continue
+		}
+		if payload == nil {
+			payload, err = deliveryBody(event)
+			if err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, entity.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        entity.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	return w.webhookRepo.CreateDeliveries(ctx, deliveries)
+}
+
+func (w webhookService) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := w.webhookRepo.ClaimDueDeliveries(ctx, w.policy.BatchSize, w.policy.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	webhooks := make(map[string]*entity.Webhook)
+	for _, delivery := range deliveries {
+		if _, ok := webhooks[delivery.WebhookID]; ok {
+			continue
+		}
+		webhook, err := w.webhookRepo.FindByID(ctx, delivery.WebhookID)
+		if err != nil && apperr.GetCode(err) != apperr.ErrNotFound.Code {
+			return 0, err
+		}
+		// A webhook deleted since the claim has taken its deliveries with it.
+		webhooks[delivery.WebhookID] = webhook
+	}
+
+	// Receivers are slow at times, so the batch is sent side by side.
+	var wg sync.WaitGroup
+	errs := make([]error, len(deliveries))
+	for i, delivery := range deliveries {
+		webhook := webhooks[delivery.WebhookID]
+		if webhook == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = w.attempt(ctx, webhook, delivery)
+		}()
+	}
+	wg.Wait()
+
+	return len(deliveries), errors.Join(errs...)
+}
+
+// attempt sends delivery to webhook once and records how it went. A failed attempt is retried
+// after a backoff until the delivery runs out of attempts.
+func (w webhookService) attempt(ctx context.Context, webhook *entity.Webhook, delivery entity.WebhookDelivery) error {
+	status, body, err := w.send(ctx, webhook, delivery)
+	if ctx.Err() != nil {
+		// Interrupted by shutdown; the delivery is claimed again once its lease has passed.
+		return ctx.Err()
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	delivery.ResponseBody = body
+	switch {
+	case err == nil:
+		delivery.Status = entity.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= w.policy.MaxAttempts:
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.LastError = err.Error()
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(w.backoff(delivery.Attempts))
+	}
+
+	disabled, err := w.webhookRepo.RecordAttempt(ctx, &delivery, w.policy.DisableAfter)
+	if err != nil {
+		return err
+	}
+	if disabled {
+		log.Printf("webhooks: webhook %s disabled after %d failed deliveries in a row", webhook.ID, w.policy.DisableAfter)
+	}
+	return nil
+}
+
+// send POSTs the delivery's payload to the webhook, signed with its secret. It fails unless the
+// receiver answers with a 2xx status.
+func (w webhookService) send(ctx context.Context, webhook *entity.Webhook, delivery entity.WebhookDelivery) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-ID", webhook.ID)
+	req.Header.Set("X-Delivery-ID", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Event-ID", strconv.FormatInt(delivery.EventID, 10))
+	req.Header.Set("X-Event-Type", string(delivery.EventType))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, time.Now(), delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, responseBodyLimit))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, string(body), fmt.Errorf("receiver answered %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+// backoff returns the wait after the given number of failed attempts.
+func (w webhookService) backoff(attempts int) time.Duration {
+	backoff := w.policy.Backoff
+	for i := 1; i < attempts && backoff < w.policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, w.policy.MaxBackoff)
+}
+
+// Sign returns the X-Signature of body sent at timestamp: the HMAC-SHA256, keyed with secret, of
+// the Unix timestamp, a dot and body. Receivers recompute it and reject old timestamps to guard
+// against replays.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix + "."))
+	mac.Write(body)
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliveryBody is the JSON a webhook receives for event.
+func deliveryBody(event entity.DomainEvent) ([]byte, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"id":            event.ID,
+		"type":          event.Type,
+		"aggregateType": event.AggregateType,
+		"aggregateId":   event.AggregateID,
+		"actor":         event.Actor,
+		"requestId":     event.RequestID,
+		"occurredAt":    event.OccurredAt,
+		"data":          event.Payload,
+	})
+	if err != nil {
+		return nil, apperr.ErrInternal.Wrap(err)
+	}
+	return body, nil
+}
+
+// eventSubject is the part of an event's payload a webhook filter looks at.
+type eventSubject struct {
+	State struct {
+		CategoryID string `json:"category_id"`
+	} `json:"state"`
+	Changes struct {
+		CategoryID *entity.FieldChange `json:"category_id"`
+	} `json:"changes"`
+}
+
+// matches tells whether event passes filter: it must be about one of the listed products or
+// categories, or about a product that is or was in one of the listed categories.
+func (s eventSubject) matches(event entity.DomainEvent, filter entity.WebhookFilter) bool {
+	if len(filter.CategoryIDs) == 0 && len(filter.ProductIDs) == 0 {
+		return true
+	}
+
+	switch event.AggregateType {
+	case entity.AuditEntityCategory:
+		return slices.Contains(filter.CategoryIDs, event.AggregateID)
+	case entity.AuditEntityProduct:
+		if slices.Contains(filter.ProductIDs, event.AggregateID) || slices.Contains(filter.CategoryIDs, s.State.CategoryID) {
+			return true
+		}
+		if s.Changes.CategoryID != nil {
+			previous, _ := s.Changes.CategoryID.Old.(string)
+			return slices.Contains(filter.CategoryIDs, previous)
+		}
+	}
+	return false
+}
+
+func validate(webhook entity.Webhook) error {
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return apperr.ErrInvalidArgument.WithMessage("url must be an absolute http or https URL")
+	}
+	for _, eventType := range webhook.EventTypes {
+		if !eventType.IsValid() {
+			return apperr.ErrInvalidArgument.WithMessage(fmt.Sprintf("unknown event type %q", eventType))
+		}
+	}
+	return nil
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", apperr.ErrInternal.Wrap(err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return 10
+	}
+	return min(limit, 100)
+}
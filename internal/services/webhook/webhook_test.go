@@ -0,0 +1,311 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirawong/crud-arise/internal/domain/entity"
+	"github.com/sirawong/crud-arise/internal/domain/repository/mocks"
+	apperr "github.com/sirawong/crud-arise/internal/errors"
+	"github.com/sirawong/crud-arise/pkg/utils"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type WebhookServiceTestSuite struct {
+	suite.Suite
+	mockCtrl *gomock.Controller
+	mockRepo *mocks.MockWebhookRepository
+	receiver *httptest.Server
+	received chan *http.Request
+	bodies   chan []byte
+	answer   int
+	service  WebhookService
+	ctx      context.Context
+}
+
+func (suite *WebhookServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+	suite.mockRepo = mocks.NewMockWebhookRepository(suite.mockCtrl)
+	suite.received = make(chan *http.Request, 10)
+	suite.bodies = make(chan []byte, 10)
+	suite.answer = http.StatusOK
+	suite.receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		suite.received <- r
+		suite.bodies <- body
+		w.WriteHeader(suite.answer)
+		_, _ = w.Write([]byte("thanks"))
+	}))
+	suite.service = NewWebhookService(suite.mockRepo, suite.receiver.Client(), entity.WebhookPolicy{
+		BatchSize:    10,
+		Lease:        time.Minute,
+		MaxAttempts:  3,
+		Backoff:      time.Second,
+		MaxBackoff:   time.Minute,
+		DisableAfter: 5,
+	})
+	suite.ctx = context.Background()
+}
+
+func (suite *WebhookServiceTestSuite) TearDownTest() {
+	suite.receiver.Close()
+	suite.mockCtrl.Finish()
+}
+
+func (suite *WebhookServiceTestSuite) webhook() *entity.Webhook {
+	return &entity.Webhook{ID: "webhook-1", URL: suite.receiver.URL, Secret: "shh", Enabled: true}
+}
+
+func (suite *WebhookServiceTestSuite) TestCreate_GeneratesSecret() {
+	suite.mockRepo.EXPECT().
+		Create(suite.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, webhook *entity.Webhook) (string, error) {
+			suite.Len(webhook.Secret, 64)
+			return "webhook-1", nil
+		}).
+		Times(1)
+	suite.mockRepo.EXPECT().FindByID(suite.ctx, "webhook-1").Return(suite.webhook(), nil).Times(1)
+
+	webhook, err := suite.service.Create(suite.ctx, entity.Webhook{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []entity.EventType{entity.EventStockChanged},
+		Enabled:    true,
+	})
+
+	suite.NoError(err)
+	suite.Equal("webhook-1", webhook.ID)
+}
+
+func (suite *WebhookServiceTestSuite) TestCreate_InvalidURL() {
+	_, err := suite.service.Create(suite.ctx, entity.Webhook{URL: "ftp://partner.example.com"})
+
+	suite.Error(err)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *WebhookServiceTestSuite) TestCreate_UnknownEventType() {
+	_, err := suite.service.Create(suite.ctx, entity.Webhook{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []entity.EventType{"ProductExploded"},
+	})
+
+	suite.Error(err)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *WebhookServiceTestSuite) TestUpdate_KeepsSecret() {
+	suite.mockRepo.EXPECT().FindByID(suite.ctx, "webhook-1").Return(suite.webhook(), nil).Times(1)
+	suite.mockRepo.EXPECT().
+		Update(suite.ctx, &entity.Webhook{ID: "webhook-1", URL: "https://partner.example.com/v2", Secret: "shh", Enabled: true}).
+		Return(nil).
+		Times(1)
+
+	err := suite.service.Update(suite.ctx, "webhook-1", entity.Webhook{URL: "https://partner.example.com/v2", Enabled: true})
+
+	suite.NoError(err)
+}
+
+func (suite *WebhookServiceTestSuite) TestListDeliveries_UnknownStatus() {
+	status := entity.WebhookDeliveryStatus("lost")
+
+	_, err := suite.service.ListDeliveries(suite.ctx, "webhook-1", entity.WebhookDeliveriesFilter{Status: &status})
+
+	suite.Error(err)
+	suite.Equal(apperr.ErrInvalidArgument.Code, apperr.GetCode(err))
+}
+
+func (suite *WebhookServiceTestSuite) TestListDeliveries_DefaultsLimit() {
+	suite.mockRepo.EXPECT().FindByID(suite.ctx, "webhook-1").Return(suite.webhook(), nil).Times(1)
+	suite.mockRepo.EXPECT().
+		FindDeliveries(suite.ctx, "webhook-1", entity.WebhookDeliveriesFilter{Pagination: entity.Pagination{Limit: 10}}).
+		Return([]entity.WebhookDelivery{{ID: 1}}, nil).
+		Times(1)
+
+	deliveries, err := suite.service.ListDeliveries(suite.ctx, "webhook-1", entity.WebhookDeliveriesFilter{})
+
+	suite.NoError(err)
+	suite.Len(deliveries, 1)
+}
+
+func (suite *WebhookServiceTestSuite) TestRedeliver() {
+	suite.mockRepo.EXPECT().FindByID(suite.ctx, "webhook-1").Return(suite.webhook(), nil).Times(1)
+	suite.mockRepo.EXPECT().
+		FindDelivery(suite.ctx, "webhook-1", int64(7)).
+		Return(&entity.WebhookDelivery{ID: 7, WebhookID: "webhook-1", EventID: 42, EventType: entity.EventStockChanged, Payload: []byte(`{}`), Status: entity.WebhookDeliveryFailed, Attempts: 3}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		CreateRedelivery(suite.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, delivery *entity.WebhookDelivery) (int64, error) {
+			suite.Equal(int64(42), delivery.EventID)
+			suite.Equal(entity.WebhookDeliveryPending, delivery.Status)
+			suite.Zero(delivery.Attempts)
+			suite.Equal(utils.SetPtr(int64(7)), delivery.RedeliveryOf)
+			return 8, nil
+		}).
+		Times(1)
+
+	delivery, err := suite.service.Redeliver(suite.ctx, "webhook-1", 7)
+
+	suite.NoError(err)
+	suite.Equal(int64(8), delivery.ID)
+}
+
+func (suite *WebhookServiceTestSuite) TestRedeliver_DisabledWebhook() {
+	webhook := suite.webhook()
+	webhook.Enabled = false
+	suite.mockRepo.EXPECT().FindByID(suite.ctx, "webhook-1").Return(webhook, nil).Times(1)
+
+	_, err := suite.service.Redeliver(suite.ctx, "webhook-1", 7)
+
+	suite.Error(err)
+	suite.Equal(apperr.ErrConflict.Code, apperr.GetCode(err))
+}
+
+func (suite *WebhookServiceTestSuite) TestPublish_FiltersByTypeAndCategory() {
+	suite.mockRepo.EXPECT().
+		FindEnabled(suite.ctx).
+		Return([]entity.Webhook{
+			{ID: "all"},
+			{ID: "stock-only", EventTypes: []entity.EventType{entity.EventStockChanged}},
+			{ID: "drinks", Filter: entity.WebhookFilter{CategoryIDs: []string{"drinks"}}},
+			{ID: "snacks", Filter: entity.WebhookFilter{CategoryIDs: []string{"snacks"}}},
+			{ID: "moved-from-snacks", Filter: entity.WebhookFilter{CategoryIDs: []string{"old"}}},
+			{ID: "product", Filter: entity.WebhookFilter{ProductIDs: []string{"product-1"}}},
+		}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().
+		CreateDeliveries(suite.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, deliveries []entity.WebhookDelivery) error {
+			ids := make([]string, 0, len(deliveries))
+			for _, delivery := range deliveries {
+				ids = append(ids, delivery.WebhookID)
+				suite.Equal(int64(42), delivery.EventID)
+				suite.Equal(entity.WebhookDeliveryPending, delivery.Status)
+			}
+			suite.Equal([]string{"all", "drinks", "moved-from-snacks", "product"}, ids)
+
+			var body map[string]interface{}
+			suite.NoError(json.Unmarshal(deliveries[0].Payload, &body))
+			suite.Equal("ProductUpdated", body["type"])
+			suite.Equal("product-1", body["aggregateId"])
+			return nil
+		}).
+		Times(1)
+
+	err := suite.service.Publish(suite.ctx, entity.DomainEvent{
+		ID:            42,
+		Type:          entity.EventProductUpdated,
+		AggregateType: entity.AuditEntityProduct,
+		AggregateID:   "product-1",
+		Payload:       []byte(`{"state":{"category_id":"drinks"},"changes":{"category_id":{"old":"old","new":"drinks"}}}`),
+	})
+
+	suite.NoError(err)
+}
+
+func (suite *WebhookServiceTestSuite) TestDeliverDue_SignsAndSucceeds() {
+	payload := []byte(`{"id":42}`)
+	suite.mockRepo.EXPECT().
+		ClaimDueDeliveries(suite.ctx, 10, time.Minute).
+		Return([]entity.WebhookDelivery{{ID: 1, WebhookID: "webhook-1", EventID: 42, EventType: entity.EventStockChanged, Payload: payload, Status: entity.WebhookDeliveryPending}}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().FindByID(suite.ctx, "webhook-1").Return(suite.webhook(), nil).Times(1)
+	suite.mockRepo.EXPECT().
+		RecordAttempt(suite.ctx, gomock.Any(), 5).
+		DoAndReturn(func(_ context.Context, delivery *entity.WebhookDelivery, _ int) (bool, error) {
+			suite.Equal(entity.WebhookDeliverySucceeded, delivery.Status)
+			suite.Equal(1, delivery.Attempts)
+			suite.Equal(http.StatusOK, delivery.ResponseStatus)
+			suite.Equal("thanks", delivery.ResponseBody)
+			suite.NotNil(delivery.DeliveredAt)
+			return false, nil
+		}).
+		Times(1)
+
+	count, err := suite.service.DeliverDue(suite.ctx)
+
+	suite.NoError(err)
+	suite.Equal(1, count)
+	req, body := <-suite.received, <-suite.bodies
+	suite.Equal(payload, body)
+	suite.Equal("StockChanged", req.Header.Get("X-Event-Type"))
+	suite.Equal("42", req.Header.Get("X-Event-ID"))
+
+	signature := req.Header.Get(SignatureHeader)
+	var unix int64
+	_, err = fmt.Sscanf(signature, "t=%d,", &unix)
+	suite.NoError(err)
+	timestamp := time.Unix(unix, 0)
+	suite.WithinDuration(time.Now(), timestamp, time.Minute)
+	suite.Equal(Sign("shh", timestamp, payload), signature)
+}
+
+func (suite *WebhookServiceTestSuite) TestDeliverDue_RetriesWithBackoff() {
+	suite.answer = http.StatusInternalServerError
+	suite.mockRepo.EXPECT().
+		ClaimDueDeliveries(suite.ctx, 10, time.Minute).
+		Return([]entity.WebhookDelivery{{ID: 1, WebhookID: "webhook-1", Payload: []byte(`{}`), Status: entity.WebhookDeliveryPending, Attempts: 1}}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().FindByID(suite.ctx, "webhook-1").Return(suite.webhook(), nil).Times(1)
+	suite.mockRepo.EXPECT().
+		RecordAttempt(suite.ctx, gomock.Any(), 5).
+		DoAndReturn(func(_ context.Context, delivery *entity.WebhookDelivery, _ int) (bool, error) {
+			suite.Equal(entity.WebhookDeliveryPending, delivery.Status)
+			suite.Equal(2, delivery.Attempts)
+			suite.Equal(http.StatusInternalServerError, delivery.ResponseStatus)
+			suite.Equal("receiver answered 500", delivery.LastError)
+			suite.WithinDuration(time.Now().Add(2*time.Second), delivery.NextAttemptAt, time.Second)
+			return false, nil
+		}).
+		Times(1)
+
+	_, err := suite.service.DeliverDue(suite.ctx)
+
+	suite.NoError(err)
+}
+
+func (suite *WebhookServiceTestSuite) TestDeliverDue_FailsAfterMaxAttempts() {
+	suite.answer = http.StatusGone
+	suite.mockRepo.EXPECT().
+		ClaimDueDeliveries(suite.ctx, 10, time.Minute).
+		Return([]entity.WebhookDelivery{{ID: 1, WebhookID: "webhook-1", Payload: []byte(`{}`), Status: entity.WebhookDeliveryPending, Attempts: 2}}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().FindByID(suite.ctx, "webhook-1").Return(suite.webhook(), nil).Times(1)
+	suite.mockRepo.EXPECT().
+		RecordAttempt(suite.ctx, gomock.Any(), 5).
+		DoAndReturn(func(_ context.Context, delivery *entity.WebhookDelivery, _ int) (bool, error) {
+			suite.Equal(entity.WebhookDeliveryFailed, delivery.Status)
+			suite.Equal(3, delivery.Attempts)
+			return true, nil
+		}).
+		Times(1)
+
+	_, err := suite.service.DeliverDue(suite.ctx)
+
+	suite.NoError(err)
+}
+
+func (suite *WebhookServiceTestSuite) TestDeliverDue_SkipsDeletedWebhook() {
+	suite.mockRepo.EXPECT().
+		ClaimDueDeliveries(suite.ctx, 10, time.Minute).
+		Return([]entity.WebhookDelivery{{ID: 1, WebhookID: "webhook-1"}}, nil).
+		Times(1)
+	suite.mockRepo.EXPECT().FindByID(suite.ctx, "webhook-1").Return(nil, apperr.ErrNotFound).Times(1)
+
+	count, err := suite.service.DeliverDue(suite.ctx)
+
+	suite.NoError(err)
+	suite.Equal(1, count)
+	suite.Empty(suite.received)
+}
+
+func TestWebhookServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceTestSuite))
+}
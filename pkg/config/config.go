@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
@@ -11,6 +12,65 @@ import (
 type Config struct {
 	HttpServerPort string `env:"HTTP_SERVER_PORT" envDefault:"8080"`
 	DnsDB          string `env:"DNS_DB,required"`
+
+	RequireIfMatch bool   `env:"REQUIRE_IF_MATCH" envDefault:"false"`
+	CacheControl   string `env:"CACHE_CONTROL" envDefault:"private, no-cache"`
+	BatchMaxItems  int    `env:"BATCH_MAX_ITEMS" envDefault:"1000"`
+
+	IdempotencyStore           string        `env:"IDEMPOTENCY_STORE" envDefault:"postgres"`
+	IdempotencyTTL             time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+	IdempotencyInFlightTimeout time.Duration `env:"IDEMPOTENCY_IN_FLIGHT_TIMEOUT" envDefault:"1m"`
+	IdempotencyPurgeInterval   time.Duration `env:"IDEMPOTENCY_PURGE_INTERVAL" envDefault:"1h"`
+
+	LotQuarantineInterval time.Duration `env:"LOT_QUARANTINE_INTERVAL" envDefault:"1h"`
+
+	RetentionDays      int           `env:"RETENTION_DAYS" envDefault:"30"`
+	RetentionInterval  time.Duration `env:"RETENTION_INTERVAL" envDefault:"24h"`
+	RetentionBatchSize int           `env:"RETENTION_BATCH_SIZE" envDefault:"500"`
+	RetentionDryRun    bool          `env:"RETENTION_DRY_RUN" envDefault:"false"`
+
+	OutboxPublisher       string        `env:"OUTBOX_PUBLISHER" envDefault:"log"`
+	OutboxPollInterval    time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"1s"`
+	OutboxBatchSize       int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	OutboxLease           time.Duration `env:"OUTBOX_LEASE" envDefault:"1m"`
+	OutboxMaxAttempts     int           `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"10"`
+	OutboxRetryBackoff    time.Duration `env:"OUTBOX_RETRY_BACKOFF" envDefault:"1s"`
+	OutboxMaxRetryBackoff time.Duration `env:"OUTBOX_MAX_RETRY_BACKOFF" envDefault:"10m"`
+	OutboxRetention       time.Duration `env:"OUTBOX_PUBLISHED_RETENTION" envDefault:"168h"`
+	OutboxPurgeInterval   time.Duration `env:"OUTBOX_PURGE_INTERVAL" envDefault:"1h"`
+
+	WebhookDeliveryInterval time.Duration `env:"WEBHOOK_DELIVERY_INTERVAL" envDefault:"1s"`
+	WebhookBatchSize        int           `env:"WEBHOOK_BATCH_SIZE" envDefault:"50"`
+	WebhookTimeout          time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	WebhookLease            time.Duration `env:"WEBHOOK_LEASE" envDefault:"1m"`
+	WebhookMaxAttempts      int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	WebhookRetryBackoff     time.Duration `env:"WEBHOOK_RETRY_BACKOFF" envDefault:"10s"`
+	WebhookMaxRetryBackoff  time.Duration `env:"WEBHOOK_MAX_RETRY_BACKOFF" envDefault:"1h"`
+	WebhookDisableAfter     int           `env:"WEBHOOK_DISABLE_AFTER" envDefault:"20"`
+
+	StreamPollInterval      time.Duration `env:"STREAM_POLL_INTERVAL" envDefault:"500ms"`
+	StreamBatchSize         int           `env:"STREAM_BATCH_SIZE" envDefault:"500"`
+	StreamBufferSize        int           `env:"STREAM_BUFFER_SIZE" envDefault:"256"`
+	StreamHeartbeatInterval time.Duration `env:"STREAM_HEARTBEAT_INTERVAL" envDefault:"15s"`
+
+	WebSocketTokens           []string      `env:"WS_TOKENS"`
+	WebSocketMaxConnections   int           `env:"WS_MAX_CONNECTIONS" envDefault:"500"`
+	WebSocketMaxSubscriptions int           `env:"WS_MAX_SUBSCRIPTIONS" envDefault:"1000"`
+	WebSocketPingInterval     time.Duration `env:"WS_PING_INTERVAL" envDefault:"20s"`
+	WebSocketPongTimeout      time.Duration `env:"WS_PONG_TIMEOUT" envDefault:"10s"`
+
+	ReservationTTL            time.Duration `env:"RESERVATION_TTL" envDefault:"15m"`
+	ReservationExpiryInterval time.Duration `env:"RESERVATION_EXPIRY_INTERVAL" envDefault:"1m"`
+	ReservationBatchSize      int           `env:"RESERVATION_BATCH_SIZE" envDefault:"100"`
+
+	JobWorkers        int           `env:"JOB_WORKERS" envDefault:"4"`
+	JobPollInterval   time.Duration `env:"JOB_POLL_INTERVAL" envDefault:"1s"`
+	JobLease          time.Duration `env:"JOB_LEASE" envDefault:"1m"`
+	JobMaxAttempts    int           `env:"JOB_MAX_ATTEMPTS" envDefault:"3"`
+	JobChunkSize      int           `env:"JOB_CHUNK_SIZE" envDefault:"100"`
+	JobMaxImportItems int           `env:"JOB_MAX_IMPORT_ITEMS" envDefault:"10000"`
+	JobRetention      time.Duration `env:"JOB_RETENTION" envDefault:"168h"`
+	JobPurgeInterval  time.Duration `env:"JOB_PURGE_INTERVAL" envDefault:"1h"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -24,6 +24,8 @@ func NewConnection(cfg *config.Config) (*gorm.DB, func(), error) {
 
 	db, err := gorm.Open(postgres.Open(cfg.DnsDB), &gorm.Config{
 		Logger: devLogger,
+		// Report constraint violations as gorm.ErrDuplicatedKey and gorm.ErrForeignKeyViolated.
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration extends time.ParseDuration with day ("30d") and week ("2w") units.
+func ParseDuration(s string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+
+	for suffix, unit := range units {
+		if value, ok := strings.CutSuffix(s, suffix); ok {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return 0, err
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	return time.ParseDuration(s)
+}
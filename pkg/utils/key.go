@@ -0,0 +1,9 @@
+package utils
+
+import "strings"
+
+// NormalizeKey returns the form names and SKUs are compared in for uniqueness: lower-cased, trimmed
+// and with runs of whitespace collapsed to one space.
+func NormalizeKey(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
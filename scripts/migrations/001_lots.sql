@@ -0,0 +1,21 @@
+-- Lots of a product's stock, each with its own expiry. A lot-tracked product's stock is the sum of
+-- its active, unexpired lots; sales take from the lot that expires first.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS lots (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    lot_number VARCHAR(100) NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 0,
+    received_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lots_product_id ON lots(product_id);
+CREATE INDEX IF NOT EXISTS idx_lots_expires_at ON lots(expires_at);
+CREATE INDEX IF NOT EXISTS idx_lots_deleted_at ON lots(deleted_at);
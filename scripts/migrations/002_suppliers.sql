@@ -0,0 +1,33 @@
+-- Suppliers and the products they supply, with each supplier's cost and minimum order.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS suppliers (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    contact_name VARCHAR(255),
+    contact_email VARCHAR(255),
+    contact_phone VARCHAR(50),
+    lead_time_days INTEGER NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE TABLE IF NOT EXISTS product_suppliers (
+    product_id UUID NOT NULL,
+    supplier_id UUID NOT NULL,
+    supplier_sku VARCHAR(100),
+    cost_price DECIMAL(10,2) NOT NULL DEFAULT 0,
+    min_order_quantity INTEGER NOT NULL DEFAULT 1,
+    preferred BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (product_id, supplier_id),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (supplier_id) REFERENCES suppliers(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_suppliers_name ON suppliers(name);
+CREATE INDEX IF NOT EXISTS idx_suppliers_deleted_at ON suppliers(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_product_suppliers_supplier_id ON product_suppliers(supplier_id);
@@ -0,0 +1,31 @@
+-- Return authorizations, optionally against an order, with a disposition on each line.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS return_authorizations (
+    id UUID PRIMARY KEY,
+    order_id UUID,
+    status VARCHAR(20) NOT NULL,
+    reason TEXT,
+    received_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id)
+);
+
+CREATE TABLE IF NOT EXISTS return_lines (
+    id UUID PRIMARY KEY,
+    return_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    disposition VARCHAR(20) NOT NULL CHECK (disposition IN ('restock', 'refurbish', 'scrap')),
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (return_id) REFERENCES return_authorizations(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_return_authorizations_order_id ON return_authorizations(order_id);
+CREATE INDEX IF NOT EXISTS idx_return_authorizations_status ON return_authorizations(status);
+CREATE INDEX IF NOT EXISTS idx_return_authorizations_deleted_at ON return_authorizations(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_return_lines_return_id ON return_lines(return_id);
+CREATE INDEX IF NOT EXISTS idx_return_lines_product_id ON return_lines(product_id);
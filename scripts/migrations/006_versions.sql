@@ -0,0 +1,5 @@
+-- Version numbers for products and categories, bumped on every write and served as their ETag.
+-- The script is idempotent.
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,18 @@
+-- Responses stored under the Idempotency-Key a client sent with a POST, so a retry is answered with
+-- the first response instead of being run again. Keys expire after their retention period.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    client_id VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('in_progress', 'completed')),
+    response_status INTEGER,
+    response_headers JSONB NOT NULL DEFAULT '{}',
+    response_body BYTEA,
+    created_at TIMESTAMP DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (client_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,17 @@
+-- Soft-delete-aware uniqueness for category names and product SKUs.
+-- Deleted rows no longer hold on to their name or SKU, and values are compared lower-cased, trimmed
+-- and with runs of whitespace collapsed, so "Home  Garden" and "home garden" collide.
+-- The script is idempotent; it fails if live rows already collide once normalized.
+
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_name_key;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS uni_categories_name;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_sku_key;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS uni_products_sku;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_categories_name_live
+    ON categories (lower(btrim(regexp_replace(name, '\s+', ' ', 'g'))))
+    WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_products_sku_live
+    ON products (lower(btrim(regexp_replace(sku, '\s+', ' ', 'g'))))
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,19 @@
+-- Audit log of every create, update and delete of products and categories. Entries are written by
+-- the application in the transaction of the change they record; changes maps each changed column
+-- to its old and new value.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255),
+    occurred_at TIMESTAMP NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log (occurred_at);
@@ -0,0 +1,28 @@
+-- Revision history of products and categories. Every create, update, delete and restore stores the
+-- full row as it was left, numbered per record from 1; a purge removes the history of the record.
+-- Rows that exist when the script runs get their current state as revision 1.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS revisions (
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    revision INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255),
+    created_at TIMESTAMP NOT NULL,
+    data JSONB NOT NULL,
+    PRIMARY KEY (entity_type, entity_id, revision)
+);
+
+INSERT INTO revisions (entity_type, entity_id, revision, action, actor, request_id, created_at, data)
+SELECT 'product', p.id, 1, CASE WHEN p.deleted_at IS NULL THEN 'create' ELSE 'delete' END,
+    'system', NULL, COALESCE(p.deleted_at, p.updated_at, p.created_at, NOW()), to_jsonb(p)
+FROM products AS p
+ON CONFLICT DO NOTHING;
+
+INSERT INTO revisions (entity_type, entity_id, revision, action, actor, request_id, created_at, data)
+SELECT 'category', c.id, 1, CASE WHEN c.deleted_at IS NULL THEN 'create' ELSE 'delete' END,
+    'system', NULL, COALESCE(c.deleted_at, c.updated_at, c.created_at, NOW()), to_jsonb(c)
+FROM categories AS c
+ON CONFLICT DO NOTHING;
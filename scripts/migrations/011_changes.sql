@@ -0,0 +1,41 @@
+-- Changes feed of products and categories. The application adds a row, without a sequence number,
+-- for every change it records, in the transaction of the change. A deferred trigger numbers the rows
+-- just before the transaction commits, under a lock held until the commit is visible, so sequence
+-- numbers become visible in order and a reader that has seen number n never sees a lower one later.
+-- The feed starts with the latest revision of every record that exists when the script runs.
+-- The script is idempotent.
+
+CREATE SEQUENCE IF NOT EXISTS changes_seq;
+
+CREATE TABLE IF NOT EXISTS changes (
+    id BIGSERIAL PRIMARY KEY,
+    seq BIGINT UNIQUE,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    revision INTEGER,
+    occurred_at TIMESTAMP NOT NULL
+);
+
+CREATE OR REPLACE FUNCTION assign_change_seq() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('changes_seq'));
+    UPDATE changes SET seq = nextval('changes_seq') WHERE id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS changes_assign_seq ON changes;
+CREATE CONSTRAINT TRIGGER changes_assign_seq AFTER INSERT ON changes
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION assign_change_seq();
+
+INSERT INTO changes (entity_type, entity_id, action, revision, occurred_at)
+SELECT r.entity_type, r.entity_id, r.action, r.revision, r.created_at
+FROM (
+    SELECT DISTINCT ON (entity_type, entity_id) entity_type, entity_id, action, revision, created_at
+    FROM revisions
+    ORDER BY entity_type, entity_id, revision DESC
+) AS r
+WHERE NOT EXISTS (SELECT 1 FROM changes)
+ORDER BY r.created_at, r.entity_id;
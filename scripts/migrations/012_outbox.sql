@@ -0,0 +1,28 @@
+-- Transactional outbox of the domain events announcing changes to products and categories. Events
+-- are written by the application in the transaction of the change they announce and delivered by
+-- its dispatcher, at least once. A dispatcher claims due events by moving next_attempt_at past a
+-- lease; a failed delivery is retried with backoff until it is dead-lettered in dead_at.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255),
+    occurred_at TIMESTAMP NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    published_at TIMESTAMP,
+    dead_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_due ON outbox (next_attempt_at, id)
+    WHERE published_at IS NULL AND dead_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_published_at ON outbox (published_at)
+    WHERE published_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_dead_at ON outbox (dead_at)
+    WHERE dead_at IS NOT NULL;
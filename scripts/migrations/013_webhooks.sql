@@ -0,0 +1,40 @@
+-- Webhook subscriptions and the log of their deliveries. Deliveries are queued as the outbox
+-- dispatcher publishes events and sent by the API process; a redelivery is a new row pointing at
+-- the delivery it repeats, so only the first delivery of an event to a webhook is unique.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id UUID PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    event_types JSONB NOT NULL DEFAULT '[]',
+    filter JSONB NOT NULL DEFAULT '{}',
+    secret VARCHAR(255) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    disabled_reason TEXT,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    response_body TEXT,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    redelivery_of BIGINT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries (webhook_id, event_id)
+    WHERE redelivery_of IS NULL;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries (webhook_id, id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at, id)
+    WHERE status = 'pending';
@@ -0,0 +1,5 @@
+-- Records which columns an update in the changes feed changed, so consumers such as the product
+-- stream can tell a stock change from other edits without loading the previous revision. Changes
+-- recorded before this script have no fields. The script is idempotent.
+
+ALTER TABLE changes ADD COLUMN IF NOT EXISTS fields JSONB;
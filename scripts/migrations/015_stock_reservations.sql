@@ -0,0 +1,19 @@
+-- Stock reservations made by point-of-sale terminals. The stock is taken from the product when a
+-- reservation is made and put back when it is released or expires. There is no foreign key to
+-- products, so a product can be purged while a reservation of it is still held.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'held',
+    actor VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    released_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_product ON stock_reservations (product_id);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_expiry ON stock_reservations (expires_at, id)
+    WHERE status = 'held';
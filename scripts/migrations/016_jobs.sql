@@ -0,0 +1,30 @@
+-- Background jobs run by the worker pool. A job is claimed by moving it to running under a lease
+-- that its worker keeps extending; a job whose lease passed is resumed from its checkpoint by
+-- another worker. Finished jobs are purged after the retention period.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id UUID PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    params JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    progress_done INTEGER NOT NULL DEFAULT 0,
+    progress_total INTEGER NOT NULL DEFAULT 0,
+    checkpoint JSONB,
+    result JSONB,
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    cancel_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255),
+    lease_until TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_pending ON jobs (created_at, id)
+    WHERE status IN ('queued', 'running');
+CREATE INDEX IF NOT EXISTS idx_jobs_finished ON jobs (finished_at)
+    WHERE finished_at IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Reservation tokens for idempotency keys. A request can only complete or release the key while
+-- the token it reserved the key with is still on the row, so a request that outlived its in-flight
+-- timeout cannot overwrite or drop the reservation of the retry that took the key over.
+-- The script is idempotent.
+
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS token VARCHAR(36) NOT NULL DEFAULT '';
@@ -0,0 +1,18 @@
+-- Runs of the retention job, one row each, so the latest outcome survives restarts and is the
+-- same on every instance. The ids a run purged or kept are stored as JSON arrays.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS retention_runs (
+    id UUID PRIMARY KEY,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL,
+    deleted_before TIMESTAMP NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    products_purged JSONB NOT NULL DEFAULT '[]',
+    products_skipped JSONB NOT NULL DEFAULT '[]',
+    categories_purged JSONB NOT NULL DEFAULT '[]',
+    categories_skipped JSONB NOT NULL DEFAULT '[]',
+    error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_runs_started_at ON retention_runs (started_at);
@@ -0,0 +1,6 @@
+-- The authenticated principal that made each stock reservation. Only that principal can release
+-- the reservation; the actor column names who the request said it came from, which a client sets
+-- itself. Reservations made before the column existed have no owner and can only expire.
+-- The script is idempotent.
+
+ALTER TABLE stock_reservations ADD COLUMN IF NOT EXISTS owner VARCHAR(64) NOT NULL DEFAULT '';
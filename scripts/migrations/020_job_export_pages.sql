@@ -0,0 +1,11 @@
+-- Pages of product exports. An export writes the products it reads a page at a time here instead
+-- of into its job's result, which only counts them, so the jobs row stays small however large the
+-- catalog is. Pages go with their job when finished jobs are purged.
+-- The script is idempotent.
+
+CREATE TABLE IF NOT EXISTS job_export_pages (
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    page INTEGER NOT NULL CHECK (page > 0),
+    products JSONB NOT NULL,
+    PRIMARY KEY (job_id, page)
+);